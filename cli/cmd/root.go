@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -19,67 +20,16 @@ import (
 
 // Command line flags
 var (
-	verbose bool
-	cfgFile string
-	dbType  string
-	query   string
+	verbose  bool
+	cfgFile  string
+	dbType   string
+	query    string
+	readOnly bool
+	maxRows  int64
+	file     string
+	cont     bool
 )
 
-type QueryResultInterface interface {
-	GetColumns() []string
-	GetRows() interface{}
-	GetTime() float64
-	GetError() string
-}
-
-type QueryResult struct {
-	Columns []string        `json:"columns"`
-	Rows    [][]interface{} `json:"rows"`
-	Time    float64         `json:"time"`
-	Error   string          `json:"error"`
-}
-
-func (q QueryResult) GetColumns() []string {
-	return q.Columns
-}
-
-func (q QueryResult) GetRows() interface{} {
-	return q.Rows
-}
-
-func (q QueryResult) GetTime() float64 {
-	return q.Time
-}
-
-func (q QueryResult) GetError() string {
-	return q.Error
-}
-
-type BigQueryResult struct {
-	Columns []string                 `json:"columns"`
-	Rows    []map[string]interface{} `json:"rows"`
-	Time    int64                    `json:"time"`
-	Error   string                   `json:"error"`
-}
-
-func (b BigQueryResult) GetColumns() []string {
-	return b.Columns
-}
-
-func (b BigQueryResult) GetRows() interface{} {
-	return b.Rows
-}
-
-func (b BigQueryResult) GetTime() float64 {
-	return float64(b.Time)
-}
-
-func (b BigQueryResult) GetError() string {
-	return b.Error
-}
-
-// QueryResult represents the result of a database query.
-
 // Command for interacting with databases
 var shellCmd = &cobra.Command{
 	Use:   "shell",
@@ -96,6 +46,19 @@ var shellCmd = &cobra.Command{
 	You can also control the verbosity of the command's output with the --verbose or -v flag. 
 	When the verbose mode is on, the command will log additional information about its operation.
 
+	With the --read-only or -r flag, or read_only: true in the configuration file, only statements
+	that read data are executed.
+
+	With the --max-rows flag, or max_rows in the configuration file, results are cut after the given
+	number of rows. Type 'next' to see the next rows of the last query.
+
+	With the --file or -f flag, the statements of a script file are executed one after another and the
+	result, affected rows and time of each statement are printed. The script stops at the first failing
+	statement unless the --continue-on-error flag is given.
+
+	Type '\explain <query>' to see the plan of a query, or '\explain analyze <query>' to run it and
+	see the actual rows and times of the plan.
+
 	In the interactive shell, you can type SQL queries and press Enter to execute them. 
 	The results will be displayed in the console. Type 'exit' to leave the shell`,
 
@@ -126,16 +89,37 @@ var shellCmd = &cobra.Command{
 			return
 		}
 
-		db, err := xray.NewClientWithConfig(&cfg, parseDbType(dbType))
+		// The --type flag overrides the type from the configuration file
+		if cmd.Flags().Changed("type") || cfg.Type == 0 {
+			cfg.Type, err = xrayTypes.ParseDbType(dbType)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+
+		// The --read-only flag can only enable the read-only mode of the configuration file
+		if readOnly {
+			cfg.ReadOnly = true
+		}
+		if maxRows > 0 {
+			cfg.MaxRows = maxRows
+		}
+
+		db, err := xray.NewClientWithConfig(&cfg, cfg.Type)
 		if err != nil {
-			fmt.Printf("Error: Failed to connect to database: %s: %v\n", dbType, err)
+			fmt.Printf("Error: Failed to connect to database: %s: %v\n", cfg.Type, err)
 			return
 		}
 
 		fmt.Println("Welcome to database shell!")
 
-		if len(query) > 0 {
-			if err := queryExecute(query, db); err != nil {
+		if len(file) > 0 {
+			if err := scriptExecute(file, db); err != nil {
+				fmt.Println(err)
+			}
+		} else if len(query) > 0 {
+			if _, err := queryExecute(query, "", db); err != nil {
 				fmt.Println(err)
 				return
 			}
@@ -143,6 +127,9 @@ var shellCmd = &cobra.Command{
 			line := liner.NewLiner()
 			defer line.Close()
 
+			// lastQuery and pageToken continue a truncated result with the next command
+			var lastQuery, pageToken string
+
 			for {
 				line.SetCtrlCAborts(true)
 
@@ -161,7 +148,27 @@ var shellCmd = &cobra.Command{
 					break
 				}
 
-				if err := queryExecute(query, db); err != nil {
+				if query == "next" {
+					if pageToken == "" {
+						fmt.Println("No more rows.")
+						continue
+					}
+					if pageToken, err = queryExecute(lastQuery, pageToken, db); err != nil {
+						fmt.Println("Error executing query:", err)
+					}
+					continue
+				}
+
+				if strings.HasPrefix(query, `\explain `) {
+					if err := queryExplain(strings.TrimPrefix(query, `\explain `), db); err != nil {
+						fmt.Println("Error explaining query:", err)
+					}
+					line.AppendHistory(query)
+					continue
+				}
+
+				lastQuery = query
+				if pageToken, err = queryExecute(query, "", db); err != nil {
 					fmt.Println("Error executing query:", err)
 				}
 
@@ -172,59 +179,125 @@ var shellCmd = &cobra.Command{
 	},
 }
 
-func queryExecute(query string, db xrayTypes.ISQL) error {
+// queryExecute prints the page of the query result selected by the page token and returns the token of the next page.
+// A query without rows prints an empty result, the caller prints the returned error.
+func queryExecute(query, pageToken string, db xrayTypes.ISQLContext) (string, error) {
 
-	b, err := db.Execute(strings.TrimSpace(query))
+	b, err := db.ExecutePage(context.Background(), strings.TrimSpace(query), pageToken)
 	if err != nil {
-		fmt.Println("Error executing query:", err)
-		return fmt.Errorf("error executing query result: %s", err)
+		return "", err
 	}
 
-	var result QueryResultInterface
-	if dbType == "bigquery" {
-		result = &BigQueryResult{}
-	} else {
-		result = &QueryResult{}
+	var result xrayTypes.QueryResult
+	err = json.Unmarshal(b, &result)
+	if err != nil {
+		return "", fmt.Errorf("error parsing query result: %s", err)
 	}
 
-	err = json.Unmarshal(b, result)
-	if err != nil {
-		return fmt.Errorf("error parsing query result: %s", err)
+	printResult(result)
+	if result.Truncated {
+		fmt.Println("More rows available, type 'next' to see them.")
 	}
+	return result.NextPageToken, nil
+}
 
+// printResult prints the rows of a query result as a table, statements without result columns only print the counts.
+func printResult(result xrayTypes.QueryResult) {
+	if len(result.Columns) == 0 {
+		fmt.Printf("(%d rows, %d ms)\n", result.RowCount, result.Time)
+		return
+	}
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader(result.GetColumns()) // Assert the type of result and call GetColumns() instead of Columns
-	switch rows := result.GetRows().(type) {
-	case [][]interface{}:
-		for _, row := range rows {
-			stringRow := make([]string, len(row))
-			for i, v := range row {
-				stringRow[i] = fmt.Sprintf("%v", v)
-			}
-
-			table.Append(stringRow)
+	table.SetHeader(result.Columns)
+	for _, row := range result.Rows {
+		stringRow := make([]string, len(row))
+		for i, v := range row {
+			stringRow[i] = fmt.Sprintf("%v", v)
 		}
-	case []map[string]interface{}:
-		for _, rowMap := range rows {
-			var stringRow []string
-			for _, v := range rowMap {
-				stringRow = append(stringRow, fmt.Sprintf("%v", v))
+
+		table.Append(stringRow)
+	}
+
+	// Print the table
+	table.Render()
+	fmt.Printf("(%d rows, %d ms)\n", result.RowCount, result.Time)
+}
+
+// scriptExecute runs the statements of a script file and prints the outcome of each statement.
+func scriptExecute(path string, db xrayTypes.ISQLContext) error {
+	script, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading script: %v", err)
+	}
+
+	opts := xrayTypes.ScriptOptions{Mode: xrayTypes.StopOnError}
+	if cont {
+		opts.Mode = xrayTypes.ContinueOnError
+	}
+	result, err := db.ExecuteScript(context.Background(), string(script), opts)
+	for i, statement := range result.Statements {
+		fmt.Printf("-- statement %d (%d ms)\n%s\n", i+1, statement.Time, statement.Statement)
+		switch {
+		case statement.Error != "":
+			fmt.Println("Error:", statement.Error)
+		case statement.Result != nil:
+			var rows xrayTypes.QueryResult
+			if err := json.Unmarshal(statement.Result, &rows); err != nil {
+				return fmt.Errorf("error unmarshalling result: %v", err)
 			}
-			table.Append(stringRow)
+			printResult(rows)
+		case statement.RowsAffected >= 0:
+			fmt.Printf("%d rows affected\n", statement.RowsAffected)
 		}
-	default:
-		return fmt.Errorf("unexpected type of rows: %T", rows)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d statements, %d failed (%d ms)\n", len(result.Statements), result.Failed, result.Time)
+	return nil
+}
+
+// queryExplain prints the plan of a query as an indented tree, a query starting with "analyze" is run
+// and its plan shows the actual rows and times.
+func queryExplain(query string, db xrayTypes.ISQLContext) error {
+	explainer, ok := db.(xrayTypes.Explainer)
+	if !ok {
+		return fmt.Errorf("query plans are not supported by this database")
 	}
 
-	if table.NumLines() == 0 {
-		return fmt.Errorf("no results found")
+	query = strings.TrimSpace(query)
+	analyze := false
+	if fields := strings.Fields(query); len(fields) > 1 && strings.EqualFold(fields[0], "analyze") {
+		analyze = true
+		query = strings.TrimSpace(query[len(fields[0]):])
 	}
 
-	// Print the table
-	table.Render()
+	plan, err := explainer.Explain(context.Background(), query, analyze)
+	if err != nil {
+		return err
+	}
+	printPlanNode(plan.Root, 0, plan.Analyzed)
 	return nil
 }
 
+// printPlanNode prints a node of a plan and its children, indented by depth.
+func printPlanNode(node *xrayTypes.PlanNode, depth int, analyzed bool) {
+	line := strings.Repeat("  ", depth) + "-> " + node.Type
+	if node.Relation != "" && !strings.Contains(node.Type, node.Relation) {
+		line += " on " + node.Relation
+	}
+	if node.EstimatedCost != 0 || node.EstimatedRows != 0 {
+		line += fmt.Sprintf("  (cost=%g rows=%g)", node.EstimatedCost, node.EstimatedRows)
+	}
+	if analyzed {
+		line += fmt.Sprintf("  (actual rows=%g time=%gms)", node.ActualRows, node.ActualTime)
+	}
+	fmt.Println(line)
+	for _, child := range node.Children {
+		printPlanNode(child, depth+1, analyzed)
+	}
+}
+
 // Execute runs the command line interface.
 func Execute() {
 	rootCmd := &cobra.Command{Use: "xray"}
@@ -234,6 +307,10 @@ func Execute() {
 	shellCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config.yaml")
 	shellCmd.PersistentFlags().StringVarP(&dbType, "type", "t", "mysql", "Database type like mysql, postgres, bigquery")
 	shellCmd.PersistentFlags().StringVarP(&query, "query", "q", "", "Database query")
+	shellCmd.PersistentFlags().Int64Var(&maxRows, "max-rows", 0, "Maximum number of rows shown per query, 0 for no limit")
+	shellCmd.PersistentFlags().BoolVarP(&readOnly, "read-only", "r", false, "Reject statements that are not reads")
+	shellCmd.PersistentFlags().StringVarP(&file, "file", "f", "", "Script file whose statements are executed one after another")
+	shellCmd.PersistentFlags().BoolVar(&cont, "continue-on-error", false, "Keep executing the script after a statement fails")
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
 	}
@@ -241,23 +318,3 @@ func Execute() {
 
 func init() {
 }
-
-// ParseDbType parses a string and returns the corresponding DbType.
-func parseDbType(s string) xrayTypes.DbType {
-	switch strings.ToLower(s) {
-	case "mysql":
-		return xrayTypes.MySQL
-	case "postgres":
-		return xrayTypes.Postgres
-	case "snowflake":
-		return xrayTypes.Snowflake
-	case "bigquery":
-		return xrayTypes.BigQuery
-	case "redshift":
-		return xrayTypes.Redshift
-	case "mssql":
-		return xrayTypes.MSSQL
-	default:
-		return xrayTypes.MySQL
-	}
-}
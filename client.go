@@ -5,106 +5,109 @@ import (
 	"fmt"
 
 	"github.com/thesaas-company/xray/config"
-	"github.com/thesaas-company/xray/databases/bigquery"
-	"github.com/thesaas-company/xray/databases/mssql"
-
-	"github.com/thesaas-company/xray/databases/mysql"
-	"github.com/thesaas-company/xray/databases/postgres"
-	"github.com/thesaas-company/xray/databases/redshift"
-	"github.com/thesaas-company/xray/databases/snowflake"
 	"github.com/thesaas-company/xray/logger"
+	"github.com/thesaas-company/xray/registry"
+	"github.com/thesaas-company/xray/translate"
 	"github.com/thesaas-company/xray/types"
+
+	// The built-in drivers register themselves with the registry.
+	_ "github.com/thesaas-company/xray/databases/bigquery"
+	_ "github.com/thesaas-company/xray/databases/mssql"
+	_ "github.com/thesaas-company/xray/databases/mysql"
+	_ "github.com/thesaas-company/xray/databases/postgres"
+	_ "github.com/thesaas-company/xray/databases/redshift"
+	_ "github.com/thesaas-company/xray/databases/snowflake"
 )
 
+// Factory creates clients for a database driver plugged in with Register.
+type Factory = registry.Factory
+
+// Register makes a database driver available under the given name and returns the DbType assigned to it.
+// Third party drivers call it from an init function; it panics if the name is already registered.
+func Register(name string, factory Factory) types.DbType {
+	return registry.Register(name, factory)
+}
+
+// Lookup returns the DbType of the driver registered under the given name or one of its aliases.
+func Lookup(name string) (types.DbType, bool) {
+	dbType, err := types.ParseDbType(name)
+	if err != nil {
+		return 0, false
+	}
+	if _, ok := registry.LookupType(dbType); !ok {
+		return 0, false
+	}
+	return dbType, true
+}
+
+// Drivers returns the sorted names of all registered drivers.
+func Drivers() []string {
+	return registry.Drivers()
+}
+
 // NewClientWithConfig creates a new SQL client with the given configuration and database type.
 // It returns an error if the database type is not supported or if there is a problem creating the client.
-func NewClientWithConfig(dbConfig *config.Config, dbType types.DbType) (types.ISQL, error) {
-	// Create a new SQL client based on the database type
-	switch dbType {
-	case types.MySQL:
-		sqlClient, err := mysql.NewMySQLWithConfig(dbConfig) // NewMySQLWithConfig is a SQL client that connects to a MySQL database using the given configuration.
-		if err != nil {
-			return nil, err
-		}
-		return logger.NewLogger(sqlClient), nil
-	case types.Postgres:
-		sqlClient, err := postgres.NewPostgresWithConfig(dbConfig) // NewPostgresWithConfig is a SQL client that connects to a Postgres database using the given configuration.
-		if err != nil {
-			return nil, err
-		}
-		return logger.NewLogger(sqlClient), nil
-	case types.Snowflake:
-		sqlClient, err := snowflake.NewSnowflakeWithConfig(dbConfig) // NewSnowflakeWithConfig is a SQL client that connects to a Snowflake database using the given configuration.
-		if err != nil {
-			return nil, err
-		}
-		return logger.NewLogger(sqlClient), nil
-	case types.BigQuery:
-		bigqueryClient, err := bigquery.NewBigQueryWithConfig(dbConfig) // NewBigQueryWithConfig is a SQL client that connects to a BigQuery database using the given configuration.
-		if err != nil {
-			return nil, err
-		}
-		return logger.NewLogger(bigqueryClient), nil
-	case types.Redshift:
-		redshiftClient, err := redshift.NewRedshiftWithConfig(dbConfig) // NewRedshiftWithConfig is a SQL client that connects to a Redshift database using the given configuration.
-		if err != nil {
-			return nil, err
-		}
-		return logger.NewLogger(redshiftClient), nil
-	case types.MSSQL:
-		mssqlClient, err := mssql.NewMSSQLFromConfig(dbConfig) // NewMSSQLFromConfig is a SQL client that connects to a MSSQL database using the given configuration.
-		if err != nil {
-			return nil, err
-		}
-		return logger.NewLogger(mssqlClient), nil
-
-	default:
+func NewClientWithConfig(dbConfig *config.Config, dbType types.DbType) (types.ISQLContext, error) {
+	factory, ok := registry.LookupType(dbType)
+	if !ok || factory.NewWithConfig == nil {
 		return nil, fmt.Errorf("unsupported database type: %s", dbType) // Return an error if the database type is not supported.
 	}
+
+	sqlClient, err := factory.NewWithConfig(dbConfig)
+	if err != nil {
+		return nil, err
+	}
+	return logger.NewLogger(sqlClient), nil
 }
 
 // NewClient creates a new SQL client with the given database client and database type.
 // It returns an error if the database type is not supported or if there is a problem creating the client.
-func NewClient(dbClient *sql.DB, dbType types.DbType) (types.ISQL, error) {
-	// Create a new SQL client based on the database type
-	switch dbType {
-	case types.MySQL:
-		sqlClient, err := mysql.NewMySQL(dbClient) // NewMySQL is a SQL client that connects to a MySQL database using the given database client.
-		if err != nil {
-			return nil, err
-		}
-		return logger.NewLogger(sqlClient), nil
-	case types.Postgres:
-		sqlClient, err := postgres.NewPostgres(dbClient) // NewPostgres is a SQL client that connects to a Postgres database using the given database client.
-		if err != nil {
-			return nil, err
-		}
-		return logger.NewLogger(sqlClient), nil
-	case types.Snowflake:
-		sqlClient, err := snowflake.NewSnowflake(dbClient) // NewSnowflake is a SQL client that connects to a Snowflake database using the given database client.
-		if err != nil {
-			return nil, err
-		}
-		return logger.NewLogger(sqlClient), nil
-	case types.BigQuery:
-		BigQueryClient, err := bigquery.NewBigQuery(dbClient) // NewBigQuery is a SQL client that connects to a BigQuery database using the given database client.
-		if err != nil {
-			return nil, err
-		}
-		return logger.NewLogger(BigQueryClient), nil
-	case types.Redshift:
-		redshiftClient, err := redshift.NewRedshift(dbClient) // NewRedshift is a SQL client that connects to a Redshift database using the given database client.
-		if err != nil {
-			return nil, err
-		}
-		return logger.NewLogger(redshiftClient), nil
-	case types.MSSQL:
-		mssqlClient, err := mssql.NewMSSQL(dbClient) // NewMSSQL is a SQL client that connects to a MSSQL database using the given database client.
-		if err != nil {
-			return nil, err
-		}
-		return logger.NewLogger(mssqlClient), nil
-	default:
+func NewClient(dbClient *sql.DB, dbType types.DbType) (types.ISQLContext, error) {
+	factory, ok := registry.LookupType(dbType)
+	if !ok || factory.New == nil {
 		return nil, fmt.Errorf("unsupported database type: %s", dbType) // Return an error if the database type is not supported.
 	}
+
+	sqlClient, err := factory.New(dbClient)
+	if err != nil {
+		return nil, err
+	}
+	return logger.NewLogger(sqlClient), nil
+}
+
+// NewReadOnlyClient creates a SQL client like NewClient whose Execute and Query reject every statement that is not a read,
+// see config.Config.ReadOnly. It returns an error if the driver of the database type has no read-only mode.
+func NewReadOnlyClient(dbClient *sql.DB, dbType types.DbType) (types.ISQLContext, error) {
+	factory, ok := registry.LookupType(dbType)
+	if !ok || factory.New == nil {
+		return nil, fmt.Errorf("unsupported database type: %s", dbType)
+	}
+
+	sqlClient, err := factory.New(dbClient)
+	if err != nil {
+		return nil, err
+	}
+	guarded, ok := sqlClient.(types.ReadOnlySetter)
+	if !ok {
+		return nil, fmt.Errorf("read-only mode is not supported by the %s driver", dbType)
+	}
+	guarded.SetReadOnly(true)
+	return logger.NewLogger(sqlClient), nil
+}
+
+// TranslateDDL generates the CREATE TABLE statement of a table read from the from database for the to database.
+// Column types, defaults and auto-increment columns are translated with the translate package and the DDL is
+// generated by the target driver without a connection. It returns warnings for every conversion that loses information.
+func TranslateDDL(table types.Table, from, to types.DbType) (string, []string, error) {
+	factory, ok := registry.LookupType(to)
+	if !ok || factory.New == nil {
+		return "", nil, fmt.Errorf("unsupported database type: %s", to)
+	}
+	target, err := factory.New(nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating %s client: %v", to, err)
+	}
+
+	translated, warnings := translate.Table(table, from, to)
+	return target.GenerateCreateTableQuery(translated), warnings, nil
 }
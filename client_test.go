@@ -0,0 +1,222 @@
+package xray
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/thesaas-company/xray/types"
+)
+
+// fakeSQL is a minimal driver used to test plugging in third party databases.
+type fakeSQL struct {
+	types.ISQLContext
+}
+
+// TestBuiltinDriversRegistered is a unit test function that tests that every built-in driver is registered.
+func TestBuiltinDriversRegistered(t *testing.T) {
+	for _, dbType := range []types.DbType{types.MySQL, types.Postgres, types.Snowflake, types.BigQuery, types.Redshift, types.MSSQL} {
+		got, ok := Lookup(dbType.String())
+		if !ok {
+			t.Errorf("driver %s is not registered", dbType)
+			continue
+		}
+		if got != dbType {
+			t.Errorf("Lookup(%q) = %v, want %v", dbType.String(), got, dbType)
+		}
+	}
+}
+
+// TestNewClient is a unit test function that tests creating a client for a built-in driver.
+func TestNewClient(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	client, err := NewClient(db, types.Postgres)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client == nil {
+		t.Fatal("NewClient() returned a nil client")
+	}
+}
+
+// TestRegister is a unit test function that tests plugging in a third party driver.
+func TestRegister(t *testing.T) {
+	dbType := Register("fakedb", Factory{
+		New: func(db *sql.DB) (types.ISQLContext, error) {
+			return &fakeSQL{}, nil
+		},
+	})
+	if dbType.String() != "fakedb" {
+		t.Errorf("String() = %q, want %q", dbType.String(), "fakedb")
+	}
+
+	got, ok := Lookup("FakeDB")
+	if !ok || got != dbType {
+		t.Fatalf("Lookup() = %v, %v, want %v, true", got, ok, dbType)
+	}
+
+	if _, err := NewClient(nil, dbType); err != nil {
+		t.Errorf("NewClient() error = %v", err)
+	}
+	if _, err := NewClientWithConfig(nil, dbType); err == nil {
+		t.Error("expected an error for a driver without a config constructor")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Register to panic on a duplicate name")
+		}
+	}()
+	Register("fakedb", Factory{New: func(db *sql.DB) (types.ISQLContext, error) { return &fakeSQL{}, nil }})
+}
+
+// TestNewClientUnsupported is a unit test function that tests that unknown database types are rejected.
+func TestNewClientUnsupported(t *testing.T) {
+	if _, err := NewClient(nil, types.DbType(99)); err == nil {
+		t.Error("expected an error for an unknown database type")
+	}
+	if got := types.DbType(99).String(); got != "DbType(99)" {
+		t.Errorf("String() = %q, want %q", got, "DbType(99)")
+	}
+	if _, ok := Lookup("unknown"); ok {
+		t.Error("expected Lookup to fail for an unknown driver")
+	}
+}
+
+// TestTranslateDDL is a unit test function that tests generating the DDL of a MySQL table for every built-in driver.
+func TestTranslateDDL(t *testing.T) {
+	table := types.Table{
+		Name:   "orders",
+		Schema: "shop",
+		Columns: []types.Column{
+			{Name: "id", Type: "bigint unsigned", Extra: "auto_increment", IsPrimary: true, IsNullable: "NO"},
+			{Name: "paid", Type: "tinyint(1)", DefaultValue: sql.NullString{String: "0", Valid: true}},
+			{Name: "payload", Type: "json"},
+			{Name: "created_at", Type: "datetime(6)"},
+		},
+	}
+	tests := []struct {
+		to   types.DbType
+		want string
+	}{
+		{types.Postgres, `CREATE TABLE "orders" ("id" BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY NOT NULL, "paid" BOOLEAN DEFAULT FALSE, "payload" JSONB, "created_at" TIMESTAMP(6));`},
+		{types.MSSQL, "CREATE TABLE [orders] ([id] BIGINT IDENTITY(1,1) NOT NULL, [paid] BIT DEFAULT (0), [payload] NVARCHAR(MAX), [created_at] DATETIME2(6), PRIMARY KEY ([id]));"},
+		{types.Snowflake, "CREATE TABLE orders (id BIGINT AUTOINCREMENT PRIMARY KEY, paid BOOLEAN DEFAULT FALSE, payload VARIANT, created_at TIMESTAMP_NTZ(6));"},
+		{types.BigQuery, "CREATE TABLE shop.orders (id INT64 NOT NULL, paid BOOL DEFAULT FALSE, payload JSON, created_at DATETIME);"},
+		{types.Redshift, `CREATE TABLE "orders" ("id" BIGINT IDENTITY(1,1) PRIMARY KEY NOT NULL, "paid" BOOLEAN DEFAULT FALSE, "payload" SUPER, "created_at" TIMESTAMP);`},
+	}
+	for _, tt := range tests {
+		got, warnings, err := TranslateDDL(table, types.MySQL, tt.to)
+		if err != nil {
+			t.Fatalf("TranslateDDL(%s) error = %v", tt.to, err)
+		}
+		if got != tt.want {
+			t.Errorf("TranslateDDL(%s) = %s, want %s", tt.to, got, tt.want)
+		}
+		if tt.to == types.BigQuery && !strings.Contains(strings.Join(warnings, "\n"), "auto-increment") {
+			t.Errorf("TranslateDDL(%s) warnings = %q, want an auto-increment warning", tt.to, warnings)
+		}
+	}
+}
+
+// TestNewReadOnlyClient is a unit test function that tests that a read-only client runs reads in a read-only
+// transaction and rejects writes before they reach the database.
+func TestNewReadOnlyClient(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	client, err := NewReadOnlyClient(db, types.Postgres)
+	if err != nil {
+		t.Fatalf("NewReadOnlyClient() error = %v", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM users").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectRollback()
+	if _, err := client.Execute("SELECT id FROM users"); err != nil {
+		t.Errorf("Execute() error = %v", err)
+	}
+
+	if _, err := client.Execute("SELECT 1; DROP TABLE users"); err == nil || !strings.Contains(err.Error(), "DROP") {
+		t.Errorf("Execute() error = %v, want a read-only error naming DROP", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	plain := Register("plaindb", Factory{New: func(db *sql.DB) (types.ISQLContext, error) { return &fakeSQL{}, nil }})
+	if _, err := NewReadOnlyClient(nil, plain); err == nil {
+		t.Error("expected an error for a driver without a read-only mode")
+	}
+}
+
+// TestEstimate is a unit test function that tests that clients forward Estimate to the drivers implementing it.
+func TestEstimate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("EXPLAIN").WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(`[{"Plan": {"Total Cost": 1.5, "Plan Rows": 1}}]`))
+	client, err := NewClient(db, types.Postgres)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	estimator, ok := client.(types.Estimator)
+	if !ok {
+		t.Fatal("client does not implement types.Estimator")
+	}
+	if estimate, err := estimator.Estimate(context.Background(), "SELECT 1"); err != nil || estimate.Cost != 1.5 {
+		t.Errorf("Estimate() = %+v, %v, want cost 1.5", estimate, err)
+	}
+
+	client, err = NewClient(db, types.MySQL)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err := client.(types.Estimator).Estimate(context.Background(), "SELECT 1"); err == nil {
+		t.Error("expected an error for a driver without cost estimation")
+	}
+}
+
+// TestExplain is a unit test function that tests that clients forward Explain to the drivers implementing it.
+func TestExplain(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("EXPLAIN").WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(`[{"Plan": {"Node Type": "Result", "Total Cost": 0.01, "Plan Rows": 1}}]`))
+	client, err := NewClient(db, types.Postgres)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	explainer, ok := client.(types.Explainer)
+	if !ok {
+		t.Fatal("client does not implement types.Explainer")
+	}
+	if plan, err := explainer.Explain(context.Background(), "SELECT 1", false); err != nil || plan.Root.Type != "Result" {
+		t.Errorf("Explain() = %+v, %v, want a Result node", plan, err)
+	}
+
+	noPlan := Register("noplandb", Factory{New: func(db *sql.DB) (types.ISQLContext, error) { return &fakeSQL{}, nil }})
+	client, err = NewClient(nil, noPlan)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err := client.(types.Explainer).Explain(context.Background(), "SELECT 1", false); err == nil {
+		t.Error("expected an error for a driver without query plans")
+	}
+}
@@ -1,11 +1,16 @@
 package config
 
+import "github.com/thesaas-company/xray/types"
+
 // Add Logging, You can use any lib - DONE!!!
 
 // Once we are done with mysql and postgres, Let's rethink about the config structure
 
 // Config holds the configuration details for various databases.
 type Config struct {
+	// Type is the database type, such as mysql, postgres or bigquery.
+	Type types.DbType `yaml:"type" pflag:",Database type like mysql, postgres, bigquery"`
+
 	// Host is the database host URL.
 	Host string `yaml:"host" pflag:",Database host url"`
 
@@ -33,6 +38,24 @@ type Config struct {
 	// Account is the Snowflake account ID.
 	Account string `yaml:"account" pflag:",Snowflake account ID"`
 
+	// BinaryEncoding is the encoding of binary column values in query results: base64 (default), hex or omit.
+	BinaryEncoding string `yaml:"binary_encoding" pflag:",Binary column encoding base64/hex/omit"`
+
+	// MaxRows is the maximum number of rows returned by Execute, the rest of the result is read with page tokens.
+	// Queries without a row limit are sent with the LIMIT, TOP or FETCH clause of the database. Zero means no limit.
+	MaxRows int64 `yaml:"max_rows" pflag:",Maximum number of rows of a query result page"`
+
+	// MaxBytes is the maximum size of the rows returned by Execute, measured as JSON. Zero means no limit.
+	MaxBytes int64 `yaml:"max_bytes" pflag:",Maximum size in bytes of a query result page"`
+
+	// MaximumBytesBilled is the maximum number of bytes a BigQuery query may process, queries whose dry run
+	// processes more are refused before they run. Zero means no limit.
+	MaximumBytesBilled int64 `yaml:"maximum_bytes_billed" pflag:",BigQuery maximum bytes processed by a query"`
+
+	// ReadOnly rejects every statement passed to Execute and Query that is not a read. Postgres, Redshift and MySQL
+	// also run the queries in read-only transactions; SQL Server, Snowflake and BigQuery rely on the statement check alone.
+	ReadOnly bool `yaml:"read_only" pflag:",Reject statements that are not reads"`
+
 	// Debug is used to enable or disable debug mode.
 	Debug bool `yaml:"debug" pflag:",Debug mode"`
 }
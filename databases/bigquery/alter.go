@@ -0,0 +1,101 @@
+package bigquery
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/diff"
+	"github.com/thesaas-company/xray/translate"
+	"github.com/thesaas-company/xray/types"
+)
+
+// bigQueryCoercions are the type changes BigQuery makes in place besides widening a parameterised type,
+// keyed by the kind of the source type.
+var bigQueryCoercions = map[translate.Kind][]translate.Kind{
+	translate.BigInt:  {translate.Decimal, translate.Double},
+	translate.Decimal: {translate.Double},
+}
+
+// GenerateAlterTableQueries generates the ALTER TABLE statements turning the from table into the to table.
+// BigQuery cannot narrow or convert column types, make columns required or change the clustering of an
+// existing table, those changes are reported as warnings. Primary keys are created NOT ENFORCED.
+func (b *BigQuery) GenerateAlterTableQueries(from, to types.Table) types.Migration {
+	var m types.Migration
+	d := diff.Tables(from, to, types.BigQuery, types.BigQuery)
+	table := dialect.QuoteTableName(types.BigQuery, types.TableName{Catalog: to.Catalog, Schema: bigQueryDataset(to), Name: to.Name})
+
+	if d.PrimaryKey != nil && d.PrimaryKey.From != "" {
+		m.Add("ALTER TABLE " + table + " DROP PRIMARY KEY;")
+	}
+
+	for _, column := range d.RemovedColumns {
+		m.Add("ALTER TABLE " + table + " DROP COLUMN " + dialect.QuoteIdentifier(types.BigQuery, column.Name) + ";")
+		m.Warn(fmt.Sprintf("dropping column %s deletes its data", column.Name))
+	}
+	for _, column := range d.AddedColumns {
+		query := "ALTER TABLE " + table + " ADD COLUMN " + dialect.QuoteIdentifier(types.BigQuery, column.Name) + " " + convertTypeToBigQuery(strings.ToUpper(column.Type))
+		if column.DefaultValue.Valid {
+			query += " DEFAULT " + column.DefaultValue.String
+		}
+		if column.IsNullable == "NO" {
+			m.Warn(fmt.Sprintf("BigQuery cannot add the REQUIRED column %s to an existing table, it is added as NULLABLE", column.Name))
+		}
+		m.Add(query + ";")
+	}
+	for _, column := range d.ChangedColumns {
+		alter := "ALTER TABLE " + table + " ALTER COLUMN " + dialect.QuoteIdentifier(types.BigQuery, column.Name)
+		for _, change := range column.Changes {
+			switch change.Field {
+			case diff.FieldType:
+				if bigQueryWidens(column.From, column.To) {
+					m.Add(alter + " SET DATA TYPE " + convertTypeToBigQuery(strings.ToUpper(column.To.Type)) + ";")
+				} else {
+					m.Warn(fmt.Sprintf("BigQuery cannot change the type of column %s from %s to %s, the table has to be recreated", column.Name, change.From, change.To))
+				}
+			case diff.FieldNullable:
+				if change.To == "YES" {
+					m.Add(alter + " DROP NOT NULL;")
+				} else {
+					m.Warn(fmt.Sprintf("BigQuery cannot make the existing column %s REQUIRED", column.Name))
+				}
+			case diff.FieldDefault:
+				if column.To.DefaultValue.Valid {
+					m.Add(alter + " SET DEFAULT " + column.To.DefaultValue.String + ";")
+				} else {
+					m.Add(alter + " DROP DEFAULT;")
+				}
+			}
+		}
+	}
+
+	if d.PrimaryKey != nil && d.PrimaryKey.To != "" {
+		m.Add("ALTER TABLE " + table + " ADD PRIMARY KEY (" + strings.Join(dialect.QuoteColumns(types.BigQuery, to, diff.PrimaryKeyColumns(to)), ", ") + ") NOT ENFORCED;")
+	}
+	if len(d.AddedIndexes) > 0 || len(d.RemovedIndexes) > 0 {
+		m.Warn(fmt.Sprintf("BigQuery has no indexes and cannot change the clustering of %s with DDL", to.Name))
+	}
+	return m
+}
+
+// bigQueryDataset returns the dataset of a table, which Schema reads into Schema and older callers set as Dataset.
+func bigQueryDataset(table types.Table) string {
+	if table.Dataset != "" {
+		return table.Dataset
+	}
+	return table.Schema
+}
+
+// bigQueryWidens reports whether BigQuery can change a column from one type to the other in place.
+func bigQueryWidens(from, to types.Column) bool {
+	if diff.Widens(types.BigQuery, from, to) {
+		return true
+	}
+	target := translate.ParseColumn(types.BigQuery, to).Kind
+	for _, kind := range bigQueryCoercions[translate.ParseColumn(types.BigQuery, from).Kind] {
+		if kind == target {
+			return true
+		}
+	}
+	return false
+}
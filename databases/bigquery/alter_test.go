@@ -0,0 +1,83 @@
+package bigquery
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestGenerateAlterTableQueries is a unit test function that tests the GenerateAlterTableQueries method of the BigQuery struct.
+func TestGenerateAlterTableQueries(t *testing.T) {
+	from := types.Table{
+		Name:    "orders",
+		Dataset: "sales",
+		Columns: []types.Column{
+			{Name: "id", Type: "INT64", IsNullable: "NO"},
+			{Name: "quantity", Type: "INT64", IsNullable: "YES"},
+			{Name: "price", Type: "NUMERIC", IsNullable: "NO"},
+			{Name: "code", Type: "STRING", IsNullable: "YES"},
+		},
+	}
+	to := types.Table{
+		Name:    "orders",
+		Dataset: "sales",
+		Columns: []types.Column{
+			{Name: "id", Type: "INT64", IsNullable: "NO"},
+			{Name: "quantity", Type: "NUMERIC", IsNullable: "YES"},
+			{Name: "price", Type: "INT64", IsNullable: "YES"},
+			{Name: "code", Type: "STRING", IsNullable: "NO"},
+			{Name: "region", Type: "STRING", IsNullable: "NO"},
+		},
+		Indexes: []types.Index{{Name: "clustering", Columns: []string{"region"}, Method: "clustering"}},
+	}
+
+	b := &BigQuery{}
+	m := b.GenerateAlterTableQueries(from, to)
+
+	wantStatements := []string{
+		"ALTER TABLE `sales`.`orders` ADD COLUMN `region` STRING;",
+		"ALTER TABLE `sales`.`orders` ALTER COLUMN `quantity` SET DATA TYPE NUMERIC;",
+		"ALTER TABLE `sales`.`orders` ALTER COLUMN `price` DROP NOT NULL;",
+	}
+	if !reflect.DeepEqual(m.Statements, wantStatements) {
+		t.Errorf("statements = %#v, want %#v", m.Statements, wantStatements)
+	}
+	wantWarnings := []string{
+		"BigQuery cannot add the REQUIRED column region to an existing table, it is added as NULLABLE",
+		"BigQuery cannot change the type of column price from decimal to bigint, the table has to be recreated",
+		"BigQuery cannot make the existing column code REQUIRED",
+		"BigQuery has no indexes and cannot change the clustering of orders with DDL",
+	}
+	if !reflect.DeepEqual(m.Warnings, wantWarnings) {
+		t.Errorf("warnings = %#v, want %#v", m.Warnings, wantWarnings)
+	}
+}
+
+// TestGenerateAlterTableQueriesQuotedNames is a unit test function that tests that reserved words, mixed case names
+// and quote characters in table, column and index names are quoted.
+func TestGenerateAlterTableQueriesQuotedNames(t *testing.T) {
+	from := types.Table{
+		Name:    "Order",
+		Schema:  "sales",
+		Dataset: "sales",
+		Columns: []types.Column{{Name: "id", Type: "INT64", IsNullable: "NO", IsPrimary: true}, {Name: "Group", Type: "STRING", IsNullable: "YES"}},
+	}
+	to := types.Table{
+		Name:    "Order",
+		Schema:  "sales",
+		Dataset: "sales",
+		Columns: []types.Column{{Name: "id", Type: "INT64", IsNullable: "NO", IsPrimary: true}, {Name: "select`s", Type: "STRING", IsNullable: "YES"}},
+	}
+
+	b := &BigQuery{}
+	m := b.GenerateAlterTableQueries(from, to)
+
+	want := []string{
+		"ALTER TABLE `sales`.`Order` DROP COLUMN `Group`;",
+		"ALTER TABLE `sales`.`Order` ADD COLUMN `select\\`s` STRING;",
+	}
+	if !reflect.DeepEqual(m.Statements, want) {
+		t.Errorf("statements = %#v, want %#v", m.Statements, want)
+	}
+}
@@ -1,13 +1,21 @@
 package bigquery
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	bq "cloud.google.com/go/bigquery"
 	"github.com/thesaas-company/xray/config"
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/governor"
+	"github.com/thesaas-company/xray/registry"
+	"github.com/thesaas-company/xray/snapshot"
 	"github.com/thesaas-company/xray/types"
 	_ "gorm.io/driver/bigquery/driver"
 )
@@ -15,19 +23,45 @@ import (
 var GOOGLE_APPLICATION_CREDENTIALS = "GOOGLE_APPLICATION_CREDENTIALS"
 
 const (
-	BigQuery_SCHEMA_QUERY = "SELECT column_name, data_type FROM %s.INFORMATION_SCHEMA.COLUMNS WHERE table_name='%s'"
-	BigQuery_TABLES_QUERY = "SELECT table_name FROM INFORMATION_SCHEMA.TABLES WHERE table_schema = '%s'"
+	// BigQuery_SCHEMA_QUERY reads the columns of a table, it is formatted with the quoted dataset and takes the table name as parameter.
+	BigQuery_SCHEMA_QUERY = "SELECT column_name, data_type, clustering_ordinal_position FROM %s.INFORMATION_SCHEMA.COLUMNS WHERE table_name = ?"
+	// BigQuery_TABLES_QUERY lists the tables of the dataset given as parameter.
+	BigQuery_TABLES_QUERY = "SELECT table_name FROM INFORMATION_SCHEMA.TABLES WHERE table_schema = ?"
+	// BigQuery_COMMENTS_QUERY reads the descriptions of a table and its columns, the table description is returned with an empty column name.
+	// Table options hold the description as a string literal.
+	BigQuery_COMMENTS_QUERY = "SELECT '', option_value FROM %[1]s.INFORMATION_SCHEMA.TABLE_OPTIONS WHERE table_name = ? AND option_name = 'description' UNION ALL SELECT column_name, description FROM %[1]s.INFORMATION_SCHEMA.COLUMN_FIELD_PATHS WHERE table_name = ? AND field_path = column_name AND description IS NOT NULL"
+	// BigQuery_TABLE_STATS_QUERY reads the row count, size and times of a table from the legacy __TABLES__ meta table.
+	// The times are milliseconds since the epoch.
+	BigQuery_TABLE_STATS_QUERY = "SELECT row_count, size_bytes, creation_time, last_modified_time FROM %s.__TABLES__ WHERE table_id = ?"
+	// BigQuery_LIST_TABLES_QUERY lists the tables, views, materialized views and external tables of a dataset.
+	// Materialized views have no entry in INFORMATION_SCHEMA.VIEWS, their DDL is used as the definition.
+	BigQuery_LIST_TABLES_QUERY = "SELECT t.table_name, t.table_schema, CASE t.table_type WHEN 'VIEW' THEN 'view' WHEN 'MATERIALIZED VIEW' THEN 'materialized_view' WHEN 'EXTERNAL' THEN 'external_table' ELSE 'table' END, COALESCE(v.view_definition, IF(t.table_type = 'MATERIALIZED VIEW', t.ddl, '')) FROM %[1]s.INFORMATION_SCHEMA.TABLES t LEFT JOIN %[1]s.INFORMATION_SCHEMA.VIEWS v ON v.table_name = t.table_name ORDER BY t.table_name"
+	// BigQuery_SNAPSHOT_COLUMNS_QUERY reads the columns of every table of a dataset at once.
+	BigQuery_SNAPSHOT_COLUMNS_QUERY = "SELECT table_name, column_name, data_type, clustering_ordinal_position FROM %s.INFORMATION_SCHEMA.COLUMNS ORDER BY table_name, ordinal_position"
+	// BigQuery_SNAPSHOT_COMMENTS_QUERY reads the descriptions of every table and column of a dataset at once.
+	BigQuery_SNAPSHOT_COMMENTS_QUERY = "SELECT table_name, '', option_value FROM %[1]s.INFORMATION_SCHEMA.TABLE_OPTIONS WHERE option_name = 'description' UNION ALL SELECT table_name, column_name, description FROM %[1]s.INFORMATION_SCHEMA.COLUMN_FIELD_PATHS WHERE field_path = column_name AND description IS NOT NULL"
+	// BigQuery_SCHEMAS_QUERY lists the datasets of a project, BigQuery's equivalent of schemas.
+	BigQuery_SCHEMAS_QUERY = "SELECT schema_name FROM %sINFORMATION_SCHEMA.SCHEMATA ORDER BY schema_name"
 )
 
 // The BigQuery struct is responsible for holding the BigQuery client and configuration.
 type BigQuery struct {
 	Client *sql.DB
 	Config *config.Config
+	// API is the BigQuery API client used for dry runs, the SQL driver cannot run them.
+	API *bq.Client
+}
+
+func init() {
+	registry.Register(types.BigQuery.String(), registry.Factory{
+		New:           NewBigQuery,
+		NewWithConfig: NewBigQueryWithConfig,
+	})
 }
 
 // NewBigQuery creates a new instance of BigQuery with the provided client.
-// It returns an instance of types.ISQL and an error.
-func NewBigQuery(client *sql.DB) (types.ISQL, error) {
+// It returns an instance of types.ISQLContext and an error.
+func NewBigQuery(client *sql.DB) (types.ISQLContext, error) {
 	return &BigQuery{
 		Client: client,
 		Config: &config.Config{},
@@ -35,8 +69,8 @@ func NewBigQuery(client *sql.DB) (types.ISQL, error) {
 }
 
 // NewBigQueryWithConfig creates a new instance of BigQuery with the provided configuration.
-// It returns an instance of types.ISQL and an error.
-func NewBigQueryWithConfig(cfg *config.Config) (types.ISQL, error) {
+// It returns an instance of types.ISQLContext and an error.
+func NewBigQueryWithConfig(cfg *config.Config) (types.ISQLContext, error) {
 	if os.Getenv(GOOGLE_APPLICATION_CREDENTIALS) == "" || len(os.Getenv(GOOGLE_APPLICATION_CREDENTIALS)) == 0 {
 		return nil, fmt.Errorf("please set %s env variable for the database", GOOGLE_APPLICATION_CREDENTIALS)
 	}
@@ -49,17 +83,41 @@ func NewBigQueryWithConfig(cfg *config.Config) (types.ISQL, error) {
 		return nil, fmt.Errorf("database connecetion failed : %v", err)
 	}
 
+	api, err := bq.NewClient(context.Background(), cfg.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("error creating bigquery api client: %v", err)
+	}
+
 	return &BigQuery{
 		Client: db,
 		Config: cfg,
+		API:    api,
 	}, nil
 }
 
 // this function extarcts the schema of a table in BigQuery.
 // It takes table name as input and returns a Table struct and an error.
 func (b *BigQuery) Schema(table string) (types.Table, error) {
+	return b.SchemaContext(context.Background(), table)
+}
+
+// SchemaContext extracts the schema of a table in BigQuery.
+// The query is cancelled when the context is done.
+func (b *BigQuery) SchemaContext(ctx context.Context, table string) (types.Table, error) {
+	// dataset.table and project.dataset.table override the configured dataset and project
+	name := types.ParseTableName(table)
+	dataset := name.Schema
+	if dataset == "" {
+		dataset = b.Config.Database
+	}
+	project := name.Catalog
+	if project == "" {
+		project = b.Config.ProjectID
+	}
+	qualifier := dialect.QuoteTableName(types.BigQuery, types.TableName{Catalog: name.Catalog, Schema: dataset})
+
 	// execute the sql statement
-	rows, err := b.Client.Query(fmt.Sprintf(BigQuery_SCHEMA_QUERY, b.Config.Database, table))
+	rows, err := b.Client.QueryContext(ctx, fmt.Sprintf(BigQuery_SCHEMA_QUERY, qualifier), name.Name)
 	if err != nil {
 		return types.Table{}, fmt.Errorf("error executing sql statement: %v", err)
 	}
@@ -71,102 +129,208 @@ func (b *BigQuery) Schema(table string) (types.Table, error) {
 	}()
 
 	var columns []types.Column
+	clustering := map[int64]string{}
 	for rows.Next() {
 		var column types.Column
-		if err := rows.Scan(&column.Name, &column.Type); err != nil {
+		var clusteringPosition sql.NullInt64
+		if err := rows.Scan(&column.Name, &column.Type, &clusteringPosition); err != nil {
 			return types.Table{}, fmt.Errorf("error scanning row: %v", err)
 		}
+		if clusteringPosition.Valid {
+			clustering[clusteringPosition.Int64] = column.Name
+		}
 		columns = append(columns, column)
 	}
 
+	if err := rows.Err(); err != nil {
+		return types.Table{}, fmt.Errorf("error iterating over rows: %v", err)
+	}
+
+	indexes := clusteringIndexes(clustering)
+	types.ApplyIndexes(columns, indexes)
+
+	comments, err := b.comments(ctx, qualifier, name.Name)
+	if err != nil {
+		return types.Table{}, err
+	}
+	description := types.ApplyComments(columns, comments)
+
 	return types.Table{
-		Name:        table,
+		Name:        name.Name,
+		Catalog:     project,
+		Schema:      dataset,
 		Columns:     columns,
-		Dataset:     b.Config.Database,
+		Dataset:     dataset,
+		Description: description,
 		ColumnCount: int64(len(columns)),
+		Indexes:     indexes,
 	}, nil
 }
 
-// Execute executes a query on BigQuery.
-// It takes a query string as input and returns the result as a byte slice and an error.
-func (b *BigQuery) Execute(query string) ([]byte, error) {
-	rows, err := b.Client.Query(query)
-	if err != nil {
-		return nil, fmt.Errorf("error executing sql statement: %v", err)
+// clusteringIndexes returns the clustering columns, keyed by their clustering position, as an index.
+// BigQuery has no indexes, the clustering columns play their role.
+func clusteringIndexes(clustering map[int64]string) []types.Index {
+	indexes := []types.Index{}
+	if len(clustering) > 0 {
+		index := types.Index{Name: "clustering", Method: "clustering"}
+		for position := int64(1); position <= int64(len(clustering)); position++ {
+			index.Columns = append(index.Columns, clustering[position])
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes
+}
+
+// TableStats returns the row count, size and modification times of a table in BigQuery.
+func (b *BigQuery) TableStats(table string) (types.TableStats, error) {
+	return b.TableStatsContext(context.Background(), table)
+}
+
+// TableStatsContext returns the row count, size and modification times of a table in BigQuery.
+// BigQuery keeps no separate DDL time, the creation time is reported instead.
+// The query is cancelled when the context is done.
+func (b *BigQuery) TableStatsContext(ctx context.Context, table string) (types.TableStats, error) {
+	name := types.ParseTableName(table)
+	dataset := name.Schema
+	if dataset == "" {
+		dataset = b.Config.Database
+	}
+	qualifier := dialect.QuoteTableName(types.BigQuery, types.TableName{Catalog: name.Catalog, Schema: dataset})
+
+	stats := types.TableStats{Name: name.Name, Schema: dataset}
+	var created, modified sql.NullInt64
+	row := b.Client.QueryRowContext(ctx, fmt.Sprintf(BigQuery_TABLE_STATS_QUERY, qualifier), name.Name)
+	if err := row.Scan(&stats.RowCount, &stats.Bytes, &created, &modified); err != nil {
+		return types.TableStats{}, fmt.Errorf("error reading table statistics: %v", err)
 	}
+	stats.LastDDL = millisTime(created)
+	stats.LastModified = millisTime(modified)
+	return stats, nil
+}
 
+// millisTime converts milliseconds since the epoch into a time.
+func millisTime(millis sql.NullInt64) sql.NullTime {
+	if !millis.Valid {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: time.UnixMilli(millis.Int64).UTC(), Valid: true}
+}
+
+// comments reads the descriptions of a table and its columns.
+func (b *BigQuery) comments(ctx context.Context, qualifier, table string) ([]types.Comment, error) {
+	rows, err := b.Client.QueryContext(ctx, fmt.Sprintf(BigQuery_COMMENTS_QUERY, qualifier), table, table)
+	if err != nil {
+		return nil, fmt.Errorf("error executing comment query: %v", err)
+	}
 	defer func() {
 		if err := rows.Close(); err != nil {
 			fmt.Println(err)
 		}
 	}()
 
-	columns, err := rows.Columns()
-	if err != nil {
-		return nil, fmt.Errorf("error getting columns: %v", err)
-	}
-
-	// Scan the result into a slice of slices
-	var results []map[string]interface{}
+	var comments []types.Comment
 	for rows.Next() {
-		// create a slice of values and pointers
-		values := make([]interface{}, len(columns))
-		pointers := make([]interface{}, len(columns))
-		for i := range values {
-			//  create a slice of pointers to the values
-			pointers[i] = &values[i]
+		var c types.Comment
+		if err := rows.Scan(&c.Column, &c.Text); err != nil {
+			return nil, fmt.Errorf("error scanning comments: %v", err)
 		}
+		comments = append(comments, unquoteDescription(c))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over comments: %v", err)
+	}
+	return comments, nil
+}
 
-		if err := rows.Scan(pointers...); err != nil {
-			return nil, fmt.Errorf("error scanning row: %v", err)
+// unquoteDescription decodes a table description, which table options hold as a string literal.
+func unquoteDescription(c types.Comment) types.Comment {
+	if c.Column == "" {
+		if text, err := strconv.Unquote(c.Text); err == nil {
+			c.Text = text
 		}
+	}
+	return c
+}
 
-		// Create a map for the current row
-        rowMap := make(map[string]interface{})
-        for i, colName := range columns {
-            // If the value is of type []byte (which is used for RECORD data types), 
-            // we attempt to unmarshal it into a map[string]interface{}
-            if b, ok := values[i].([]byte); ok {
-                var m map[string]interface{}
-                if err := json.Unmarshal(b, &m); err == nil {
-                    rowMap[colName] = m
-                } else {
-                    rowMap[colName] = values[i]
-                }
-            } else {
-                rowMap[colName] = values[i]
-            }
-        }
+// Execute executes a query on BigQuery.
+// It takes a query string as input and returns the result as a byte slice and an error.
+func (b *BigQuery) Execute(query string) ([]byte, error) {
+	return b.ExecuteContext(context.Background(), query)
+}
 
-		results = append(results, rowMap)
-	}
+// ExecuteContext executes a query on BigQuery.
+// The query is cancelled when the context is done.
+func (b *BigQuery) ExecuteContext(ctx context.Context, query string, args ...interface{}) ([]byte, error) {
+	return b.ExecutePage(ctx, query, "", args...)
+}
 
-	// Check for errors from iterating over rows
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %v", err)
+// ExecutePage executes the given SQL query and returns the page of its result selected by the page token as JSON,
+// the first page for an empty token. The page is limited by the MaxRows and MaxBytes of the configuration,
+// the NextPageToken of a truncated result selects the next page.
+func (b *BigQuery) ExecutePage(ctx context.Context, query, pageToken string, args ...interface{}) ([]byte, error) {
+	start := time.Now()
+	limits := governor.Limits{MaxRows: b.Config.MaxRows, MaxBytes: b.Config.MaxBytes}
+	queryResult, err := governor.Execute(ctx, b, types.BigQuery, limits, query, pageToken, args...)
+	if err != nil {
+		return nil, err
 	}
+	queryResult.Time = time.Since(start).Milliseconds()
 
 	// Convert the result to JSON
-	queryResult := types.BigQueryResult{
-		Columns: columns,
-		Rows:    results,
-	}
-
 	jsonData, err := json.Marshal(queryResult)
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling json: %v", err)
 	}
 
 	return jsonData, nil
+}
 
+// Query executes a query on BigQuery and returns an iterator over its rows.
+// The rows are read from the database as the iterator advances.
+func (b *BigQuery) Query(ctx context.Context, query string, args ...interface{}) (types.RowIterator, error) {
+	query, args, err := dialect.Bind(types.BigQuery, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error binding query arguments: %v", err)
+	}
+	if b.Config.ReadOnly {
+		if err := dialect.CheckReadOnly(types.BigQuery, query); err != nil {
+			return nil, err
+		}
+	}
+	if err := b.checkBytesBilled(ctx, query, args); err != nil {
+		return nil, err
+	}
+	rows, err := b.Client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+
+	it, err := types.NewRowIterator(rows, newValueConverter(types.BinaryEncoding(b.Config.BinaryEncoding)))
+	if err != nil {
+		_ = rows.Close()
+		return nil, err
+	}
+	return it, nil
+}
+
+// SetReadOnly enables or disables the read-only mode of the client, see config.Config.ReadOnly.
+// The configuration is copied, clients created with the same configuration keep their mode.
+func (b *BigQuery) SetReadOnly(readOnly bool) {
+	cfg := *b.Config
+	cfg.ReadOnly = readOnly
+	b.Config = &cfg
 }
 
 // Tables returns a list of tables in a dataset.
 // It takes a dataset name as input and returns a slice of strings and an error.
 func (b *BigQuery) Tables(dataset string) ([]string, error) {
-	// res, err := b.Client.Query("SELECT table_name FROM INFORMATION_SCHEMA.TABLES WHERE table_schema = '" + Dataset + "'")
+	return b.TablesContext(context.Background(), dataset)
+}
 
-	rows, err := b.Client.Query(fmt.Sprintf(BigQuery_TABLES_QUERY, dataset))
+// TablesContext returns a list of tables in a dataset.
+// The query is cancelled when the context is done.
+func (b *BigQuery) TablesContext(ctx context.Context, dataset string) ([]string, error) {
+	rows, err := b.Client.QueryContext(ctx, BigQuery_TABLES_QUERY, dataset)
 	if err != nil {
 		return nil, fmt.Errorf("error executing sql statement: %v", err)
 	}
@@ -193,18 +357,193 @@ func (b *BigQuery) Tables(dataset string) ([]string, error) {
 	return tables, nil
 }
 
+// ListTables returns the tables, views, materialized views and external tables of a dataset.
+// BigQuery does not record view dependencies, so they are read from the view definitions.
+// The query is cancelled when the context is done.
+func (b *BigQuery) ListTables(ctx context.Context, dataset string, opts types.ListTablesOptions) ([]types.TableInfo, error) {
+	rows, err := b.Client.QueryContext(ctx, fmt.Sprintf(BigQuery_LIST_TABLES_QUERY, quoteDataset(dataset)))
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var tables []types.TableInfo
+	for rows.Next() {
+		var table types.TableInfo
+		if err := rows.Scan(&table.Name, &table.Schema, &table.Kind, &table.Definition); err != nil {
+			return nil, fmt.Errorf("error scanning tables: %v", err)
+		}
+		tables = append(tables, table)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error interating over rows: %v", err)
+	}
+
+	tables = types.FilterTables(tables, opts)
+	dialect.ApplyViewReferences(types.BigQuery, tables)
+	return tables, nil
+}
+
+// quoteDataset quotes a dataset name, optionally qualified with its project as project.dataset.
+func quoteDataset(dataset string) string {
+	name := types.ParseTableName(dataset)
+	return dialect.QuoteTableName(types.BigQuery, types.TableName{Catalog: name.Schema, Schema: name.Name})
+}
+
+// Schemas returns the datasets of a BigQuery project.
+// An empty project name lists the datasets of the project the client is connected to.
+func (b *BigQuery) Schemas(ctx context.Context, project string) ([]string, error) {
+	qualifier := ""
+	if project != "" {
+		qualifier = dialect.QuoteIdentifier(types.BigQuery, project) + "."
+	}
+
+	rows, err := b.Client.QueryContext(ctx, fmt.Sprintf(BigQuery_SCHEMAS_QUERY, qualifier))
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var datasets []string
+	for rows.Next() {
+		var dataset string
+		if err := rows.Scan(&dataset); err != nil {
+			return nil, fmt.Errorf("error scanning dataset: %v", err)
+		}
+		datasets = append(datasets, dataset)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error interating over rows: %v", err)
+	}
+
+	return datasets, nil
+}
+
+// Snapshot reads the schema of every table of the given database matching the options;
+// the columns and descriptions of the dataset are read in bulk from INFORMATION_SCHEMA.
+func (b *BigQuery) Snapshot(ctx context.Context, databaseName string, opts types.SnapshotOptions) (types.DatabaseSchema, error) {
+	return snapshot.Take(ctx, b, types.BigQuery, databaseName, opts)
+}
+
+// BulkSchemas reads the schemas of the given tables of a dataset with one query for the columns
+// and one for the descriptions, instead of two queries per table.
+func (b *BigQuery) BulkSchemas(ctx context.Context, dataset string, tables []types.TableInfo) ([]types.Table, error) {
+	if dataset == "" {
+		dataset = b.Config.Database
+	}
+
+	rows, err := b.Client.QueryContext(ctx, fmt.Sprintf(BigQuery_SNAPSHOT_COLUMNS_QUERY, quoteDataset(dataset)))
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	columns := map[string][]types.Column{}
+	clustering := map[string]map[int64]string{}
+	for rows.Next() {
+		var table string
+		var column types.Column
+		var clusteringPosition sql.NullInt64
+		if err := rows.Scan(&table, &column.Name, &column.Type, &clusteringPosition); err != nil {
+			return nil, fmt.Errorf("error scanning row: %v", err)
+		}
+		if clusteringPosition.Valid {
+			if clustering[table] == nil {
+				clustering[table] = map[int64]string{}
+			}
+			clustering[table][clusteringPosition.Int64] = column.Name
+		}
+		columns[table] = append(columns[table], column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %v", err)
+	}
+
+	comments, err := b.datasetComments(ctx, dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.Table, len(tables))
+	for i, t := range tables {
+		tableColumns := columns[t.Name]
+		indexes := clusteringIndexes(clustering[t.Name])
+		types.ApplyIndexes(tableColumns, indexes)
+		description := types.ApplyComments(tableColumns, comments[t.Name])
+		result[i] = types.Table{
+			Name:        t.Name,
+			Catalog:     b.Config.ProjectID,
+			Schema:      dataset,
+			Columns:     tableColumns,
+			Dataset:     dataset,
+			Description: description,
+			ColumnCount: int64(len(tableColumns)),
+			Indexes:     indexes,
+		}
+	}
+	return result, nil
+}
+
+// datasetComments reads the descriptions of every table and column of a dataset, keyed by table name.
+func (b *BigQuery) datasetComments(ctx context.Context, dataset string) (map[string][]types.Comment, error) {
+	rows, err := b.Client.QueryContext(ctx, fmt.Sprintf(BigQuery_SNAPSHOT_COMMENTS_QUERY, quoteDataset(dataset)))
+	if err != nil {
+		return nil, fmt.Errorf("error executing comment query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	comments := map[string][]types.Comment{}
+	for rows.Next() {
+		var table string
+		var c types.Comment
+		if err := rows.Scan(&table, &c.Column, &c.Text); err != nil {
+			return nil, fmt.Errorf("error scanning comments: %v", err)
+		}
+		comments[table] = append(comments[table], unquoteDescription(c))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over comments: %v", err)
+	}
+	return comments, nil
+}
+
 // GenerateCreateTableQuery generates a CREATE TABLE query for BigQuery.
 func (b *BigQuery) GenerateCreateTableQuery(table types.Table) string {
 	query := "CREATE TABLE " + table.Dataset + "." + table.Name + " ("
 	for i, column := range table.Columns {
 		colType := strings.ToUpper(column.Type)
 		query += column.Name + " " + convertTypeToBigQuery(colType)
+		if column.Description != "" {
+			query += " OPTIONS(description=" + dialect.QuoteString(types.BigQuery, column.Description) + ")"
+		}
 
 		if i < len(table.Columns)-1 {
 			query += ", "
 		}
 	}
-	query += ");"
+	query += ")"
+	if table.Description != "" {
+		query += " OPTIONS(description=" + dialect.QuoteString(types.BigQuery, table.Description) + ")"
+	}
+	query += ";"
 	return query
 }
 
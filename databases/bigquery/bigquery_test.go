@@ -1,16 +1,23 @@
 package bigquery
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	bq "cloud.google.com/go/bigquery"
 	"github.com/DATA-DOG/go-sqlmock"
-	// "github.com/thesaas-company/xray/config"
+	"github.com/thesaas-company/xray/config"
 	"github.com/thesaas-company/xray/types"
+	"google.golang.org/api/option"
 )
 
 // setting up a mock db connection
@@ -38,11 +45,15 @@ func TestSchema(t *testing.T) {
 	table_name := "user" // table name to be used in the test
 
 	// mock rows to be returned by the query
-	columns := []string{"column", "type"}
-	mockRows := sqlmock.NewRows(columns).AddRow("id", "int").AddRow("name", "varchar")
+	columns := []string{"column", "type", "clustering_ordinal_position"}
+	mockRows := sqlmock.NewRows(columns).AddRow("id", "int", 2).AddRow("name", "varchar", 1).AddRow("age", "int", nil)
 	// set the expected return values for the query
-	expectedQuery := fmt.Sprintf("SELECT column_name, data_type FROM %s.INFORMATION_SCHEMA.COLUMNS WHERE table_name='%s'", "", table_name)
-	mock.ExpectQuery(regexp.QuoteMeta(expectedQuery)).WillReturnRows(mockRows)
+	expectedQuery := fmt.Sprintf(BigQuery_SCHEMA_QUERY, "")
+	mock.ExpectQuery(regexp.QuoteMeta(expectedQuery)).WithArgs(table_name).WillReturnRows(mockRows)
+	commentRows := sqlmock.NewRows([]string{"column_name", "description"}).
+		AddRow("", `"Registered \"users\""`).
+		AddRow("id", "Surrogate key")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(BigQuery_COMMENTS_QUERY, ""))).WithArgs(table_name, table_name).WillReturnRows(commentRows)
 
 	// we then create a new instance of our Redshift object and test the function
 	b, err := NewBigQuery(db)
@@ -56,6 +67,19 @@ func TestSchema(t *testing.T) {
 
 	fmt.Printf("Table schema: %+v\n", response)
 
+	wantIndexes := []types.Index{
+		{Name: "clustering", Columns: []string{"name", "id"}, Method: "clustering"},
+	}
+	if !reflect.DeepEqual(response.Indexes, wantIndexes) {
+		t.Errorf("indexes = %+v, want %+v", response.Indexes, wantIndexes)
+	}
+	if response.Description != `Registered "users"` || response.Columns[0].Description != "Surrogate key" {
+		t.Errorf("descriptions = %q, %q", response.Description, response.Columns[0].Description)
+	}
+	if column := response.Columns[2]; column.IsIndex != false || column.IsUnique.String != "NO" {
+		t.Errorf("column %s: IsIndex = %v, IsUnique = %q", column.Name, column.IsIndex, column.IsUnique.String)
+	}
+
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there was unfulfilled expectations: %s", err)
 	}
@@ -80,7 +104,7 @@ func TestTables(t *testing.T) {
 		AddRow(tableList[0]).
 		AddRow(tableList[1]).
 		AddRow(tableList[2])
-	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(BigQuery_TABLES_QUERY, schema))).WillReturnRows(rows) // set the expected return values for the query
+	mock.ExpectQuery(regexp.QuoteMeta(BigQuery_TABLES_QUERY)).WithArgs(schema).WillReturnRows(rows) // set the expected return values for the query
 
 	b, err := NewBigQuery(db) // create a new instance of our BigQuery object
 	if err != nil {
@@ -134,7 +158,7 @@ func TestExecute(t *testing.T) {
 	}
 
 	// Unmarshal the JSON data
-	var result types.BigQueryResult
+	var result types.QueryResult
 	if err := json.Unmarshal(jsonData, &result); err != nil {
 		t.Errorf("error unmarshaling json: %s", err)
 	}
@@ -146,13 +170,16 @@ func TestExecute(t *testing.T) {
 	}
 
 	// Check if the returned rows match the expected rows
-	expectedRows := []map[string]interface{}{
-		{"column1": "value1", "column2": "value2"},
-		{"column1": "value3", "column2": "value4"},
+	expectedRows := [][]interface{}{
+		{"value1", "value2"},
+		{"value3", "value4"},
 	}
 	if !reflect.DeepEqual(result.Rows, expectedRows) {
 		t.Errorf("expected %v, got %v", expectedRows, result.Rows)
 	}
+	if result.RowCount != int64(len(expectedRows)) {
+		t.Errorf("expected row count %d, got %d", len(expectedRows), result.RowCount)
+	}
 
 	// Check if all expectations were met
 	if err := mock.ExpectationsWereMet(); err != nil {
@@ -198,3 +225,474 @@ func TestGenerateCreateTablequery(t *testing.T) {
 	}
 
 }
+
+// TestGenerateCreateTableQueryComments is a unit test function that tests that the GenerateCreateTableQuery method of the
+// BigQuery struct emits the table and column descriptions as description options.
+func TestGenerateCreateTableQueryComments(t *testing.T) {
+	table := types.Table{
+		Dataset:     "test_dataset",
+		Name:        "table_name",
+		Description: "Registered users",
+		Columns: []types.Column{
+			{Name: "id", Type: "INT64", Description: `The "id"`},
+			{Name: "name", Type: "STRING"},
+		},
+	}
+
+	b := &BigQuery{}
+	query := b.GenerateCreateTableQuery(table)
+
+	expectedQuery := `CREATE TABLE test_dataset.table_name (id INT64 OPTIONS(description="The \"id\""), name STRING) OPTIONS(description="Registered users");`
+	if query != expectedQuery {
+		t.Errorf("Expected '%s', but got '%s'", expectedQuery, query)
+	}
+}
+
+// TestTableStats is a unit test function that tests the TableStats method of the BigQuery struct.
+func TestTableStats(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	rows := sqlmock.NewRows([]string{"row_count", "size_bytes", "creation_time", "last_modified_time"}).
+		AddRow(1000, 52000, int64(1700000000000), int64(1700000360000))
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(BigQuery_TABLE_STATS_QUERY, "`sales`"))).WithArgs("orders").WillReturnRows(rows)
+
+	b, err := NewBigQuery(db)
+	if err != nil {
+		t.Errorf("error initializing bigquery: %s", err)
+	}
+	stats, err := b.TableStats("sales.orders")
+	if err != nil {
+		t.Fatalf("error reading table statistics: %s", err)
+	}
+
+	expected := types.TableStats{
+		Name:         "orders",
+		Schema:       "sales",
+		RowCount:     1000,
+		Bytes:        52000,
+		LastDDL:      sql.NullTime{Time: time.UnixMilli(1700000000000).UTC(), Valid: true},
+		LastModified: sql.NullTime{Time: time.UnixMilli(1700000360000).UTC(), Valid: true},
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("expected: %+v, got: %+v", expected, stats)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSnapshot is a unit test function that tests that the Snapshot method of the BigQuery struct reads the
+// whole dataset with bulk INFORMATION_SCHEMA queries.
+func TestSnapshot(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	tableRows := sqlmock.NewRows([]string{"table_name", "table_schema", "kind", "definition"}).
+		AddRow("orders", "sales", "table", "").
+		AddRow("tmp_orders", "sales", "table", "").
+		AddRow("users", "sales", "table", "")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(BigQuery_LIST_TABLES_QUERY, "`sales`"))).WillReturnRows(tableRows)
+	columnRows := sqlmock.NewRows([]string{"table_name", "column_name", "data_type", "clustering_ordinal_position"}).
+		AddRow("orders", "id", "INT64", nil).
+		AddRow("orders", "user_id", "INT64", 1).
+		AddRow("tmp_orders", "id", "INT64", nil).
+		AddRow("users", "id", "INT64", nil)
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(BigQuery_SNAPSHOT_COLUMNS_QUERY, "`sales`"))).WillReturnRows(columnRows)
+	commentRows := sqlmock.NewRows([]string{"table_name", "column_name", "description"}).
+		AddRow("orders", "", `"Customer orders"`).
+		AddRow("users", "id", "User key")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(BigQuery_SNAPSHOT_COMMENTS_QUERY, "`sales`"))).WillReturnRows(commentRows)
+
+	b, err := NewBigQuery(db)
+	if err != nil {
+		t.Errorf("error initializing bigquery: %s", err)
+	}
+	schema, err := b.Snapshot(context.Background(), "sales", types.SnapshotOptions{Exclude: []string{"tmp_*"}})
+	if err != nil {
+		t.Fatalf("error taking snapshot: %s", err)
+	}
+
+	if len(schema.Tables) != 2 {
+		t.Fatalf("expected 2 tables, got %d", len(schema.Tables))
+	}
+	orders, users := schema.Tables[0], schema.Tables[1]
+	if orders.Name != "orders" || orders.Description != "Customer orders" || orders.Kind != types.KindTable {
+		t.Errorf("orders = %+v", orders)
+	}
+	wantIndexes := []types.Index{{Name: "clustering", Columns: []string{"user_id"}, Method: "clustering"}}
+	if !reflect.DeepEqual(orders.Indexes, wantIndexes) {
+		t.Errorf("indexes = %+v, want %+v", orders.Indexes, wantIndexes)
+	}
+	if users.Name != "users" || users.Columns[0].Description != "User key" {
+		t.Errorf("users = %+v", users)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSchemaHostileTableName is a unit test function that tests that hostile names are quoted or sent as parameters.
+func TestSchemaHostileTableName(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	table := "x' OR '1'='1"
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(BigQuery_SCHEMA_QUERY, "`my-project`.`sales\\`; DROP SCHEMA sales; --`"))).
+		WithArgs(table).WillReturnError(fmt.Errorf("not found"))
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(BigQuery_LIST_TABLES_QUERY, "`sales\\\\`"))).WillReturnError(fmt.Errorf("not found"))
+
+	b, err := NewBigQuery(db)
+	if err != nil {
+		t.Fatalf("error initializing bigquery: %s", err)
+	}
+	if _, err := b.Schema("`my-project`.`sales``; DROP SCHEMA sales; --`.`" + table + "`"); err == nil {
+		t.Error("expected an error")
+	}
+	if _, err := b.ListTables(context.Background(), `sales\`, types.ListTablesOptions{}); err == nil {
+		t.Error("expected an error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// dryRunServer is a fake BigQuery API answering dry run jobs, queries reading the events table process 5 GB.
+func dryRunServer(t *testing.T) *bq.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var job struct {
+			Configuration struct {
+				DryRun bool `json:"dryRun"`
+				Query  struct {
+					Query string `json:"query"`
+				} `json:"query"`
+			} `json:"configuration"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&job); err != nil || !job.Configuration.DryRun {
+			http.Error(w, "expected a dry run job", http.StatusBadRequest)
+			return
+		}
+		bytes := "1024"
+		if strings.Contains(job.Configuration.Query.Query, "events") {
+			bytes = "5000000000"
+		}
+		fmt.Fprintf(w, `{"jobReference": {"projectId": "p", "jobId": "dry"}, "status": {"state": "DONE"}, "statistics": {"totalBytesProcessed": %q, "query": {"totalBytesProcessed": %q}}}`, bytes, bytes)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := bq.NewClient(context.Background(), "p", option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("error creating bigquery client: %s", err)
+	}
+	return client
+}
+
+// TestEstimate is a unit test function that tests reading the bytes processed by a query from a dry run.
+func TestEstimate(t *testing.T) {
+	b := &BigQuery{Config: &config.Config{Database: "sales"}, API: dryRunServer(t)}
+
+	estimate, err := b.Estimate(context.Background(), "SELECT id FROM users")
+	if err != nil {
+		t.Fatalf("error estimating the query: %s", err)
+	}
+	if estimate.BytesProcessed != 1024 {
+		t.Errorf("BytesProcessed = %d, want 1024", estimate.BytesProcessed)
+	}
+
+	if _, err := b.Estimate(context.Background(), "SELECT 1; DROP TABLE users"); err == nil {
+		t.Error("expected an error for several statements")
+	}
+	if _, err := (&BigQuery{Config: &config.Config{}}).Estimate(context.Background(), "SELECT 1"); err == nil {
+		t.Error("expected an error without an api client")
+	}
+}
+
+// TestExecuteMaximumBytesBilled is a unit test function that tests that queries processing more bytes than
+// maximum_bytes_billed are refused before they run.
+func TestExecuteMaximumBytesBilled(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM users WHERE id = ?")).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	b := &BigQuery{Client: db, Config: &config.Config{MaximumBytesBilled: 1 << 20}, API: dryRunServer(t)}
+	if _, err := b.ExecuteContext(context.Background(), "SELECT id FROM users WHERE id = ?", 1); err != nil {
+		t.Errorf("error executing the query: %s", err)
+	}
+	_, err := b.Execute("SELECT * FROM events")
+	if err == nil || !strings.Contains(err.Error(), "5000000000 bytes") {
+		t.Errorf("Execute() error = %v, want the query to be refused", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// queryPlanServer is a fake BigQuery API running query jobs at once and reporting a plan of two stages.
+func queryPlanServer(t *testing.T) *bq.Client {
+	job := `{"jobReference": {"projectId": "p", "jobId": "run"}, "configuration": {"query": {"query": "SELECT"}}, "status": {"state": "DONE"},
+		"statistics": {"query": {"queryPlan": [
+			{"id": "0", "name": "S00: Input", "recordsWritten": "1000", "startMs": "1000", "endMs": "1250",
+				"steps": [{"kind": "READ", "substeps": ["$1:region", "FROM sales.orders"]}]},
+			{"id": "1", "name": "S01: Output", "inputStages": ["0"], "recordsWritten": "20", "startMs": "1250", "endMs": "1300",
+				"steps": [{"kind": "READ", "substeps": ["FROM __stage00_output"]}]}
+		]}}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/queries/") {
+			fmt.Fprint(w, `{"jobReference": {"projectId": "p", "jobId": "run"}, "jobComplete": true}`)
+			return
+		}
+		fmt.Fprint(w, job)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := bq.NewClient(context.Background(), "p", option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("error creating bigquery client: %s", err)
+	}
+	return client
+}
+
+// TestExplain is a unit test function that tests converting the stages of a query job to a plan tree.
+func TestExplain(t *testing.T) {
+	b := &BigQuery{Config: &config.Config{Database: "sales"}, API: queryPlanServer(t)}
+
+	plan, err := b.Explain(context.Background(), "SELECT region, COUNT(*) FROM orders GROUP BY region", true)
+	if err != nil {
+		t.Fatalf("error explaining the query: %s", err)
+	}
+	want := &types.PlanNode{Type: "S01: Output", ActualRows: 20, ActualTime: 50, Children: []*types.PlanNode{
+		{Type: "S00: Input", Relation: "sales.orders", ActualRows: 1000, ActualTime: 250},
+	}}
+	if !plan.Analyzed || !reflect.DeepEqual(plan.Root, want) {
+		t.Errorf("Explain() = %+v, want %+v", plan.Root, want)
+	}
+
+	if _, err := b.Explain(context.Background(), "SELECT 1", false); err == nil {
+		t.Error("expected an error without analyze")
+	}
+}
+
+// sessionServer is a fake BigQuery API recording the statements it runs with their session, every statement returns the row 1.
+// The job creating a session reports the session id s1.
+type sessionServer struct {
+	statements []string
+}
+
+func (s *sessionServer) client(t *testing.T) *bq.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			var job struct {
+				Configuration struct {
+					Query struct {
+						Query                string `json:"query"`
+						CreateSession        bool   `json:"createSession"`
+						ConnectionProperties []struct {
+							Value string `json:"value"`
+						} `json:"connectionProperties"`
+					} `json:"query"`
+				} `json:"configuration"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			q := job.Configuration.Query
+			session := "new"
+			if !q.CreateSession && len(q.ConnectionProperties) == 1 {
+				session = q.ConnectionProperties[0].Value
+			}
+			s.statements = append(s.statements, session+": "+q.Query)
+			fmt.Fprint(w, `{"jobReference": {"projectId": "p", "jobId": "j"}, "configuration": {"query": {"query": "SELECT"}}, "status": {"state": "DONE"}, "statistics": {"sessionInfo": {"sessionId": "s1"}}}`)
+		case strings.Contains(r.URL.Path, "/queries/"):
+			fmt.Fprint(w, `{"jobReference": {"projectId": "p", "jobId": "j"}, "jobComplete": true, "totalRows": "1",
+				"schema": {"fields": [{"name": "n", "type": "INTEGER", "mode": "REQUIRED"}]}, "rows": [{"f": [{"v": "1"}]}]}`)
+		default:
+			fmt.Fprint(w, `{"jobReference": {"projectId": "p", "jobId": "j"}, "configuration": {"query": {"query": "SELECT"}}, "status": {"state": "DONE"}, "statistics": {"sessionInfo": {"sessionId": "s1"}}}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := bq.NewClient(context.Background(), "p", option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("error creating bigquery client: %s", err)
+	}
+	return client
+}
+
+// TestBegin is a unit test function that tests running a multi-statement transaction in a session of its own.
+func TestBegin(t *testing.T) {
+	server := &sessionServer{}
+	b := &BigQuery{Config: &config.Config{Database: "sales"}, API: server.client(t)}
+
+	tx, err := b.Begin(context.Background(), types.TxOptions{})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if _, err := tx.Execute(context.Background(), "UPDATE orders SET paid = TRUE WHERE id = 1"); err != nil {
+		t.Fatalf("error executing statement: %s", err)
+	}
+	result, err := tx.Execute(context.Background(), "SELECT COUNT(*) AS n FROM orders WHERE paid")
+	if err != nil {
+		t.Fatalf("error executing statement: %s", err)
+	}
+	var queryResult types.QueryResult
+	if err := json.Unmarshal(result, &queryResult); err != nil || queryResult.RowCount != 1 || queryResult.Columns[0] != "n" {
+		t.Errorf("unexpected result %s, %v", result, err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("error committing transaction: %s", err)
+	}
+
+	want := []string{
+		"new: BEGIN TRANSACTION",
+		"s1: UPDATE orders SET paid = TRUE WHERE id = 1",
+		"s1: SELECT COUNT(*) AS n FROM orders WHERE paid",
+		"s1: COMMIT TRANSACTION",
+		"s1: CALL BQ.ABORT_SESSION()",
+	}
+	if !reflect.DeepEqual(server.statements, want) {
+		t.Errorf("statements = %q, want %q", server.statements, want)
+	}
+
+	if _, err := b.Begin(context.Background(), types.TxOptions{Isolation: sql.LevelSerializable}); err == nil {
+		t.Error("expected an error for an unsupported isolation level")
+	}
+}
+
+// TestExecuteReadOnly is a unit test function that tests that read-only mode rejects writes before they reach the database.
+func TestExecuteReadOnly(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	query := "SELECT IF(amount > 0, 'credit', 'debit') AS side FROM `sales.orders`"
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"side"}).AddRow("credit"))
+
+	b := &BigQuery{Client: db, Config: &config.Config{ReadOnly: true}}
+	if _, err := b.Execute(query); err != nil {
+		t.Errorf("error executing query: %s", err)
+	}
+	// BigQuery has no read-only transaction, statements of a script following a SELECT are rejected
+	for _, write := range []string{
+		"SELECT 1\nBEGIN TRANSACTION",
+		"SELECT 1\nDECLARE x INT64 DEFAULT 1",
+		"SELECT 1\nEXPORT DATA OPTIONS (uri = 'gs://bucket/*.csv') AS SELECT * FROM `sales.orders`",
+	} {
+		if _, err := b.Execute(write); err == nil {
+			t.Errorf("Execute(%q): expected an error", write)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestListTables is a unit test function that tests the ListTables method of the BigQuery struct.
+// The dependencies of the views are read from their definitions, unqualified names resolve to the dataset of the view.
+func TestListTables(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	rows := sqlmock.NewRows([]string{"table_name", "table_schema", "kind", "definition"}).
+		AddRow("clicks", "sales", "external_table", "").
+		AddRow("order_totals", "sales", "materialized_view", "SELECT user_id, SUM(total) FROM orders GROUP BY user_id").
+		AddRow("orders", "sales", "table", "").
+		AddRow("recent_orders", "sales", "view", "SELECT * FROM `my-project.sales.orders` o JOIN crm.users u ON u.id = o.user_id")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(BigQuery_LIST_TABLES_QUERY, "`my-project`.`sales`"))).WillReturnRows(rows)
+
+	b, err := NewBigQuery(db)
+	if err != nil {
+		t.Fatalf("error initializing bigquery: %s", err)
+	}
+	tables, err := b.ListTables(context.Background(), "my-project.sales", types.ListTablesOptions{Kinds: []types.TableKind{types.KindView, types.KindMaterializedView}})
+	if err != nil {
+		t.Fatalf("error listing tables: %s", err)
+	}
+
+	expected := []types.TableInfo{
+		{Name: "order_totals", Schema: "sales", Kind: types.KindMaterializedView, Definition: "SELECT user_id, SUM(total) FROM orders GROUP BY user_id",
+			Dependencies: []types.TableName{{Schema: "sales", Name: "orders"}}},
+		{Name: "recent_orders", Schema: "sales", Kind: types.KindView, Definition: "SELECT * FROM `my-project.sales.orders` o JOIN crm.users u ON u.id = o.user_id",
+			Dependencies: []types.TableName{{Catalog: "my-project", Schema: "sales", Name: "orders"}, {Schema: "crm", Name: "users"}}},
+	}
+	if !reflect.DeepEqual(tables, expected) {
+		t.Errorf("expected: %+v, got: %+v", expected, tables)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSchemas is a unit test function that tests the Schemas method of the BigQuery struct.
+func TestSchemas(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	rows := sqlmock.NewRows([]string{"schema_name"}).AddRow("analytics").AddRow("sales")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(BigQuery_SCHEMAS_QUERY, "`my-project`."))).WillReturnRows(rows)
+
+	b, err := NewBigQuery(db)
+	if err != nil {
+		t.Errorf("error initialising bigquery: %s", err)
+	}
+	schemas, err := b.Schemas(context.Background(), "my-project")
+	if err != nil {
+		t.Errorf("error retrieving schemas: %s", err)
+	}
+
+	expected := []string{"analytics", "sales"}
+	if !reflect.DeepEqual(schemas, expected) {
+		t.Errorf("expected: %v, got: %v", expected, schemas)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSetReadOnly is a unit test function that tests that the read-only mode of a BigQuery client does not change
+// the mode of the other clients sharing its configuration.
+func TestSetReadOnly(t *testing.T) {
+	cfg := &config.Config{}
+	b, other := &BigQuery{Config: cfg}, &BigQuery{Config: cfg}
+	b.SetReadOnly(true)
+	if !b.Config.ReadOnly || other.Config.ReadOnly || cfg.ReadOnly {
+		t.Errorf("SetReadOnly(true) changed the shared configuration %+v", cfg)
+	}
+}
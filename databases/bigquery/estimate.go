@@ -0,0 +1,82 @@
+package bigquery
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	bq "cloud.google.com/go/bigquery"
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/types"
+)
+
+// Estimate returns the number of bytes BigQuery would process for a single statement, read from a dry run.
+// A dry run is free and does not read any data; it needs the API client set by NewBigQueryWithConfig.
+func (b *BigQuery) Estimate(ctx context.Context, query string) (types.CostEstimate, error) {
+	if _, err := dialect.SingleStatement(types.BigQuery, query); err != nil {
+		return types.CostEstimate{}, fmt.Errorf("error estimating query: %v", err)
+	}
+	return b.dryRun(ctx, query, nil)
+}
+
+// dryRun runs a dry run of a query with the arguments bound by dialect.Bind.
+func (b *BigQuery) dryRun(ctx context.Context, query string, args []interface{}) (types.CostEstimate, error) {
+	if b.API == nil {
+		return types.CostEstimate{}, fmt.Errorf("error estimating query: a dry run needs the BigQuery API client")
+	}
+
+	q := b.API.Query(query)
+	q.DryRun = true
+	q.DefaultDatasetID = b.Config.Database
+	q.Parameters = queryParameters(args)
+
+	job, err := q.Run(ctx)
+	if err != nil {
+		return types.CostEstimate{}, fmt.Errorf("error running dry run: %v", err)
+	}
+	status := job.LastStatus()
+	if status == nil || status.Statistics == nil {
+		return types.CostEstimate{}, fmt.Errorf("error running dry run: no statistics returned")
+	}
+	if err := status.Err(); err != nil {
+		return types.CostEstimate{}, fmt.Errorf("error running dry run: %v", err)
+	}
+
+	plan, err := json.Marshal(status.Statistics)
+	if err != nil {
+		return types.CostEstimate{}, fmt.Errorf("error marshaling json: %v", err)
+	}
+	return types.CostEstimate{
+		BytesProcessed: status.Statistics.TotalBytesProcessed,
+		Plan:           string(plan),
+	}, nil
+}
+
+// queryParameters converts bind arguments to query parameters, sql.NamedArg values become named parameters.
+func queryParameters(args []interface{}) []bq.QueryParameter {
+	var params []bq.QueryParameter
+	for _, arg := range args {
+		if named, ok := arg.(sql.NamedArg); ok {
+			params = append(params, bq.QueryParameter{Name: named.Name, Value: named.Value})
+			continue
+		}
+		params = append(params, bq.QueryParameter{Value: arg})
+	}
+	return params
+}
+
+// checkBytesBilled refuses a query whose dry run processes more bytes than the configured maximum_bytes_billed.
+func (b *BigQuery) checkBytesBilled(ctx context.Context, query string, args []interface{}) error {
+	if b.Config.MaximumBytesBilled <= 0 {
+		return nil
+	}
+	estimate, err := b.dryRun(ctx, query, args)
+	if err != nil {
+		return err
+	}
+	if estimate.BytesProcessed > b.Config.MaximumBytesBilled {
+		return fmt.Errorf("query would process %d bytes, more than the maximum of %d bytes billed", estimate.BytesProcessed, b.Config.MaximumBytesBilled)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package bigquery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	bq "cloud.google.com/go/bigquery"
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/types"
+)
+
+// Explain returns the plan of a single statement, read from the stages of its query job.
+// BigQuery only reports the plan of a job that has run, so analyze is required; the statement runs through
+// the API client set by NewBigQueryWithConfig and, unlike the other databases, its writes are not rolled back.
+// The stages hold the records they wrote and their time, BigQuery plans hold no estimates.
+func (b *BigQuery) Explain(ctx context.Context, query string, analyze bool) (types.Plan, error) {
+	if !analyze {
+		return types.Plan{}, fmt.Errorf("error explaining query: bigquery only reports the plan of executed queries, use analyze")
+	}
+	statement, err := dialect.SingleStatement(types.BigQuery, query)
+	if err != nil {
+		return types.Plan{}, fmt.Errorf("error explaining query: %v", err)
+	}
+	if b.Config.ReadOnly {
+		if err := dialect.CheckReadOnly(types.BigQuery, statement.Text); err != nil {
+			return types.Plan{}, err
+		}
+	}
+	if b.API == nil {
+		return types.Plan{}, fmt.Errorf("error explaining query: the query plan needs the BigQuery API client")
+	}
+
+	q := b.API.Query(statement.Text)
+	q.DefaultDatasetID = b.Config.Database
+	if b.Config.MaximumBytesBilled > 0 {
+		q.MaxBytesBilled = b.Config.MaximumBytesBilled
+	}
+	job, err := q.Run(ctx)
+	if err != nil {
+		return types.Plan{}, fmt.Errorf("error running query: %v", err)
+	}
+	status, err := job.Wait(ctx)
+	if err != nil {
+		return types.Plan{}, fmt.Errorf("error running query: %v", err)
+	}
+	if err := status.Err(); err != nil {
+		return types.Plan{}, fmt.Errorf("error running query: %v", err)
+	}
+	stats, ok := status.Statistics.Details.(*bq.QueryStatistics)
+	if !ok || len(stats.QueryPlan) == 0 {
+		return types.Plan{}, fmt.Errorf("error explaining query: no query plan returned")
+	}
+
+	raw, err := json.Marshal(stats.QueryPlan)
+	if err != nil {
+		return types.Plan{}, fmt.Errorf("error marshaling json: %v", err)
+	}
+	return types.Plan{Root: stageTree(stats.QueryPlan), Analyzed: true, Format: "json", Raw: string(raw)}, nil
+}
+
+// stageTree converts the stages of a query job to a plan tree. The stages form a graph through their input stages,
+// the root is the last stage no other stage reads from.
+func stageTree(stages []*bq.ExplainQueryStage) *types.PlanNode {
+	nodes := make(map[int64]*types.PlanNode, len(stages))
+	read := make(map[int64]bool)
+	for _, stage := range stages {
+		node := &types.PlanNode{
+			Type:       stage.Name,
+			ActualRows: float64(stage.RecordsWritten),
+			ActualTime: float64(stage.EndTime.Sub(stage.StartTime).Microseconds()) / 1000,
+		}
+		for _, step := range stage.Steps {
+			for _, substep := range step.Substeps {
+				// the outputs of other stages are read FROM __stage00_output
+				if node.Relation == "" && step.Kind == "READ" && strings.HasPrefix(substep, "FROM ") && !strings.HasPrefix(substep, "FROM __") {
+					node.Relation = strings.TrimPrefix(substep, "FROM ")
+				}
+			}
+		}
+		nodes[stage.ID] = node
+		for _, input := range stage.InputStages {
+			read[input] = true
+		}
+	}
+
+	var root *types.PlanNode
+	for _, stage := range stages {
+		for _, input := range stage.InputStages {
+			if child, ok := nodes[input]; ok {
+				nodes[stage.ID].Children = append(nodes[stage.ID].Children, child)
+			}
+		}
+		if !read[stage.ID] {
+			root = nodes[stage.ID]
+		}
+	}
+	return root
+}
@@ -0,0 +1,327 @@
+package bigquery
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bq "cloud.google.com/go/bigquery"
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/governor"
+	"github.com/thesaas-company/xray/script"
+	"github.com/thesaas-company/xray/types"
+	"google.golang.org/api/iterator"
+)
+
+const (
+	BIGQUERY_BEGIN_QUERY         = "BEGIN TRANSACTION"       // BIGQUERY_BEGIN_QUERY starts a multi-statement transaction in the session.
+	BIGQUERY_COMMIT_QUERY        = "COMMIT TRANSACTION"      // BIGQUERY_COMMIT_QUERY commits the transaction of the session.
+	BIGQUERY_ROLLBACK_QUERY      = "ROLLBACK TRANSACTION"    // BIGQUERY_ROLLBACK_QUERY aborts the transaction of the session.
+	BIGQUERY_ABORT_SESSION_QUERY = "CALL BQ.ABORT_SESSION()" // BIGQUERY_ABORT_SESSION_QUERY ends the session and aborts its open transaction.
+)
+
+// bigQuerySession is a BigQuery session, the jobs of its statements share the session created by the first one.
+type bigQuerySession struct {
+	b  *BigQuery
+	id string // id is the session id, empty until the first statement ran
+}
+
+// bigQueryTx is a multi-statement transaction in a BigQuery session.
+type bigQueryTx struct {
+	session  *bigQuerySession
+	readOnly bool // readOnly rejects the statements that are not reads
+	owned    bool // owned closes the session when the transaction ends
+}
+
+// bigQueryRowIterator is a types.RowIterator over the rows of a query job.
+type bigQueryRowIterator struct {
+	it      *bq.RowIterator
+	columns []types.ColumnMeta
+	convert types.ValueConverter
+	row     []interface{}
+	next    []bq.Value // next is the first row, read ahead to learn the schema
+	err     error
+}
+
+// Begin starts a multi-statement transaction in a new session, the session ends with the transaction.
+// BigQuery transactions use snapshot isolation, so only the default level and SNAPSHOT are accepted;
+// read-only transactions are checked with dialect.CheckReadOnly. Statements run through the API client
+// set by NewBigQueryWithConfig.
+func (b *BigQuery) Begin(ctx context.Context, opts types.TxOptions) (types.Tx, error) {
+	s, err := b.Session(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.(*bigQuerySession).begin(ctx, opts)
+	if err != nil {
+		_ = s.Close()
+		return nil, err
+	}
+	tx.owned = true
+	return tx, nil
+}
+
+// Session starts a BigQuery session, in which temporary tables and variables hold between statements.
+// The session is created by its first statement.
+func (b *BigQuery) Session(ctx context.Context) (types.Session, error) {
+	if b.API == nil {
+		return nil, fmt.Errorf("error opening session: sessions need the BigQuery API client")
+	}
+	return &bigQuerySession{b: b}, nil
+}
+
+// ExecuteScript runs the statements of a script one after the other in a BigQuery session.
+func (b *BigQuery) ExecuteScript(ctx context.Context, query string, opts types.ScriptOptions) (types.ScriptResult, error) {
+	return script.Run(ctx, b, types.BigQuery, query, opts)
+}
+
+// Execute executes a statement in the session and returns its result as JSON.
+func (s *bigQuerySession) Execute(ctx context.Context, query string, args ...interface{}) ([]byte, error) {
+	return execute(ctx, s, s.b.Config.MaxRows, s.b.Config.MaxBytes, query, args)
+}
+
+// Query executes a statement in the session and streams its rows.
+func (s *bigQuerySession) Query(ctx context.Context, query string, args ...interface{}) (types.RowIterator, error) {
+	return s.query(ctx, s.b.Config.ReadOnly, query, args)
+}
+
+// Begin starts a multi-statement transaction in the session.
+func (s *bigQuerySession) Begin(ctx context.Context, opts types.TxOptions) (types.Tx, error) {
+	tx, err := s.begin(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// Close ends the session, an open transaction is aborted.
+func (s *bigQuerySession) Close() error {
+	if s.id == "" {
+		return nil
+	}
+	if err := s.run(context.Background(), BIGQUERY_ABORT_SESSION_QUERY); err != nil {
+		return fmt.Errorf("error closing session: %v", err)
+	}
+	s.id = ""
+	return nil
+}
+
+// begin runs BEGIN TRANSACTION in the session.
+func (s *bigQuerySession) begin(ctx context.Context, opts types.TxOptions) (*bigQueryTx, error) {
+	if opts.Isolation != sql.LevelDefault && opts.Isolation != sql.LevelSnapshot {
+		return nil, fmt.Errorf("isolation level %s is not supported by %s", opts.Isolation, types.BigQuery)
+	}
+	if err := s.run(ctx, BIGQUERY_BEGIN_QUERY); err != nil {
+		return nil, fmt.Errorf("error starting transaction: %v", err)
+	}
+	return &bigQueryTx{session: s, readOnly: opts.ReadOnly || s.b.Config.ReadOnly}, nil
+}
+
+// run executes a statement in the session and discards its result.
+func (s *bigQuerySession) run(ctx context.Context, query string) error {
+	_, err := s.runJob(ctx, false, query, nil)
+	return err
+}
+
+// Exec executes a statement in the session and returns the number of rows changed by DML statements.
+func (s *bigQuerySession) Exec(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	return s.exec(ctx, s.b.Config.ReadOnly, query, args)
+}
+
+// query runs a statement in the session and streams its rows.
+func (s *bigQuerySession) query(ctx context.Context, readOnly bool, query string, args []interface{}) (types.RowIterator, error) {
+	job, err := s.runJob(ctx, readOnly, query, args)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := job.Read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error reading query results: %v", err)
+	}
+	return newBigQueryRowIterator(rows, newValueConverter(types.BinaryEncoding(s.b.Config.BinaryEncoding))), nil
+}
+
+// exec runs a statement in the session and returns the number of rows changed by DML statements, 0 for other statements.
+func (s *bigQuerySession) exec(ctx context.Context, readOnly bool, query string, args []interface{}) (int64, error) {
+	job, err := s.runJob(ctx, readOnly, query, args)
+	if err != nil {
+		return 0, err
+	}
+	if stats, ok := job.LastStatus().Statistics.Details.(*bq.QueryStatistics); ok {
+		return stats.NumDMLAffectedRows, nil
+	}
+	return 0, nil
+}
+
+// runJob runs a statement as a job of the session and waits for it to complete. The first job creates the session.
+func (s *bigQuerySession) runJob(ctx context.Context, readOnly bool, query string, args []interface{}) (*bq.Job, error) {
+	query, args, err := dialect.Bind(types.BigQuery, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error binding query arguments: %v", err)
+	}
+	if readOnly {
+		if err := dialect.CheckReadOnly(types.BigQuery, query); err != nil {
+			return nil, err
+		}
+	}
+
+	q := s.b.API.Query(query)
+	q.DefaultDatasetID = s.b.Config.Database
+	q.Parameters = queryParameters(args)
+	if s.b.Config.MaximumBytesBilled > 0 {
+		q.MaxBytesBilled = s.b.Config.MaximumBytesBilled
+	}
+	if s.id == "" {
+		q.CreateSession = true
+	} else {
+		q.ConnectionProperties = []*bq.ConnectionProperty{{Key: "session_id", Value: s.id}}
+	}
+
+	job, err := q.Run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+	status, err := job.Wait(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+	if err := status.Err(); err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+	if status.Statistics == nil {
+		return nil, fmt.Errorf("error executing sql statement: no statistics returned")
+	}
+	if s.id == "" && status.Statistics.SessionInfo != nil {
+		s.id = status.Statistics.SessionInfo.SessionID
+	}
+	return job, nil
+}
+
+// Execute executes a statement in the transaction and returns its result as JSON.
+func (t *bigQueryTx) Execute(ctx context.Context, query string, args ...interface{}) ([]byte, error) {
+	return execute(ctx, t, t.session.b.Config.MaxRows, t.session.b.Config.MaxBytes, query, args)
+}
+
+// Query executes a statement in the transaction and streams its rows.
+func (t *bigQueryTx) Query(ctx context.Context, query string, args ...interface{}) (types.RowIterator, error) {
+	return t.session.query(ctx, t.readOnly, query, args)
+}
+
+// Exec executes a statement in the transaction and returns the number of rows changed by DML statements.
+func (t *bigQueryTx) Exec(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	return t.session.exec(ctx, t.readOnly, query, args)
+}
+
+// Commit commits the transaction.
+func (t *bigQueryTx) Commit() error {
+	return t.end(BIGQUERY_COMMIT_QUERY)
+}
+
+// Rollback aborts the transaction.
+func (t *bigQueryTx) Rollback() error {
+	return t.end(BIGQUERY_ROLLBACK_QUERY)
+}
+
+// end runs COMMIT or ROLLBACK and closes the session owned by the transaction.
+func (t *bigQueryTx) end(query string) error {
+	err := t.session.run(context.Background(), query)
+	if t.owned {
+		if closeErr := t.session.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("error ending transaction: %v", err)
+	}
+	return nil
+}
+
+// execute reads the result of a statement within the size limits and marshals it to JSON.
+func execute(ctx context.Context, q governor.Querier, maxRows, maxBytes int64, query string, args []interface{}) ([]byte, error) {
+	start := time.Now()
+	queryResult, err := governor.Execute(ctx, q, types.BigQuery, governor.Limits{MaxRows: maxRows, MaxBytes: maxBytes}, query, "", args...)
+	if err != nil {
+		return nil, err
+	}
+	queryResult.Time = time.Since(start).Milliseconds()
+
+	jsonData, err := json.Marshal(queryResult)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %v", err)
+	}
+	return jsonData, nil
+}
+
+// newBigQueryRowIterator reads the first row of a job result, the schema is only known once a page is read.
+func newBigQueryRowIterator(rows *bq.RowIterator, convert types.ValueConverter) *bigQueryRowIterator {
+	it := &bigQueryRowIterator{it: rows, convert: convert}
+	var first []bq.Value
+	switch err := rows.Next(&first); err {
+	case nil:
+		it.next = first
+	case iterator.Done:
+	default:
+		it.err = err
+	}
+	for _, field := range rows.Schema {
+		it.columns = append(it.columns, types.ColumnMeta{Name: field.Name, DatabaseType: string(field.Type), Nullable: !field.Required})
+	}
+	return it
+}
+
+// Columns returns the metadata of the result columns.
+func (it *bigQueryRowIterator) Columns() []types.ColumnMeta {
+	return it.columns
+}
+
+// Next advances the iterator to the next row and reports whether there is one.
+func (it *bigQueryRowIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	values := it.next
+	it.next = nil
+	if values == nil {
+		if err := it.it.Next(&values); err != nil {
+			if err != iterator.Done {
+				it.err = err
+			}
+			return false
+		}
+	}
+
+	it.row = make([]interface{}, len(values))
+	for i, v := range values {
+		var column types.ColumnMeta
+		if i < len(it.columns) {
+			column = it.columns[i]
+		}
+		if i < len(it.it.Schema) {
+			v = namedRecord(it.it.Schema[i], v)
+		}
+		converted, err := it.convert(column, v)
+		if err != nil {
+			it.err = fmt.Errorf("error converting column %s: %v", column.Name, err)
+			return false
+		}
+		it.row[i] = converted
+	}
+	return true
+}
+
+// Row returns the converted values of the current row.
+func (it *bigQueryRowIterator) Row() []interface{} {
+	return it.row
+}
+
+// Err returns the error, if any, that was encountered during iteration.
+func (it *bigQueryRowIterator) Err() error {
+	return it.err
+}
+
+// Close releases the iterator, the rows of a job need no cleanup.
+func (it *bigQueryRowIterator) Close() error {
+	return nil
+}
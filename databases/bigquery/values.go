@@ -0,0 +1,124 @@
+package bigquery
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	bq "cloud.google.com/go/bigquery"
+	"github.com/thesaas-company/xray/types"
+)
+
+// bigNumericScale is the maximum scale of BIGNUMERIC values; NUMERIC values use a scale of 9.
+const bigNumericScale = 38
+
+// newValueConverter returns the converter for values scanned from BigQuery.
+// NUMERIC and BIGNUMERIC become exact decimal strings, DATE/TIME/DATETIME their canonical strings,
+// TIMESTAMP an RFC3339 string and RECORD/STRUCT values and arrays nested JSON.
+// BYTES values are encoded with the given encoding.
+func newValueConverter(binary types.BinaryEncoding) types.ValueConverter {
+	return func(column types.ColumnMeta, v interface{}) (interface{}, error) {
+		return convertValue(column, v, binary)
+	}
+}
+
+// convertValue converts a single value scanned from BigQuery.
+func convertValue(_ types.ColumnMeta, v interface{}, binary types.BinaryEncoding) (interface{}, error) {
+	switch value := v.(type) {
+	case nil:
+		return nil, nil
+	case float64:
+		return types.FloatValue(value), nil
+	case time.Time:
+		return types.TimestampValue(value), nil
+	case *big.Rat:
+		return decimalString(value), nil
+	case []bq.Value, map[string]bq.Value:
+		// arrays and records read through the API iterator hold unconverted values
+		return convertNested(value, binary)
+	case json.Marshaler:
+		// RECORD and repeated columns are handed over as JSON marshalable values
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling record: %v", err)
+		}
+		return json.RawMessage(b), nil
+	case fmt.Stringer:
+		// civil.Date, civil.Time and civil.DateTime
+		return value.String(), nil
+	case []byte:
+		// RECORD values arrive as json.Marshaler, so []byte is always a BYTES column
+		return binary.Encode(value)
+	default:
+		return value, nil
+	}
+}
+
+// convertNested converts the elements of an array or the fields of a record and returns them as JSON.
+func convertNested(v interface{}, binary types.BinaryEncoding) (interface{}, error) {
+	var nested interface{}
+	switch value := v.(type) {
+	case []bq.Value:
+		elements := make([]interface{}, len(value))
+		for i, element := range value {
+			converted, err := convertValue(types.ColumnMeta{}, element, binary)
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = converted
+		}
+		nested = elements
+	case map[string]bq.Value:
+		fields := make(map[string]interface{}, len(value))
+		for name, field := range value {
+			converted, err := convertValue(types.ColumnMeta{}, field, binary)
+			if err != nil {
+				return nil, fmt.Errorf("error converting field %s: %v", name, err)
+			}
+			fields[name] = converted
+		}
+		nested = fields
+	}
+	b, err := json.Marshal(nested)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling record: %v", err)
+	}
+	return json.RawMessage(b), nil
+}
+
+// namedRecord names the fields of the RECORD values read through the API iterator, which returns them as
+// []bq.Value in the order of the field schema. Values of other fields are returned as they are.
+func namedRecord(field *bq.FieldSchema, v bq.Value) bq.Value {
+	if field == nil || len(field.Schema) == 0 {
+		return v
+	}
+	values, ok := v.([]bq.Value)
+	if !ok {
+		return v
+	}
+	if field.Repeated {
+		records := make([]bq.Value, len(values))
+		element := *field
+		element.Repeated = false
+		for i, record := range values {
+			records[i] = namedRecord(&element, record)
+		}
+		return records
+	}
+	record := make(map[string]bq.Value, len(values))
+	for i, value := range values {
+		if i < len(field.Schema) {
+			record[field.Schema[i].Name] = namedRecord(field.Schema[i], value)
+		}
+	}
+	return record
+}
+
+// decimalString formats r as an exact decimal string without trailing zeros.
+func decimalString(r *big.Rat) string {
+	s := r.FloatString(bigNumericScale)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
@@ -0,0 +1,67 @@
+package bigquery
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	bq "cloud.google.com/go/bigquery"
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestConvertValue is a unit test function that tests how BigQuery values are mapped to JSON friendly values.
+func TestConvertValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{name: "null", value: nil, want: nil},
+		{name: "integer", value: int64(7), want: int64(7)},
+		{name: "numeric", value: big.NewRat(5, 4), want: "1.25"},
+		{name: "whole numeric", value: big.NewRat(10, 1), want: "10"},
+		{name: "string", value: "abc", want: "abc"},
+		{name: "base64 looking string", value: "test", want: "test"},
+		{name: "bytes", value: []byte("test"), want: "dGVzdA=="},
+		{name: "array", value: []bq.Value{int64(1), big.NewRat(1, 2)}, want: json.RawMessage(`[1,"0.5"]`)},
+		{name: "record", value: map[string]bq.Value{"id": int64(1), "key": []byte("x")}, want: json.RawMessage(`{"id":1,"key":"eA=="}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertValue(types.ColumnMeta{}, tt.value, "")
+			if err != nil {
+				t.Fatalf("convertValue() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("convertValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNamedRecord is a unit test function that tests naming the fields of the records read through the API iterator.
+func TestNamedRecord(t *testing.T) {
+	field := &bq.FieldSchema{Name: "orders", Type: bq.RecordFieldType, Repeated: true, Schema: bq.Schema{
+		{Name: "id", Type: bq.IntegerFieldType},
+		{Name: "total", Type: bq.NumericFieldType},
+		{Name: "customer", Type: bq.RecordFieldType, Schema: bq.Schema{{Name: "name", Type: bq.StringFieldType}}},
+	}}
+	value := []bq.Value{
+		[]bq.Value{int64(1), big.NewRat(25, 2), []bq.Value{"Rohan"}},
+	}
+
+	got, err := convertValue(types.ColumnMeta{}, namedRecord(field, value), "")
+	if err != nil {
+		t.Fatalf("convertValue() error = %v", err)
+	}
+	want := json.RawMessage(`[{"customer":{"name":"Rohan"},"id":1,"total":"12.5"}]`)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("convertValue() = %s, want %s", got, want)
+	}
+
+	// values of scalar fields are left alone
+	if got := namedRecord(&bq.FieldSchema{Name: "id", Type: bq.IntegerFieldType}, int64(7)); got != int64(7) {
+		t.Errorf("namedRecord() = %#v, want 7", got)
+	}
+}
@@ -0,0 +1,127 @@
+package mssql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/diff"
+	"github.com/thesaas-company/xray/types"
+)
+
+// GenerateAlterTableQueries generates the ALTER TABLE statements turning the from table into the to table.
+// Defaults are named constraints in SQL Server whose names are not part of types.Column, so dropping or
+// replacing a default is reported as a warning instead of a statement.
+func (m *MSSQL) GenerateAlterTableQueries(from, to types.Table) types.Migration {
+	var migration types.Migration
+	d := diff.Tables(from, to, types.MSSQL, types.MSSQL)
+	table := dialect.QuoteTableName(types.MSSQL, types.TableName{Schema: to.Schema, Name: to.Name})
+
+	for _, index := range d.RemovedIndexes {
+		migration.Add("DROP INDEX " + dialect.QuoteIdentifier(types.MSSQL, index.Name) + " ON " + table + ";")
+	}
+	if d.PrimaryKey != nil && d.PrimaryKey.From != "" {
+		if name := diff.PrimaryKeyName(from); name != "" {
+			migration.Add("ALTER TABLE " + table + " DROP CONSTRAINT " + dialect.QuoteIdentifier(types.MSSQL, name) + ";")
+		} else {
+			migration.Warn(fmt.Sprintf("the primary key constraint of %s is not known, drop it before running the migration", from.Name))
+		}
+	}
+
+	for _, column := range d.RemovedColumns {
+		if mssqlDefault(column).Valid {
+			migration.Warn(fmt.Sprintf("the default constraint of column %s must be dropped before the column", column.Name))
+		}
+		migration.Add("ALTER TABLE " + table + " DROP COLUMN " + dialect.QuoteIdentifier(types.MSSQL, column.Name) + ";")
+		migration.Warn(fmt.Sprintf("dropping column %s deletes its data", column.Name))
+	}
+	for _, column := range d.AddedColumns {
+		query := "ALTER TABLE " + table + " ADD " + dialect.QuoteIdentifier(types.MSSQL, column.Name) + " " + mssqlType(column)
+		if column.IsNullable == "NO" {
+			query += " NOT NULL"
+		}
+		if value := mssqlDefault(column); value.Valid {
+			query += " DEFAULT " + mssqlParenthesize(value.String)
+		} else if column.IsNullable == "NO" {
+			migration.Warn(fmt.Sprintf("adding NOT NULL column %s without a default fails on a non-empty table", column.Name))
+		}
+		migration.Add(query + ";")
+	}
+	for _, column := range d.ChangedColumns {
+		var typeChanged, nullChanged bool
+		for _, change := range column.Changes {
+			switch change.Field {
+			case diff.FieldType:
+				typeChanged = true
+				if !diff.Widens(types.MSSQL, column.From, column.To) {
+					migration.Warn(fmt.Sprintf("changing the type of column %s from %s to %s may fail or lose data", column.Name, change.From, change.To))
+				}
+			case diff.FieldNullable:
+				nullChanged = true
+				if change.To == "NO" {
+					migration.Warn(fmt.Sprintf("setting column %s NOT NULL fails if it contains NULL values", column.Name))
+				}
+			case diff.FieldDefault:
+				if mssqlDefault(column.From).Valid {
+					migration.Warn(fmt.Sprintf("the default constraint of column %s must be dropped before its default can change", column.Name))
+				}
+				if value := mssqlDefault(column.To); value.Valid {
+					migration.Add("ALTER TABLE " + table + " ADD DEFAULT " + mssqlParenthesize(value.String) + " FOR " + dialect.QuoteIdentifier(types.MSSQL, column.Name) + ";")
+				}
+			}
+		}
+		// ALTER COLUMN restates the type and nullability together
+		if typeChanged || nullChanged {
+			query := "ALTER TABLE " + table + " ALTER COLUMN " + dialect.QuoteIdentifier(types.MSSQL, column.Name) + " " + mssqlType(column.To)
+			if column.To.IsNullable == "NO" {
+				query += " NOT NULL"
+			} else {
+				query += " NULL"
+			}
+			migration.Add(query + ";")
+		}
+	}
+
+	if d.PrimaryKey != nil && d.PrimaryKey.To != "" {
+		columns := dialect.QuoteColumns(types.MSSQL, to, diff.PrimaryKeyColumns(to))
+		migration.Add("ALTER TABLE " + table + " ADD PRIMARY KEY (" + strings.Join(columns, ", ") + ");")
+	}
+	for _, index := range d.AddedIndexes {
+		query := "CREATE "
+		if index.Unique {
+			query += "UNIQUE "
+		}
+		if strings.EqualFold(index.Method, "clustered") {
+			query += "CLUSTERED "
+		}
+		columns := dialect.QuoteColumns(types.MSSQL, to, index.Columns)
+		query += "INDEX " + dialect.QuoteIdentifier(types.MSSQL, index.Name) + " ON " + table + " (" + strings.Join(columns, ", ") + ")"
+		if index.Predicate != "" {
+			query += " WHERE " + index.Predicate
+		}
+		migration.Add(query + ";")
+	}
+	return migration
+}
+
+// mssqlType returns the type of a column as used in DDL, including the length SQL Server reports separately.
+func mssqlType(column types.Column) string {
+	return strings.ToUpper(column.Type) + dialect.ColumnLength(column)
+}
+
+// mssqlDefault returns the default value of a column, which the schema query reads into ColumnDefault.
+func mssqlDefault(column types.Column) sql.NullString {
+	if column.DefaultValue.Valid {
+		return column.DefaultValue
+	}
+	return column.ColumnDefault
+}
+
+// mssqlParenthesize wraps a default value in parentheses unless SQL Server already reported it wrapped.
+func mssqlParenthesize(value string) string {
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		return value
+	}
+	return "(" + value + ")"
+}
@@ -0,0 +1,84 @@
+package mssql
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestGenerateAlterTableQueries is a unit test function that tests the GenerateAlterTableQueries method of the MSSQL struct.
+func TestGenerateAlterTableQueries(t *testing.T) {
+	from := types.Table{
+		Name: "orders",
+		Columns: []types.Column{
+			{Name: "id", Type: "int", IsNullable: "NO", IsPrimary: true},
+			{Name: "note", Type: "nvarchar", CharacterMaximumLength: sql.NullInt64{Int64: 100, Valid: true}, IsNullable: "YES"},
+			{Name: "status", Type: "int", IsNullable: "NO", ColumnDefault: sql.NullString{String: "((0))", Valid: true}},
+		},
+		Indexes: []types.Index{
+			{Name: "PK_orders", Columns: []string{"id"}, Unique: true, Primary: true, Method: "clustered"},
+		},
+	}
+	to := types.Table{
+		Name: "orders",
+		Columns: []types.Column{
+			{Name: "id", Type: "int", IsNullable: "NO", IsPrimary: true},
+			{Name: "note", Type: "nvarchar", CharacterMaximumLength: sql.NullInt64{Int64: -1, Valid: true}, IsNullable: "YES"},
+			{Name: "status", Type: "int", IsNullable: "NO", ColumnDefault: sql.NullString{String: "((1))", Valid: true}},
+			{Name: "placed_at", Type: "datetime2", IsNullable: "NO", ColumnDefault: sql.NullString{String: "(sysdatetime())", Valid: true}},
+		},
+		Indexes: []types.Index{
+			{Name: "PK_orders", Columns: []string{"id"}, Unique: true, Primary: true, Method: "clustered"},
+			{Name: "IX_orders_placed_at", Columns: []string{"placed_at"}, Method: "nonclustered"},
+		},
+	}
+
+	m := &MSSQL{}
+	migration := m.GenerateAlterTableQueries(from, to)
+
+	wantStatements := []string{
+		"ALTER TABLE [orders] ADD [placed_at] DATETIME2 NOT NULL DEFAULT (sysdatetime());",
+		"ALTER TABLE [orders] ALTER COLUMN [note] NVARCHAR(MAX) NULL;",
+		"ALTER TABLE [orders] ADD DEFAULT ((1)) FOR [status];",
+		"CREATE INDEX [IX_orders_placed_at] ON [orders] ([placed_at]);",
+	}
+	if !reflect.DeepEqual(migration.Statements, wantStatements) {
+		t.Errorf("statements = %#v, want %#v", migration.Statements, wantStatements)
+	}
+	wantWarnings := []string{
+		"the default constraint of column status must be dropped before its default can change",
+	}
+	if !reflect.DeepEqual(migration.Warnings, wantWarnings) {
+		t.Errorf("warnings = %#v, want %#v", migration.Warnings, wantWarnings)
+	}
+}
+
+// TestGenerateAlterTableQueriesQuotedNames is a unit test function that tests that reserved words, mixed case names
+// and quote characters in table, column and index names are quoted.
+func TestGenerateAlterTableQueriesQuotedNames(t *testing.T) {
+	from := types.Table{
+		Name:    "Order",
+		Schema:  "Sales",
+		Columns: []types.Column{{Name: "id", Type: "int", IsNullable: "NO", IsPrimary: true}, {Name: "Group", Type: "nvarchar", IsNullable: "YES"}},
+	}
+	to := types.Table{
+		Name:    "Order",
+		Schema:  "Sales",
+		Columns: []types.Column{{Name: "id", Type: "int", IsNullable: "NO", IsPrimary: true}, {Name: "Select]s", Type: "nvarchar", IsNullable: "YES"}},
+		Indexes: []types.Index{{Name: "Order]Idx", Columns: []string{"Select]s"}}},
+	}
+
+	s := &MSSQL{}
+	m := s.GenerateAlterTableQueries(from, to)
+
+	want := []string{
+		"ALTER TABLE [Sales].[Order] DROP COLUMN [Group];",
+		"ALTER TABLE [Sales].[Order] ADD [Select]]s] NVARCHAR;",
+		"CREATE INDEX [Order]]Idx] ON [Sales].[Order] ([Select]]s]);",
+	}
+	if !reflect.DeepEqual(m.Statements, want) {
+		t.Errorf("statements = %#v, want %#v", m.Statements, want)
+	}
+}
@@ -0,0 +1,212 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/types"
+)
+
+const (
+	MSSQL_SHOWPLAN_ON        = "SET SHOWPLAN_XML ON"    // MSSQL_SHOWPLAN_ON makes the statements of the session return their estimated plan instead of running.
+	MSSQL_SHOWPLAN_OFF       = "SET SHOWPLAN_XML OFF"   // MSSQL_SHOWPLAN_OFF makes the statements of the session run again.
+	MSSQL_STATISTICS_XML_ON  = "SET STATISTICS XML ON"  // MSSQL_STATISTICS_XML_ON makes the statements of the session return their actual plan after their results.
+	MSSQL_STATISTICS_XML_OFF = "SET STATISTICS XML OFF" // MSSQL_STATISTICS_XML_OFF stops returning the actual plans.
+)
+
+// mssqlShowplanColumn is the name of the column of the result sets holding a plan.
+const mssqlShowplanColumn = "Microsoft SQL Server 2005 XML Showplan"
+
+// showplanElement is an element of a showplan document with its attributes and child elements.
+type showplanElement struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr        `xml:",any,attr"`
+	Children []showplanElement `xml:",any"`
+}
+
+// Explain returns the plan of a single statement, read from the showplan XML. Without analyze the estimated plan
+// is returned by SHOWPLAN_XML and the statement does not run; with analyze the actual plan is returned by
+// STATISTICS XML after the statement has run inside a transaction that is rolled back.
+// The SET options hold for the session, so both run on a single connection that is discarded if they cannot be reset.
+func (m *MSSQL) Explain(ctx context.Context, query string, analyze bool) (types.Plan, error) {
+	statement, err := dialect.SingleStatement(types.MSSQL, query)
+	if err != nil {
+		return types.Plan{}, fmt.Errorf("error explaining query: %v", err)
+	}
+	if analyze && m.Config.ReadOnly {
+		if err := dialect.CheckReadOnly(types.MSSQL, statement.Text); err != nil {
+			return types.Plan{}, err
+		}
+	}
+
+	conn, err := m.Client.Conn(ctx)
+	if err != nil {
+		return types.Plan{}, fmt.Errorf("error opening connection: %v", err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	on, off := MSSQL_SHOWPLAN_ON, MSSQL_SHOWPLAN_OFF
+	if analyze {
+		on, off = MSSQL_STATISTICS_XML_ON, MSSQL_STATISTICS_XML_OFF
+	}
+	if _, err := conn.ExecContext(ctx, on); err != nil {
+		return types.Plan{}, fmt.Errorf("error enabling showplan: %v", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), off); err != nil {
+			fmt.Println(err)
+			// a connection left in showplan mode must not return to the pool
+			_ = conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+	}()
+
+	var raw string
+	if analyze {
+		raw, err = actualShowplan(ctx, conn, statement.Text)
+	} else {
+		err = conn.QueryRowContext(ctx, statement.Text).Scan(&raw)
+	}
+	if err != nil {
+		return types.Plan{}, fmt.Errorf("error explaining query: %v", err)
+	}
+
+	var doc showplanElement
+	if err := xml.Unmarshal([]byte(raw), &doc); err != nil {
+		return types.Plan{}, fmt.Errorf("error parsing query plan: %v", err)
+	}
+	relOps := doc.relOps()
+	if len(relOps) == 0 {
+		return types.Plan{}, fmt.Errorf("error parsing query plan: no operator in plan")
+	}
+	return types.Plan{Root: relOps[0].node(), Analyzed: analyze, Format: "xml", Raw: raw}, nil
+}
+
+// actualShowplan runs a statement inside a transaction that is rolled back and returns the plan
+// of the result set following its results.
+func actualShowplan(ctx context.Context, conn *sql.Conn, query string) (string, error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			fmt.Println(err)
+		}
+	}()
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var plan string
+	for {
+		columns, err := rows.Columns()
+		if err != nil {
+			return "", err
+		}
+		isPlan := len(columns) == 1 && columns[0] == mssqlShowplanColumn
+		for rows.Next() {
+			if isPlan {
+				if err := rows.Scan(&plan); err != nil {
+					return "", err
+				}
+			}
+		}
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	if plan == "" {
+		return "", fmt.Errorf("no plan returned")
+	}
+	return plan, nil
+}
+
+// attr returns the value of an attribute of the element, or an empty string.
+func (e showplanElement) attr(name string) string {
+	for _, a := range e.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// relOps returns the outermost RelOp elements below the element, the operators it feeds on.
+func (e showplanElement) relOps() []showplanElement {
+	var ops []showplanElement
+	for _, child := range e.Children {
+		if child.XMLName.Local == "RelOp" {
+			ops = append(ops, child)
+			continue
+		}
+		ops = append(ops, child.relOps()...)
+	}
+	return ops
+}
+
+// object returns the first Object element below the element that is not part of another operator.
+func (e showplanElement) object() (showplanElement, bool) {
+	for _, child := range e.Children {
+		switch child.XMLName.Local {
+		case "RelOp":
+			continue
+		case "Object":
+			return child, true
+		}
+		if obj, ok := child.object(); ok {
+			return obj, true
+		}
+	}
+	return showplanElement{}, false
+}
+
+// node converts a RelOp element and the operators below it to a plan node.
+// The actual rows are summed over the threads of the operator and the actual time is that of the slowest thread.
+func (e showplanElement) node() *types.PlanNode {
+	node := &types.PlanNode{Type: e.attr("PhysicalOp")}
+	node.EstimatedRows, _ = strconv.ParseFloat(e.attr("EstimateRows"), 64)
+	node.EstimatedCost, _ = strconv.ParseFloat(e.attr("EstimatedTotalSubtreeCost"), 64)
+	if obj, ok := e.object(); ok {
+		node.Relation = strings.Trim(obj.attr("Table"), "[]")
+		if schema := strings.Trim(obj.attr("Schema"), "[]"); schema != "" && node.Relation != "" {
+			node.Relation = schema + "." + node.Relation
+		}
+	}
+	for _, child := range e.Children {
+		if child.XMLName.Local != "RunTimeInformation" {
+			continue
+		}
+		for _, thread := range child.Children {
+			rows, _ := strconv.ParseFloat(thread.attr("ActualRows"), 64)
+			elapsed, _ := strconv.ParseFloat(thread.attr("ActualElapsedms"), 64)
+			node.ActualRows += rows
+			if elapsed > node.ActualTime {
+				node.ActualTime = elapsed
+			}
+		}
+	}
+	for _, op := range e.relOps() {
+		node.Children = append(node.Children, op.node())
+	}
+	return node
+}
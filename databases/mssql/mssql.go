@@ -1,15 +1,21 @@
 package mssql
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	_ "github.com/denisenkom/go-mssqldb"
 	"github.com/thesaas-company/xray/config"
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/governor"
+	"github.com/thesaas-company/xray/registry"
+	"github.com/thesaas-company/xray/snapshot"
 	"github.com/thesaas-company/xray/types"
 )
 
@@ -17,10 +23,105 @@ import (
 var DB_PASSWORD = "DB_PASSWORD"
 
 // MSSQL_SCHEMA_QUERY is the SQL query for retrieving table schema.
-const MSSQL_SCHEMA_QUERY = "SELECT COLUMN_NAME, DATA_TYPE, IS_NULLABLE, COLUMN_DEFAULT, ORDINAL_POSITION, CHARACTER_MAXIMUM_LENGTH FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_NAME = '%s'"
-
-// MSSQL_TABLES_QUERY is the SQL query for listing tables within a database.
-const MSSQL_TABLES_QUERY = "USE %s; SELECT table_name FROM INFORMATION_SCHEMA.TABLES;"
+// An empty schema selects the default schema of the user.
+const MSSQL_SCHEMA_QUERY = "SELECT COLUMN_NAME, DATA_TYPE, IS_NULLABLE, COLUMN_DEFAULT, ORDINAL_POSITION, CHARACTER_MAXIMUM_LENGTH, NUMERIC_PRECISION, NUMERIC_SCALE FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_NAME = @p1 AND TABLE_SCHEMA = COALESCE(NULLIF(@p2, ''), SCHEMA_NAME()) ORDER BY ORDINAL_POSITION"
+
+// mssqlObjectID is the SQL expression resolving the object id of table @p1 in schema @p2.
+const mssqlObjectID = "OBJECT_ID(QUOTENAME(COALESCE(NULLIF(@p2, ''), SCHEMA_NAME())) + '.' + QUOTENAME(@p1))"
+
+// MSSQL_FOREIGN_KEYS_QUERY is the SQL query for listing the foreign key columns of a table.
+const MSSQL_FOREIGN_KEYS_QUERY = `SELECT fk.name, pc.name, SCHEMA_NAME(rt.schema_id), rt.name, rc.name, fk.delete_referential_action_desc, fk.update_referential_action_desc
+FROM sys.foreign_keys fk
+JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+JOIN sys.columns pc ON pc.object_id = fkc.parent_object_id AND pc.column_id = fkc.parent_column_id
+JOIN sys.tables rt ON rt.object_id = fkc.referenced_object_id
+JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+WHERE fk.parent_object_id = ` + mssqlObjectID + `
+ORDER BY fk.name, fkc.constraint_column_id`
+
+// MSSQL_INDEXES_QUERY is the SQL query for listing the index key columns of a table.
+const MSSQL_INDEXES_QUERY = `SELECT i.name, c.name, i.is_unique, i.is_primary_key, LOWER(i.type_desc), COALESCE(i.filter_definition, '')
+FROM sys.indexes i
+JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+WHERE i.object_id = ` + mssqlObjectID + ` AND i.type > 0 AND ic.is_included_column = 0
+ORDER BY i.name, ic.key_ordinal`
+
+// MSSQL_TABLES_QUERY is the SQL query for listing tables within a schema of a database.
+// It is formatted with the quoted database name; an empty schema selects the default schema of the user.
+const MSSQL_TABLES_QUERY = "USE %s; SELECT table_name FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = COALESCE(NULLIF(@p1, ''), SCHEMA_NAME());"
+
+// MSSQL_COMMENTS_QUERY is the SQL query for reading the MS_Description extended properties of a table and its columns.
+// The table description is returned with an empty column name.
+const MSSQL_COMMENTS_QUERY = "SELECT COALESCE(c.name, ''), CAST(ep.value AS NVARCHAR(MAX)) FROM sys.extended_properties ep LEFT JOIN sys.columns c ON c.object_id = ep.major_id AND c.column_id = ep.minor_id WHERE ep.class = 1 AND ep.name = 'MS_Description' AND ep.major_id = " + mssqlObjectID
+
+// MSSQL_TABLE_STATS_QUERY is the SQL query for reading the row count, reserved size and modification times of a table.
+// Rows are counted on the heap or clustered index only, the last data change comes from the index usage statistics,
+// which are reset when the server restarts.
+const MSSQL_TABLE_STATS_QUERY = `SELECT COALESCE(SUM(CASE WHEN ps.index_id IN (0, 1) THEN ps.row_count ELSE 0 END), 0), COALESCE(SUM(ps.reserved_page_count), 0) * 8192, o.modify_date,
+	(SELECT MAX(us.last_user_update) FROM sys.dm_db_index_usage_stats us WHERE us.database_id = DB_ID() AND us.object_id = o.object_id)
+FROM sys.objects o
+LEFT JOIN sys.dm_db_partition_stats ps ON ps.object_id = o.object_id
+WHERE o.object_id = ` + mssqlObjectID + `
+GROUP BY o.object_id, o.modify_date`
+
+// MSSQL_LIST_TABLES_QUERY is the SQL query for listing the tables, views and external tables of a schema with the view definitions.
+// An empty schema selects the default schema of the user.
+const MSSQL_LIST_TABLES_QUERY = `USE %s; SELECT o.name, SCHEMA_NAME(o.schema_id),
+	CASE WHEN o.type = 'V' THEN 'view' WHEN t.is_external = 1 THEN 'external_table' ELSE 'table' END,
+	COALESCE(OBJECT_DEFINITION(o.object_id), '')
+FROM sys.objects o
+LEFT JOIN sys.tables t ON t.object_id = o.object_id
+WHERE o.type IN ('U', 'V') AND o.schema_id = SCHEMA_ID(COALESCE(NULLIF(@p1, ''), SCHEMA_NAME()))
+ORDER BY o.name;`
+
+// MSSQL_VIEW_DEPENDENCIES_QUERY is the SQL query for listing the objects the views of a schema read from.
+// Unqualified references resolve to the schema of the view.
+const MSSQL_VIEW_DEPENDENCIES_QUERY = `USE %s; SELECT DISTINCT SCHEMA_NAME(o.schema_id), o.name, COALESCE(d.referenced_schema_name, SCHEMA_NAME(o.schema_id)), d.referenced_entity_name
+FROM sys.sql_expression_dependencies d
+JOIN sys.objects o ON o.object_id = d.referencing_id
+WHERE o.type = 'V' AND o.schema_id = SCHEMA_ID(COALESCE(NULLIF(@p1, ''), SCHEMA_NAME())) AND d.referenced_database_name IS NULL
+ORDER BY 1, 2, 3, 4;`
+
+// MSSQL_SCHEMAS_QUERY is the SQL query for listing the user schemas of a database.
+// Schema ids from 16384 on belong to the fixed database roles.
+const MSSQL_SCHEMAS_QUERY = "USE %s; SELECT name FROM sys.schemas WHERE schema_id < 16384 AND name NOT IN ('sys', 'INFORMATION_SCHEMA', 'guest') ORDER BY name;"
+
+// mssqlSchemaID is the SQL expression resolving the id of schema @p1, the default schema of the user when it is empty.
+const mssqlSchemaID = "SCHEMA_ID(COALESCE(NULLIF(@p1, ''), SCHEMA_NAME()))"
+
+// MSSQL_SNAPSHOT_COLUMNS_QUERY is the SQL query for reading the columns of every table of a schema at once.
+// It is formatted with the quoted database name.
+const MSSQL_SNAPSHOT_COLUMNS_QUERY = "USE %s; SELECT TABLE_NAME, COLUMN_NAME, DATA_TYPE, IS_NULLABLE, COLUMN_DEFAULT, ORDINAL_POSITION, CHARACTER_MAXIMUM_LENGTH, NUMERIC_PRECISION, NUMERIC_SCALE FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_SCHEMA = COALESCE(NULLIF(@p1, ''), SCHEMA_NAME()) ORDER BY TABLE_NAME, ORDINAL_POSITION;"
+
+// MSSQL_SNAPSHOT_FOREIGN_KEYS_QUERY is the SQL query for listing the foreign key columns of every table of a schema at once.
+// It is formatted with the quoted database name.
+const MSSQL_SNAPSHOT_FOREIGN_KEYS_QUERY = `USE %s; SELECT OBJECT_NAME(fk.parent_object_id), fk.name, pc.name, SCHEMA_NAME(rt.schema_id), rt.name, rc.name, fk.delete_referential_action_desc, fk.update_referential_action_desc
+FROM sys.foreign_keys fk
+JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+JOIN sys.columns pc ON pc.object_id = fkc.parent_object_id AND pc.column_id = fkc.parent_column_id
+JOIN sys.tables rt ON rt.object_id = fkc.referenced_object_id
+JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+WHERE fk.schema_id = ` + mssqlSchemaID + `
+ORDER BY 1, fk.name, fkc.constraint_column_id;`
+
+// MSSQL_SNAPSHOT_INDEXES_QUERY is the SQL query for listing the index key columns of every table of a schema at once.
+// It is formatted with the quoted database name.
+const MSSQL_SNAPSHOT_INDEXES_QUERY = `USE %s; SELECT o.name, i.name, c.name, i.is_unique, i.is_primary_key, LOWER(i.type_desc), COALESCE(i.filter_definition, '')
+FROM sys.indexes i
+JOIN sys.objects o ON o.object_id = i.object_id
+JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+WHERE o.schema_id = ` + mssqlSchemaID + ` AND o.type IN ('U', 'V') AND i.type > 0 AND ic.is_included_column = 0
+ORDER BY o.name, i.name, ic.key_ordinal;`
+
+// MSSQL_SNAPSHOT_COMMENTS_QUERY is the SQL query for reading the MS_Description extended properties of every table and column of a schema at once.
+// It is formatted with the quoted database name; table descriptions are returned with an empty column name.
+const MSSQL_SNAPSHOT_COMMENTS_QUERY = `USE %s; SELECT o.name, COALESCE(c.name, ''), CAST(ep.value AS NVARCHAR(MAX))
+FROM sys.extended_properties ep
+JOIN sys.objects o ON o.object_id = ep.major_id
+LEFT JOIN sys.columns c ON c.object_id = ep.major_id AND c.column_id = ep.minor_id
+WHERE ep.class = 1 AND ep.name = 'MS_Description' AND o.schema_id = ` + mssqlSchemaID + `;`
 
 // MSSQL represents the MSSQL database implementation.
 type MSSQL struct {
@@ -28,8 +129,15 @@ type MSSQL struct {
 	Config *config.Config
 }
 
+func init() {
+	registry.Register(types.MSSQL.String(), registry.Factory{
+		New:           NewMSSQL,
+		NewWithConfig: NewMSSQLFromConfig,
+	})
+}
+
 // NewMSSQL creates a new MSSQL instance with the given client.
-func NewMSSQL(client *sql.DB) (types.ISQL, error) {
+func NewMSSQL(client *sql.DB) (types.ISQLContext, error) {
 	return &MSSQL{
 		Client: client,
 		Config: &config.Config{},
@@ -37,7 +145,7 @@ func NewMSSQL(client *sql.DB) (types.ISQL, error) {
 }
 
 // NewMSSQLFromConfig creates a new MSSQL instance with the given configuration.
-func NewMSSQLFromConfig(config *config.Config) (types.ISQL, error) {
+func NewMSSQLFromConfig(config *config.Config) (types.ISQLContext, error) {
 	if os.Getenv(DB_PASSWORD) == "" || len(os.Getenv(DB_PASSWORD)) == 0 {
 		return nil, fmt.Errorf("please set %s env variable for the database", DB_PASSWORD)
 	}
@@ -59,8 +167,19 @@ func NewMSSQLFromConfig(config *config.Config) (types.ISQL, error) {
 // Schema retrieves the table schema for the given table name.
 // It takes the table name as an argument and returns the table schema as a types.Table object.
 func (m *MSSQL) Schema(table string) (types.Table, error) {
-	query := fmt.Sprintf(MSSQL_SCHEMA_QUERY, table)
-	rows, err := m.Client.Query(query)
+	return m.SchemaContext(context.Background(), table)
+}
+
+// SchemaContext retrieves the table schema for the given table name.
+// The query is cancelled when the context is done.
+func (m *MSSQL) SchemaContext(ctx context.Context, table string) (types.Table, error) {
+	name := types.ParseTableName(table)
+	schema, err := m.schemaOf(name)
+	if err != nil {
+		return types.Table{}, err
+	}
+
+	rows, err := m.Client.QueryContext(ctx, MSSQL_SCHEMA_QUERY, name.Name, schema)
 	if err != nil {
 		return types.Table{}, fmt.Errorf("error executing sql statement: %v", err)
 	}
@@ -80,10 +199,11 @@ func (m *MSSQL) Schema(table string) (types.Table, error) {
 			&col.ColumnDefault,
 			&col.OrdinalPosition,
 			&col.CharacterMaximumLength,
+			&col.NumericPrecision,
+			&col.NumericScale,
 		); err != nil {
 			return types.Table{}, fmt.Errorf("error scanning rows : %v", err)
 		}
-		col.Description = ""      // default description
 		col.Metatags = []string{} // default metatags as an empty string slice
 		col.Metatags = append(col.Metatags, col.Name)
 		col.Visibility = true // default visibility
@@ -94,19 +214,159 @@ func (m *MSSQL) Schema(table string) (types.Table, error) {
 		return types.Table{}, fmt.Errorf("error iterating over rows: %v", err)
 	}
 
+	foreignKeys, err := m.foreignKeys(ctx, schema, name.Name)
+	if err != nil {
+		return types.Table{}, err
+	}
+
+	indexes, err := m.indexes(ctx, schema, name.Name)
+	if err != nil {
+		return types.Table{}, err
+	}
+	types.ApplyIndexes(columns, indexes)
+
+	comments, err := m.comments(ctx, schema, name.Name)
+	if err != nil {
+		return types.Table{}, err
+	}
+	description := types.ApplyComments(columns, comments)
+
 	return types.Table{
-		Name:        table,
+		Name:        name.Name,
+		Catalog:     m.Config.Database,
+		Schema:      schema,
 		Columns:     columns,
 		ColumnCount: int64(len(columns)),
+		Description: description,
 		Metatags:    []string{},
+		ForeignKeys: foreignKeys,
+		Indexes:     indexes,
 	}, nil
 }
 
+// schemaOf returns the schema of the given table, falling back to the configured schema.
+// Tables in other databases are not supported.
+func (m *MSSQL) schemaOf(name types.TableName) (string, error) {
+	if name.Catalog != "" && m.Config.Database != "" && name.Catalog != m.Config.Database {
+		return "", fmt.Errorf("table %s is not in the connected database %s", name, m.Config.Database)
+	}
+	if name.Schema != "" {
+		return name.Schema, nil
+	}
+	return m.Config.Schema, nil
+}
+
+// TableStats retrieves the row count, size and modification times of the given table.
+func (m *MSSQL) TableStats(table string) (types.TableStats, error) {
+	return m.TableStatsContext(context.Background(), table)
+}
+
+// TableStatsContext retrieves the row count, size and modification times of the given table.
+// The query is cancelled when the context is done.
+func (m *MSSQL) TableStatsContext(ctx context.Context, table string) (types.TableStats, error) {
+	name := types.ParseTableName(table)
+	schema, err := m.schemaOf(name)
+	if err != nil {
+		return types.TableStats{}, err
+	}
+
+	stats := types.TableStats{Name: name.Name, Schema: schema}
+	row := m.Client.QueryRowContext(ctx, MSSQL_TABLE_STATS_QUERY, name.Name, schema)
+	if err := row.Scan(&stats.RowCount, &stats.Bytes, &stats.LastDDL, &stats.LastModified); err != nil {
+		return types.TableStats{}, fmt.Errorf("error reading table statistics: %v", err)
+	}
+	return stats, nil
+}
+
+// comments retrieves the MS_Description extended properties of the given table and its columns.
+func (m *MSSQL) comments(ctx context.Context, schema, table string) ([]types.Comment, error) {
+	rows, err := m.Client.QueryContext(ctx, MSSQL_COMMENTS_QUERY, table, schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing comment query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Println("Failed to close rows:", err)
+		}
+	}()
+
+	var comments []types.Comment
+	for rows.Next() {
+		var c types.Comment
+		if err := rows.Scan(&c.Column, &c.Text); err != nil {
+			return nil, fmt.Errorf("error scanning comments: %v", err)
+		}
+		comments = append(comments, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over comments: %v", err)
+	}
+	return comments, nil
+}
+
+// indexes retrieves the indexes of the given table.
+func (m *MSSQL) indexes(ctx context.Context, schema, table string) ([]types.Index, error) {
+	rows, err := m.Client.QueryContext(ctx, MSSQL_INDEXES_QUERY, table, schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing index query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Println("Failed to close rows:", err)
+		}
+	}()
+
+	var columns []types.IndexColumn
+	for rows.Next() {
+		var c types.IndexColumn
+		if err := rows.Scan(&c.Index, &c.Column, &c.Unique, &c.Primary, &c.Method, &c.Predicate); err != nil {
+			return nil, fmt.Errorf("error scanning indexes: %v", err)
+		}
+		columns = append(columns, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over indexes: %v", err)
+	}
+	return types.GroupIndexes(columns), nil
+}
+
+// foreignKeys retrieves the foreign key constraints declared on the given table.
+func (m *MSSQL) foreignKeys(ctx context.Context, schema, table string) ([]types.ForeignKey, error) {
+	rows, err := m.Client.QueryContext(ctx, MSSQL_FOREIGN_KEYS_QUERY, table, schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing foreign key query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Println("Failed to close rows:", err)
+		}
+	}()
+
+	var columns []types.ForeignKeyColumn
+	for rows.Next() {
+		var c types.ForeignKeyColumn
+		if err := rows.Scan(&c.Constraint, &c.Column, &c.ReferencedSchema, &c.ReferencedTable, &c.ReferencedColumn, &c.OnDelete, &c.OnUpdate); err != nil {
+			return nil, fmt.Errorf("error scanning foreign keys: %v", err)
+		}
+		columns = append(columns, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over foreign keys: %v", err)
+	}
+	return types.GroupForeignKeys(columns), nil
+}
+
 // Tables lists the tables within the given database in a bigquery.
 // It takes the database name as an argument and returns a slice of table names.
 func (m *MSSQL) Tables(databaseName string) ([]string, error) {
-	query := fmt.Sprintf(MSSQL_TABLES_QUERY, databaseName)
-	rows, err := m.Client.Query(query)
+	return m.TablesContext(context.Background(), databaseName)
+}
+
+// TablesContext lists the tables within the given database.
+// The query is cancelled when the context is done.
+func (m *MSSQL) TablesContext(ctx context.Context, databaseName string) ([]string, error) {
+	query := fmt.Sprintf(MSSQL_TABLES_QUERY, dialect.QuoteIdentifier(types.MSSQL, databaseName))
+	rows, err := m.Client.QueryContext(ctx, query, m.Config.Schema)
 	if err != nil {
 		return nil, fmt.Errorf("error executing the sql statement: %v", err)
 	}
@@ -133,57 +393,349 @@ func (m *MSSQL) Tables(databaseName string) ([]string, error) {
 	return tables, nil
 }
 
-// Execute executes the given SQL query and returns the result as JSON.
-// It takes the SQL query as an argument.
-func (m *MSSQL) Execute(query string) ([]byte, error) {
-	rows, err := m.Client.Query(query)
+// ListTables returns the tables, views and external tables of the configured schema
+// together with the definition and dependencies of the views.
+// The query is cancelled when the context is done.
+func (m *MSSQL) ListTables(ctx context.Context, databaseName string, opts types.ListTablesOptions) ([]types.TableInfo, error) {
+	tables, err := m.listTables(ctx, databaseName)
 	if err != nil {
-		return nil, fmt.Errorf("error executing the sql statement %v", err)
+		return nil, err
+	}
+
+	tables = types.FilterTables(tables, opts)
+	if !types.HasViews(tables) {
+		return tables, nil
+	}
+
+	dependencies, err := m.viewDependencies(ctx, databaseName)
+	if err != nil {
+		return nil, err
+	}
+	types.ApplyDependencies(tables, dependencies)
+	return tables, nil
+}
+
+// listTables retrieves every table, view and external table of the configured schema.
+func (m *MSSQL) listTables(ctx context.Context, databaseName string) ([]types.TableInfo, error) {
+	query := fmt.Sprintf(MSSQL_LIST_TABLES_QUERY, dialect.QuoteIdentifier(types.MSSQL, databaseName))
+	rows, err := m.Client.QueryContext(ctx, query, m.Config.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing the sql statement: %v", err)
 	}
 
 	defer func() {
 		if err := rows.Close(); err != nil {
-			log.Println("failed to close rows:", err)
+			fmt.Printf("error closing the rows: %v", err)
 		}
 	}()
 
-	columns, err := rows.Columns()
+	var tables []types.TableInfo
+	for rows.Next() {
+		var table types.TableInfo
+		if err := rows.Scan(&table.Name, &table.Schema, &table.Kind, &table.Definition); err != nil {
+			return nil, fmt.Errorf("error scanning tables: %v", err)
+		}
+		tables = append(tables, table)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows :%v", err)
+	}
+
+	return tables, nil
+}
+
+// viewDependencies retrieves the objects the views of the configured schema read from.
+func (m *MSSQL) viewDependencies(ctx context.Context, databaseName string) ([]types.TableDependency, error) {
+	query := fmt.Sprintf(MSSQL_VIEW_DEPENDENCIES_QUERY, dialect.QuoteIdentifier(types.MSSQL, databaseName))
+	rows, err := m.Client.QueryContext(ctx, query, m.Config.Schema)
 	if err != nil {
-		return nil, fmt.Errorf("error getting columns : %v", err)
+		return nil, fmt.Errorf("error executing dependency query: %v", err)
 	}
 
-	var results [][]interface{}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Printf("error closing the rows: %v", err)
+		}
+	}()
+
+	var dependencies []types.TableDependency
 	for rows.Next() {
-		values := make([]interface{}, len(columns))
-		pointers := make([]interface{}, len(columns))
-		for i := range values {
-			pointers[i] = &values[i]
+		var d types.TableDependency
+		if err := rows.Scan(&d.Schema, &d.Name, &d.Referenced.Schema, &d.Referenced.Name); err != nil {
+			return nil, fmt.Errorf("error scanning dependencies: %v", err)
 		}
+		dependencies = append(dependencies, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over dependencies: %v", err)
+	}
+
+	return dependencies, nil
+}
 
-		if err := rows.Scan(pointers...); err != nil {
-			return nil, fmt.Errorf("error scanning rows:%v", err)
+// Schemas lists the user schemas within the given database.
+// The query is cancelled when the context is done.
+func (m *MSSQL) Schemas(ctx context.Context, databaseName string) ([]string, error) {
+	query := fmt.Sprintf(MSSQL_SCHEMAS_QUERY, dialect.QuoteIdentifier(types.MSSQL, databaseName))
+	rows, err := m.Client.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error executing the sql statement: %v", err)
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Printf("error closing the rows: %v", err)
 		}
+	}()
 
-		results = append(results, values)
+	var schemas []string
+	for rows.Next() {
+		var schema string
+		if err := rows.Scan(&schema); err != nil {
+			return nil, fmt.Errorf("error scanning the schemas :%v", err)
+		}
+		schemas = append(schemas, schema)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over rows : %v", err)
+		return nil, fmt.Errorf("error iterating over rows :%v", err)
 	}
 
-	queryResult := types.QueryResult{
-		Columns: columns,
-		Rows:    results,
+	return schemas, nil
+}
+
+// Execute executes the given SQL query and returns the result as JSON.
+// It takes the SQL query as an argument.
+func (m *MSSQL) Execute(query string) ([]byte, error) {
+	return m.ExecuteContext(context.Background(), query)
+}
+
+// ExecuteContext executes the given SQL query and returns the result as JSON.
+// The query is cancelled when the context is done.
+func (m *MSSQL) ExecuteContext(ctx context.Context, query string, args ...interface{}) ([]byte, error) {
+	return m.ExecutePage(ctx, query, "", args...)
+}
+
+// ExecutePage executes the given SQL query and returns the page of its result selected by the page token as JSON,
+// the first page for an empty token. The page is limited by the MaxRows and MaxBytes of the configuration,
+// the NextPageToken of a truncated result selects the next page.
+func (m *MSSQL) ExecutePage(ctx context.Context, query, pageToken string, args ...interface{}) ([]byte, error) {
+	start := time.Now()
+	limits := governor.Limits{MaxRows: m.Config.MaxRows, MaxBytes: m.Config.MaxBytes}
+	queryResult, err := governor.Execute(ctx, m, types.MSSQL, limits, query, pageToken, args...)
+	if err != nil {
+		return nil, err
 	}
+	queryResult.Time = time.Since(start).Milliseconds()
 
+	// Convert the result to JSON
 	jsonData, err := json.Marshal(queryResult)
 	if err != nil {
-		return nil, fmt.Errorf("error marshalling json: %v", err)
+		return nil, fmt.Errorf("error marshaling json: %v", err)
 	}
 
 	return jsonData, nil
 }
 
+// Query executes the given SQL query and returns an iterator over its rows.
+// The rows are read from the database as the iterator advances.
+func (m *MSSQL) Query(ctx context.Context, query string, args ...interface{}) (types.RowIterator, error) {
+	query, args, err := dialect.Bind(types.MSSQL, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error binding query arguments: %v", err)
+	}
+	if m.Config.ReadOnly {
+		if err := dialect.CheckReadOnly(types.MSSQL, query); err != nil {
+			return nil, err
+		}
+	}
+	rows, err := m.Client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing the sql statement %v", err)
+	}
+
+	it, err := types.NewRowIterator(rows, newValueConverter(types.BinaryEncoding(m.Config.BinaryEncoding)))
+	if err != nil {
+		_ = rows.Close()
+		return nil, err
+	}
+	return it, nil
+}
+
+// SetReadOnly enables or disables the read-only mode of the client, see config.Config.ReadOnly.
+// The configuration is copied, clients created with the same configuration keep their mode.
+func (m *MSSQL) SetReadOnly(readOnly bool) {
+	cfg := *m.Config
+	cfg.ReadOnly = readOnly
+	m.Config = &cfg
+}
+
+// Snapshot reads the schema of every table of the given database matching the options;
+// the columns, indexes, foreign keys and descriptions of the configured schema are read in bulk from the catalog.
+func (m *MSSQL) Snapshot(ctx context.Context, databaseName string, opts types.SnapshotOptions) (types.DatabaseSchema, error) {
+	return snapshot.Take(ctx, m, types.MSSQL, databaseName, opts)
+}
+
+// BulkSchemas reads the schemas of the given tables of the configured schema with four queries in total,
+// instead of four queries per table.
+func (m *MSSQL) BulkSchemas(ctx context.Context, databaseName string, tables []types.TableInfo) ([]types.Table, error) {
+	database := dialect.QuoteIdentifier(types.MSSQL, databaseName)
+	rows, err := m.Client.QueryContext(ctx, fmt.Sprintf(MSSQL_SNAPSHOT_COLUMNS_QUERY, database), m.Config.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Println("Failed to close rows:", err)
+		}
+	}()
+
+	columns := map[string][]types.Column{}
+	for rows.Next() {
+		var table string
+		var col types.Column
+		if err := rows.Scan(
+			&table,
+			&col.Name,
+			&col.Type,
+			&col.IsNullable,
+			&col.ColumnDefault,
+			&col.OrdinalPosition,
+			&col.CharacterMaximumLength,
+			&col.NumericPrecision,
+			&col.NumericScale,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning rows : %v", err)
+		}
+		col.Metatags = []string{col.Name}
+		col.Visibility = true // default visibility
+		columns[table] = append(columns[table], col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %v", err)
+	}
+
+	foreignKeys, err := m.schemaForeignKeys(ctx, database)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := m.schemaIndexes(ctx, database)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := m.schemaComments(ctx, database)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.Table, len(tables))
+	for i, t := range tables {
+		tableColumns := columns[t.Name]
+		tableIndexes := types.GroupIndexes(indexes[t.Name])
+		types.ApplyIndexes(tableColumns, tableIndexes)
+		description := types.ApplyComments(tableColumns, comments[t.Name])
+
+		result[i] = types.Table{
+			Name:        t.Name,
+			Catalog:     databaseName,
+			Schema:      t.Schema,
+			Columns:     tableColumns,
+			ColumnCount: int64(len(tableColumns)),
+			Description: description,
+			Metatags:    []string{},
+			ForeignKeys: types.GroupForeignKeys(foreignKeys[t.Name]),
+			Indexes:     tableIndexes,
+		}
+	}
+	return result, nil
+}
+
+// schemaForeignKeys retrieves the foreign key columns of every table of the configured schema, keyed by table name.
+func (m *MSSQL) schemaForeignKeys(ctx context.Context, database string) (map[string][]types.ForeignKeyColumn, error) {
+	rows, err := m.Client.QueryContext(ctx, fmt.Sprintf(MSSQL_SNAPSHOT_FOREIGN_KEYS_QUERY, database), m.Config.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing foreign key query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Println("Failed to close rows:", err)
+		}
+	}()
+
+	columns := map[string][]types.ForeignKeyColumn{}
+	for rows.Next() {
+		var table string
+		var c types.ForeignKeyColumn
+		if err := rows.Scan(&table, &c.Constraint, &c.Column, &c.ReferencedSchema, &c.ReferencedTable, &c.ReferencedColumn, &c.OnDelete, &c.OnUpdate); err != nil {
+			return nil, fmt.Errorf("error scanning foreign keys: %v", err)
+		}
+		columns[table] = append(columns[table], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over foreign keys: %v", err)
+	}
+	return columns, nil
+}
+
+// schemaIndexes retrieves the index key columns of every table of the configured schema, keyed by table name.
+func (m *MSSQL) schemaIndexes(ctx context.Context, database string) (map[string][]types.IndexColumn, error) {
+	rows, err := m.Client.QueryContext(ctx, fmt.Sprintf(MSSQL_SNAPSHOT_INDEXES_QUERY, database), m.Config.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing index query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Println("Failed to close rows:", err)
+		}
+	}()
+
+	columns := map[string][]types.IndexColumn{}
+	for rows.Next() {
+		var table string
+		var c types.IndexColumn
+		if err := rows.Scan(&table, &c.Index, &c.Column, &c.Unique, &c.Primary, &c.Method, &c.Predicate); err != nil {
+			return nil, fmt.Errorf("error scanning indexes: %v", err)
+		}
+		columns[table] = append(columns[table], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over indexes: %v", err)
+	}
+	return columns, nil
+}
+
+// schemaComments retrieves the MS_Description extended properties of every table and column of the configured schema,
+// keyed by table name.
+func (m *MSSQL) schemaComments(ctx context.Context, database string) (map[string][]types.Comment, error) {
+	rows, err := m.Client.QueryContext(ctx, fmt.Sprintf(MSSQL_SNAPSHOT_COMMENTS_QUERY, database), m.Config.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing comment query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Println("Failed to close rows:", err)
+		}
+	}()
+
+	comments := map[string][]types.Comment{}
+	for rows.Next() {
+		var table string
+		var c types.Comment
+		if err := rows.Scan(&table, &c.Column, &c.Text); err != nil {
+			return nil, fmt.Errorf("error scanning comments: %v", err)
+		}
+		comments[table] = append(comments[table], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over comments: %v", err)
+	}
+	return comments, nil
+}
+
 // GenerateCreateTableQuery generates the SQL query for creating a table based on the given table definition.
 // It takes the table definition as an argument and returns the SQL query as a string.
 func (m *MSSQL) GenerateCreateTableQuery(table types.Table) string {
@@ -225,5 +777,30 @@ func (m *MSSQL) GenerateCreateTableQuery(table types.Table) string {
 	}
 
 	query += ");"
+
+	// descriptions are stored as MS_Description extended properties
+	schema := table.Schema
+	if schema == "" {
+		schema = "dbo"
+	}
+	if table.Description != "" {
+		query += " " + mssqlDescription(table.Description, schema, table.Name, "")
+	}
+	for _, column := range table.Columns {
+		if column.Description != "" {
+			query += " " + mssqlDescription(column.Description, schema, table.Name, column.Name)
+		}
+	}
 	return query
 }
+
+// mssqlDescription returns the statement setting the MS_Description extended property of a table, or of one of its columns.
+func mssqlDescription(description, schema, table, column string) string {
+	stmt := "EXEC sp_addextendedproperty @name = N'MS_Description', @value = " + dialect.QuoteString(types.MSSQL, description) +
+		", @level0type = N'SCHEMA', @level0name = " + dialect.QuoteString(types.MSSQL, schema) +
+		", @level1type = N'TABLE', @level1name = " + dialect.QuoteString(types.MSSQL, table)
+	if column != "" {
+		stmt += ", @level2type = N'COLUMN', @level2name = " + dialect.QuoteString(types.MSSQL, column)
+	}
+	return stmt + ";"
+}
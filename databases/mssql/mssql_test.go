@@ -1,15 +1,19 @@
 package mssql
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"reflect"
 
 	"fmt"
 	"log"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/thesaas-company/xray/config"
 	"github.com/thesaas-company/xray/types"
 )
 
@@ -35,9 +39,19 @@ func TestSchema(t *testing.T) {
 	}() // close the connection when the function returns
 
 	tableName := "user"
-	mockRows := sqlmock.NewRows([]string{"Field", "Type", "IsNullable", "ColumnDefault", "OrdinalPosition", "CharacterMaximumLength"}).AddRow("id", "int", "true", "", 1, nil)
+	mockRows := sqlmock.NewRows([]string{"Field", "Type", "IsNullable", "ColumnDefault", "OrdinalPosition", "CharacterMaximumLength", "NumericPrecision", "NumericScale"}).AddRow("id", "int", "true", "", 1, nil, 10, 0)
 
-	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(MSSQL_SCHEMA_QUERY, tableName))).WillReturnRows(mockRows) // set the expected return values for the query
+	mock.ExpectQuery(regexp.QuoteMeta(MSSQL_SCHEMA_QUERY)).WithArgs(tableName, "").WillReturnRows(mockRows) // set the expected return values for the query
+	fkRows := sqlmock.NewRows([]string{"constraint_name", "column_name", "referenced_schema", "referenced_table", "referenced_column", "on_delete", "on_update"}).
+		AddRow("FK_user_team", "team_id", "dbo", "team", "id", "SET_NULL", "NO_ACTION")
+	mock.ExpectQuery(regexp.QuoteMeta(MSSQL_FOREIGN_KEYS_QUERY)).WithArgs(tableName, "").WillReturnRows(fkRows)
+	indexRows := sqlmock.NewRows([]string{"index_name", "column_name", "unique", "primary", "method", "predicate"}).
+		AddRow("PK_user", "id", true, true, "clustered", "")
+	mock.ExpectQuery(regexp.QuoteMeta(MSSQL_INDEXES_QUERY)).WithArgs(tableName, "").WillReturnRows(indexRows)
+	commentRows := sqlmock.NewRows([]string{"column_name", "description"}).
+		AddRow("", "Registered users").
+		AddRow("id", "Surrogate key")
+	mock.ExpectQuery(regexp.QuoteMeta(MSSQL_COMMENTS_QUERY)).WithArgs(tableName, "").WillReturnRows(commentRows)
 
 	// we then create a new instance of our MySQL object and test the function
 	m, err := NewMSSQL(db)
@@ -49,6 +63,32 @@ func TestSchema(t *testing.T) {
 		t.Errorf("error executing query: %s", err)
 	}
 
+	wantForeignKeys := []types.ForeignKey{{
+		Name:              "FK_user_team",
+		Columns:           []string{"team_id"},
+		ReferencedSchema:  "dbo",
+		ReferencedTable:   "team",
+		ReferencedColumns: []string{"id"},
+		OnDelete:          "SET NULL",
+		OnUpdate:          "NO ACTION",
+	}}
+	if !reflect.DeepEqual(response.ForeignKeys, wantForeignKeys) {
+		t.Errorf("foreign keys = %+v, want %+v", response.ForeignKeys, wantForeignKeys)
+	}
+	if response.Description != "Registered users" || response.Columns[0].Description != "Surrogate key" {
+		t.Errorf("descriptions = %q, %q", response.Description, response.Columns[0].Description)
+	}
+
+	wantIndexes := []types.Index{
+		{Name: "PK_user", Columns: []string{"id"}, Unique: true, Primary: true, Method: "clustered"},
+	}
+	if !reflect.DeepEqual(response.Indexes, wantIndexes) {
+		t.Errorf("indexes = %+v, want %+v", response.Indexes, wantIndexes)
+	}
+	if column := response.Columns[0]; column.IsIndex != true || column.IsUnique.String != "YES" {
+		t.Errorf("column %s: IsIndex = %v, IsUnique = %q", column.Name, column.IsIndex, column.IsUnique.String)
+	}
+
 	fmt.Printf("Table schema : %+v\n", response)
 
 	// we make sure that all expectations were met, otherwise an error will be reported
@@ -77,7 +117,7 @@ func TestTables(t *testing.T) {
 		AddRow(tableList[0]).
 		AddRow(tableList[1]).
 		AddRow(tableList[2])
-	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(MSSQL_TABLES_QUERY, schema))).WillReturnRows(rows) // set the expected return values for the query
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(MSSQL_TABLES_QUERY, "["+schema+"]"))).WithArgs("").WillReturnRows(rows) // set the expected return values for the query
 
 	b, err := NewMSSQL(db) // create a new instance of our BigQuery object
 	if err != nil {
@@ -134,6 +174,80 @@ func TestExecute(t *testing.T) {
 
 }
 
+// TestExecuteWithArgs is a unit test function that tests the ExecuteContext method with bind arguments.
+// It checks that ? placeholders are rewritten to @pn and the arguments reach the driver.
+func TestExecuteWithArgs(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Println("Failed to close rows:", err)
+		}
+	}()
+
+	mockRows := sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Rohan")
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name FROM [user] WHERE id = @p1 AND name = @p2")).WithArgs(1, "Rohan").WillReturnRows(mockRows)
+
+	m := &MSSQL{Client: db, Config: &config.Config{}}
+	if _, err := m.ExecuteContext(context.Background(), "SELECT id, name FROM [user] WHERE id = ? AND name = ?", 1, "Rohan"); err != nil {
+		t.Errorf("error executing the query: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestQuery is a unit test function that tests the Query method of the MSSQL struct.
+// It streams the mocked rows through the iterator and checks the column metadata and converted row values.
+func TestQuery(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Println("Failed to close rows:", err)
+		}
+	}()
+
+	query := "SELECT id, name, price FROM [product]"
+	mockRows := sqlmock.NewRowsWithColumnDefinition(
+		sqlmock.NewColumn("id").OfType("INT", int64(0)),
+		sqlmock.NewColumn("name").OfType("NVARCHAR", "").Nullable(true),
+		sqlmock.NewColumn("price").OfType("MONEY", []byte{}),
+	).AddRow(int64(1), "Pen", []byte("1.5000")).AddRow(int64(2), nil, []byte("10.0000"))
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(mockRows)
+
+	m := &MSSQL{Client: db, Config: &config.Config{}}
+	it, err := m.Query(context.Background(), query)
+	if err != nil {
+		t.Fatalf("error executing the query: %s", err)
+	}
+	defer func() {
+		if err := it.Close(); err != nil {
+			log.Println("Failed to close rows:", err)
+		}
+	}()
+
+	columns := it.Columns()
+	if len(columns) != 3 || columns[2].DatabaseType != "MONEY" || !columns[1].Nullable {
+		t.Errorf("unexpected column metadata: %+v", columns)
+	}
+
+	var rows [][]interface{}
+	for it.Next() {
+		rows = append(rows, it.Row())
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("error iterating rows: %s", err)
+	}
+	expected := [][]interface{}{{int64(1), "Pen", "1.5000"}, {int64(2), nil, "10.0000"}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %v, got %v", expected, rows)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 // TestGenerateCreateTablequery is a unit test function that tests the GenerateCreateTableQuery method of the mssql
 // It creates a mock instance of mssql, sets the expected return values, and calls the method under test.
 // It then asserts the expected return values and checks if the method was called with the correct arguments.
@@ -187,3 +301,437 @@ func TestGenerateCreateTablequery(t *testing.T) {
 	}
 
 }
+
+// TestGenerateCreateTableQueryComments is a unit test function that tests that the GenerateCreateTableQuery method of the
+// mssql struct emits the table and column descriptions as MS_Description extended properties.
+func TestGenerateCreateTableQueryComments(t *testing.T) {
+	table := types.Table{
+		Name:        "user",
+		Schema:      "sales",
+		Description: "Registered users",
+		Columns: []types.Column{
+			{Name: "id", Type: "int", Description: "User's key"},
+		},
+	}
+
+	m := &MSSQL{}
+	query := m.GenerateCreateTableQuery(table)
+
+	expectedQuery := "CREATE TABLE [user] ([id] INT);" +
+		" EXEC sp_addextendedproperty @name = N'MS_Description', @value = N'Registered users', @level0type = N'SCHEMA', @level0name = N'sales', @level1type = N'TABLE', @level1name = N'user';" +
+		" EXEC sp_addextendedproperty @name = N'MS_Description', @value = N'User''s key', @level0type = N'SCHEMA', @level0name = N'sales', @level1type = N'TABLE', @level1name = N'user', @level2type = N'COLUMN', @level2name = N'id';"
+	if query != expectedQuery {
+		t.Errorf("Expected '%s', but got '%s'", expectedQuery, query)
+	}
+}
+
+// TestHostileNames is a unit test function that tests that a hostile database name is quoted and a hostile table name is sent as a parameter.
+func TestHostileNames(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Println("Failed to close rows:", err)
+		}
+	}()
+
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(MSSQL_TABLES_QUERY, "[master]]; DROP DATABASE shop; --]"))).WithArgs("").
+		WillReturnError(fmt.Errorf("database does not exist"))
+	mock.ExpectQuery(regexp.QuoteMeta(MSSQL_SCHEMA_QUERY)).WithArgs("user'; DROP TABLE user; --", "dbo").
+		WillReturnError(fmt.Errorf("invalid object"))
+
+	m, err := NewMSSQL(db)
+	if err != nil {
+		t.Fatalf("error initialising mssql: %s", err)
+	}
+	if _, err := m.Tables("master]; DROP DATABASE shop; --"); err == nil {
+		t.Error("expected an error")
+	}
+	if _, err := m.Schema("dbo.[user'; DROP TABLE user; --]"); err == nil {
+		t.Error("expected an error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestExecuteReadOnly is a unit test function that tests that read-only mode rejects writes before they reach the database.
+func TestExecuteReadOnly(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Println("Failed to close rows:", err)
+		}
+	}()
+
+	query := "SELECT [delete] FROM [user]"
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"delete"}).AddRow(1))
+
+	m, err := NewMSSQL(db)
+	if err != nil {
+		t.Fatalf("error initialising mssql: %s", err)
+	}
+	m.(types.ReadOnlySetter).SetReadOnly(true)
+	if _, err := m.Execute(query); err != nil {
+		t.Errorf("error executing query: %s", err)
+	}
+	// MSSQL has no read-only transaction, statements following a SELECT without semicolon and locking hints are rejected
+	for _, write := range []string{
+		"SELECT 1 EXEC sp_configure 'xp_cmdshell', 1",
+		"SELECT 1 DENY SELECT ON t TO public",
+		"SELECT 1 DISABLE TRIGGER trg ON t",
+		"SELECT 1 ENABLE TRIGGER ALL ON t",
+		"SELECT * FROM t WITH (TABLOCKX, HOLDLOCK)",
+	} {
+		if _, err := m.Execute(write); err == nil {
+			t.Errorf("Execute(%q): expected an error", write)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestExplain is a unit test function that tests converting the estimated and the actual showplan XML to plan trees.
+func TestExplain(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Println("Failed to close rows:", err)
+		}
+	}()
+
+	query := "SELECT u.name FROM [user] u JOIN orders o ON o.user_id = u.id"
+	plan := `<ShowPlanXML xmlns="http://schemas.microsoft.com/sqlserver/2004/07/showplan"><BatchSequence><Batch><Statements>
+<StmtSimple StatementText="SELECT u.name FROM [user] u JOIN orders o ON o.user_id = u.id"><QueryPlan>
+<RelOp NodeId="0" PhysicalOp="Nested Loops" LogicalOp="Inner Join" EstimateRows="12" EstimatedTotalSubtreeCost="0.0071">
+  <NestedLoops Optimized="0">
+    <RelOp NodeId="1" PhysicalOp="Clustered Index Scan" EstimateRows="10" EstimatedTotalSubtreeCost="0.0033">
+      <IndexScan Ordered="0"><Object Database="[app]" Schema="[dbo]" Table="[user]" Index="[PK_user]"/></IndexScan>
+    </RelOp>
+    <RelOp NodeId="2" PhysicalOp="Index Seek" EstimateRows="1.2" EstimatedTotalSubtreeCost="0.0032">
+      <IndexScan Ordered="1"><Object Database="[app]" Schema="[dbo]" Table="[orders]" Index="[IX_user_id]"/></IndexScan>
+    </RelOp>
+  </NestedLoops>
+</RelOp>
+</QueryPlan></StmtSimple></Statements></Batch></BatchSequence></ShowPlanXML>`
+	mock.ExpectExec(regexp.QuoteMeta("SET SHOWPLAN_XML ON")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{mssqlShowplanColumn}).AddRow(plan))
+	mock.ExpectExec(regexp.QuoteMeta("SET SHOWPLAN_XML OFF")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	m, err := NewMSSQL(db)
+	if err != nil {
+		t.Fatalf("error initializing mssql: %s", err)
+	}
+	got, err := m.(types.Explainer).Explain(context.Background(), query, false)
+	if err != nil {
+		t.Fatalf("error explaining the query: %s", err)
+	}
+	want := &types.PlanNode{Type: "Nested Loops", EstimatedRows: 12, EstimatedCost: 0.0071, Children: []*types.PlanNode{
+		{Type: "Clustered Index Scan", Relation: "dbo.user", EstimatedRows: 10, EstimatedCost: 0.0033},
+		{Type: "Index Seek", Relation: "dbo.orders", EstimatedRows: 1.2, EstimatedCost: 0.0032},
+	}}
+	if !reflect.DeepEqual(got.Root, want) {
+		t.Errorf("Explain() = %+v, want %+v", got.Root, want)
+	}
+
+	// the actual plan follows the results of the statement, its counters are per thread
+	actual := `<ShowPlanXML><BatchSequence><Batch><Statements><StmtSimple><QueryPlan>
+<RelOp NodeId="0" PhysicalOp="Table Scan" EstimateRows="10" EstimatedTotalSubtreeCost="0.0033">
+  <RunTimeInformation>
+    <RunTimeCountersPerThread Thread="1" ActualRows="6" ActualElapsedms="3"/>
+    <RunTimeCountersPerThread Thread="2" ActualRows="5" ActualElapsedms="4"/>
+  </RunTimeInformation>
+  <TableScan><Object Schema="[dbo]" Table="[user]"/></TableScan>
+</RelOp>
+</QueryPlan></StmtSimple></Statements></Batch></BatchSequence></ShowPlanXML>`
+	mock.ExpectExec(regexp.QuoteMeta("SET STATISTICS XML ON")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name FROM [user]")).WillReturnRows(
+		sqlmock.NewRows([]string{"name"}).AddRow("a").AddRow("b"),
+		sqlmock.NewRows([]string{mssqlShowplanColumn}).AddRow(actual))
+	mock.ExpectRollback()
+	mock.ExpectExec(regexp.QuoteMeta("SET STATISTICS XML OFF")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	got, err = m.(types.Explainer).Explain(context.Background(), "SELECT name FROM [user]", true)
+	if err != nil {
+		t.Fatalf("error explaining the query: %s", err)
+	}
+	want = &types.PlanNode{Type: "Table Scan", Relation: "dbo.user", EstimatedRows: 10, EstimatedCost: 0.0033, ActualRows: 11, ActualTime: 4}
+	if !got.Analyzed || !reflect.DeepEqual(got.Root, want) {
+		t.Errorf("Explain() = %+v, want %+v", got.Root, want)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestBeginReadOnly is a unit test function that tests that read-only transactions, which the driver does not
+// support, are started as normal transactions whose statements are checked.
+func TestBeginReadOnly(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Println("Failed to close rows:", err)
+		}
+	}()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT [id] FROM [user] WHERE [id] = @p1")).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectRollback()
+
+	m, err := NewMSSQL(db)
+	if err != nil {
+		t.Fatalf("error initializing mssql: %s", err)
+	}
+	tx, err := m.Begin(context.Background(), types.TxOptions{Isolation: sql.LevelSnapshot, ReadOnly: true})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if _, err := tx.Execute(context.Background(), "SELECT [id] FROM [user] WHERE [id] = @p1", 1); err != nil {
+		t.Errorf("error executing statement: %s", err)
+	}
+	if _, err := tx.Execute(context.Background(), "DELETE FROM [user]"); err == nil {
+		t.Error("expected an error for a write in a read-only transaction")
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Errorf("error rolling back transaction: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestTableStats is a unit test function that tests reading the row count, size and modification times of a table.
+func TestTableStats(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Println("Failed to close rows:", err)
+		}
+	}()
+
+	modified := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"row_count", "bytes", "modify_date", "last_user_update"}).AddRow(1200, 98304, modified, nil)
+	mock.ExpectQuery(regexp.QuoteMeta(MSSQL_TABLE_STATS_QUERY)).WithArgs("user", "sales").WillReturnRows(rows)
+
+	m := &MSSQL{Client: db, Config: &config.Config{Database: "shop", Schema: "dbo"}}
+	stats, err := m.TableStats("shop.sales.user")
+	if err != nil {
+		t.Fatalf("error reading table statistics: %s", err)
+	}
+
+	expected := types.TableStats{
+		Name:     "user",
+		Schema:   "sales",
+		RowCount: 1200,
+		Bytes:    98304,
+		LastDDL:  sql.NullTime{Time: modified, Valid: true},
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("expected: %+v, got: %+v", expected, stats)
+	}
+
+	// a table of another database is rejected before querying
+	if _, err := m.TableStats("other.sales.user"); err == nil {
+		t.Error("expected an error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSnapshot is a unit test function that tests that the Snapshot method of the MSSQL struct reads the
+// columns, foreign keys, indexes and descriptions of every table of the schema with one query each.
+func TestSnapshot(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Println(err)
+		}
+	}()
+
+	tableRows := sqlmock.NewRows([]string{"name", "schema", "kind", "definition"}).
+		AddRow("orders", "sales", "table", "").
+		AddRow("users", "sales", "table", "")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(MSSQL_LIST_TABLES_QUERY, "[shop]"))).WithArgs("sales").WillReturnRows(tableRows)
+	columnRows := sqlmock.NewRows([]string{"TABLE_NAME", "COLUMN_NAME", "DATA_TYPE", "IS_NULLABLE", "COLUMN_DEFAULT", "ORDINAL_POSITION", "CHARACTER_MAXIMUM_LENGTH", "NUMERIC_PRECISION", "NUMERIC_SCALE"}).
+		AddRow("orders", "id", "int", "NO", nil, 1, nil, 10, 0).
+		AddRow("orders", "user_id", "int", "NO", nil, 2, nil, 10, 0).
+		AddRow("users", "id", "int", "NO", nil, 1, nil, 10, 0)
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(MSSQL_SNAPSHOT_COLUMNS_QUERY, "[shop]"))).WithArgs("sales").WillReturnRows(columnRows)
+	fkRows := sqlmock.NewRows([]string{"table", "name", "column", "ref_schema", "ref_table", "ref_column", "on_delete", "on_update"}).
+		AddRow("orders", "fk_orders_user", "user_id", "sales", "users", "id", "CASCADE", "NO_ACTION")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(MSSQL_SNAPSHOT_FOREIGN_KEYS_QUERY, "[shop]"))).WithArgs("sales").WillReturnRows(fkRows)
+	indexRows := sqlmock.NewRows([]string{"table", "index", "column", "unique", "primary", "method", "predicate"}).
+		AddRow("orders", "pk_orders", "id", true, true, "clustered", "").
+		AddRow("orders", "ix_orders_user", "user_id", false, false, "nonclustered", "[user_id] IS NOT NULL")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(MSSQL_SNAPSHOT_INDEXES_QUERY, "[shop]"))).WithArgs("sales").WillReturnRows(indexRows)
+	commentRows := sqlmock.NewRows([]string{"table", "column", "description"}).
+		AddRow("users", "", "Registered users")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(MSSQL_SNAPSHOT_COMMENTS_QUERY, "[shop]"))).WithArgs("sales").WillReturnRows(commentRows)
+
+	m := &MSSQL{Client: db, Config: &config.Config{Database: "shop", Schema: "sales"}}
+	schema, err := m.Snapshot(context.Background(), "shop", types.SnapshotOptions{})
+	if err != nil {
+		t.Fatalf("error taking snapshot: %s", err)
+	}
+
+	if len(schema.Tables) != 2 {
+		t.Fatalf("expected 2 tables, got %d", len(schema.Tables))
+	}
+	orders, users := schema.Tables[0], schema.Tables[1]
+	wantForeignKeys := []types.ForeignKey{{
+		Name:              "fk_orders_user",
+		Columns:           []string{"user_id"},
+		ReferencedSchema:  "sales",
+		ReferencedTable:   "users",
+		ReferencedColumns: []string{"id"},
+		OnDelete:          "CASCADE",
+		OnUpdate:          "NO ACTION",
+	}}
+	if !reflect.DeepEqual(orders.ForeignKeys, wantForeignKeys) {
+		t.Errorf("foreign keys = %+v, want %+v", orders.ForeignKeys, wantForeignKeys)
+	}
+	if orders.Catalog != "shop" || orders.ColumnCount != 2 || len(orders.Indexes) != 2 || orders.Indexes[1].Predicate != "[user_id] IS NOT NULL" {
+		t.Errorf("orders = %+v", orders)
+	}
+	if users.Description != "Registered users" || len(users.Indexes) != 0 || len(users.ForeignKeys) != 0 {
+		t.Errorf("users = %+v", users)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestListTables is a unit test function that tests the ListTables method of the MSSQL struct.
+// The kinds are filtered before the view dependencies are read, which are only queried when views are listed.
+func TestListTables(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Println(err)
+		}
+	}()
+
+	tableRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"name", "schema", "kind", "definition"}).
+			AddRow("events", "sales", "external_table", "").
+			AddRow("order_totals", "sales", "view", "CREATE VIEW sales.order_totals AS SELECT user_id FROM orders").
+			AddRow("orders", "sales", "table", "")
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(MSSQL_LIST_TABLES_QUERY, "[shop]"))).WithArgs("sales").WillReturnRows(tableRows())
+	dependencyRows := sqlmock.NewRows([]string{"view_schema", "view_name", "ref_schema", "ref_name"}).
+		AddRow("sales", "order_totals", "sales", "orders")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(MSSQL_VIEW_DEPENDENCIES_QUERY, "[shop]"))).WithArgs("sales").WillReturnRows(dependencyRows)
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(MSSQL_LIST_TABLES_QUERY, "[shop]"))).WithArgs("sales").WillReturnRows(tableRows())
+
+	m := &MSSQL{Client: db, Config: &config.Config{Schema: "sales"}}
+	tables, err := m.ListTables(context.Background(), "shop", types.ListTablesOptions{Kinds: []types.TableKind{types.KindView, types.KindTable}})
+	if err != nil {
+		t.Fatalf("error listing tables: %s", err)
+	}
+	expected := []types.TableInfo{
+		{Name: "order_totals", Schema: "sales", Kind: types.KindView, Definition: "CREATE VIEW sales.order_totals AS SELECT user_id FROM orders",
+			Dependencies: []types.TableName{{Schema: "sales", Name: "orders"}}},
+		{Name: "orders", Schema: "sales", Kind: types.KindTable},
+	}
+	if !reflect.DeepEqual(tables, expected) {
+		t.Errorf("expected: %+v, got: %+v", expected, tables)
+	}
+
+	// without views the dependencies are not queried
+	tables, err = m.ListTables(context.Background(), "shop", types.ListTablesOptions{Kinds: []types.TableKind{types.KindExternalTable}})
+	if err != nil {
+		t.Fatalf("error listing tables: %s", err)
+	}
+	expected = []types.TableInfo{{Name: "events", Schema: "sales", Kind: types.KindExternalTable}}
+	if !reflect.DeepEqual(tables, expected) {
+		t.Errorf("expected: %+v, got: %+v", expected, tables)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestExecuteScript is a unit test function that tests running a script batch by batch, GO n repeats a batch and
+// the rows selected by a procedure are kept.
+func TestExecuteScript(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO users (name) VALUES ('ann')")).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO users (name) VALUES ('ann')")).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("EXEC dbo.list_users @name = 'ann'")).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "ann").AddRow(2, "ann"))
+
+	script := "INSERT INTO users (name) VALUES ('ann')\nGO 2\nEXEC dbo.list_users @name = 'ann'\nGO\n"
+	m := &MSSQL{Client: db, Config: &config.Config{}}
+	result, err := m.ExecuteScript(context.Background(), script, types.ScriptOptions{})
+	if err != nil {
+		t.Fatalf("error executing script: %s", err)
+	}
+	if len(result.Statements) != 3 || result.Statements[1].RowsAffected != 1 {
+		t.Fatalf("ExecuteScript() = %+v, want 3 batches", result)
+	}
+	var rows types.QueryResult
+	if err := json.Unmarshal(result.Statements[2].Result, &rows); err != nil || rows.RowCount != 2 {
+		t.Errorf("unexpected procedure result %s, %v", result.Statements[2].Result, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSchemas is a unit test function that tests the Schemas method of the MSSQL struct.
+func TestSchemas(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	rows := sqlmock.NewRows([]string{"schema_name"}).AddRow("dbo").AddRow("sales")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(MSSQL_SCHEMAS_QUERY, "[shop]"))).WillReturnRows(rows)
+
+	m, err := NewMSSQL(db)
+	if err != nil {
+		t.Errorf("error initialising mssql: %s", err)
+	}
+	schemas, err := m.Schemas(context.Background(), "shop")
+	if err != nil {
+		t.Errorf("error retrieving schemas: %s", err)
+	}
+
+	expected := []string{"dbo", "sales"}
+	if !reflect.DeepEqual(schemas, expected) {
+		t.Errorf("expected: %v, got: %v", expected, schemas)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSetReadOnly is a unit test function that tests that the read-only mode of a MSSQL client does not change
+// the mode of the other clients sharing its configuration.
+func TestSetReadOnly(t *testing.T) {
+	cfg := &config.Config{}
+	m, other := &MSSQL{Config: cfg}, &MSSQL{Config: cfg}
+	m.SetReadOnly(true)
+	if !m.Config.ReadOnly || other.Config.ReadOnly || cfg.ReadOnly {
+		t.Errorf("SetReadOnly(true) changed the shared configuration %+v", cfg)
+	}
+}
@@ -0,0 +1,42 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/thesaas-company/xray/governor"
+	"github.com/thesaas-company/xray/script"
+	"github.com/thesaas-company/xray/session"
+	"github.com/thesaas-company/xray/types"
+)
+
+// mssqlIsolation lists the isolation levels of SQL Server, SNAPSHOT needs ALLOW_SNAPSHOT_ISOLATION on the database.
+var mssqlIsolation = []sql.IsolationLevel{sql.LevelReadUncommitted, sql.LevelReadCommitted, sql.LevelRepeatableRead, sql.LevelSnapshot, sql.LevelSerializable}
+
+// Begin starts a transaction. SQL Server supports the standard isolation levels and SNAPSHOT; the driver has no
+// read-only transactions, their statements are checked with dialect.CheckReadOnly instead.
+func (m *MSSQL) Begin(ctx context.Context, opts types.TxOptions) (types.Tx, error) {
+	return session.Begin(ctx, m.Client, m.sessionConfig(), opts)
+}
+
+// Session pins a connection of the pool, so that SET options and temporary tables hold between statements.
+func (m *MSSQL) Session(ctx context.Context) (types.Session, error) {
+	return session.Open(ctx, m.Client, m.sessionConfig())
+}
+
+// ExecuteScript runs the batches of a script, separated by GO lines, one after the other in a session.
+func (m *MSSQL) ExecuteScript(ctx context.Context, query string, opts types.ScriptOptions) (types.ScriptResult, error) {
+	return script.Run(ctx, m, types.MSSQL, query, opts)
+}
+
+// sessionConfig describes how the statements of transactions and sessions run.
+func (m *MSSQL) sessionConfig() session.Config {
+	return session.Config{
+		DbType:     types.MSSQL,
+		Convert:    newValueConverter(types.BinaryEncoding(m.Config.BinaryEncoding)),
+		Limits:     governor.Limits{MaxRows: m.Config.MaxRows, MaxBytes: m.Config.MaxBytes},
+		ReadOnly:   m.Config.ReadOnly,
+		Isolation:  mssqlIsolation,
+		ReadOnlyTx: false,
+	}
+}
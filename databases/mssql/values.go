@@ -0,0 +1,54 @@
+package mssql
+
+import (
+	"strings"
+	"time"
+
+	mssqldb "github.com/denisenkom/go-mssqldb"
+	"github.com/thesaas-company/xray/types"
+)
+
+// newValueConverter returns the converter for values scanned from MSSQL.
+// DECIMAL and MONEY become exact decimal strings, UNIQUEIDENTIFIER its canonical string form
+// and temporal values RFC3339 strings.
+// BINARY, VARBINARY and IMAGE values are encoded with the given encoding.
+func newValueConverter(binary types.BinaryEncoding) types.ValueConverter {
+	return func(column types.ColumnMeta, v interface{}) (interface{}, error) {
+		return convertValue(column, v, binary)
+	}
+}
+
+// convertValue converts a single value scanned from MSSQL.
+func convertValue(column types.ColumnMeta, val interface{}, binary types.BinaryEncoding) (interface{}, error) {
+	typeName := strings.ToUpper(column.DatabaseType)
+	switch v := val.(type) {
+	case nil:
+		return nil, nil
+	case float64:
+		return types.FloatValue(v), nil
+	case time.Time:
+		switch typeName {
+		case "DATE":
+			return v.Format(types.DateLayout), nil
+		case "TIME":
+			return v.Format(types.TimeLayout), nil
+		}
+		return types.TimestampValue(v), nil
+	case []byte:
+		switch typeName {
+		case "DECIMAL", "NUMERIC", "MONEY", "SMALLMONEY":
+			return string(v), nil
+		case "UNIQUEIDENTIFIER":
+			var id mssqldb.UniqueIdentifier
+			if err := id.Scan(v); err == nil {
+				return id.String(), nil
+			}
+		case "BINARY", "VARBINARY", "IMAGE":
+			return binary.Encode(v)
+		}
+		return string(v), nil
+	default:
+		// int64, bool and string are already JSON friendly
+		return v, nil
+	}
+}
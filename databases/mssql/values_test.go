@@ -0,0 +1,49 @@
+package mssql
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestConvertValue is a unit test function that tests how MSSQL values are mapped to JSON friendly values.
+func TestConvertValue(t *testing.T) {
+	moment := time.Date(2024, 5, 1, 12, 30, 15, 500000000, time.UTC)
+	tests := []struct {
+		name     string
+		typeName string
+		binary   types.BinaryEncoding
+		value    interface{}
+		want     interface{}
+	}{
+		{name: "null", typeName: "NVARCHAR", value: nil, want: nil},
+		{name: "integer", typeName: "BIGINT", value: int64(7), want: int64(7)},
+		{name: "bit", typeName: "BIT", value: true, want: true},
+		{name: "float", typeName: "FLOAT", value: 1.5, want: 1.5},
+		{name: "infinite float", typeName: "FLOAT", value: math.Inf(1), want: "Infinity"},
+		{name: "base64 looking nvarchar", typeName: "NVARCHAR", value: "test", want: "test"},
+		{name: "decimal", typeName: "DECIMAL", value: []byte("12345678901234567890.10"), want: "12345678901234567890.10"},
+		{name: "money", typeName: "MONEY", value: []byte("19.9900"), want: "19.9900"},
+		{name: "uniqueidentifier", typeName: "UNIQUEIDENTIFIER", value: []byte{0x67, 0x45, 0x23, 0x01, 0xab, 0x89, 0xef, 0xcd, 0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}, want: "01234567-89AB-CDEF-0123-456789ABCDEF"},
+		{name: "date", typeName: "DATE", value: moment, want: "2024-05-01"},
+		{name: "time", typeName: "TIME", value: moment, want: "12:30:15.5"},
+		{name: "datetime2", typeName: "DATETIME2", value: moment, want: "2024-05-01T12:30:15.5Z"},
+		{name: "varbinary", typeName: "VARBINARY", value: []byte("test"), want: "dGVzdA=="},
+		{name: "varbinary as hex", typeName: "VARBINARY", binary: types.BinaryHex, value: []byte("test"), want: "74657374"},
+		{name: "image omitted", typeName: "IMAGE", binary: types.BinaryOmit, value: []byte("test"), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertValue(types.ColumnMeta{DatabaseType: tt.typeName}, tt.value, tt.binary)
+			if err != nil {
+				t.Fatalf("convertValue() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("convertValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
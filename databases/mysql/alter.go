@@ -0,0 +1,81 @@
+package mysql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/diff"
+	"github.com/thesaas-company/xray/types"
+)
+
+// GenerateAlterTableQueries generates the ALTER TABLE statements turning the from table into the to table.
+// Changed columns are redefined as a whole with MODIFY COLUMN.
+func (m *MySQL) GenerateAlterTableQueries(from, to types.Table) types.Migration {
+	var migration types.Migration
+	d := diff.Tables(from, to, types.MySQL, types.MySQL)
+	table := dialect.QuoteTableName(types.MySQL, types.TableName{Schema: to.Schema, Name: to.Name})
+
+	for _, index := range d.RemovedIndexes {
+		migration.Add("DROP INDEX " + dialect.QuoteIdentifier(types.MySQL, index.Name) + " ON " + table + ";")
+	}
+	if d.PrimaryKey != nil && d.PrimaryKey.From != "" {
+		migration.Add("ALTER TABLE " + table + " DROP PRIMARY KEY;")
+	}
+
+	for _, column := range d.RemovedColumns {
+		migration.Add("ALTER TABLE " + table + " DROP COLUMN " + dialect.QuoteIdentifier(types.MySQL, column.Name) + ";")
+		migration.Warn(fmt.Sprintf("dropping column %s deletes its data", column.Name))
+	}
+	for _, column := range d.AddedColumns {
+		migration.Add("ALTER TABLE " + table + " ADD COLUMN " + mysqlColumnDefinition(column) + ";")
+		if column.IsNullable == "NO" && !column.DefaultValue.Valid {
+			migration.Warn(fmt.Sprintf("adding NOT NULL column %s without a default fails in strict mode on a non-empty table", column.Name))
+		}
+	}
+	for _, column := range d.ChangedColumns {
+		migration.Add("ALTER TABLE " + table + " MODIFY COLUMN " + mysqlColumnDefinition(column.To) + ";")
+		for _, change := range column.Changes {
+			switch {
+			case change.Field == diff.FieldType && !diff.Widens(types.MySQL, column.From, column.To):
+				migration.Warn(fmt.Sprintf("changing the type of column %s from %s to %s may fail or lose data", column.Name, change.From, change.To))
+			case change.Field == diff.FieldNullable && change.To == "NO":
+				migration.Warn(fmt.Sprintf("setting column %s NOT NULL fails if it contains NULL values", column.Name))
+			}
+		}
+	}
+
+	if d.PrimaryKey != nil && d.PrimaryKey.To != "" {
+		migration.Add("ALTER TABLE " + table + " ADD PRIMARY KEY (" + strings.Join(dialect.QuoteColumns(types.MySQL, to, diff.PrimaryKeyColumns(to)), ", ") + ");")
+	}
+	for _, index := range d.AddedIndexes {
+		query := "CREATE "
+		switch {
+		case index.Unique:
+			query += "UNIQUE "
+		case strings.EqualFold(index.Method, "fulltext"), strings.EqualFold(index.Method, "spatial"):
+			query += strings.ToUpper(index.Method) + " "
+		}
+		migration.Add(query + "INDEX " + dialect.QuoteIdentifier(types.MySQL, index.Name) + " ON " + table + " (" + strings.Join(dialect.QuoteColumns(types.MySQL, to, index.Columns), ", ") + ");")
+	}
+	return migration
+}
+
+// mysqlColumnDefinition returns the definition of a column as used by ADD COLUMN and MODIFY COLUMN.
+// The primary key is left out, it is changed separately.
+func mysqlColumnDefinition(column types.Column) string {
+	definition := dialect.QuoteIdentifier(types.MySQL, column.Name) + " " + convertTypeToMysql(strings.ToUpper(column.Type)) + dialect.ColumnLength(column)
+	if column.IsNullable == "NO" {
+		definition += " NOT NULL"
+	}
+	if column.DefaultValue.Valid {
+		definition += " DEFAULT " + column.DefaultValue.String
+	}
+	if column.AutoIncrement {
+		definition += " AUTO_INCREMENT"
+	}
+	if column.Description != "" {
+		definition += " COMMENT " + dialect.QuoteString(types.MySQL, column.Description)
+	}
+	return definition
+}
@@ -0,0 +1,86 @@
+package mysql
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestGenerateAlterTableQueries is a unit test function that tests the GenerateAlterTableQueries method of the MySQL struct.
+func TestGenerateAlterTableQueries(t *testing.T) {
+	from := types.Table{
+		Name: "users",
+		Columns: []types.Column{
+			{Name: "id", Type: "int(11)", IsNullable: "NO", Key: "PRI", IsPrimary: true, AutoIncrement: true},
+			{Name: "email", Type: "varchar(255)", IsNullable: "YES"},
+			{Name: "bio", Type: "text", IsNullable: "YES"},
+		},
+		Indexes: []types.Index{
+			{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Primary: true, Method: "btree"},
+		},
+	}
+	to := types.Table{
+		Name: "users",
+		Columns: []types.Column{
+			{Name: "id", Type: "int(11)", IsNullable: "NO", Key: "PRI", IsPrimary: true, AutoIncrement: true},
+			{Name: "email", Type: "varchar(100)", IsNullable: "NO", DefaultValue: sql.NullString{String: "''", Valid: true}},
+			{Name: "tenant_id", Type: "int", IsNullable: "NO"},
+		},
+		Indexes: []types.Index{
+			{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Primary: true, Method: "btree"},
+			{Name: "idx_tenant", Columns: []string{"tenant_id"}, Method: "btree"},
+		},
+	}
+
+	m := &MySQL{}
+	migration := m.GenerateAlterTableQueries(from, to)
+
+	wantStatements := []string{
+		"ALTER TABLE `users` DROP COLUMN `bio`;",
+		"ALTER TABLE `users` ADD COLUMN `tenant_id` INT NOT NULL;",
+		"ALTER TABLE `users` MODIFY COLUMN `email` VARCHAR(100) NOT NULL DEFAULT '';",
+		"CREATE INDEX `idx_tenant` ON `users` (`tenant_id`);",
+	}
+	if !reflect.DeepEqual(migration.Statements, wantStatements) {
+		t.Errorf("statements = %#v, want %#v", migration.Statements, wantStatements)
+	}
+	wantWarnings := []string{
+		"dropping column bio deletes its data",
+		"adding NOT NULL column tenant_id without a default fails in strict mode on a non-empty table",
+		"changing the type of column email from varchar(255) to varchar(100) may fail or lose data",
+		"setting column email NOT NULL fails if it contains NULL values",
+	}
+	if !reflect.DeepEqual(migration.Warnings, wantWarnings) {
+		t.Errorf("warnings = %#v, want %#v", migration.Warnings, wantWarnings)
+	}
+}
+
+// TestGenerateAlterTableQueriesQuotedNames is a unit test function that tests that reserved words, mixed case names
+// and quote characters in table, column and index names are quoted.
+func TestGenerateAlterTableQueriesQuotedNames(t *testing.T) {
+	from := types.Table{
+		Name:    "Order",
+		Schema:  "Shop",
+		Columns: []types.Column{{Name: "id", Type: "int", IsNullable: "NO", IsPrimary: true}, {Name: "Group", Type: "text", IsNullable: "YES"}},
+	}
+	to := types.Table{
+		Name:    "Order",
+		Schema:  "Shop",
+		Columns: []types.Column{{Name: "id", Type: "int", IsNullable: "NO", IsPrimary: true}, {Name: "select`", Type: "text", IsNullable: "YES"}},
+		Indexes: []types.Index{{Name: "select", Columns: []string{"select`"}}},
+	}
+
+	s := &MySQL{}
+	m := s.GenerateAlterTableQueries(from, to)
+
+	want := []string{
+		"ALTER TABLE `Shop`.`Order` DROP COLUMN `Group`;",
+		"ALTER TABLE `Shop`.`Order` ADD COLUMN `select``` TEXT;",
+		"CREATE INDEX `select` ON `Shop`.`Order` (`select```);",
+	}
+	if !reflect.DeepEqual(m.Statements, want) {
+		t.Errorf("statements = %#v, want %#v", m.Statements, want)
+	}
+}
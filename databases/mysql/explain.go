@@ -0,0 +1,164 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/explain"
+	"github.com/thesaas-company/xray/types"
+)
+
+const (
+	MYSQL_EXPLAIN_QUERY         = "EXPLAIN FORMAT=JSON %s" // MYSQL_EXPLAIN_QUERY plans a statement without running it and returns the plan as a single JSON value.
+	MYSQL_EXPLAIN_ANALYZE_QUERY = "EXPLAIN ANALYZE %s"     // MYSQL_EXPLAIN_ANALYZE_QUERY runs a statement and returns its plan with the measured values as an indented tree.
+)
+
+// mysqlOperations names the keys of the JSON plan that hold operations, keys not listed hold node details.
+// The operations of the arrays are the elements' own operations.
+var mysqlOperations = map[string]string{
+	"query_block":                "Query block",
+	"nested_loop":                "Nested loop",
+	"ordering_operation":         "Sort",
+	"grouping_operation":         "Aggregate",
+	"duplicates_removal":         "Duplicates removal",
+	"windowing":                  "Window",
+	"buffer_result":              "Buffer result",
+	"union_result":               "Union",
+	"materialized_from_subquery": "Materialize",
+	"attached_subqueries":        "Subqueries",
+}
+
+// mysqlAccessTypes names the access types of the tables of the JSON plan.
+var mysqlAccessTypes = map[string]string{
+	"ALL":             "Table scan",
+	"index":           "Index scan",
+	"range":           "Index range scan",
+	"ref":             "Index lookup",
+	"eq_ref":          "Index lookup",
+	"ref_or_null":     "Index lookup",
+	"unique_subquery": "Index lookup",
+	"index_subquery":  "Index lookup",
+	"fulltext":        "Fulltext index lookup",
+	"index_merge":     "Index merge",
+	"const":           "Constant lookup",
+	"system":          "Constant lookup",
+}
+
+// Explain returns the plan of a single statement. Without analyze the plan is read from EXPLAIN FORMAT=JSON,
+// with analyze from the tree of EXPLAIN ANALYZE, run inside a transaction that is rolled back.
+func (m *MySQL) Explain(ctx context.Context, query string, analyze bool) (types.Plan, error) {
+	statement, err := dialect.SingleStatement(types.MySQL, query)
+	if err != nil {
+		return types.Plan{}, fmt.Errorf("error explaining query: %v", err)
+	}
+
+	if !analyze {
+		var raw string
+		if err := m.Client.QueryRowContext(ctx, fmt.Sprintf(MYSQL_EXPLAIN_QUERY, statement.Text)).Scan(&raw); err != nil {
+			return types.Plan{}, fmt.Errorf("error explaining query: %v", err)
+		}
+		var plan map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &plan); err != nil {
+			return types.Plan{}, fmt.Errorf("error parsing query plan: %v", err)
+		}
+		root := mysqlNode("", plan)
+		if len(root.Children) != 1 {
+			return types.Plan{}, fmt.Errorf("error parsing query plan: expected a single query block")
+		}
+		return types.Plan{Root: root.Children[0], Format: "json", Raw: raw}, nil
+	}
+
+	if m.Config.ReadOnly {
+		if err := dialect.CheckReadOnly(types.MySQL, statement.Text); err != nil {
+			return types.Plan{}, err
+		}
+	}
+	tx, err := m.Client.BeginTx(ctx, &sql.TxOptions{ReadOnly: m.Config.ReadOnly})
+	if err != nil {
+		return types.Plan{}, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			fmt.Println(err)
+		}
+	}()
+
+	plan := types.Plan{Analyzed: true, Format: "text"}
+	if err := tx.QueryRowContext(ctx, fmt.Sprintf(MYSQL_EXPLAIN_ANALYZE_QUERY, statement.Text)).Scan(&plan.Raw); err != nil {
+		return types.Plan{}, fmt.Errorf("error explaining query: %v", err)
+	}
+	if plan.Root, err = explain.ParseText(plan.Raw); err != nil {
+		return types.Plan{}, err
+	}
+	return plan, nil
+}
+
+// mysqlNode converts an object of the JSON plan to a plan node whose children are the operations the object holds.
+func mysqlNode(typ string, obj map[string]interface{}) *types.PlanNode {
+	node := &types.PlanNode{Type: typ}
+	if cost, ok := obj["cost_info"].(map[string]interface{}); ok {
+		node.EstimatedCost = mysqlNumber(cost["query_cost"])
+		if node.EstimatedCost == 0 {
+			node.EstimatedCost = mysqlNumber(cost["prefix_cost"])
+		}
+	}
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch value := obj[key].(type) {
+		case map[string]interface{}:
+			if key == "table" {
+				node.Children = append(node.Children, mysqlTable(value))
+			} else if name, ok := mysqlOperations[key]; ok {
+				node.Children = append(node.Children, mysqlNode(name, value))
+			}
+		case []interface{}:
+			var children []*types.PlanNode
+			for _, element := range value {
+				if element, ok := element.(map[string]interface{}); ok {
+					children = append(children, mysqlNode("", element).Children...)
+				}
+			}
+			if name, ok := mysqlOperations[key]; ok {
+				node.Children = append(node.Children, &types.PlanNode{Type: name, Children: children})
+			} else if key == "query_specifications" {
+				node.Children = append(node.Children, children...)
+			}
+		}
+	}
+	return node
+}
+
+// mysqlTable converts a table of the JSON plan to a plan node named after its access type.
+func mysqlTable(table map[string]interface{}) *types.PlanNode {
+	accessType, _ := table["access_type"].(string)
+	typ, ok := mysqlAccessTypes[accessType]
+	if !ok {
+		typ = accessType
+	}
+	node := mysqlNode(typ, table)
+	node.Relation, _ = table["table_name"].(string)
+	node.EstimatedRows = mysqlNumber(table["rows_produced_per_join"])
+	return node
+}
+
+// mysqlNumber reads a number of the JSON plan, MySQL 8 writes costs as strings.
+func mysqlNumber(value interface{}) float64 {
+	switch value := value.(type) {
+	case float64:
+		return value
+	case string:
+		f, _ := strconv.ParseFloat(value, 64)
+		return f
+	}
+	return 0
+}
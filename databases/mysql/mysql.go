@@ -1,14 +1,20 @@
 package mysql
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/thesaas-company/xray/config"
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/governor"
+	"github.com/thesaas-company/xray/registry"
+	"github.com/thesaas-company/xray/snapshot"
 	"github.com/thesaas-company/xray/types"
 	// "github.com/joho/godotenv"
 )
@@ -19,23 +25,107 @@ var DB_PASSWORD = "DB_PASSWORD"
 const (
 	SCHEMA_QUERY            = "DESCRIBE %s"                                                             // SCHEMA_QUERY is the SQL query used to describe a table schema.
 	MYSQL_TABLES_LIST_QUERY = "SELECT table_name FROM information_schema.tables WHERE table_schema = ?" // MYSQL_TABLES_LIST_QUERY is the SQL query used to list all tables in a schema.
+
+	// MYSQL_COMMENTS_QUERY is the SQL query used to read the comments of a table and its columns.
+	// The table comment is returned with an empty column name.
+	MYSQL_COMMENTS_QUERY = `SELECT '', TABLE_COMMENT FROM information_schema.TABLES
+	WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND TABLE_NAME = ? AND TABLE_COMMENT <> ''
+	UNION ALL
+	SELECT COLUMN_NAME, COLUMN_COMMENT FROM information_schema.COLUMNS
+	WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND TABLE_NAME = ? AND COLUMN_COMMENT <> ''`
+
+	// MYSQL_TABLE_STATS_QUERY is the SQL query used to read the estimated row count, size and times of a table.
+	// The times are returned as unix timestamps, the connection does not parse DATETIME values.
+	MYSQL_TABLE_STATS_QUERY = `SELECT COALESCE(TABLE_ROWS, 0), COALESCE(DATA_LENGTH, 0) + COALESCE(INDEX_LENGTH, 0), UNIX_TIMESTAMP(CREATE_TIME), UNIX_TIMESTAMP(UPDATE_TIME)
+	FROM information_schema.TABLES
+	WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND TABLE_NAME = ?`
+
+	// MYSQL_LIST_TABLES_QUERY is the SQL query used to list the tables and views of a database with the view definitions.
+	MYSQL_LIST_TABLES_QUERY = `SELECT t.TABLE_NAME, t.TABLE_SCHEMA, CASE WHEN t.TABLE_TYPE LIKE '%VIEW' THEN 'view' ELSE 'table' END, COALESCE(v.VIEW_DEFINITION, '')
+	FROM information_schema.TABLES t
+	LEFT JOIN information_schema.VIEWS v ON v.TABLE_SCHEMA = t.TABLE_SCHEMA AND v.TABLE_NAME = t.TABLE_NAME
+	WHERE t.TABLE_SCHEMA = ?
+	ORDER BY t.TABLE_NAME`
+
+	// MYSQL_VIEW_DEPENDENCIES_QUERY is the SQL query used to list the tables and views the views of a database read from.
+	MYSQL_VIEW_DEPENDENCIES_QUERY = `SELECT VIEW_SCHEMA, VIEW_NAME, TABLE_SCHEMA, TABLE_NAME
+	FROM information_schema.VIEW_TABLE_USAGE
+	WHERE VIEW_SCHEMA = ?
+	ORDER BY VIEW_NAME, TABLE_SCHEMA, TABLE_NAME`
+
+	// MYSQL_SCHEMAS_LIST_QUERY is the SQL query used to list the user databases of the server.
+	// MySQL has no separate schema level, so the databases are its schemas.
+	MYSQL_SCHEMAS_LIST_QUERY = `SELECT SCHEMA_NAME FROM information_schema.SCHEMATA
+	WHERE SCHEMA_NAME NOT IN ('information_schema', 'mysql', 'performance_schema', 'sys')
+	ORDER BY SCHEMA_NAME`
+
+	// MYSQL_FOREIGN_KEYS_QUERY is the SQL query used to list the foreign key columns of a table.
+	MYSQL_FOREIGN_KEYS_QUERY = `SELECT kcu.CONSTRAINT_NAME, kcu.COLUMN_NAME, kcu.REFERENCED_TABLE_SCHEMA, kcu.REFERENCED_TABLE_NAME, kcu.REFERENCED_COLUMN_NAME, rc.DELETE_RULE, rc.UPDATE_RULE
+	FROM information_schema.KEY_COLUMN_USAGE kcu
+	JOIN information_schema.REFERENTIAL_CONSTRAINTS rc ON rc.CONSTRAINT_SCHEMA = kcu.CONSTRAINT_SCHEMA AND rc.CONSTRAINT_NAME = kcu.CONSTRAINT_NAME AND rc.TABLE_NAME = kcu.TABLE_NAME
+	WHERE kcu.TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND kcu.TABLE_NAME = ? AND kcu.REFERENCED_TABLE_NAME IS NOT NULL
+	ORDER BY kcu.CONSTRAINT_NAME, kcu.ORDINAL_POSITION`
+
+	// MYSQL_INDEXES_QUERY is the SQL query used to list the index columns of a table.
+	MYSQL_INDEXES_QUERY = `SELECT INDEX_NAME, COALESCE(COLUMN_NAME, ''), NON_UNIQUE = 0, INDEX_NAME = 'PRIMARY', LOWER(INDEX_TYPE), ''
+	FROM information_schema.STATISTICS
+	WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND TABLE_NAME = ?
+	ORDER BY INDEX_NAME, SEQ_IN_INDEX`
+
+	// MYSQL_SNAPSHOT_COLUMNS_QUERY is the SQL query used to read the columns of every table of a database at once,
+	// in the form DESCRIBE returns them.
+	MYSQL_SNAPSHOT_COLUMNS_QUERY = `SELECT TABLE_NAME, COLUMN_NAME, COLUMN_TYPE, IS_NULLABLE, COLUMN_KEY, COLUMN_DEFAULT, EXTRA
+	FROM information_schema.COLUMNS
+	WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE())
+	ORDER BY TABLE_NAME, ORDINAL_POSITION`
+
+	// MYSQL_SNAPSHOT_FOREIGN_KEYS_QUERY is the SQL query used to list the foreign key columns of every table of a database at once.
+	MYSQL_SNAPSHOT_FOREIGN_KEYS_QUERY = `SELECT kcu.TABLE_NAME, kcu.CONSTRAINT_NAME, kcu.COLUMN_NAME, kcu.REFERENCED_TABLE_SCHEMA, kcu.REFERENCED_TABLE_NAME, kcu.REFERENCED_COLUMN_NAME, rc.DELETE_RULE, rc.UPDATE_RULE
+	FROM information_schema.KEY_COLUMN_USAGE kcu
+	JOIN information_schema.REFERENTIAL_CONSTRAINTS rc ON rc.CONSTRAINT_SCHEMA = kcu.CONSTRAINT_SCHEMA AND rc.CONSTRAINT_NAME = kcu.CONSTRAINT_NAME AND rc.TABLE_NAME = kcu.TABLE_NAME
+	WHERE kcu.TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND kcu.REFERENCED_TABLE_NAME IS NOT NULL
+	ORDER BY kcu.TABLE_NAME, kcu.CONSTRAINT_NAME, kcu.ORDINAL_POSITION`
+
+	// MYSQL_SNAPSHOT_INDEXES_QUERY is the SQL query used to list the index columns of every table of a database at once.
+	MYSQL_SNAPSHOT_INDEXES_QUERY = `SELECT TABLE_NAME, INDEX_NAME, COALESCE(COLUMN_NAME, ''), NON_UNIQUE = 0, INDEX_NAME = 'PRIMARY', LOWER(INDEX_TYPE), ''
+	FROM information_schema.STATISTICS
+	WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE())
+	ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX`
+
+	// MYSQL_SNAPSHOT_COMMENTS_QUERY is the SQL query used to read the comments of every table and column of a database at once.
+	// Table comments are returned with an empty column name.
+	MYSQL_SNAPSHOT_COMMENTS_QUERY = `SELECT TABLE_NAME, '', TABLE_COMMENT FROM information_schema.TABLES
+	WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND TABLE_COMMENT <> ''
+	UNION ALL
+	SELECT TABLE_NAME, COLUMN_NAME, COLUMN_COMMENT FROM information_schema.COLUMNS
+	WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND COLUMN_COMMENT <> ''`
 )
 
 // MySQL is a MySQL implementation of the ISQL interface.
 type MySQL struct {
-	Client *sql.DB // Client is the MySQL database client.
+	Client *sql.DB        // Client is the MySQL database client.
+	Config *config.Config // Config is the configuration the client was created with.
+}
+
+func init() {
+	registry.Register(types.MySQL.String(), registry.Factory{
+		New:           NewMySQL,
+		NewWithConfig: NewMySQLWithConfig,
+	})
 }
 
-func NewMySQL(dbClient *sql.DB) (types.ISQL, error) {
+// NewMySQL creates a new MySQL client with the given sql.DB.
+func NewMySQL(dbClient *sql.DB) (types.ISQLContext, error) {
 	return &MySQL{
 		Client: dbClient,
+		Config: &config.Config{},
 	}, nil
 
 }
 
 // NewMySQLWithConfig creates a new MySQL client with the given configuration.
 // It returns an error if the DB_PASSWORD environment variable is not set.
-func NewMySQLWithConfig(dbConfig *config.Config) (types.ISQL, error) {
+func NewMySQLWithConfig(dbConfig *config.Config) (types.ISQLContext, error) {
 	if os.Getenv(DB_PASSWORD) == "" || len(os.Getenv(DB_PASSWORD)) == 0 { // added mysql to be more verbose about the db type
 		return nil, fmt.Errorf("please set %s env variable for the database", DB_PASSWORD)
 	}
@@ -52,6 +142,7 @@ func NewMySQLWithConfig(dbConfig *config.Config) (types.ISQL, error) {
 
 	return &MySQL{
 		Client: db,
+		Config: dbConfig,
 	}, nil
 
 }
@@ -59,10 +150,22 @@ func NewMySQLWithConfig(dbConfig *config.Config) (types.ISQL, error) {
 // Schema retrieves the table schema for the given table name.
 // It takes the table name as an argument and returns the table schema as a types.Table object.
 func (m *MySQL) Schema(table string) (types.Table, error) {
+	return m.SchemaContext(context.Background(), table)
+}
+
+// SchemaContext retrieves the table schema for the given table name.
+// The query is cancelled when the context is done.
+func (m *MySQL) SchemaContext(ctx context.Context, table string) (types.Table, error) {
 	var response types.Table
+	// a qualified name is database.table, DESCRIBE takes it as quoted identifiers
+	name := types.ParseTableName(table)
+	schema := name.Schema
+	if schema == "" {
+		schema = m.Config.Database
+	}
 
 	// execute the sql statement
-	rows, err := m.Client.Query(fmt.Sprintf(SCHEMA_QUERY, table))
+	rows, err := m.Client.QueryContext(ctx, fmt.Sprintf(SCHEMA_QUERY, dialect.QuoteTableName(types.MySQL, types.TableName{Schema: name.Schema, Name: name.Name})))
 	if err != nil {
 		return response, fmt.Errorf("error executing sql statement: %v", err)
 	}
@@ -80,7 +183,6 @@ func (m *MySQL) Schema(table string) (types.Table, error) {
 		if err := rows.Scan(&column.Name, &column.Type, &column.IsNullable, &column.Key, &column.DefaultValue, &column.Extra); err != nil {
 			return response, fmt.Errorf("error scanning rows: %v", err)
 		}
-		column.Description = ""      // default description
 		column.Metatags = []string{} // default metatags as an empty string slice
 		column.Metatags = append(column.Metatags, column.Name)
 		column.Visibility = true // default visibility
@@ -92,23 +194,75 @@ func (m *MySQL) Schema(table string) (types.Table, error) {
 		return response, fmt.Errorf("error iterating over rows: %v", err)
 	}
 
+	foreignKeys, err := m.foreignKeys(ctx, name.Schema, name.Name)
+	if err != nil {
+		return response, err
+	}
+
+	indexes, err := m.indexes(ctx, name.Schema, name.Name)
+	if err != nil {
+		return response, err
+	}
+	types.ApplyIndexes(columns, indexes)
+
+	comments, err := m.comments(ctx, name.Schema, name.Name)
+	if err != nil {
+		return response, err
+	}
+	description := types.ApplyComments(columns, comments)
+
 	return types.Table{
-		Name:        table,
+		Name:        name.Name,
+		Schema:      schema,
 		Columns:     columns,
 		ColumnCount: int64(len(columns)),
-		Description: "",
+		Description: description,
 		Metatags:    []string{},
+		ForeignKeys: foreignKeys,
+		Indexes:     indexes,
 	}, nil
 }
 
-// Execute executes the given SQL query and returns the result as JSON.
-// It takes the SQL query as an argument.
-func (m *MySQL) Execute(query string) ([]byte, error) {
+// TableStats retrieves the estimated row count, size and modification times of the given table.
+func (m *MySQL) TableStats(table string) (types.TableStats, error) {
+	return m.TableStatsContext(context.Background(), table)
+}
 
-	// execute the sql statement
-	rows, err := m.Client.Query(query)
+// TableStatsContext retrieves the estimated row count, size and modification times of the given table.
+// The row count is exact for MyISAM and an estimate for InnoDB. UPDATE_TIME is only tracked by some engines
+// and is reset when the server restarts.
+// The query is cancelled when the context is done.
+func (m *MySQL) TableStatsContext(ctx context.Context, table string) (types.TableStats, error) {
+	name := types.ParseTableName(table)
+	stats := types.TableStats{Name: name.Name, Schema: name.Schema}
+	if stats.Schema == "" {
+		stats.Schema = m.Config.Database
+	}
+
+	var created, updated sql.NullInt64
+	row := m.Client.QueryRowContext(ctx, MYSQL_TABLE_STATS_QUERY, name.Schema, name.Name)
+	if err := row.Scan(&stats.RowCount, &stats.Bytes, &created, &updated); err != nil {
+		return types.TableStats{}, fmt.Errorf("error reading table statistics: %v", err)
+	}
+	stats.LastDDL = unixTime(created)
+	stats.LastModified = unixTime(updated)
+	return stats, nil
+}
+
+// unixTime converts a unix timestamp in seconds into a time.
+func unixTime(seconds sql.NullInt64) sql.NullTime {
+	if !seconds.Valid {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: time.Unix(seconds.Int64, 0).UTC(), Valid: true}
+}
+
+// comments retrieves the comments of the given table and its columns.
+// An empty schema means the current database.
+func (m *MySQL) comments(ctx context.Context, schema, table string) ([]types.Comment, error) {
+	rows, err := m.Client.QueryContext(ctx, MYSQL_COMMENTS_QUERY, schema, table, schema, table)
 	if err != nil {
-		return nil, fmt.Errorf("error executing sql statement: %v", err)
+		return nil, fmt.Errorf("error executing comment query: %v", err)
 	}
 	defer func() {
 		if err := rows.Close(); err != nil {
@@ -116,52 +270,99 @@ func (m *MySQL) Execute(query string) ([]byte, error) {
 		}
 	}()
 
-	// getting the column names
-	columns, err := rows.Columns()
+	var comments []types.Comment
+	for rows.Next() {
+		var c types.Comment
+		if err := rows.Scan(&c.Column, &c.Text); err != nil {
+			return nil, fmt.Errorf("error scanning comments: %v", err)
+		}
+		comments = append(comments, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over comments: %v", err)
+	}
+	return comments, nil
+}
+
+// indexes retrieves the indexes of the given table.
+// An empty schema means the current database.
+func (m *MySQL) indexes(ctx context.Context, schema, table string) ([]types.Index, error) {
+	rows, err := m.Client.QueryContext(ctx, MYSQL_INDEXES_QUERY, schema, table)
 	if err != nil {
-		return nil, fmt.Errorf("error getting columns: %v", err)
+		return nil, fmt.Errorf("error executing index query: %v", err)
 	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
 
-	// Scan the result into a slice of slices
-	var results [][]interface{}
+	var columns []types.IndexColumn
 	for rows.Next() {
-		values := make([]interface{}, len(columns))
-		pointers := make([]interface{}, len(columns))
-		for i := range values {
-			pointers[i] = &values[i]
+		var c types.IndexColumn
+		if err := rows.Scan(&c.Index, &c.Column, &c.Unique, &c.Primary, &c.Method, &c.Predicate); err != nil {
+			return nil, fmt.Errorf("error scanning indexes: %v", err)
 		}
+		columns = append(columns, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over indexes: %v", err)
+	}
+	return types.GroupIndexes(columns), nil
+}
 
-		if err := rows.Scan(pointers...); err != nil {
-			return nil, fmt.Errorf("error scanning row: %v", err)
+// foreignKeys retrieves the foreign key constraints declared on the given table.
+// An empty schema means the current database.
+func (m *MySQL) foreignKeys(ctx context.Context, schema, table string) ([]types.ForeignKey, error) {
+	rows, err := m.Client.QueryContext(ctx, MYSQL_FOREIGN_KEYS_QUERY, schema, table)
+	if err != nil {
+		return nil, fmt.Errorf("error executing foreign key query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
 		}
+	}()
 
-		// Convert the values to the appropriate types
-		stringRow := make([]interface{}, len(values))
-		for i, v := range values {
-			switch value := v.(type) {
-			case []byte:
-				stringRow[i] = string(value)
-			case string:
-				stringRow[i] = value
-			default:
-				stringRow[i] = fmt.Sprintf("%v", value)
-			}
+	var columns []types.ForeignKeyColumn
+	for rows.Next() {
+		var c types.ForeignKeyColumn
+		if err := rows.Scan(&c.Constraint, &c.Column, &c.ReferencedSchema, &c.ReferencedTable, &c.ReferencedColumn, &c.OnDelete, &c.OnUpdate); err != nil {
+			return nil, fmt.Errorf("error scanning foreign keys: %v", err)
 		}
-
-		// Append the modified row to the results
-		results = append(results, stringRow)
+		columns = append(columns, c)
 	}
-
-	// Check for errors from iterating over rows
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %v", err)
+		return nil, fmt.Errorf("error iterating over foreign keys: %v", err)
 	}
+	return types.GroupForeignKeys(columns), nil
+}
 
-	// Convert the result to JSON
-	queryResult := types.QueryResult{
-		Columns: columns,
-		Rows:    results,
+// Execute executes the given SQL query and returns the result as JSON.
+// It takes the SQL query as an argument.
+func (m *MySQL) Execute(query string) ([]byte, error) {
+	return m.ExecuteContext(context.Background(), query)
+}
+
+// ExecuteContext executes the given SQL query and returns the result as JSON.
+// The query is cancelled when the context is done.
+func (m *MySQL) ExecuteContext(ctx context.Context, query string, args ...interface{}) ([]byte, error) {
+	return m.ExecutePage(ctx, query, "", args...)
+}
+
+// ExecutePage executes the given SQL query and returns the page of its result selected by the page token as JSON,
+// the first page for an empty token. The page is limited by the MaxRows and MaxBytes of the configuration,
+// the NextPageToken of a truncated result selects the next page.
+func (m *MySQL) ExecutePage(ctx context.Context, query, pageToken string, args ...interface{}) ([]byte, error) {
+	start := time.Now()
+	limits := governor.Limits{MaxRows: m.Config.MaxRows, MaxBytes: m.Config.MaxBytes}
+	queryResult, err := governor.Execute(ctx, m, types.MySQL, limits, query, pageToken, args...)
+	if err != nil {
+		return nil, err
 	}
+	queryResult.Time = time.Since(start).Milliseconds()
+
+	// Convert the result to JSON
 	jsonData, err := json.Marshal(queryResult)
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling json: %v", err)
@@ -170,12 +371,53 @@ func (m *MySQL) Execute(query string) ([]byte, error) {
 	return jsonData, nil
 }
 
+// Query executes the given SQL query and returns an iterator over its rows.
+// The rows are read from the database as the iterator advances.
+func (m *MySQL) Query(ctx context.Context, query string, args ...interface{}) (types.RowIterator, error) {
+	// execute the sql statement
+	query, args, err := dialect.Bind(types.MySQL, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error binding query arguments: %v", err)
+	}
+	if m.Config.ReadOnly {
+		// writes hidden in functions are rejected by the read-only transaction
+		if err := dialect.CheckReadOnly(types.MySQL, query); err != nil {
+			return nil, err
+		}
+		return types.QueryReadOnly(ctx, m.Client, newValueConverter(types.BinaryEncoding(m.Config.BinaryEncoding)), query, args...)
+	}
+	rows, err := m.Client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+
+	it, err := types.NewRowIterator(rows, newValueConverter(types.BinaryEncoding(m.Config.BinaryEncoding)))
+	if err != nil {
+		_ = rows.Close()
+		return nil, err
+	}
+	return it, nil
+}
+
+// SetReadOnly enables or disables the read-only mode of the client, see config.Config.ReadOnly.
+// The configuration is copied, clients created with the same configuration keep their mode.
+func (m *MySQL) SetReadOnly(readOnly bool) {
+	cfg := *m.Config
+	cfg.ReadOnly = readOnly
+	m.Config = &cfg
+}
+
 // Tables retrieves the list of tables in the given database.
 // It takes the database name as an argument and returns a list of table names.
 func (m *MySQL) Tables(databaseName string) ([]string, error) {
+	return m.TablesContext(context.Background(), databaseName)
+}
 
+// TablesContext retrieves the list of tables in the given database.
+// The query is cancelled when the context is done.
+func (m *MySQL) TablesContext(ctx context.Context, databaseName string) ([]string, error) {
 	// execute the sql statement
-	rows, err := m.Client.Query(MYSQL_TABLES_LIST_QUERY, databaseName)
+	rows, err := m.Client.QueryContext(ctx, MYSQL_TABLES_LIST_QUERY, databaseName)
 	if err != nil {
 		return nil, fmt.Errorf("error executing sql statement: %v", err)
 	}
@@ -203,12 +445,269 @@ func (m *MySQL) Tables(databaseName string) ([]string, error) {
 	return tables, nil
 }
 
+// ListTables retrieves the tables and views of the given database together with the definition and dependencies of the views.
+// MySQL has no materialized views or external tables.
+func (m *MySQL) ListTables(ctx context.Context, databaseName string, opts types.ListTablesOptions) ([]types.TableInfo, error) {
+	tables, err := m.listTables(ctx, databaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	tables = types.FilterTables(tables, opts)
+	if !types.HasViews(tables) {
+		return tables, nil
+	}
+
+	dependencies, err := m.viewDependencies(ctx, databaseName)
+	if err != nil {
+		return nil, err
+	}
+	types.ApplyDependencies(tables, dependencies)
+	return tables, nil
+}
+
+// listTables retrieves every table and view of the given database.
+func (m *MySQL) listTables(ctx context.Context, databaseName string) ([]types.TableInfo, error) {
+	rows, err := m.Client.QueryContext(ctx, MYSQL_LIST_TABLES_QUERY, databaseName)
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var tables []types.TableInfo
+	for rows.Next() {
+		var table types.TableInfo
+		if err := rows.Scan(&table.Name, &table.Schema, &table.Kind, &table.Definition); err != nil {
+			return nil, fmt.Errorf("error scanning tables: %v", err)
+		}
+		tables = append(tables, table)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %v", err)
+	}
+
+	return tables, nil
+}
+
+// viewDependencies retrieves the tables and views the views of the given database read from.
+func (m *MySQL) viewDependencies(ctx context.Context, databaseName string) ([]types.TableDependency, error) {
+	rows, err := m.Client.QueryContext(ctx, MYSQL_VIEW_DEPENDENCIES_QUERY, databaseName)
+	if err != nil {
+		return nil, fmt.Errorf("error executing dependency query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var dependencies []types.TableDependency
+	for rows.Next() {
+		var d types.TableDependency
+		if err := rows.Scan(&d.Schema, &d.Name, &d.Referenced.Schema, &d.Referenced.Name); err != nil {
+			return nil, fmt.Errorf("error scanning dependencies: %v", err)
+		}
+		dependencies = append(dependencies, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over dependencies: %v", err)
+	}
+
+	return dependencies, nil
+}
+
+// Schemas retrieves the list of user databases, which are the schemas of a MySQL server.
+// The database name is ignored because MySQL has no level above its databases.
+func (m *MySQL) Schemas(ctx context.Context, databaseName string) ([]string, error) {
+	rows, err := m.Client.QueryContext(ctx, MYSQL_SCHEMAS_LIST_QUERY)
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var schemas []string
+	for rows.Next() {
+		var schema string
+		if err := rows.Scan(&schema); err != nil {
+			return nil, fmt.Errorf("error scanning schema: %v", err)
+		}
+		schemas = append(schemas, schema)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %v", err)
+	}
+
+	return schemas, nil
+}
+
+// Snapshot reads the schema of every table of the given database matching the options;
+// the columns, indexes, foreign keys and comments are read in bulk from information_schema.
+func (m *MySQL) Snapshot(ctx context.Context, databaseName string, opts types.SnapshotOptions) (types.DatabaseSchema, error) {
+	return snapshot.Take(ctx, m, types.MySQL, databaseName, opts)
+}
+
+// BulkSchemas reads the schemas of the given tables of the database with four queries in total,
+// instead of four queries per table. An empty database name means the current database.
+func (m *MySQL) BulkSchemas(ctx context.Context, databaseName string, tables []types.TableInfo) ([]types.Table, error) {
+	rows, err := m.Client.QueryContext(ctx, MYSQL_SNAPSHOT_COLUMNS_QUERY, databaseName)
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	columns := map[string][]types.Column{}
+	for rows.Next() {
+		var table string
+		var column types.Column
+		if err := rows.Scan(&table, &column.Name, &column.Type, &column.IsNullable, &column.Key, &column.DefaultValue, &column.Extra); err != nil {
+			return nil, fmt.Errorf("error scanning rows: %v", err)
+		}
+		column.Metatags = []string{column.Name}
+		column.Visibility = true // default visibility
+		columns[table] = append(columns[table], column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %v", err)
+	}
+
+	foreignKeys, err := m.schemaForeignKeys(ctx, databaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := m.schemaIndexes(ctx, databaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := m.schemaComments(ctx, databaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.Table, len(tables))
+	for i, t := range tables {
+		tableColumns := columns[t.Name]
+		tableIndexes := types.GroupIndexes(indexes[t.Name])
+		types.ApplyIndexes(tableColumns, tableIndexes)
+		description := types.ApplyComments(tableColumns, comments[t.Name])
+
+		result[i] = types.Table{
+			Name:        t.Name,
+			Schema:      t.Schema,
+			Columns:     tableColumns,
+			ColumnCount: int64(len(tableColumns)),
+			Description: description,
+			Metatags:    []string{},
+			ForeignKeys: types.GroupForeignKeys(foreignKeys[t.Name]),
+			Indexes:     tableIndexes,
+		}
+	}
+	return result, nil
+}
+
+// schemaForeignKeys retrieves the foreign key columns of every table of a database, keyed by table name.
+func (m *MySQL) schemaForeignKeys(ctx context.Context, databaseName string) (map[string][]types.ForeignKeyColumn, error) {
+	rows, err := m.Client.QueryContext(ctx, MYSQL_SNAPSHOT_FOREIGN_KEYS_QUERY, databaseName)
+	if err != nil {
+		return nil, fmt.Errorf("error executing foreign key query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	columns := map[string][]types.ForeignKeyColumn{}
+	for rows.Next() {
+		var table string
+		var c types.ForeignKeyColumn
+		if err := rows.Scan(&table, &c.Constraint, &c.Column, &c.ReferencedSchema, &c.ReferencedTable, &c.ReferencedColumn, &c.OnDelete, &c.OnUpdate); err != nil {
+			return nil, fmt.Errorf("error scanning foreign keys: %v", err)
+		}
+		columns[table] = append(columns[table], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over foreign keys: %v", err)
+	}
+	return columns, nil
+}
+
+// schemaIndexes retrieves the index columns of every table of a database, keyed by table name.
+func (m *MySQL) schemaIndexes(ctx context.Context, databaseName string) (map[string][]types.IndexColumn, error) {
+	rows, err := m.Client.QueryContext(ctx, MYSQL_SNAPSHOT_INDEXES_QUERY, databaseName)
+	if err != nil {
+		return nil, fmt.Errorf("error executing index query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	columns := map[string][]types.IndexColumn{}
+	for rows.Next() {
+		var table string
+		var c types.IndexColumn
+		if err := rows.Scan(&table, &c.Index, &c.Column, &c.Unique, &c.Primary, &c.Method, &c.Predicate); err != nil {
+			return nil, fmt.Errorf("error scanning indexes: %v", err)
+		}
+		columns[table] = append(columns[table], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over indexes: %v", err)
+	}
+	return columns, nil
+}
+
+// schemaComments retrieves the comments of every table and column of a database, keyed by table name.
+func (m *MySQL) schemaComments(ctx context.Context, databaseName string) (map[string][]types.Comment, error) {
+	rows, err := m.Client.QueryContext(ctx, MYSQL_SNAPSHOT_COMMENTS_QUERY, databaseName, databaseName)
+	if err != nil {
+		return nil, fmt.Errorf("error executing comment query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	comments := map[string][]types.Comment{}
+	for rows.Next() {
+		var table string
+		var c types.Comment
+		if err := rows.Scan(&table, &c.Column, &c.Text); err != nil {
+			return nil, fmt.Errorf("error scanning comments: %v", err)
+		}
+		comments[table] = append(comments[table], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over comments: %v", err)
+	}
+	return comments, nil
+}
+
 // GenerateCreateTableQuery generates a SQL query to create a table with the same structure as the input table.
 func (m *MySQL) GenerateCreateTableQuery(table types.Table) string {
-	query := "CREATE TABLE " + table.Name + " ("
+	query := "CREATE TABLE " + dialect.QuoteIdentifier(types.MySQL, table.Name) + " ("
 	for i, column := range table.Columns {
 		colType := strings.ToUpper(column.Type)
-		query += column.Name + " " + convertTypeToMysql(colType)
+		query += dialect.QuoteIdentifier(types.MySQL, column.Name) + " " + convertTypeToMysql(colType)
 		if column.AutoIncrement {
 			query += " AUTO_INCREMENT"
 		}
@@ -224,11 +723,17 @@ func (m *MySQL) GenerateCreateTableQuery(table types.Table) string {
 		if column.IsNullable == "NO" && !column.IsPrimary {
 			query += " NOT NULL"
 		}
+		if column.Description != "" {
+			query += " COMMENT " + dialect.QuoteString(types.MySQL, column.Description)
+		}
 		if i < len(table.Columns)-1 {
 			query += ", "
 		}
 	}
 	query += ")"
+	if table.Description != "" {
+		query += " COMMENT=" + dialect.QuoteString(types.MySQL, table.Description)
+	}
 	return query
 }
 
@@ -1,12 +1,14 @@
 package mysql
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	_ "github.com/go-sql-driver/mysql"
@@ -38,7 +40,20 @@ func TestSchema(t *testing.T) {
 	tableName := "user"                                                                                                                               // table name to be used in the test
 	mockRows := sqlmock.NewRows([]string{"Field", "Type", "Null", "Key", "Default", "Extra"}).AddRow("id", "int", "NO", "PRI", nil, "auto_increment") // mock rows to be returned by the query
 
-	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SCHEMA_QUERY, tableName))).WillReturnRows(mockRows) // set the expected return values for the query
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SCHEMA_QUERY, "`"+tableName+"`"))).WillReturnRows(mockRows) // set the expected return values for the query
+	fkRows := sqlmock.NewRows([]string{"constraint_name", "column_name", "referenced_schema", "referenced_table", "referenced_column", "on_delete", "on_update"}).
+		AddRow("fk_user_team", "org_id", "test", "team", "org_id", "CASCADE", "NO ACTION").
+		AddRow("fk_user_team", "team_id", "test", "team", "id", "CASCADE", "NO ACTION")
+	mock.ExpectQuery(regexp.QuoteMeta(MYSQL_FOREIGN_KEYS_QUERY)).WithArgs("", tableName).WillReturnRows(fkRows)
+	indexRows := sqlmock.NewRows([]string{"index_name", "column_name", "unique", "primary", "method", "predicate"}).
+		AddRow("PRIMARY", "id", true, true, "btree", "").
+		AddRow("fk_user_team", "org_id", false, false, "btree", "").
+		AddRow("fk_user_team", "team_id", false, false, "btree", "")
+	mock.ExpectQuery(regexp.QuoteMeta(MYSQL_INDEXES_QUERY)).WithArgs("", tableName).WillReturnRows(indexRows)
+	commentRows := sqlmock.NewRows([]string{"column_name", "comment"}).
+		AddRow("", "Registered users").
+		AddRow("id", "Surrogate key")
+	mock.ExpectQuery(regexp.QuoteMeta(MYSQL_COMMENTS_QUERY)).WithArgs("", tableName, "", tableName).WillReturnRows(commentRows)
 
 	// we then create a new instance of our MySQL object and test the function
 	m, err := NewMySQL(db)
@@ -50,6 +65,33 @@ func TestSchema(t *testing.T) {
 		t.Errorf("error executing query: %s", err)
 	}
 
+	wantForeignKeys := []types.ForeignKey{{
+		Name:              "fk_user_team",
+		Columns:           []string{"org_id", "team_id"},
+		ReferencedSchema:  "test",
+		ReferencedTable:   "team",
+		ReferencedColumns: []string{"org_id", "id"},
+		OnDelete:          "CASCADE",
+		OnUpdate:          "NO ACTION",
+	}}
+	if !reflect.DeepEqual(response.ForeignKeys, wantForeignKeys) {
+		t.Errorf("foreign keys = %+v, want %+v", response.ForeignKeys, wantForeignKeys)
+	}
+
+	wantIndexes := []types.Index{
+		{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Primary: true, Method: "btree"},
+		{Name: "fk_user_team", Columns: []string{"org_id", "team_id"}, Method: "btree"},
+	}
+	if !reflect.DeepEqual(response.Indexes, wantIndexes) {
+		t.Errorf("indexes = %+v, want %+v", response.Indexes, wantIndexes)
+	}
+	if column := response.Columns[0]; column.IsIndex != true || column.IsUnique.String != "YES" {
+		t.Errorf("column %s: IsIndex = %v, IsUnique = %q", column.Name, column.IsIndex, column.IsUnique.String)
+	}
+	if response.Description != "Registered users" || response.Columns[0].Description != "Surrogate key" {
+		t.Errorf("descriptions = %q, %q", response.Description, response.Columns[0].Description)
+	}
+
 	fmt.Printf("Table schema : %+v\n", response)
 
 	// we make sure that all expectations were met, otherwise an error will be reported
@@ -98,6 +140,106 @@ func TestExecute(t *testing.T) {
 
 }
 
+// TestExecuteWithArgs is a unit test function that tests the ExecuteContext method with bind arguments.
+// It checks that $n placeholders are rewritten to ? with the arguments in placeholder order.
+func TestExecuteWithArgs(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mockRows := sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Rohan")
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name FROM `user` WHERE name = ? AND id = ?")).WithArgs("Rohan", 1).WillReturnRows(mockRows)
+
+	m := &MySQL{Client: db, Config: &config.Config{}}
+	if _, err := m.ExecuteContext(context.Background(), "SELECT id, name FROM `user` WHERE name = $2 AND id = $1", 1, "Rohan"); err != nil {
+		t.Errorf("error executing the query: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestQuery is a unit test function that tests the Query method of the MySQL struct.
+// It streams the mocked rows through the iterator and checks the column metadata and converted row values.
+func TestQuery(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	query := "SELECT id, name, price FROM `product`"
+	mockRows := sqlmock.NewRowsWithColumnDefinition(
+		sqlmock.NewColumn("id").OfType("BIGINT", []byte{}),
+		sqlmock.NewColumn("name").OfType("VARCHAR", []byte{}).Nullable(true),
+		sqlmock.NewColumn("price").OfType("DECIMAL", []byte{}),
+	).AddRow([]byte("1"), []byte("Pen"), []byte("1.50")).AddRow([]byte("2"), nil, []byte("10.00"))
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(mockRows)
+
+	m := &MySQL{Client: db, Config: &config.Config{}}
+	it, err := m.Query(context.Background(), query)
+	if err != nil {
+		t.Fatalf("error executing the query: %s", err)
+	}
+	defer func() {
+		if err := it.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	columns := it.Columns()
+	if len(columns) != 3 || columns[0].DatabaseType != "BIGINT" || !columns[1].Nullable {
+		t.Errorf("unexpected column metadata: %+v", columns)
+	}
+
+	var rows [][]interface{}
+	for it.Next() {
+		rows = append(rows, it.Row())
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("error iterating rows: %s", err)
+	}
+	expected := [][]interface{}{{int64(1), "Pen", "1.50"}, {int64(2), nil, "10.00"}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %v, got %v", expected, rows)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestExecuteContextCancelled is a unit test function that tests the ExecuteContext method of the MySQL struct.
+// It calls the method with an already cancelled context and checks that no query reaches the database.
+func TestExecuteContextCancelled(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	m, err := NewMySQL(db)
+	if err != nil {
+		t.Errorf("error initialising mysql: %s", err)
+	}
+	if _, err := m.ExecuteContext(ctx, "SELECT id FROM user"); err == nil {
+		t.Errorf("expected an error for a cancelled context")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 // TestGetTableName is a unit test function that tests the Tables method of the MySQL struct.
 // It creates a mock instance of MySQL, sets the expected return values, and calls the method under test.
 // It then asserts the expected return values and checks if the method was called with the correct arguments.
@@ -178,7 +320,7 @@ func TestGenerateCreateTablequery(t *testing.T) {
 
 	fmt.Printf("Create table query: %s\n", query)
 
-	expectedQuery := "CREATE TABLE user (id INT PRIMARY KEY, name VARCHAR(255) NOT NULL, age INT)"
+	expectedQuery := "CREATE TABLE `user` (`id` INT PRIMARY KEY, `name` VARCHAR(255) NOT NULL, `age` INT)"
 	if query != expectedQuery {
 		t.Errorf("Expected '%s', but got '%s'", expectedQuery, query)
 	}
@@ -332,7 +474,31 @@ func TestMySQL_GenerateCreateTableQuery(t *testing.T) {
 		args args
 		want string
 	}{
-		// TODO: Add test cases.
+		{
+			name: "comments",
+			m:    &MySQL{},
+			args: args{table: types.Table{
+				Name:        "user",
+				Description: "Registered users",
+				Columns: []types.Column{
+					{Name: "id", Type: "INT", IsPrimary: true, AutoIncrement: true, Description: "Surrogate key"},
+					{Name: "name", Type: "VARCHAR(255)", IsNullable: "NO", Description: "User's name"},
+				},
+			}},
+			want: "CREATE TABLE `user` (`id` INT AUTO_INCREMENT PRIMARY KEY COMMENT 'Surrogate key', `name` VARCHAR(255) NOT NULL COMMENT 'User''s name') COMMENT='Registered users'",
+		},
+		{
+			name: "reserved words",
+			m:    &MySQL{},
+			args: args{table: types.Table{
+				Name: "order",
+				Columns: []types.Column{
+					{Name: "select", Type: "INT", IsPrimary: true},
+					{Name: "group`name", Type: "TEXT", IsNullable: "YES"},
+				},
+			}},
+			want: "CREATE TABLE `order` (`select` INT PRIMARY KEY, `group``name` TEXT)",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -382,3 +548,483 @@ func Test_dbURLMySQL(t *testing.T) {
 		})
 	}
 }
+
+// TestTableStats is a unit test function that tests the TableStats method of the MySQL struct.
+func TestTableStats(t *testing.T) {
+	db, mock := MockDB() // create a new mock database connection
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	rows := sqlmock.NewRows([]string{"rows", "bytes", "created", "updated"}).AddRow(42, 16384, 1700000000, nil)
+	mock.ExpectQuery(regexp.QuoteMeta(MYSQL_TABLE_STATS_QUERY)).WithArgs("shop", "user").WillReturnRows(rows)
+
+	m := &MySQL{Client: db, Config: &config.Config{}}
+	stats, err := m.TableStats("shop.user")
+	if err != nil {
+		t.Fatalf("error reading table statistics: %s", err)
+	}
+
+	expected := types.TableStats{
+		Name:     "user",
+		Schema:   "shop",
+		RowCount: 42,
+		Bytes:    16384,
+		LastDDL:  sql.NullTime{Time: time.Unix(1700000000, 0).UTC(), Valid: true},
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("expected: %+v, got: %+v", expected, stats)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSchemaHostileTableName is a unit test function that tests that a hostile table name is sent as a quoted identifier.
+func TestSchemaHostileTableName(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectQuery(regexp.QuoteMeta("DESCRIBE `shop`.`user``; DROP TABLE user; --`")).WillReturnError(fmt.Errorf("no such table"))
+
+	m := &MySQL{Client: db, Config: &config.Config{}}
+	if _, err := m.Schema("shop.`user``; DROP TABLE user; --`"); err == nil {
+		t.Error("expected an error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestExecuteReadOnly is a unit test function that tests that read-only mode runs reads in a read-only transaction and rejects writes.
+func TestExecuteReadOnly(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	query := "SELECT id FROM user WHERE note = 'DELETE FROM user'"
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectRollback()
+
+	m := &MySQL{Client: db, Config: &config.Config{ReadOnly: true}}
+	if _, err := m.Execute(query); err != nil {
+		t.Errorf("error executing query: %s", err)
+	}
+	for _, write := range []string{"UPDATE user SET admin = 1", "SELECT 1; DROP TABLE user", "SELECT * FROM user FOR UPDATE"} {
+		if _, err := m.Execute(write); err == nil {
+			t.Errorf("expected an error for %q", write)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestExplain is a unit test function that tests converting the JSON plan and the EXPLAIN ANALYZE tree of MySQL to plan trees.
+func TestExplain(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	query := "SELECT u.name FROM user u JOIN orders o ON o.user_id = u.id ORDER BY u.name"
+	plan := `{"query_block": {"select_id": 1, "cost_info": {"query_cost": "5.10"},
+		"ordering_operation": {"using_filesort": true, "nested_loop": [
+			{"table": {"table_name": "u", "access_type": "ALL", "rows_produced_per_join": 10, "cost_info": {"prefix_cost": "1.25"}}},
+			{"table": {"table_name": "o", "access_type": "ref", "key": "user_id", "rows_produced_per_join": 12, "cost_info": {"prefix_cost": "5.10"}}}
+		]}}}`
+	mock.ExpectQuery(regexp.QuoteMeta("EXPLAIN FORMAT=JSON " + query)).
+		WillReturnRows(sqlmock.NewRows([]string{"EXPLAIN"}).AddRow(plan))
+
+	m := &MySQL{Client: db, Config: &config.Config{}}
+	got, err := m.Explain(context.Background(), query, false)
+	if err != nil {
+		t.Fatalf("error explaining the query: %s", err)
+	}
+	want := &types.PlanNode{Type: "Query block", EstimatedCost: 5.1, Children: []*types.PlanNode{
+		{Type: "Sort", Children: []*types.PlanNode{
+			{Type: "Nested loop", Children: []*types.PlanNode{
+				{Type: "Table scan", Relation: "u", EstimatedRows: 10, EstimatedCost: 1.25},
+				{Type: "Index lookup", Relation: "o", EstimatedRows: 12, EstimatedCost: 5.1},
+			}},
+		}},
+	}}
+	if !reflect.DeepEqual(got.Root, want) {
+		t.Errorf("Explain() = %+v, want %+v", got.Root, want)
+	}
+
+	tree := "-> Sort: u.`name`  (cost=5.10 rows=12) (actual time=0.410..0.412 rows=12 loops=1)\n" +
+		"    -> Table scan on u  (cost=1.25 rows=10) (actual time=0.050..0.080 rows=10 loops=1)\n"
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("EXPLAIN ANALYZE " + query)).
+		WillReturnRows(sqlmock.NewRows([]string{"EXPLAIN"}).AddRow(tree))
+	mock.ExpectRollback()
+
+	got, err = m.Explain(context.Background(), query, true)
+	if err != nil {
+		t.Fatalf("error explaining the query: %s", err)
+	}
+	if !got.Analyzed || got.Root.ActualRows != 12 || len(got.Root.Children) != 1 || got.Root.Children[0].Relation != "u" {
+		t.Errorf("unexpected analyzed plan %+v", got.Root)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestExecuteScript is a unit test function that tests running a script with a DELIMITER block one statement at a time.
+func TestExecuteScript(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectExec(regexp.QuoteMeta("DROP PROCEDURE IF EXISTS count_users")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE PROCEDURE count_users() BEGIN SELECT COUNT(*) FROM user; END")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM user")).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(3))
+
+	script := "DROP PROCEDURE IF EXISTS count_users;\nDELIMITER $$\nCREATE PROCEDURE count_users() BEGIN SELECT COUNT(*) FROM user; END$$\nDELIMITER ;\nSELECT COUNT(*) FROM user;"
+	m := &MySQL{Client: db, Config: &config.Config{}}
+	result, err := m.ExecuteScript(context.Background(), script, types.ScriptOptions{})
+	if err != nil {
+		t.Fatalf("error executing script: %s", err)
+	}
+	if len(result.Statements) != 3 || result.Statements[2].Result == nil {
+		t.Errorf("ExecuteScript() = %+v, want 3 statements and the rows of the last one", result)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSnapshot is a unit test function that tests that the Snapshot method of the MySQL struct reads the
+// columns, foreign keys, indexes and comments of every table with one query each.
+func TestSnapshot(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	tableRows := sqlmock.NewRows([]string{"table_name", "table_schema", "kind", "definition"}).
+		AddRow("orders", "shop", "table", "").
+		AddRow("users", "shop", "table", "")
+	mock.ExpectQuery(regexp.QuoteMeta(MYSQL_LIST_TABLES_QUERY)).WithArgs("shop").WillReturnRows(tableRows)
+	columnRows := sqlmock.NewRows([]string{"table_name", "column_name", "column_type", "is_nullable", "column_key", "column_default", "extra"}).
+		AddRow("orders", "id", "int", "NO", "PRI", nil, "auto_increment").
+		AddRow("orders", "user_id", "int", "NO", "MUL", nil, "").
+		AddRow("users", "id", "int", "NO", "PRI", nil, "auto_increment")
+	mock.ExpectQuery(regexp.QuoteMeta(MYSQL_SNAPSHOT_COLUMNS_QUERY)).WithArgs("shop").WillReturnRows(columnRows)
+	fkRows := sqlmock.NewRows([]string{"table_name", "constraint_name", "column_name", "referenced_table_schema", "referenced_table_name", "referenced_column_name", "delete_rule", "update_rule"}).
+		AddRow("orders", "fk_orders_user", "user_id", "shop", "users", "id", "CASCADE", "NO ACTION")
+	mock.ExpectQuery(regexp.QuoteMeta(MYSQL_SNAPSHOT_FOREIGN_KEYS_QUERY)).WithArgs("shop").WillReturnRows(fkRows)
+	indexRows := sqlmock.NewRows([]string{"table_name", "index_name", "column_name", "unique", "primary", "method", "predicate"}).
+		AddRow("orders", "PRIMARY", "id", true, true, "btree", "").
+		AddRow("orders", "fk_orders_user", "user_id", false, false, "btree", "").
+		AddRow("users", "PRIMARY", "id", true, true, "btree", "")
+	mock.ExpectQuery(regexp.QuoteMeta(MYSQL_SNAPSHOT_INDEXES_QUERY)).WithArgs("shop").WillReturnRows(indexRows)
+	commentRows := sqlmock.NewRows([]string{"table_name", "column_name", "comment"}).
+		AddRow("users", "", "Registered users").
+		AddRow("users", "id", "User id")
+	mock.ExpectQuery(regexp.QuoteMeta(MYSQL_SNAPSHOT_COMMENTS_QUERY)).WithArgs("shop", "shop").WillReturnRows(commentRows)
+
+	m := &MySQL{Client: db, Config: &config.Config{Database: "shop"}}
+	schema, err := m.Snapshot(context.Background(), "shop", types.SnapshotOptions{})
+	if err != nil {
+		t.Fatalf("error taking snapshot: %s", err)
+	}
+
+	if len(schema.Tables) != 2 {
+		t.Fatalf("expected 2 tables, got %d", len(schema.Tables))
+	}
+	orders, users := schema.Tables[0], schema.Tables[1]
+	wantForeignKeys := []types.ForeignKey{{
+		Name:              "fk_orders_user",
+		Columns:           []string{"user_id"},
+		ReferencedSchema:  "shop",
+		ReferencedTable:   "users",
+		ReferencedColumns: []string{"id"},
+		OnDelete:          "CASCADE",
+		OnUpdate:          "NO ACTION",
+	}}
+	if !reflect.DeepEqual(orders.ForeignKeys, wantForeignKeys) {
+		t.Errorf("foreign keys = %+v, want %+v", orders.ForeignKeys, wantForeignKeys)
+	}
+	if orders.Schema != "shop" || orders.ColumnCount != 2 || len(orders.Indexes) != 2 || !orders.Columns[1].IsIndex {
+		t.Errorf("orders = %+v", orders)
+	}
+	if users.Description != "Registered users" || users.Columns[0].Description != "User id" || len(users.ForeignKeys) != 0 {
+		t.Errorf("users = %+v", users)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestListTables is a unit test function that tests the ListTables method of the MySQL struct.
+// The kinds are filtered before the view dependencies are read, which are only queried when views are listed.
+func TestListTables(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	tableRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"table_name", "table_schema", "kind", "definition"}).
+			AddRow("order_totals", "shop", "view", "select `shop`.`orders`.`user_id` AS `user_id` from `shop`.`orders`").
+			AddRow("orders", "shop", "table", "")
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(MYSQL_LIST_TABLES_QUERY)).WithArgs("shop").WillReturnRows(tableRows())
+	dependencyRows := sqlmock.NewRows([]string{"view_schema", "view_name", "table_schema", "table_name"}).
+		AddRow("shop", "order_totals", "shop", "orders")
+	mock.ExpectQuery(regexp.QuoteMeta(MYSQL_VIEW_DEPENDENCIES_QUERY)).WithArgs("shop").WillReturnRows(dependencyRows)
+	mock.ExpectQuery(regexp.QuoteMeta(MYSQL_LIST_TABLES_QUERY)).WithArgs("shop").WillReturnRows(tableRows())
+
+	m := &MySQL{Client: db, Config: &config.Config{}}
+	tables, err := m.ListTables(context.Background(), "shop", types.ListTablesOptions{})
+	if err != nil {
+		t.Fatalf("error listing tables: %s", err)
+	}
+	expected := []types.TableInfo{
+		{Name: "order_totals", Schema: "shop", Kind: types.KindView, Definition: "select `shop`.`orders`.`user_id` AS `user_id` from `shop`.`orders`",
+			Dependencies: []types.TableName{{Schema: "shop", Name: "orders"}}},
+		{Name: "orders", Schema: "shop", Kind: types.KindTable},
+	}
+	if !reflect.DeepEqual(tables, expected) {
+		t.Errorf("expected: %+v, got: %+v", expected, tables)
+	}
+
+	// without views the dependencies are not queried
+	tables, err = m.ListTables(context.Background(), "shop", types.ListTablesOptions{Kinds: []types.TableKind{types.KindTable}})
+	if err != nil {
+		t.Fatalf("error listing tables: %s", err)
+	}
+	expected = []types.TableInfo{{Name: "orders", Schema: "shop", Kind: types.KindTable}}
+	if !reflect.DeepEqual(tables, expected) {
+		t.Errorf("expected: %+v, got: %+v", expected, tables)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestBegin is a unit test function that tests committing and rolling back transactions of the MySQL struct.
+// Unsupported isolation levels are rejected before a transaction is started.
+func TestBegin(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO user (name) VALUES (?)")).WithArgs("ann").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM user")).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectRollback()
+
+	m := &MySQL{Client: db, Config: &config.Config{}}
+	tx, err := m.Begin(context.Background(), types.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if n, err := tx.Exec(context.Background(), "INSERT INTO user (name) VALUES (?)", "ann"); err != nil || n != 1 {
+		t.Errorf("Exec() = %d, %v, want 1 row", n, err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Errorf("error committing transaction: %s", err)
+	}
+
+	tx, err = m.Begin(context.Background(), types.TxOptions{})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if n, err := tx.Exec(context.Background(), "DELETE FROM user"); err != nil || n != 3 {
+		t.Errorf("Exec() = %d, %v, want 3 rows", n, err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Errorf("error rolling back transaction: %s", err)
+	}
+
+	if _, err := m.Begin(context.Background(), types.TxOptions{Isolation: sql.LevelSnapshot}); err == nil {
+		t.Error("expected an error for an unsupported isolation level")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestBeginReadOnly is a unit test function that tests that read-only transactions of the MySQL struct run reads
+// and reject writes before they are sent, whether requested by the options or by the read-only mode of the client.
+func TestBeginReadOnly(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM user WHERE id = ?")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	m := &MySQL{Client: db, Config: &config.Config{}}
+	tx, err := m.Begin(context.Background(), types.TxOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if _, err := tx.Execute(context.Background(), "SELECT id FROM user WHERE id = ?", 1); err != nil {
+		t.Errorf("error executing statement: %s", err)
+	}
+	if _, err := tx.Exec(context.Background(), "DELETE FROM user"); err == nil {
+		t.Error("expected an error for a write in a read-only transaction")
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Errorf("error rolling back transaction: %s", err)
+	}
+
+	m.SetReadOnly(true)
+	tx, err = m.Begin(context.Background(), types.TxOptions{})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if _, err := tx.Exec(context.Background(), "INSERT INTO user (name) VALUES (?)", "ann"); err == nil {
+		t.Error("expected an error for a write in read-only mode")
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Errorf("error rolling back transaction: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSession is a unit test function that tests that the statements of a session of the MySQL struct,
+// and of the transactions started on it, run on the pinned connection.
+func TestSession(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectExec(regexp.QuoteMeta("SET SESSION sql_mode = 'ANSI_QUOTES'")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM user WHERE id = ?")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO user (name) VALUES (?)")).WithArgs("ann").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	m := &MySQL{Client: db, Config: &config.Config{}}
+	session, err := m.Session(context.Background())
+	if err != nil {
+		t.Fatalf("error opening session: %s", err)
+	}
+	defer func() {
+		if err := session.Close(); err != nil {
+			t.Errorf("error closing session: %s", err)
+		}
+	}()
+
+	if _, err := session.Exec(context.Background(), "SET SESSION sql_mode = 'ANSI_QUOTES'"); err != nil {
+		t.Errorf("error executing statement: %s", err)
+	}
+	it, err := session.Query(context.Background(), "SELECT id FROM user WHERE id = ?", 1)
+	if err != nil {
+		t.Fatalf("error querying: %s", err)
+	}
+	result, err := types.ReadAll(it)
+	if err != nil {
+		t.Fatalf("error reading rows: %s", err)
+	}
+	if result.RowCount != 1 {
+		t.Errorf("RowCount = %d, want 1", result.RowCount)
+	}
+
+	tx, err := session.Begin(context.Background(), types.TxOptions{})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if _, err := tx.Exec(context.Background(), "INSERT INTO user (name) VALUES (?)", "ann"); err != nil {
+		t.Errorf("error executing statement: %s", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Errorf("error committing transaction: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSchemas is a unit test function that tests the Schemas method of the MySQL struct.
+func TestSchemas(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	rows := sqlmock.NewRows([]string{"schema_name"}).AddRow("shop").AddRow("crm")
+	mock.ExpectQuery(regexp.QuoteMeta(MYSQL_SCHEMAS_LIST_QUERY)).WillReturnRows(rows)
+
+	m, err := NewMySQL(db)
+	if err != nil {
+		t.Errorf("error initialising mysql: %s", err)
+	}
+	schemas, err := m.Schemas(context.Background(), "")
+	if err != nil {
+		t.Errorf("error retrieving schemas: %s", err)
+	}
+
+	expected := []string{"shop", "crm"}
+	if !reflect.DeepEqual(schemas, expected) {
+		t.Errorf("expected: %v, got: %v", expected, schemas)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSetReadOnly is a unit test function that tests that the read-only mode of a MySQL client does not change
+// the mode of the other clients sharing its configuration.
+func TestSetReadOnly(t *testing.T) {
+	cfg := &config.Config{}
+	m, other := &MySQL{Config: cfg}, &MySQL{Config: cfg}
+	m.SetReadOnly(true)
+	if !m.Config.ReadOnly || other.Config.ReadOnly || cfg.ReadOnly {
+		t.Errorf("SetReadOnly(true) changed the shared configuration %+v", cfg)
+	}
+}
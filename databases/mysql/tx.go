@@ -0,0 +1,42 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/thesaas-company/xray/governor"
+	"github.com/thesaas-company/xray/script"
+	"github.com/thesaas-company/xray/session"
+	"github.com/thesaas-company/xray/types"
+)
+
+// mysqlIsolation lists the isolation levels of InnoDB, its default level is REPEATABLE READ.
+var mysqlIsolation = []sql.IsolationLevel{sql.LevelReadUncommitted, sql.LevelReadCommitted, sql.LevelRepeatableRead, sql.LevelSerializable}
+
+// Begin starts a transaction. MySQL supports the standard isolation levels and read-only transactions.
+func (m *MySQL) Begin(ctx context.Context, opts types.TxOptions) (types.Tx, error) {
+	return session.Begin(ctx, m.Client, m.sessionConfig(), opts)
+}
+
+// Session pins a connection of the pool, so that SET options and temporary tables hold between statements.
+func (m *MySQL) Session(ctx context.Context) (types.Session, error) {
+	return session.Open(ctx, m.Client, m.sessionConfig())
+}
+
+// ExecuteScript runs the statements of a script one after the other in a session. The connection does not accept
+// several statements at once, so the script is split first, with DELIMITER lines handled as the mysql client does.
+func (m *MySQL) ExecuteScript(ctx context.Context, query string, opts types.ScriptOptions) (types.ScriptResult, error) {
+	return script.Run(ctx, m, types.MySQL, query, opts)
+}
+
+// sessionConfig describes how the statements of transactions and sessions run.
+func (m *MySQL) sessionConfig() session.Config {
+	return session.Config{
+		DbType:     types.MySQL,
+		Convert:    newValueConverter(types.BinaryEncoding(m.Config.BinaryEncoding)),
+		Limits:     governor.Limits{MaxRows: m.Config.MaxRows, MaxBytes: m.Config.MaxBytes},
+		ReadOnly:   m.Config.ReadOnly,
+		Isolation:  mysqlIsolation,
+		ReadOnlyTx: true,
+	}
+}
@@ -0,0 +1,91 @@
+package mysql
+
+import (
+	"strings"
+	"time"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// mysqlDateTimeLayout is the layout MySQL uses for DATETIME and TIMESTAMP values on the text protocol.
+const mysqlDateTimeLayout = "2006-01-02 15:04:05.999999"
+
+// newValueConverter returns the converter for values scanned from MySQL.
+// Numbers, booleans and JSON keep their type, temporal values become RFC3339 strings,
+// DECIMAL stays an exact decimal string and NULL stays nil.
+// Values of binary columns are encoded with the given encoding; textual values are never altered.
+func newValueConverter(binary types.BinaryEncoding) types.ValueConverter {
+	return func(column types.ColumnMeta, v interface{}) (interface{}, error) {
+		return convertValue(column, v, binary)
+	}
+}
+
+// convertValue converts a single value scanned from MySQL.
+func convertValue(column types.ColumnMeta, v interface{}, binary types.BinaryEncoding) (interface{}, error) {
+	typeName := strings.ToUpper(column.DatabaseType)
+	switch value := v.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		if isBinaryType(typeName) {
+			return binary.Encode(value)
+		}
+		return convertBytes(typeName, value), nil
+	case string:
+		return convertBytes(typeName, []byte(value)), nil
+	case float32:
+		return types.FloatValue(float64(value)), nil
+	case float64:
+		return types.FloatValue(value), nil
+	case time.Time:
+		if typeName == "DATE" {
+			return value.Format(types.DateLayout), nil
+		}
+		return types.TimestampValue(value), nil
+	default:
+		// int64, uint64 and the other native values are already JSON friendly
+		return value, nil
+	}
+}
+
+// convertBytes converts the textual representation of a MySQL value by its column type.
+func convertBytes(typeName string, b []byte) interface{} {
+	s := string(b)
+	if strings.HasPrefix(typeName, "UNSIGNED ") {
+		return types.UintValue(s)
+	}
+
+	switch typeName {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "BIGINT", "YEAR":
+		return types.IntValue(s)
+	case "FLOAT", "DOUBLE":
+		return types.ParseFloatValue(s)
+	case "DECIMAL":
+		return s
+	case "BIT":
+		var n uint64
+		for _, c := range b {
+			n = n<<8 | uint64(c)
+		}
+		return n
+	case "DATETIME", "TIMESTAMP":
+		if t, err := time.Parse(mysqlDateTimeLayout, s); err == nil {
+			return types.TimestampValue(t)
+		}
+		return s
+	case "JSON":
+		return types.JSONValue(b)
+	default:
+		return s
+	}
+}
+
+// isBinaryType reports whether the MySQL column type holds binary data.
+func isBinaryType(typeName string) bool {
+	switch typeName {
+	case "BINARY", "VARBINARY", "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "GEOMETRY":
+		return true
+	default:
+		return false
+	}
+}
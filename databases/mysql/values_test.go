@@ -0,0 +1,46 @@
+package mysql
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestConvertValue is a unit test function that tests how MySQL values are mapped to JSON friendly values.
+func TestConvertValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		typeName string
+		binary   types.BinaryEncoding
+		value    interface{}
+		want     interface{}
+	}{
+		{name: "null", typeName: "VARCHAR", value: nil, want: nil},
+		{name: "text", typeName: "VARCHAR", value: []byte("John"), want: "John"},
+		{name: "integer", typeName: "INT", value: []byte("42"), want: int64(42)},
+		{name: "native integer", typeName: "BIGINT", value: int64(42), want: int64(42)},
+		{name: "unsigned", typeName: "UNSIGNED BIGINT", value: []byte("18446744073709551615"), want: uint64(18446744073709551615)},
+		{name: "double", typeName: "DOUBLE", value: []byte("1.5"), want: 1.5},
+		{name: "decimal", typeName: "DECIMAL", value: []byte("10.10"), want: "10.10"},
+		{name: "bit", typeName: "BIT", value: []byte{1}, want: uint64(1)},
+		{name: "datetime", typeName: "DATETIME", value: []byte("2024-05-01 10:20:30"), want: "2024-05-01T10:20:30Z"},
+		{name: "json", typeName: "JSON", value: []byte(`{"a":1}`), want: json.RawMessage(`{"a":1}`)},
+		{name: "base64 looking text", typeName: "VARCHAR", value: []byte("test"), want: "test"},
+		{name: "blob", typeName: "BLOB", value: []byte{0xde, 0xad, 0xbe, 0xef}, want: "3q2+7w=="},
+		{name: "varbinary as hex", typeName: "VARBINARY", binary: types.BinaryHex, value: []byte{0xde, 0xad, 0xbe, 0xef}, want: "deadbeef"},
+		{name: "binary omitted", typeName: "BINARY", binary: types.BinaryOmit, value: []byte{0xde, 0xad}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertValue(types.ColumnMeta{DatabaseType: tt.typeName}, tt.value, tt.binary)
+			if err != nil {
+				t.Fatalf("convertValue() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("convertValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
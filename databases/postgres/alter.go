@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/diff"
+	"github.com/thesaas-company/xray/types"
+)
+
+// GenerateAlterTableQueries generates the ALTER TABLE statements turning the from table into the to table.
+// Indexes and the primary key are dropped before and created after the column changes.
+func (p *Postgres) GenerateAlterTableQueries(from, to types.Table) types.Migration {
+	var m types.Migration
+	d := diff.Tables(from, to, types.Postgres, types.Postgres)
+	table := dialect.QuoteTableName(types.Postgres, types.TableName{Schema: to.Schema, Name: to.Name})
+
+	for _, index := range d.RemovedIndexes {
+		// indexes live in the schema of their table
+		m.Add("DROP INDEX " + dialect.QuoteTableName(types.Postgres, types.TableName{Schema: from.Schema, Name: index.Name}) + ";")
+	}
+	if d.PrimaryKey != nil && d.PrimaryKey.From != "" {
+		name := diff.PrimaryKeyName(from)
+		if name == "" {
+			name = from.Name + "_pkey"
+		}
+		m.Add("ALTER TABLE " + table + " DROP CONSTRAINT " + dialect.QuoteIdentifier(types.Postgres, name) + ";")
+	}
+
+	for _, column := range d.RemovedColumns {
+		m.Add("ALTER TABLE " + table + " DROP COLUMN " + dialect.QuoteIdentifier(types.Postgres, column.Name) + ";")
+		m.Warn(fmt.Sprintf("dropping column %s deletes its data", column.Name))
+	}
+	for _, column := range d.AddedColumns {
+		query := "ALTER TABLE " + table + " ADD COLUMN " + dialect.QuoteIdentifier(types.Postgres, column.Name) + " " + postgresType(column)
+		if column.DefaultValue.Valid {
+			query += " DEFAULT " + column.DefaultValue.String
+		}
+		if column.IsNullable == "NO" {
+			query += " NOT NULL"
+			if !column.DefaultValue.Valid {
+				m.Warn(fmt.Sprintf("adding NOT NULL column %s without a default fails on a non-empty table", column.Name))
+			}
+		}
+		m.Add(query + ";")
+	}
+	for _, column := range d.ChangedColumns {
+		name := dialect.QuoteIdentifier(types.Postgres, column.Name)
+		alter := "ALTER TABLE " + table + " ALTER COLUMN " + name
+		for _, change := range column.Changes {
+			switch change.Field {
+			case diff.FieldType:
+				typ := postgresType(column.To)
+				m.Add(alter + " TYPE " + typ + " USING " + name + "::" + typ + ";")
+				if !diff.Widens(types.Postgres, column.From, column.To) {
+					m.Warn(fmt.Sprintf("changing the type of column %s from %s to %s may fail or lose data", column.Name, change.From, change.To))
+				}
+			case diff.FieldNullable:
+				if change.To == "NO" {
+					m.Add(alter + " SET NOT NULL;")
+					m.Warn(fmt.Sprintf("setting column %s NOT NULL fails if it contains NULL values", column.Name))
+				} else {
+					m.Add(alter + " DROP NOT NULL;")
+				}
+			case diff.FieldDefault:
+				if column.To.DefaultValue.Valid {
+					m.Add(alter + " SET DEFAULT " + column.To.DefaultValue.String + ";")
+				} else {
+					m.Add(alter + " DROP DEFAULT;")
+				}
+			}
+		}
+	}
+
+	if d.PrimaryKey != nil && d.PrimaryKey.To != "" {
+		m.Add("ALTER TABLE " + table + " ADD PRIMARY KEY (" + strings.Join(dialect.QuoteColumns(types.Postgres, to, diff.PrimaryKeyColumns(to)), ", ") + ");")
+	}
+	for _, index := range d.AddedIndexes {
+		query := "CREATE "
+		if index.Unique {
+			query += "UNIQUE "
+		}
+		query += "INDEX " + dialect.QuoteIdentifier(types.Postgres, index.Name) + " ON " + table
+		if index.Method != "" && index.Method != "btree" {
+			query += " USING " + index.Method
+		}
+		query += " (" + strings.Join(dialect.QuoteColumns(types.Postgres, to, index.Columns), ", ") + ")"
+		if index.Predicate != "" {
+			query += " WHERE " + index.Predicate
+		}
+		m.Add(query + ";")
+	}
+	return m
+}
+
+// postgresType returns the type of a column as used in DDL, including the length Postgres reports separately.
+func postgresType(column types.Column) string {
+	return strings.ToUpper(column.Type) + dialect.ColumnLength(column)
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestGenerateAlterTableQueries is a unit test function that tests the GenerateAlterTableQueries method of the Postgres struct.
+func TestGenerateAlterTableQueries(t *testing.T) {
+	from := types.Table{
+		Name: "users",
+		Columns: []types.Column{
+			{Name: "id", Type: "integer", IsNullable: "NO", IsPrimary: true},
+			{Name: "email", Type: "character varying", CharacterMaximumLength: sql.NullInt64{Int64: 100, Valid: true}, IsNullable: "YES"},
+			{Name: "age", Type: "integer", IsNullable: "YES"},
+			{Name: "nickname", Type: "text", IsNullable: "YES"},
+		},
+		Indexes: []types.Index{
+			{Name: "users_pkey", Columns: []string{"id"}, Unique: true, Primary: true, Method: "btree"},
+			{Name: "users_age_idx", Columns: []string{"age"}, Method: "btree"},
+		},
+	}
+	to := types.Table{
+		Name: "users",
+		Columns: []types.Column{
+			{Name: "id", Type: "integer", IsNullable: "NO", IsPrimary: true},
+			{Name: "email", Type: "character varying", CharacterMaximumLength: sql.NullInt64{Int64: 255, Valid: true}, IsNullable: "NO"},
+			{Name: "age", Type: "smallint", IsNullable: "YES", DefaultValue: sql.NullString{String: "0", Valid: true}},
+			{Name: "created_at", Type: "timestamp", IsNullable: "NO", DefaultValue: sql.NullString{String: "now()", Valid: true}},
+		},
+		Indexes: []types.Index{
+			{Name: "users_pkey", Columns: []string{"id"}, Unique: true, Primary: true, Method: "btree"},
+			{Name: "users_email_key", Columns: []string{"email"}, Unique: true, Method: "btree"},
+		},
+	}
+
+	p := &Postgres{}
+	m := p.GenerateAlterTableQueries(from, to)
+
+	wantStatements := []string{
+		`DROP INDEX "users_age_idx";`,
+		`ALTER TABLE "users" DROP COLUMN "nickname";`,
+		`ALTER TABLE "users" ADD COLUMN "created_at" TIMESTAMP DEFAULT now() NOT NULL;`,
+		`ALTER TABLE "users" ALTER COLUMN "email" TYPE CHARACTER VARYING(255) USING "email"::CHARACTER VARYING(255);`,
+		`ALTER TABLE "users" ALTER COLUMN "email" SET NOT NULL;`,
+		`ALTER TABLE "users" ALTER COLUMN "age" TYPE SMALLINT USING "age"::SMALLINT;`,
+		`ALTER TABLE "users" ALTER COLUMN "age" SET DEFAULT 0;`,
+		`CREATE UNIQUE INDEX "users_email_key" ON "users" ("email");`,
+	}
+	if !reflect.DeepEqual(m.Statements, wantStatements) {
+		t.Errorf("statements = %#v, want %#v", m.Statements, wantStatements)
+	}
+	wantWarnings := []string{
+		"dropping column nickname deletes its data",
+		"setting column email NOT NULL fails if it contains NULL values",
+		"changing the type of column age from integer to smallint may fail or lose data",
+	}
+	if !reflect.DeepEqual(m.Warnings, wantWarnings) {
+		t.Errorf("warnings = %#v, want %#v", m.Warnings, wantWarnings)
+	}
+}
+
+// TestGenerateAlterTableQueriesPrimaryKey is a unit test function that tests changing the primary key of a table.
+func TestGenerateAlterTableQueriesPrimaryKey(t *testing.T) {
+	from := types.Table{
+		Name:    "memberships",
+		Columns: []types.Column{{Name: "user_id", Type: "integer", IsPrimary: true}, {Name: "team_id", Type: "integer"}},
+	}
+	to := types.Table{
+		Name:    "memberships",
+		Columns: []types.Column{{Name: "user_id", Type: "integer", IsPrimary: true}, {Name: "team_id", Type: "integer", IsPrimary: true}},
+	}
+
+	p := &Postgres{}
+	m := p.GenerateAlterTableQueries(from, to)
+
+	want := []string{
+		`ALTER TABLE "memberships" DROP CONSTRAINT "memberships_pkey";`,
+		`ALTER TABLE "memberships" ADD PRIMARY KEY ("user_id", "team_id");`,
+	}
+	if !reflect.DeepEqual(m.Statements, want) {
+		t.Errorf("statements = %#v, want %#v", m.Statements, want)
+	}
+}
+
+// TestGenerateAlterTableQueriesQuotedNames is a unit test function that tests that reserved words, mixed case names
+// and quote characters in table, column and index names are quoted.
+func TestGenerateAlterTableQueriesQuotedNames(t *testing.T) {
+	from := types.Table{
+		Name:    "Order",
+		Schema:  "Sales",
+		Columns: []types.Column{{Name: "id", Type: "integer", IsNullable: "NO", IsPrimary: true}, {Name: "Group", Type: "text", IsNullable: "YES"}},
+	}
+	to := types.Table{
+		Name:    "Order",
+		Schema:  "Sales",
+		Columns: []types.Column{{Name: "id", Type: "integer", IsNullable: "NO", IsPrimary: true}, {Name: `Select"s`, Type: "text", IsNullable: "YES"}},
+		Indexes: []types.Index{{Name: "Order_lower", Columns: []string{`lower(("Select""s")::text)`, `Select"s`}, Method: "btree"}},
+	}
+
+	p := &Postgres{}
+	m := p.GenerateAlterTableQueries(from, to)
+
+	want := []string{
+		`ALTER TABLE "Sales"."Order" DROP COLUMN "Group";`,
+		`ALTER TABLE "Sales"."Order" ADD COLUMN "Select""s" TEXT;`,
+		`CREATE INDEX "Order_lower" ON "Sales"."Order" (lower(("Select""s")::text), "Select""s");`,
+	}
+	if !reflect.DeepEqual(m.Statements, want) {
+		t.Errorf("statements = %#v, want %#v", m.Statements, want)
+	}
+}
@@ -0,0 +1,24 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// POSTGRES_EXPLAIN_QUERY plans a statement without running it and returns the plan as a single JSON value.
+const POSTGRES_EXPLAIN_QUERY = "EXPLAIN (FORMAT JSON) %s"
+
+// Estimate returns the cost and row count the Postgres planner expects for a single statement, without running it.
+// They are read from the top node of the plan.
+func (p *Postgres) Estimate(ctx context.Context, query string) (types.CostEstimate, error) {
+	plan, err := p.Explain(ctx, query, false)
+	if err != nil {
+		return types.CostEstimate{}, err
+	}
+	return types.CostEstimate{
+		Rows: int64(plan.Root.EstimatedRows),
+		Cost: plan.Root.EstimatedCost,
+		Plan: plan.Raw,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/types"
+)
+
+// POSTGRES_EXPLAIN_ANALYZE_QUERY runs a statement and returns its plan with the measured values as a single JSON value.
+const POSTGRES_EXPLAIN_ANALYZE_QUERY = "EXPLAIN (ANALYZE, FORMAT JSON) %s"
+
+// postgresNode is a node of the JSON plan, the actual values are only set by EXPLAIN ANALYZE.
+type postgresNode struct {
+	NodeType        string         `json:"Node Type"`
+	RelationName    string         `json:"Relation Name"`
+	PlanRows        float64        `json:"Plan Rows"`
+	TotalCost       float64        `json:"Total Cost"`
+	ActualRows      float64        `json:"Actual Rows"`
+	ActualTotalTime float64        `json:"Actual Total Time"`
+	ActualLoops     float64        `json:"Actual Loops"`
+	Plans           []postgresNode `json:"Plans"`
+}
+
+// Explain returns the plan of a single statement. With analyze the statement is run inside a transaction
+// that is rolled back, so EXPLAIN ANALYZE of a write does not change any data.
+func (p *Postgres) Explain(ctx context.Context, query string, analyze bool) (types.Plan, error) {
+	statement, err := dialect.SingleStatement(types.Postgres, query)
+	if err != nil {
+		return types.Plan{}, fmt.Errorf("error explaining query: %v", err)
+	}
+
+	var plan string
+	if !analyze {
+		if err := p.Client.QueryRowContext(ctx, fmt.Sprintf(POSTGRES_EXPLAIN_QUERY, statement.Text)).Scan(&plan); err != nil {
+			return types.Plan{}, fmt.Errorf("error explaining query: %v", err)
+		}
+	} else {
+		if p.Config.ReadOnly {
+			if err := dialect.CheckReadOnly(types.Postgres, statement.Text); err != nil {
+				return types.Plan{}, err
+			}
+		}
+		tx, err := p.Client.BeginTx(ctx, &sql.TxOptions{ReadOnly: p.Config.ReadOnly})
+		if err != nil {
+			return types.Plan{}, fmt.Errorf("error starting transaction: %v", err)
+		}
+		defer func() {
+			if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+				fmt.Println(err)
+			}
+		}()
+		if err := tx.QueryRowContext(ctx, fmt.Sprintf(POSTGRES_EXPLAIN_ANALYZE_QUERY, statement.Text)).Scan(&plan); err != nil {
+			return types.Plan{}, fmt.Errorf("error explaining query: %v", err)
+		}
+	}
+
+	var plans []struct {
+		Plan postgresNode `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(plan), &plans); err != nil {
+		return types.Plan{}, fmt.Errorf("error parsing query plan: %v", err)
+	}
+	if len(plans) == 0 {
+		return types.Plan{}, fmt.Errorf("error parsing query plan: empty plan")
+	}
+	return types.Plan{
+		Root:     plans[0].Plan.node(),
+		Analyzed: analyze,
+		Format:   "json",
+		Raw:      plan,
+	}, nil
+}
+
+// node converts a JSON plan node and its children to a types.PlanNode tree.
+// The actual values of Postgres are averages over the loops of the node, they are multiplied back to totals.
+func (n postgresNode) node() *types.PlanNode {
+	node := &types.PlanNode{
+		Type:          n.NodeType,
+		Relation:      n.RelationName,
+		EstimatedRows: n.PlanRows,
+		EstimatedCost: n.TotalCost,
+		ActualRows:    n.ActualRows * n.ActualLoops,
+		ActualTime:    n.ActualTotalTime * n.ActualLoops,
+	}
+	for _, child := range n.Plans {
+		node.Children = append(node.Children, child.node())
+	}
+	return node
+}
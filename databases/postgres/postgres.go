@@ -1,15 +1,20 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	_ "github.com/lib/pq"
 	"github.com/thesaas-company/xray/config"
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/governor"
+	"github.com/thesaas-company/xray/registry"
+	"github.com/thesaas-company/xray/snapshot"
 	"github.com/thesaas-company/xray/types"
 )
 
@@ -26,6 +31,8 @@ const (
     	c.is_nullable AS is_nullable,
     	c.column_default AS default_value,
     	c.character_maximum_length AS character_maximum_length,
+    	c.numeric_precision AS numeric_precision,
+    	c.numeric_scale AS numeric_scale,
     	c.ordinal_position AS ordinal_position,
     	CASE WHEN c.column_default IS NOT NULL THEN true ELSE false END AS visibility,
     	CASE WHEN kcu.column_name IS NOT NULL THEN true ELSE false END AS is_primary,
@@ -35,31 +42,217 @@ const (
 	LEFT JOIN 
     	information_schema.key_column_usage kcu 
 	ON 
-    	c.table_name = kcu.table_name AND c.column_name = kcu.column_name
+    	c.table_schema = kcu.table_schema AND c.table_name = kcu.table_name AND c.column_name = kcu.column_name
 	WHERE 
-    	c.table_name = $1;
+    	c.table_name = $1 AND c.table_schema = COALESCE(NULLIF($2, ''), current_schema());
 	`
 
 	// POSTGRES_TABLE_LIST_QUERY is the SQL query used to list all tables in a schema in PostgreSQL.
-	POSTGRES_TABLE_LIST_QUERY = "SELECT table_name FROM information_schema.tables WHERE table_schema= 'public' AND table_type='BASE TABLE' AND table_catalog = $1;"
+	// An empty schema lists the tables of the current schema.
+	POSTGRES_TABLE_LIST_QUERY = "SELECT table_name FROM information_schema.tables WHERE table_schema = COALESCE(NULLIF($2, ''), current_schema()) AND table_type='BASE TABLE' AND table_catalog = $1;"
+
+	// POSTGRES_COMMENTS_QUERY is the SQL query used to read the comments of a table and its columns in PostgreSQL.
+	// The table comment is returned with an empty column name.
+	POSTGRES_COMMENTS_QUERY = `
+	SELECT COALESCE(a.attname, ''), d.description
+	FROM pg_description d
+	JOIN pg_class c ON c.oid = d.objoid AND d.classoid = 'pg_class'::regclass
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	LEFT JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum = d.objsubid AND d.objsubid > 0
+	WHERE c.relname = $1 AND n.nspname = COALESCE(NULLIF($2, ''), current_schema());
+	`
+
+	// POSTGRES_TABLE_STATS_QUERY is the SQL query used to read the estimated row count and total size of a table in PostgreSQL.
+	// Tables that were never analyzed have a negative reltuples, the live tuple count of the statistics collector is used for them.
+	POSTGRES_TABLE_STATS_QUERY = `
+	SELECT
+		CASE WHEN c.reltuples < 0 THEN COALESCE(s.n_live_tup, 0) ELSE c.reltuples::bigint END,
+		pg_total_relation_size(c.oid)
+	FROM pg_class c
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	LEFT JOIN pg_stat_user_tables s ON s.relid = c.oid
+	WHERE c.relname = $1 AND n.nspname = COALESCE(NULLIF($2, ''), current_schema());
+	`
+
+	// POSTGRES_LIST_TABLES_QUERY is the SQL query used to list the tables, views, materialized views and foreign tables of a schema in PostgreSQL.
+	// Foreign tables are reported as external tables, partitions are left out in favour of their parent.
+	POSTGRES_LIST_TABLES_QUERY = `
+	SELECT
+		c.relname,
+		n.nspname,
+		CASE c.relkind WHEN 'v' THEN 'view' WHEN 'm' THEN 'materialized_view' WHEN 'f' THEN 'external_table' ELSE 'table' END,
+		CASE WHEN c.relkind IN ('v', 'm') THEN pg_get_viewdef(c.oid, true) ELSE '' END
+	FROM pg_class c
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	WHERE current_database() = $1 AND n.nspname = COALESCE(NULLIF($2, ''), current_schema())
+		AND c.relkind IN ('r', 'p', 'v', 'm', 'f') AND NOT c.relispartition
+	ORDER BY c.relname;
+	`
+
+	// POSTGRES_VIEW_DEPENDENCIES_QUERY is the SQL query used to list the relations the views and materialized views of a schema read from.
+	POSTGRES_VIEW_DEPENDENCIES_QUERY = `
+	SELECT DISTINCT n.nspname, v.relname, refns.nspname, ref.relname
+	FROM pg_depend d
+	JOIN pg_rewrite r ON r.oid = d.objid
+	JOIN pg_class v ON v.oid = r.ev_class
+	JOIN pg_namespace n ON n.oid = v.relnamespace
+	JOIN pg_class ref ON ref.oid = d.refobjid
+	JOIN pg_namespace refns ON refns.oid = ref.relnamespace
+	WHERE d.classid = 'pg_rewrite'::regclass AND d.refclassid = 'pg_class'::regclass AND ref.oid <> v.oid
+		AND n.nspname = COALESCE(NULLIF($1, ''), current_schema())
+	ORDER BY 1, 2, 3, 4;
+	`
+
+	// POSTGRES_SCHEMA_LIST_QUERY is the SQL query used to list the user schemas of a database in PostgreSQL.
+	POSTGRES_SCHEMA_LIST_QUERY = "SELECT schema_name FROM information_schema.schemata WHERE catalog_name = $1 AND schema_name <> 'information_schema' AND schema_name NOT LIKE 'pg\\_%' ORDER BY schema_name;"
+
+	// POSTGRES_FOREIGN_KEYS_QUERY is the SQL query used to list the foreign key columns of a table in PostgreSQL.
+	POSTGRES_FOREIGN_KEYS_QUERY = `
+	SELECT
+		con.conname,
+		att.attname,
+		refns.nspname,
+		ref.relname,
+		refatt.attname,
+		CASE con.confdeltype WHEN 'a' THEN 'NO ACTION' WHEN 'r' THEN 'RESTRICT' WHEN 'c' THEN 'CASCADE' WHEN 'n' THEN 'SET NULL' WHEN 'd' THEN 'SET DEFAULT' END,
+		CASE con.confupdtype WHEN 'a' THEN 'NO ACTION' WHEN 'r' THEN 'RESTRICT' WHEN 'c' THEN 'CASCADE' WHEN 'n' THEN 'SET NULL' WHEN 'd' THEN 'SET DEFAULT' END
+	FROM pg_constraint con
+	JOIN pg_class rel ON rel.oid = con.conrelid
+	JOIN pg_namespace relns ON relns.oid = rel.relnamespace
+	JOIN pg_class ref ON ref.oid = con.confrelid
+	JOIN pg_namespace refns ON refns.oid = ref.relnamespace
+	CROSS JOIN LATERAL unnest(con.conkey, con.confkey) WITH ORDINALITY AS cols(attnum, refattnum, position)
+	JOIN pg_attribute att ON att.attrelid = con.conrelid AND att.attnum = cols.attnum
+	JOIN pg_attribute refatt ON refatt.attrelid = con.confrelid AND refatt.attnum = cols.refattnum
+	WHERE con.contype = 'f' AND rel.relname = $1 AND relns.nspname = COALESCE(NULLIF($2, ''), current_schema())
+	ORDER BY con.conname, cols.position;
+	`
+
+	// POSTGRES_INDEXES_QUERY is the SQL query used to list the index columns of a table in PostgreSQL.
+	// Expression index columns are returned as their expression.
+	POSTGRES_INDEXES_QUERY = `
+	SELECT
+		idx.relname,
+		COALESCE(att.attname, pg_get_indexdef(ix.indexrelid, cols.position::int, true)),
+		ix.indisunique,
+		ix.indisprimary,
+		am.amname,
+		COALESCE(pg_get_expr(ix.indpred, ix.indrelid), '')
+	FROM pg_index ix
+	JOIN pg_class rel ON rel.oid = ix.indrelid
+	JOIN pg_namespace relns ON relns.oid = rel.relnamespace
+	JOIN pg_class idx ON idx.oid = ix.indexrelid
+	JOIN pg_am am ON am.oid = idx.relam
+	CROSS JOIN LATERAL unnest(ix.indkey::int2[]) WITH ORDINALITY AS cols(attnum, position)
+	LEFT JOIN pg_attribute att ON att.attrelid = ix.indrelid AND att.attnum = cols.attnum AND cols.attnum > 0
+	WHERE rel.relname = $1 AND relns.nspname = COALESCE(NULLIF($2, ''), current_schema())
+	ORDER BY idx.relname, cols.position;
+	`
+
+	// POSTGRES_SNAPSHOT_COLUMNS_QUERY is the SQL query used to read the columns of every table of a schema in PostgreSQL at once.
+	POSTGRES_SNAPSHOT_COLUMNS_QUERY = `
+	SELECT
+		c.table_name,
+		c.column_name,
+		c.data_type,
+		c.is_nullable,
+		c.column_default,
+		c.character_maximum_length,
+		c.numeric_precision,
+		c.numeric_scale,
+		c.ordinal_position,
+		CASE WHEN c.column_default IS NOT NULL THEN true ELSE false END,
+		CASE WHEN kcu.column_name IS NOT NULL THEN true ELSE false END,
+		CASE WHEN c.is_updatable = 'YES' THEN true ELSE false END
+	FROM information_schema.columns c
+	LEFT JOIN information_schema.key_column_usage kcu
+		ON c.table_schema = kcu.table_schema AND c.table_name = kcu.table_name AND c.column_name = kcu.column_name
+	WHERE c.table_schema = COALESCE(NULLIF($1, ''), current_schema())
+	ORDER BY c.table_name, c.ordinal_position;
+	`
+
+	// POSTGRES_SNAPSHOT_FOREIGN_KEYS_QUERY is the SQL query used to list the foreign key columns of every table of a schema in PostgreSQL at once.
+	POSTGRES_SNAPSHOT_FOREIGN_KEYS_QUERY = `
+	SELECT
+		rel.relname,
+		con.conname,
+		att.attname,
+		refns.nspname,
+		ref.relname,
+		refatt.attname,
+		CASE con.confdeltype WHEN 'a' THEN 'NO ACTION' WHEN 'r' THEN 'RESTRICT' WHEN 'c' THEN 'CASCADE' WHEN 'n' THEN 'SET NULL' WHEN 'd' THEN 'SET DEFAULT' END,
+		CASE con.confupdtype WHEN 'a' THEN 'NO ACTION' WHEN 'r' THEN 'RESTRICT' WHEN 'c' THEN 'CASCADE' WHEN 'n' THEN 'SET NULL' WHEN 'd' THEN 'SET DEFAULT' END
+	FROM pg_constraint con
+	JOIN pg_class rel ON rel.oid = con.conrelid
+	JOIN pg_namespace relns ON relns.oid = rel.relnamespace
+	JOIN pg_class ref ON ref.oid = con.confrelid
+	JOIN pg_namespace refns ON refns.oid = ref.relnamespace
+	CROSS JOIN LATERAL unnest(con.conkey, con.confkey) WITH ORDINALITY AS cols(attnum, refattnum, position)
+	JOIN pg_attribute att ON att.attrelid = con.conrelid AND att.attnum = cols.attnum
+	JOIN pg_attribute refatt ON refatt.attrelid = con.confrelid AND refatt.attnum = cols.refattnum
+	WHERE con.contype = 'f' AND relns.nspname = COALESCE(NULLIF($1, ''), current_schema())
+	ORDER BY rel.relname, con.conname, cols.position;
+	`
+
+	// POSTGRES_SNAPSHOT_INDEXES_QUERY is the SQL query used to list the index columns of every table of a schema in PostgreSQL at once.
+	// Expression index columns are returned as their expression.
+	POSTGRES_SNAPSHOT_INDEXES_QUERY = `
+	SELECT
+		rel.relname,
+		idx.relname,
+		COALESCE(att.attname, pg_get_indexdef(ix.indexrelid, cols.position::int, true)),
+		ix.indisunique,
+		ix.indisprimary,
+		am.amname,
+		COALESCE(pg_get_expr(ix.indpred, ix.indrelid), '')
+	FROM pg_index ix
+	JOIN pg_class rel ON rel.oid = ix.indrelid
+	JOIN pg_namespace relns ON relns.oid = rel.relnamespace
+	JOIN pg_class idx ON idx.oid = ix.indexrelid
+	JOIN pg_am am ON am.oid = idx.relam
+	CROSS JOIN LATERAL unnest(ix.indkey::int2[]) WITH ORDINALITY AS cols(attnum, position)
+	LEFT JOIN pg_attribute att ON att.attrelid = ix.indrelid AND att.attnum = cols.attnum AND cols.attnum > 0
+	WHERE relns.nspname = COALESCE(NULLIF($1, ''), current_schema())
+	ORDER BY rel.relname, idx.relname, cols.position;
+	`
+
+	// POSTGRES_SNAPSHOT_COMMENTS_QUERY is the SQL query used to read the comments of every table and column of a schema in PostgreSQL at once.
+	// Table comments are returned with an empty column name.
+	POSTGRES_SNAPSHOT_COMMENTS_QUERY = `
+	SELECT c.relname, COALESCE(a.attname, ''), d.description
+	FROM pg_description d
+	JOIN pg_class c ON c.oid = d.objoid AND d.classoid = 'pg_class'::regclass
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	LEFT JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum = d.objsubid AND d.objsubid > 0
+	WHERE n.nspname = COALESCE(NULLIF($1, ''), current_schema());
+	`
 )
 
 // Postgres is a PostgreSQL implementation of the ISQL interface.
 type Postgres struct {
 	Client *sql.DB
+	Config *config.Config
+}
+
+func init() {
+	registry.Register(types.Postgres.String(), registry.Factory{
+		New:           NewPostgres,
+		NewWithConfig: NewPostgresWithConfig,
+	})
 }
 
 // NewPostgres creates a new PostgreSQL client with the given sql.DB.
-func NewPostgres(dbClient *sql.DB) (types.ISQL, error) {
+func NewPostgres(dbClient *sql.DB) (types.ISQLContext, error) {
 	return &Postgres{
 		Client: dbClient,
+		Config: &config.Config{},
 	}, nil
 
 }
 
 // NewPostgresWithConfig creates a new PostgreSQL client with the given configuration.
 // It returns an error if the DB_PASSWORD environment variable is not set.
-func NewPostgresWithConfig(dbConfig *config.Config) (types.ISQL, error) {
+func NewPostgresWithConfig(dbConfig *config.Config) (types.ISQLContext, error) {
 	if os.Getenv(DB_PASSWORD) == "" || len(os.Getenv(DB_PASSWORD)) == 0 {
 		return nil, fmt.Errorf("please set %s env variable for the database", DB_PASSWORD)
 	}
@@ -72,17 +265,31 @@ func NewPostgresWithConfig(dbConfig *config.Config) (types.ISQL, error) {
 	}
 	return &Postgres{
 		Client: db,
+		Config: dbConfig,
 	}, nil
 }
 
 // Schema returns the schema of a table in the database.
 // It returns an error if the SQL query fails.
 func (p *Postgres) Schema(table string) (types.Table, error) {
+	return p.SchemaContext(context.Background(), table)
+}
 
+// SchemaContext returns the schema of a table in the database.
+// The table may be qualified with its schema; unqualified tables are looked up in the configured
+// schema, or in the current schema of the session when none is configured.
+// The query is cancelled when the context is done.
+func (p *Postgres) SchemaContext(ctx context.Context, table string) (types.Table, error) {
 	var response types.Table
 
+	name := types.ParseTableName(table)
+	schema, err := p.schemaOf(name)
+	if err != nil {
+		return response, err
+	}
+
 	// execute the sql statement
-	rows, err := p.Client.Query(POSTGRES_SCHEMA_QUERY, table)
+	rows, err := p.Client.QueryContext(ctx, POSTGRES_SCHEMA_QUERY, name.Name, schema)
 	if err != nil {
 		return response, fmt.Errorf("error executing sql statement: %v", err)
 	}
@@ -103,6 +310,8 @@ func (p *Postgres) Schema(table string) (types.Table, error) {
 			&column.IsNullable,
 			&column.DefaultValue,
 			&column.CharacterMaximumLength,
+			&column.NumericPrecision,
+			&column.NumericScale,
 			&column.OrdinalPosition,
 			&column.Visibility,
 			&column.IsPrimary,
@@ -110,7 +319,6 @@ func (p *Postgres) Schema(table string) (types.Table, error) {
 		); err != nil {
 			return response, fmt.Errorf("error scanning rows: %v", err)
 		}
-		column.Description = ""      // default description
 		column.Metatags = []string{} // default metatags as an empty slice
 		column.Metatags = append(column.Metatags, column.Name)
 		column.Visibility = true // default visibility
@@ -122,26 +330,104 @@ func (p *Postgres) Schema(table string) (types.Table, error) {
 		return response, fmt.Errorf("error iterating over rows: %v", err)
 	}
 
-	tbl, err := types.Table{
-		Name:        table,
+	foreignKeys, err := p.foreignKeys(ctx, schema, name.Name)
+	if err != nil {
+		return response, err
+	}
+
+	indexes, err := p.indexes(ctx, schema, name.Name)
+	if err != nil {
+		return response, err
+	}
+	types.ApplyIndexes(columns, indexes)
+
+	comments, err := p.comments(ctx, schema, name.Name)
+	if err != nil {
+		return response, err
+	}
+	description := types.ApplyComments(columns, comments)
+
+	tbl := types.Table{
+		Name:        name.Name,
+		Catalog:     p.Config.Database,
+		Schema:      schema,
 		Columns:     columns,
 		ColumnCount: int64(len(columns)),
-		Description: "",
+		Description: description,
 		Metatags:    []string{},
-	}, nil
+		ForeignKeys: foreignKeys,
+		Indexes:     indexes,
+	}
 
-	fmt.Println(TableToString(tbl))
 	return tbl, nil
 }
 
-// Execute executes a SQL query and returns the result as a JSON byte slice.
-// It returns an error if the SQL query fails.
-func (p *Postgres) Execute(query string) ([]byte, error) {
-	// execute the sql statement
-	query = PostgresMetaCommands(query)
-	rows, err := p.Client.Query(query)
+// schemaOf returns the schema of the given table, falling back to the configured schema.
+// Tables in other databases cannot be queried over the same connection.
+func (p *Postgres) schemaOf(name types.TableName) (string, error) {
+	if name.Catalog != "" && p.Config.Database != "" && name.Catalog != p.Config.Database {
+		return "", fmt.Errorf("table %s is not in the connected database %s", name, p.Config.Database)
+	}
+	if name.Schema != "" {
+		return name.Schema, nil
+	}
+	return p.Config.Schema, nil
+}
+
+// TableStats returns the estimated row count and size of a table in the database.
+func (p *Postgres) TableStats(table string) (types.TableStats, error) {
+	return p.TableStatsContext(context.Background(), table)
+}
+
+// TableStatsContext returns the estimated row count and size of a table in the database.
+// PostgreSQL keeps no DDL or modification times, so those are left unset.
+// The query is cancelled when the context is done.
+func (p *Postgres) TableStatsContext(ctx context.Context, table string) (types.TableStats, error) {
+	name := types.ParseTableName(table)
+	schema, err := p.schemaOf(name)
 	if err != nil {
-		return nil, fmt.Errorf("error executing sql statement: %v", err)
+		return types.TableStats{}, err
+	}
+
+	stats := types.TableStats{Name: name.Name, Schema: schema}
+	row := p.Client.QueryRowContext(ctx, POSTGRES_TABLE_STATS_QUERY, name.Name, schema)
+	if err := row.Scan(&stats.RowCount, &stats.Bytes); err != nil {
+		return types.TableStats{}, fmt.Errorf("error reading table statistics: %v", err)
+	}
+	return stats, nil
+}
+
+// comments retrieves the comments of the given table and its columns.
+func (p *Postgres) comments(ctx context.Context, schema, table string) ([]types.Comment, error) {
+	rows, err := p.Client.QueryContext(ctx, POSTGRES_COMMENTS_QUERY, table, schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing comment query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var comments []types.Comment
+	for rows.Next() {
+		var c types.Comment
+		if err := rows.Scan(&c.Column, &c.Text); err != nil {
+			return nil, fmt.Errorf("error scanning comments: %v", err)
+		}
+		comments = append(comments, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over comments: %v", err)
+	}
+	return comments, nil
+}
+
+// indexes retrieves the indexes of the given table.
+func (p *Postgres) indexes(ctx context.Context, schema, table string) ([]types.Index, error) {
+	rows, err := p.Client.QueryContext(ctx, POSTGRES_INDEXES_QUERY, table, schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing index query: %v", err)
 	}
 	defer func() {
 		if err := rows.Close(); err != nil {
@@ -149,62 +435,71 @@ func (p *Postgres) Execute(query string) ([]byte, error) {
 		}
 	}()
 
-	// getting the column names
-	columns, err := rows.Columns()
+	var columns []types.IndexColumn
+	for rows.Next() {
+		var c types.IndexColumn
+		if err := rows.Scan(&c.Index, &c.Column, &c.Unique, &c.Primary, &c.Method, &c.Predicate); err != nil {
+			return nil, fmt.Errorf("error scanning indexes: %v", err)
+		}
+		columns = append(columns, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over indexes: %v", err)
+	}
+	return types.GroupIndexes(columns), nil
+}
+
+// foreignKeys retrieves the foreign key constraints declared on the given table.
+func (p *Postgres) foreignKeys(ctx context.Context, schema, table string) ([]types.ForeignKey, error) {
+	rows, err := p.Client.QueryContext(ctx, POSTGRES_FOREIGN_KEYS_QUERY, table, schema)
 	if err != nil {
-		return nil, fmt.Errorf("error getting columns: %v", err)
+		return nil, fmt.Errorf("error executing foreign key query: %v", err)
 	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
 
-	// Scan the result into a slice of slices
-	var results [][]interface{}
+	var columns []types.ForeignKeyColumn
 	for rows.Next() {
-		// create a slice of values and pointers
-		values := make([]interface{}, len(columns))
-		pointers := make([]interface{}, len(columns))
-		for i := range values {
-			pointers[i] = &values[i]
-		}
-
-		if err := rows.Scan(pointers...); err != nil {
-			return nil, fmt.Errorf("error scanning row: %v", err)
-		}
-
-		// Decode base64 data
-		stringRow := make([]interface{}, len(values))
-		for i, val := range values {
-			switch v := val.(type) {
-			case []byte:
-				strVal := string(v)
-				if isBase64(strVal) {
-					decoded, err := base64.StdEncoding.DecodeString(strVal)
-					if err != nil {
-						return nil, fmt.Errorf("error decoding base64 data: %v", err)
-					}
-					stringRow[i] = string(decoded)
-				} else {
-					stringRow[i] = strVal
-				}
-			case string:
-				stringRow[i] = v
-			case nil:
-				stringRow[i] = nil
-			default:
-				stringRow[i] = fmt.Sprintf("%v", v)
-			}
-		}
-		results = append(results, stringRow)
-	}
-
-	// Check for errors from iterating over rows
+		var c types.ForeignKeyColumn
+		if err := rows.Scan(&c.Constraint, &c.Column, &c.ReferencedSchema, &c.ReferencedTable, &c.ReferencedColumn, &c.OnDelete, &c.OnUpdate); err != nil {
+			return nil, fmt.Errorf("error scanning foreign keys: %v", err)
+		}
+		columns = append(columns, c)
+	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %v", err)
+		return nil, fmt.Errorf("error iterating over foreign keys: %v", err)
 	}
+	return types.GroupForeignKeys(columns), nil
+}
 
-	// Convert the result to JSON
-	queryResult := types.QueryResult{
-		Columns: columns,
-		Rows:    results,
+// Execute executes a SQL query and returns the result as a JSON byte slice.
+// It returns an error if the SQL query fails.
+func (p *Postgres) Execute(query string) ([]byte, error) {
+	return p.ExecuteContext(context.Background(), query)
+}
+
+// ExecuteContext executes a SQL query and returns the result as a JSON byte slice.
+// The query is cancelled when the context is done.
+func (p *Postgres) ExecuteContext(ctx context.Context, query string, args ...interface{}) ([]byte, error) {
+	return p.ExecutePage(ctx, query, "", args...)
+}
+
+// ExecutePage executes the given SQL query and returns the page of its result selected by the page token as JSON,
+// the first page for an empty token. The page is limited by the MaxRows and MaxBytes of the configuration,
+// the NextPageToken of a truncated result selects the next page.
+func (p *Postgres) ExecutePage(ctx context.Context, query, pageToken string, args ...interface{}) ([]byte, error) {
+	start := time.Now()
+	limits := governor.Limits{MaxRows: p.Config.MaxRows, MaxBytes: p.Config.MaxBytes}
+	queryResult, err := governor.Execute(ctx, p, types.Postgres, limits, query, pageToken, args...)
+	if err != nil {
+		return nil, err
 	}
+	queryResult.Time = time.Since(start).Milliseconds()
+
+	// Convert the result to JSON
 	jsonData, err := json.Marshal(queryResult)
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling json: %v", err)
@@ -213,19 +508,53 @@ func (p *Postgres) Execute(query string) ([]byte, error) {
 	return jsonData, nil
 }
 
-func isBase64(s string) bool {
-	decoded, err := base64.StdEncoding.DecodeString(s)
+// Query executes a SQL query and returns an iterator over its rows.
+// Meta commands such as \dt are expanded before the query is sent.
+func (p *Postgres) Query(ctx context.Context, query string, args ...interface{}) (types.RowIterator, error) {
+	// execute the sql statement
+	query = PostgresMetaCommands(query)
+	query, args, err := dialect.Bind(types.Postgres, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error binding query arguments: %v", err)
+	}
+	if p.Config.ReadOnly {
+		// writes hidden in functions are rejected by the read-only transaction
+		if err := dialect.CheckReadOnly(types.Postgres, query); err != nil {
+			return nil, err
+		}
+		return types.QueryReadOnly(ctx, p.Client, newValueConverter(types.BinaryEncoding(p.Config.BinaryEncoding)), query, args...)
+	}
+	rows, err := p.Client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+
+	it, err := types.NewRowIterator(rows, newValueConverter(types.BinaryEncoding(p.Config.BinaryEncoding)))
 	if err != nil {
-		return false
+		_ = rows.Close()
+		return nil, err
 	}
-	encoded := base64.StdEncoding.EncodeToString(decoded)
-	return s == encoded
+	return it, nil
+}
+
+// SetReadOnly enables or disables the read-only mode of the client, see config.Config.ReadOnly.
+// The configuration is copied, clients created with the same configuration keep their mode.
+func (p *Postgres) SetReadOnly(readOnly bool) {
+	cfg := *p.Config
+	cfg.ReadOnly = readOnly
+	p.Config = &cfg
 }
 
 // Tables returns a list of all tables in the given database.
 // It returns an error if the SQL query fails.
 func (p *Postgres) Tables(databaseName string) ([]string, error) {
-	rows, err := p.Client.Query(POSTGRES_TABLE_LIST_QUERY, databaseName)
+	return p.TablesContext(context.Background(), databaseName)
+}
+
+// TablesContext returns a list of all tables in the given database.
+// The query is cancelled when the context is done.
+func (p *Postgres) TablesContext(ctx context.Context, databaseName string) ([]string, error) {
+	rows, err := p.Client.QueryContext(ctx, POSTGRES_TABLE_LIST_QUERY, databaseName, p.Config.Schema)
 	if err != nil {
 		return nil, fmt.Errorf("error executing sql statement: %v", err)
 	}
@@ -251,13 +580,287 @@ func (p *Postgres) Tables(databaseName string) ([]string, error) {
 	return tables, nil
 }
 
+// ListTables returns the tables, views, materialized views and foreign tables of the configured schema
+// together with the definition and dependencies of the views.
+// The query is cancelled when the context is done.
+func (p *Postgres) ListTables(ctx context.Context, databaseName string, opts types.ListTablesOptions) ([]types.TableInfo, error) {
+	tables, err := p.listTables(ctx, databaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	tables = types.FilterTables(tables, opts)
+	if !types.HasViews(tables) {
+		return tables, nil
+	}
+
+	dependencies, err := p.viewDependencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	types.ApplyDependencies(tables, dependencies)
+	return tables, nil
+}
+
+// listTables retrieves every table like object of the configured schema.
+func (p *Postgres) listTables(ctx context.Context, databaseName string) ([]types.TableInfo, error) {
+	rows, err := p.Client.QueryContext(ctx, POSTGRES_LIST_TABLES_QUERY, databaseName, p.Config.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var tables []types.TableInfo
+	for rows.Next() {
+		var table types.TableInfo
+		if err := rows.Scan(&table.Name, &table.Schema, &table.Kind, &table.Definition); err != nil {
+			return nil, fmt.Errorf("error scanning tables: %v", err)
+		}
+		tables = append(tables, table)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error interating over rows: %v", err)
+	}
+
+	return tables, nil
+}
+
+// viewDependencies retrieves the relations the views of the configured schema depend on.
+func (p *Postgres) viewDependencies(ctx context.Context) ([]types.TableDependency, error) {
+	rows, err := p.Client.QueryContext(ctx, POSTGRES_VIEW_DEPENDENCIES_QUERY, p.Config.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing dependency query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var dependencies []types.TableDependency
+	for rows.Next() {
+		var d types.TableDependency
+		if err := rows.Scan(&d.Schema, &d.Name, &d.Referenced.Schema, &d.Referenced.Name); err != nil {
+			return nil, fmt.Errorf("error scanning dependencies: %v", err)
+		}
+		dependencies = append(dependencies, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over dependencies: %v", err)
+	}
+
+	return dependencies, nil
+}
+
+// Schemas returns the user schemas of the given database.
+// The query is cancelled when the context is done.
+func (p *Postgres) Schemas(ctx context.Context, databaseName string) ([]string, error) {
+	rows, err := p.Client.QueryContext(ctx, POSTGRES_SCHEMA_LIST_QUERY, databaseName)
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var schemas []string
+	for rows.Next() {
+		var schema string
+		if err := rows.Scan(&schema); err != nil {
+			return nil, fmt.Errorf("error scanning schemas: %v", err)
+		}
+		schemas = append(schemas, schema)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %v", err)
+	}
+
+	return schemas, nil
+}
+
+// Snapshot reads the schema of every table of the given database matching the options;
+// the columns, indexes, foreign keys and comments of the configured schema are read in bulk from the catalog.
+func (p *Postgres) Snapshot(ctx context.Context, databaseName string, opts types.SnapshotOptions) (types.DatabaseSchema, error) {
+	return snapshot.Take(ctx, p, types.Postgres, databaseName, opts)
+}
+
+// BulkSchemas reads the schemas of the given tables of the configured schema with four queries in total,
+// instead of four queries per table. The tables are listed from the database of the connection like ListTables does.
+func (p *Postgres) BulkSchemas(ctx context.Context, databaseName string, tables []types.TableInfo) ([]types.Table, error) {
+	schema := p.Config.Schema
+	rows, err := p.Client.QueryContext(ctx, POSTGRES_SNAPSHOT_COLUMNS_QUERY, schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	columns := map[string][]types.Column{}
+	for rows.Next() {
+		var table string
+		var column types.Column
+		if err := rows.Scan(
+			&table,
+			&column.Name,
+			&column.Type,
+			&column.IsNullable,
+			&column.DefaultValue,
+			&column.CharacterMaximumLength,
+			&column.NumericPrecision,
+			&column.NumericScale,
+			&column.OrdinalPosition,
+			&column.Visibility,
+			&column.IsPrimary,
+			&column.IsUpdatable,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning rows: %v", err)
+		}
+		column.Metatags = []string{column.Name}
+		column.Visibility = true // default visibility
+		columns[table] = append(columns[table], column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %v", err)
+	}
+
+	foreignKeys, err := p.schemaForeignKeys(ctx, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := p.schemaIndexes(ctx, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := p.schemaComments(ctx, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.Table, len(tables))
+	for i, t := range tables {
+		tableColumns := columns[t.Name]
+		tableIndexes := types.GroupIndexes(indexes[t.Name])
+		types.ApplyIndexes(tableColumns, tableIndexes)
+		description := types.ApplyComments(tableColumns, comments[t.Name])
+
+		result[i] = types.Table{
+			Name:        t.Name,
+			Catalog:     p.Config.Database,
+			Schema:      t.Schema,
+			Columns:     tableColumns,
+			ColumnCount: int64(len(tableColumns)),
+			Description: description,
+			Metatags:    []string{},
+			ForeignKeys: types.GroupForeignKeys(foreignKeys[t.Name]),
+			Indexes:     tableIndexes,
+		}
+	}
+	return result, nil
+}
+
+// schemaForeignKeys retrieves the foreign key columns of every table of a schema, keyed by table name.
+func (p *Postgres) schemaForeignKeys(ctx context.Context, schema string) (map[string][]types.ForeignKeyColumn, error) {
+	rows, err := p.Client.QueryContext(ctx, POSTGRES_SNAPSHOT_FOREIGN_KEYS_QUERY, schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing foreign key query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	columns := map[string][]types.ForeignKeyColumn{}
+	for rows.Next() {
+		var table string
+		var c types.ForeignKeyColumn
+		if err := rows.Scan(&table, &c.Constraint, &c.Column, &c.ReferencedSchema, &c.ReferencedTable, &c.ReferencedColumn, &c.OnDelete, &c.OnUpdate); err != nil {
+			return nil, fmt.Errorf("error scanning foreign keys: %v", err)
+		}
+		columns[table] = append(columns[table], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over foreign keys: %v", err)
+	}
+	return columns, nil
+}
+
+// schemaIndexes retrieves the index columns of every table of a schema, keyed by table name.
+func (p *Postgres) schemaIndexes(ctx context.Context, schema string) (map[string][]types.IndexColumn, error) {
+	rows, err := p.Client.QueryContext(ctx, POSTGRES_SNAPSHOT_INDEXES_QUERY, schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing index query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	columns := map[string][]types.IndexColumn{}
+	for rows.Next() {
+		var table string
+		var c types.IndexColumn
+		if err := rows.Scan(&table, &c.Index, &c.Column, &c.Unique, &c.Primary, &c.Method, &c.Predicate); err != nil {
+			return nil, fmt.Errorf("error scanning indexes: %v", err)
+		}
+		columns[table] = append(columns[table], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over indexes: %v", err)
+	}
+	return columns, nil
+}
+
+// schemaComments retrieves the comments of every table and column of a schema, keyed by table name.
+func (p *Postgres) schemaComments(ctx context.Context, schema string) (map[string][]types.Comment, error) {
+	rows, err := p.Client.QueryContext(ctx, POSTGRES_SNAPSHOT_COMMENTS_QUERY, schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing comment query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	comments := map[string][]types.Comment{}
+	for rows.Next() {
+		var table string
+		var c types.Comment
+		if err := rows.Scan(&table, &c.Column, &c.Text); err != nil {
+			return nil, fmt.Errorf("error scanning comments: %v", err)
+		}
+		comments[table] = append(comments[table], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over comments: %v", err)
+	}
+	return comments, nil
+}
+
 // GenerateCreateTableQuery generates a CREATE TABLE query for the given table.
 // It returns the query as a string.
 func (p *Postgres) GenerateCreateTableQuery(table types.Table) string {
-	query := `CREATE TABLE "` + table.Name + `" (`
+	name := dialect.QuoteIdentifier(types.Postgres, table.Name)
+	query := "CREATE TABLE " + name + " ("
 	for i, col := range table.Columns {
 		colType := strings.ToUpper(col.Type)
-		query += col.Name + " " + colType
+		query += dialect.QuoteIdentifier(types.Postgres, col.Name) + " " + colType
 		if col.IsPrimary {
 			query += " PRIMARY KEY"
 		}
@@ -275,6 +878,16 @@ func (p *Postgres) GenerateCreateTableQuery(table types.Table) string {
 		}
 	}
 	query += ");"
+
+	// PostgreSQL has no inline comments, they are set with separate statements
+	if table.Description != "" {
+		query += " COMMENT ON TABLE " + name + " IS " + dialect.QuoteString(types.Postgres, table.Description) + ";"
+	}
+	for _, col := range table.Columns {
+		if col.Description != "" {
+			query += " COMMENT ON COLUMN " + name + "." + dialect.QuoteIdentifier(types.Postgres, col.Name) + " IS " + dialect.QuoteString(types.Postgres, col.Description) + ";"
+		}
+	}
 	return query
 }
 
@@ -304,6 +917,9 @@ func TableToString(t types.Table) string {
 	)
 }
 
+// PostgresMetaCommands expands the psql meta commands such as \dt into SQL.
+// Table and schema names given to a meta command are folded to lower case like unquoted names and quoted,
+// so they cannot change the expanded statement.
 func PostgresMetaCommands(query string) string {
 	switch query {
 	case "\\l":
@@ -330,14 +946,16 @@ func PostgresMetaCommands(query string) string {
 			dbName := strings.TrimPrefix(query, "\\c ")
 			return fmt.Sprintf("switch_database %s", dbName)
 		} else if strings.HasPrefix(query, "\\d ") {
-			tableName := strings.TrimPrefix(query, "\\d ")
-			return fmt.Sprintf("SELECT * FROM %s;", tableName)
+			tableName := types.ParseTableName(strings.TrimPrefix(query, "\\d "))
+			tableName.Schema = dialect.FoldIdentifier(types.Postgres, tableName.Schema)
+			tableName.Name = dialect.FoldIdentifier(types.Postgres, tableName.Name)
+			return fmt.Sprintf("SELECT * FROM %s;", dialect.QuoteTableName(types.Postgres, tableName))
 		} else if strings.HasPrefix(query, "\\dn ") {
 			schemaName := strings.TrimPrefix(query, "\\dn ")
-			return fmt.Sprintf("SELECT nspname FROM pg_catalog.pg_namespace WHERE nspname = '%s';", schemaName)
+			return fmt.Sprintf("SELECT nspname FROM pg_catalog.pg_namespace WHERE nspname = %s;", dialect.QuoteString(types.Postgres, schemaName))
 		} else if strings.HasPrefix(query, "\\dp ") {
 			tableName := strings.TrimPrefix(query, "\\dp ")
-			return fmt.Sprintf("SELECT * FROM pg_catalog.pg_statio_all_tables WHERE relname = '%s';", tableName)
+			return fmt.Sprintf("SELECT * FROM pg_catalog.pg_statio_all_tables WHERE relname = %s;", dialect.QuoteString(types.Postgres, tableName))
 		}
 	}
 	// If the query doesn't match any known meta commands, return it unchanged
@@ -3,6 +3,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/thesaas-company/xray/config"
 	"github.com/thesaas-company/xray/types"
 )
 
@@ -39,10 +41,22 @@ func TestSchema(t *testing.T) {
 	table_name := "user" // table name to be used in the test
 
 	// mock rows to be returned by the query
-	columns := []string{"name", "type", "IsNullable", "DefaultValue", "CharacterMaximumLength", "OrdinalPosition", "Visibility", "IsPrimary", "IsUpdatable"}
-	mockRows := sqlmock.NewRows(columns).AddRow("id", "int", "No", "", 0, 1, true, true, true)
+	columns := []string{"name", "type", "IsNullable", "DefaultValue", "CharacterMaximumLength", "NumericPrecision", "NumericScale", "OrdinalPosition", "Visibility", "IsPrimary", "IsUpdatable"}
+	mockRows := sqlmock.NewRows(columns).AddRow("id", "int", "No", "", nil, 32, 0, 1, true, true, true)
 	// set the expected return values for the query
-	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_SCHEMA_QUERY)).WithArgs(table_name).WillReturnRows(mockRows)
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_SCHEMA_QUERY)).WithArgs(table_name, "").WillReturnRows(mockRows)
+	fkRows := sqlmock.NewRows([]string{"constraint_name", "column_name", "referenced_schema", "referenced_table", "referenced_column", "on_delete", "on_update"}).
+		AddRow("user_team_fkey", "team_id", "public", "team", "id", "SET NULL", "NO ACTION").
+		AddRow("user_org_fkey", "org_id", "public", "org", "id", "CASCADE", "CASCADE")
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_FOREIGN_KEYS_QUERY)).WithArgs(table_name, "").WillReturnRows(fkRows)
+	indexRows := sqlmock.NewRows([]string{"index_name", "column_name", "unique", "primary", "method", "predicate"}).
+		AddRow("user_pkey", "id", true, true, "btree", "").
+		AddRow("user_email_idx", "lower((email)::text)", true, false, "btree", "(deleted_at IS NULL)")
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_INDEXES_QUERY)).WithArgs(table_name, "").WillReturnRows(indexRows)
+	commentRows := sqlmock.NewRows([]string{"column_name", "description"}).
+		AddRow("", "Registered users").
+		AddRow("id", "Surrogate key")
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_COMMENTS_QUERY)).WithArgs(table_name, "").WillReturnRows(commentRows)
 
 	// we then create a new instance of our Postgres object and test the function
 	m, err := NewPostgres(db)
@@ -54,6 +68,28 @@ func TestSchema(t *testing.T) {
 		t.Errorf("error executing query : %v", err)
 	}
 
+	wantForeignKeys := []types.ForeignKey{
+		{Name: "user_team_fkey", Columns: []string{"team_id"}, ReferencedSchema: "public", ReferencedTable: "team", ReferencedColumns: []string{"id"}, OnDelete: "SET NULL", OnUpdate: "NO ACTION"},
+		{Name: "user_org_fkey", Columns: []string{"org_id"}, ReferencedSchema: "public", ReferencedTable: "org", ReferencedColumns: []string{"id"}, OnDelete: "CASCADE", OnUpdate: "CASCADE"},
+	}
+	if !reflect.DeepEqual(response.ForeignKeys, wantForeignKeys) {
+		t.Errorf("foreign keys = %+v, want %+v", response.ForeignKeys, wantForeignKeys)
+	}
+
+	wantIndexes := []types.Index{
+		{Name: "user_pkey", Columns: []string{"id"}, Unique: true, Primary: true, Method: "btree"},
+		{Name: "user_email_idx", Columns: []string{"lower((email)::text)"}, Unique: true, Method: "btree", Predicate: "(deleted_at IS NULL)"},
+	}
+	if !reflect.DeepEqual(response.Indexes, wantIndexes) {
+		t.Errorf("indexes = %+v, want %+v", response.Indexes, wantIndexes)
+	}
+	if column := response.Columns[0]; column.IsIndex != true || column.IsUnique.String != "YES" {
+		t.Errorf("column %s: IsIndex = %v, IsUnique = %q", column.Name, column.IsIndex, column.IsUnique.String)
+	}
+	if response.Description != "Registered users" || response.Columns[0].Description != "Surrogate key" {
+		t.Errorf("descriptions = %q, %q", response.Description, response.Columns[0].Description)
+	}
+
 	fmt.Printf("Table schema: %+v\n", response)
 
 	if err := mock.ExpectationsWereMet(); err != nil {
@@ -101,6 +137,84 @@ func TestExecute(t *testing.T) {
 	}
 }
 
+// TestExecuteWithArgs is a unit test function that tests the ExecuteContext method with bind arguments.
+// It checks that ? placeholders are rewritten to $n and the arguments reach the driver.
+func TestExecuteWithArgs(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mockRows := sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Rohan")
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name FROM "user" WHERE id = $1 AND name = $2`)).WithArgs(1, "Rohan").WillReturnRows(mockRows)
+
+	p, err := NewPostgres(db)
+	if err != nil {
+		t.Errorf("error initialising postgres: %s", err)
+	}
+	if _, err := p.ExecuteContext(context.Background(), `SELECT id, name FROM "user" WHERE id = ? AND name = ?`, 1, "Rohan"); err != nil {
+		t.Errorf("error executing the query: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestQuery is a unit test function that tests the Query method of the Postgres struct.
+// It streams the mocked rows through the iterator and checks the column metadata and row values.
+func TestQuery(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	query := `SELECT id, name FROM user`
+	mockRows := sqlmock.NewRowsWithColumnDefinition(
+		sqlmock.NewColumn("id").OfType("INT4", int64(0)),
+		sqlmock.NewColumn("name").OfType("TEXT", "").Nullable(true),
+	).AddRow(int64(1), "Rohan").AddRow(int64(2), "Mohan")
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(mockRows)
+
+	p, err := NewPostgres(db)
+	if err != nil {
+		t.Errorf("error initialising postgres: %s", err)
+	}
+	it, err := p.Query(context.Background(), query)
+	if err != nil {
+		t.Fatalf("error executing the query: %s", err)
+	}
+	defer func() {
+		if err := it.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	columns := it.Columns()
+	if len(columns) != 2 || columns[0].DatabaseType != "INT4" || !columns[1].Nullable {
+		t.Errorf("unexpected column metadata: %+v", columns)
+	}
+
+	var names []interface{}
+	for it.Next() {
+		names = append(names, it.Row()[1])
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("error iterating rows: %s", err)
+	}
+	if !reflect.DeepEqual(names, []interface{}{"Rohan", "Mohan"}) {
+		t.Errorf("expected [Rohan Mohan], got %v", names)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 // TestGetTableName is a unit test function that tests the Tables method of the Postgres struct.
 // It creates a mock instance of Postgres, sets the expected return values, and calls the method under test.
 // It then asserts the expected return values and checks if the method was called with the correct arguments.
@@ -118,7 +232,7 @@ func TestTables(t *testing.T) {
 								AddRow(tableList[0]).
 								AddRow(tableList[1]).
 								AddRow(tableList[2])
-	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_TABLE_LIST_QUERY)).WithArgs(DatabaseName).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_TABLE_LIST_QUERY)).WithArgs(DatabaseName, "").WillReturnRows(rows)
 
 	p, err := NewPostgres(db) // create a new instance of our Postgres object
 	if err != nil {
@@ -139,6 +253,148 @@ func TestTables(t *testing.T) {
 	}
 }
 
+// TestSchemaQualified is a unit test function that tests the Schema method of the Postgres struct with a schema qualified table name.
+// The schema of the name is passed to the queries and the catalog has to be the connected database.
+func TestSchemaQualified(t *testing.T) {
+	db, mock := MockDB() // create a new mock database connection
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mockRows := sqlmock.NewRows([]string{"name", "type", "is_nullable", "default_value", "character_maximum_length", "numeric_precision", "numeric_scale", "ordinal_position", "visibility", "is_primary", "is_updatable"}).
+		AddRow("id", "int", "No", "", nil, 32, 0, 1, true, true, true)
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_SCHEMA_QUERY)).WithArgs("user", "sales").WillReturnRows(mockRows)
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_FOREIGN_KEYS_QUERY)).WithArgs("user", "sales").
+		WillReturnRows(sqlmock.NewRows([]string{"conname", "attname", "nspname", "relname", "refattname", "on_delete", "on_update"}))
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_INDEXES_QUERY)).WithArgs("user", "sales").
+		WillReturnRows(sqlmock.NewRows([]string{"index_name", "column_name", "unique", "primary", "method", "predicate"}))
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_COMMENTS_QUERY)).WithArgs("user", "sales").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name", "description"}))
+
+	p := &Postgres{Client: db, Config: &config.Config{Database: "shop"}}
+	res, err := p.Schema(`shop.sales."user"`)
+	if err != nil {
+		t.Fatalf("error executing query: %v", err)
+	}
+	if res.Name != "user" || res.Schema != "sales" || res.Catalog != "shop" {
+		t.Errorf("table = %s/%s/%s, want shop/sales/user", res.Catalog, res.Schema, res.Name)
+	}
+
+	if _, err := p.Schema("other.sales.user"); err == nil {
+		t.Errorf("expected an error for a table in another database")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestListTables is a unit test function that tests the ListTables method of the Postgres struct.
+// The dependencies of the views are read from the catalog and attached to the views.
+func TestListTables(t *testing.T) {
+	db, mock := MockDB() // create a new mock database connection
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	rows := sqlmock.NewRows([]string{"relname", "nspname", "kind", "definition"}).
+		AddRow("orders", "public", "table", "").
+		AddRow("order_totals", "public", "materialized_view", " SELECT user_id, sum(total) FROM orders GROUP BY user_id;").
+		AddRow("remote_orders", "public", "external_table", "")
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_LIST_TABLES_QUERY)).WithArgs("test", "").WillReturnRows(rows)
+	dependencyRows := sqlmock.NewRows([]string{"view_schema", "view_name", "ref_schema", "ref_name"}).
+		AddRow("public", "order_totals", "public", "orders")
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_VIEW_DEPENDENCIES_QUERY)).WithArgs("").WillReturnRows(dependencyRows)
+
+	p, err := NewPostgres(db)
+	if err != nil {
+		t.Errorf("error initialising postgres: %s", err)
+	}
+	tables, err := p.ListTables(context.Background(), "test", types.ListTablesOptions{Kinds: []types.TableKind{types.KindTable, types.KindMaterializedView}})
+	if err != nil {
+		t.Fatalf("error listing tables: %s", err)
+	}
+
+	expected := []types.TableInfo{
+		{Name: "orders", Schema: "public", Kind: types.KindTable},
+		{Name: "order_totals", Schema: "public", Kind: types.KindMaterializedView, Definition: " SELECT user_id, sum(total) FROM orders GROUP BY user_id;",
+			Dependencies: []types.TableName{{Schema: "public", Name: "orders"}}},
+	}
+	if !reflect.DeepEqual(tables, expected) {
+		t.Errorf("expected: %+v, got: %+v", expected, tables)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestTableStats is a unit test function that tests the TableStats method of the Postgres struct.
+func TestTableStats(t *testing.T) {
+	db, mock := MockDB() // create a new mock database connection
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	rows := sqlmock.NewRows([]string{"row_count", "bytes"}).AddRow(1200, 81920)
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_TABLE_STATS_QUERY)).WithArgs("user", "sales").WillReturnRows(rows)
+
+	p, err := NewPostgres(db)
+	if err != nil {
+		t.Errorf("error initialising postgres: %s", err)
+	}
+	stats, err := p.TableStats("sales.user")
+	if err != nil {
+		t.Fatalf("error reading table statistics: %s", err)
+	}
+
+	expected := types.TableStats{Name: "user", Schema: "sales", RowCount: 1200, Bytes: 81920}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("expected: %+v, got: %+v", expected, stats)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSchemas is a unit test function that tests the Schemas method of the Postgres struct.
+func TestSchemas(t *testing.T) {
+	db, mock := MockDB() // create a new mock database connection
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	rows := sqlmock.NewRows([]string{"schema_name"}).AddRow("public").AddRow("sales")
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_SCHEMA_LIST_QUERY)).WithArgs("test").WillReturnRows(rows)
+
+	p, err := NewPostgres(db)
+	if err != nil {
+		t.Errorf("error initialising postgres: %s", err)
+	}
+	schemas, err := p.Schemas(context.Background(), "test")
+	if err != nil {
+		t.Errorf("error retrieving schemas: %s", err)
+	}
+
+	expected := []string{"public", "sales"}
+	if !reflect.DeepEqual(schemas, expected) {
+		t.Errorf("expected: %v, got: %v", expected, schemas)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 // TestGenerateCreateTablequery is a unit test function that tests the GenerateCreateTableQuery method of the Postgres struct.
 // It creates a mock instance of Postgres, sets the expected return values, and calls the method under test.
 // It then asserts the expected return values and checks if the method was called with the correct arguments.
@@ -182,7 +438,7 @@ func TestGenerateCreateTablequery(t *testing.T) {
 
 	fmt.Printf("Create table query: %v\n", query)
 
-	expectedQuery := `CREATE TABLE "user" (id SERIAL PRIMARY KEY UNIQUE NOT NULL, name VARCHAR(255) NOT NULL, age INTEGER);`
+	expectedQuery := `CREATE TABLE "user" ("id" SERIAL PRIMARY KEY UNIQUE NOT NULL, "name" VARCHAR(255) NOT NULL, "age" INTEGER);`
 	if query != expectedQuery {
 		t.Errorf("Expected '%s', but got '%s'", expectedQuery, query)
 	}
@@ -191,3 +447,458 @@ func TestGenerateCreateTablequery(t *testing.T) {
 		t.Errorf("There were unfulfilled expectations: %s", err)
 	}
 }
+
+// TestGenerateCreateTableQueryComments is a unit test function that tests that the GenerateCreateTableQuery method of the
+// Postgres struct emits the table and column descriptions as COMMENT statements.
+func TestGenerateCreateTableQueryComments(t *testing.T) {
+	p := &Postgres{}
+	table := types.Table{
+		Name:        "user",
+		Description: "Registered users",
+		Columns: []types.Column{
+			{Name: "id", Type: "INTEGER", IsPrimary: true, Description: "Surrogate key"},
+			{Name: "name", Type: "TEXT", Description: "User's name"},
+			{Name: "age", Type: "INTEGER"},
+		},
+	}
+
+	query := p.GenerateCreateTableQuery(table)
+
+	expectedQuery := `CREATE TABLE "user" ("id" INTEGER PRIMARY KEY, "name" TEXT, "age" INTEGER);` +
+		` COMMENT ON TABLE "user" IS 'Registered users';` +
+		` COMMENT ON COLUMN "user"."id" IS 'Surrogate key';` +
+		` COMMENT ON COLUMN "user"."name" IS 'User''s name';`
+	if query != expectedQuery {
+		t.Errorf("Expected '%s', but got '%s'", expectedQuery, query)
+	}
+
+	// names with quotes, upper case letters or reserved words are quoted
+	table = types.Table{
+		Name:        `Order"s`,
+		Description: "Orders",
+		Columns:     []types.Column{{Name: "Select", Type: "TEXT", Description: "Picked"}},
+	}
+	query = p.GenerateCreateTableQuery(table)
+	expectedQuery = `CREATE TABLE "Order""s" ("Select" TEXT);` +
+		` COMMENT ON TABLE "Order""s" IS 'Orders';` +
+		` COMMENT ON COLUMN "Order""s"."Select" IS 'Picked';`
+	if query != expectedQuery {
+		t.Errorf("Expected '%s', but got '%s'", expectedQuery, query)
+	}
+}
+
+// TestPostgresMetaCommands is a unit test function that tests expanding meta commands, including hostile names.
+func TestPostgresMetaCommands(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`\dt`, "SELECT * FROM pg_catalog.pg_tables;"},
+		{`\d sales.orders`, `SELECT * FROM "sales"."orders";`},
+		{`\d Sales.Orders`, `SELECT * FROM "sales"."orders";`},
+		{`\d users; DROP TABLE users; --`, `SELECT * FROM "users; DROP TABLE users; --";`},
+		{`\d "my""table"`, `SELECT * FROM "my""table";`},
+		{`\dn public' OR '1'='1`, "SELECT nspname FROM pg_catalog.pg_namespace WHERE nspname = 'public'' OR ''1''=''1';"},
+		{`\dp x'; DELETE FROM users; --`, "SELECT * FROM pg_catalog.pg_statio_all_tables WHERE relname = 'x''; DELETE FROM users; --';"},
+		{"SELECT 1", "SELECT 1"},
+	}
+	for _, tt := range tests {
+		if got := PostgresMetaCommands(tt.query); got != tt.want {
+			t.Errorf("PostgresMetaCommands(%q) = %s, want %s", tt.query, got, tt.want)
+		}
+	}
+}
+
+// TestExecutePage is a unit test function that tests that the row limit of the configuration is sent as a LIMIT
+// and that the next page is read with an OFFSET.
+func TestExecutePage(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	query := `SELECT id FROM "user" WHERE id > ? ORDER BY id;`
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM "user" WHERE id > $1 ORDER BY id LIMIT 3`)).WithArgs(0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM "user" WHERE id > $1 ORDER BY id LIMIT 3 OFFSET 2`)).WithArgs(0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+	p := &Postgres{Client: db, Config: &config.Config{MaxRows: 2}}
+	res, err := p.ExecuteContext(context.Background(), query, 0)
+	if err != nil {
+		t.Fatalf("error executing the query: %s", err)
+	}
+	var first types.QueryResult
+	if err := json.Unmarshal(res, &first); err != nil {
+		t.Fatalf("error unmarshalling the result: %s", err)
+	}
+	if first.RowCount != 2 || !first.Truncated || first.NextPageToken == "" {
+		t.Errorf("first page = %+v, want 2 rows and a next page token", first)
+	}
+
+	res, err = p.ExecutePage(context.Background(), query, first.NextPageToken, 0)
+	if err != nil {
+		t.Fatalf("error executing the query: %s", err)
+	}
+	var second types.QueryResult
+	if err := json.Unmarshal(res, &second); err != nil {
+		t.Fatalf("error unmarshalling the result: %s", err)
+	}
+	if second.RowCount != 1 || second.Truncated || second.NextPageToken != "" {
+		t.Errorf("second page = %+v, want the last row", second)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestEstimate is a unit test function that tests reading the cost and row count of a query from its JSON plan.
+func TestEstimate(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	plan := `[{"Plan": {"Node Type": "Seq Scan", "Relation Name": "user", "Total Cost": 35.5, "Plan Rows": 2550, "Plan Width": 4}}]`
+	mock.ExpectQuery(regexp.QuoteMeta(`EXPLAIN (FORMAT JSON) SELECT id FROM "user"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(plan))
+
+	p := &Postgres{Client: db, Config: &config.Config{}}
+	estimate, err := p.Estimate(context.Background(), `SELECT id FROM "user";`)
+	if err != nil {
+		t.Fatalf("error estimating the query: %s", err)
+	}
+	want := types.CostEstimate{Rows: 2550, Cost: 35.5, Plan: plan}
+	if !reflect.DeepEqual(estimate, want) {
+		t.Errorf("Estimate() = %+v, want %+v", estimate, want)
+	}
+
+	// a second statement must not run behind the EXPLAIN
+	if _, err := p.Estimate(context.Background(), `SELECT 1; DROP TABLE "user"`); err == nil {
+		t.Error("expected an error for several statements")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestExplain is a unit test function that tests converting an EXPLAIN ANALYZE plan to a plan tree,
+// the statement runs in a transaction that is rolled back.
+func TestExplain(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	plan := `[{"Plan": {"Node Type": "Hash Join", "Total Cost": 70.1, "Plan Rows": 100, "Actual Rows": 98, "Actual Total Time": 1.5, "Actual Loops": 1,
+		"Plans": [
+			{"Node Type": "Seq Scan", "Relation Name": "orders", "Total Cost": 30.2, "Plan Rows": 1000, "Actual Rows": 990, "Actual Total Time": 0.8, "Actual Loops": 1},
+			{"Node Type": "Index Scan", "Relation Name": "user", "Total Cost": 8.3, "Plan Rows": 1, "Actual Rows": 1, "Actual Total Time": 0.01, "Actual Loops": 2}
+		]}}]`
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`EXPLAIN (ANALYZE, FORMAT JSON) DELETE FROM orders USING "user" WHERE orders.user_id = "user".id`)).
+		WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(plan))
+	mock.ExpectRollback()
+
+	p := &Postgres{Client: db, Config: &config.Config{}}
+	got, err := p.Explain(context.Background(), `DELETE FROM orders USING "user" WHERE orders.user_id = "user".id`, true)
+	if err != nil {
+		t.Fatalf("error explaining the query: %s", err)
+	}
+	want := &types.PlanNode{Type: "Hash Join", EstimatedRows: 100, EstimatedCost: 70.1, ActualRows: 98, ActualTime: 1.5, Children: []*types.PlanNode{
+		{Type: "Seq Scan", Relation: "orders", EstimatedRows: 1000, EstimatedCost: 30.2, ActualRows: 990, ActualTime: 0.8},
+		{Type: "Index Scan", Relation: "user", EstimatedRows: 1, EstimatedCost: 8.3, ActualRows: 2, ActualTime: 0.02},
+	}}
+	if !got.Analyzed || !reflect.DeepEqual(got.Root, want) {
+		t.Errorf("Explain() = %+v, want %+v", got.Root, want)
+	}
+
+	// in read-only mode EXPLAIN ANALYZE would run the write
+	p.SetReadOnly(true)
+	if _, err := p.Explain(context.Background(), "DELETE FROM orders", true); err == nil {
+		t.Error("expected an error for EXPLAIN ANALYZE of a write in read-only mode")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSnapshot is a unit test function that tests that the Snapshot method of the Postgres struct reads the
+// columns, foreign keys, indexes and comments of every table of the schema with one query each.
+func TestSnapshot(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	tableRows := sqlmock.NewRows([]string{"relname", "nspname", "kind", "definition"}).
+		AddRow("orders", "sales", "table", "").
+		AddRow("users", "sales", "table", "")
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_LIST_TABLES_QUERY)).WithArgs("shop", "sales").WillReturnRows(tableRows)
+	columnRows := sqlmock.NewRows([]string{"table_name", "name", "type", "is_nullable", "default_value", "character_maximum_length", "numeric_precision", "numeric_scale", "ordinal_position", "visibility", "is_primary", "is_updatable"}).
+		AddRow("orders", "id", "integer", "NO", nil, nil, 32, 0, 1, false, true, true).
+		AddRow("orders", "user_id", "integer", "NO", nil, nil, 32, 0, 2, false, true, true).
+		AddRow("users", "email", "text", "NO", nil, nil, nil, nil, 1, false, false, true)
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_SNAPSHOT_COLUMNS_QUERY)).WithArgs("sales").WillReturnRows(columnRows)
+	fkRows := sqlmock.NewRows([]string{"relname", "conname", "attname", "ref_schema", "ref_table", "ref_column", "on_delete", "on_update"}).
+		AddRow("orders", "orders_user_id_fkey", "user_id", "sales", "users", "id", "CASCADE", "NO ACTION")
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_SNAPSHOT_FOREIGN_KEYS_QUERY)).WithArgs("sales").WillReturnRows(fkRows)
+	indexRows := sqlmock.NewRows([]string{"relname", "index", "column", "unique", "primary", "method", "predicate"}).
+		AddRow("orders", "orders_pkey", "id", true, true, "btree", "").
+		AddRow("users", "users_email_idx", "lower(email)", true, false, "btree", "")
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_SNAPSHOT_INDEXES_QUERY)).WithArgs("sales").WillReturnRows(indexRows)
+	commentRows := sqlmock.NewRows([]string{"relname", "attname", "description"}).
+		AddRow("users", "", "Registered users").
+		AddRow("users", "email", "Login email")
+	mock.ExpectQuery(regexp.QuoteMeta(POSTGRES_SNAPSHOT_COMMENTS_QUERY)).WithArgs("sales").WillReturnRows(commentRows)
+
+	p := &Postgres{Client: db, Config: &config.Config{Database: "shop", Schema: "sales"}}
+	schema, err := p.Snapshot(context.Background(), "shop", types.SnapshotOptions{})
+	if err != nil {
+		t.Fatalf("error taking snapshot: %s", err)
+	}
+
+	if len(schema.Tables) != 2 {
+		t.Fatalf("expected 2 tables, got %d", len(schema.Tables))
+	}
+	orders, users := schema.Tables[0], schema.Tables[1]
+	wantForeignKeys := []types.ForeignKey{{
+		Name:              "orders_user_id_fkey",
+		Columns:           []string{"user_id"},
+		ReferencedSchema:  "sales",
+		ReferencedTable:   "users",
+		ReferencedColumns: []string{"id"},
+		OnDelete:          "CASCADE",
+		OnUpdate:          "NO ACTION",
+	}}
+	if !reflect.DeepEqual(orders.ForeignKeys, wantForeignKeys) {
+		t.Errorf("foreign keys = %+v, want %+v", orders.ForeignKeys, wantForeignKeys)
+	}
+	if orders.Catalog != "shop" || orders.Schema != "sales" || orders.ColumnCount != 2 || len(orders.Indexes) != 1 {
+		t.Errorf("orders = %+v", orders)
+	}
+	wantIndexes := []types.Index{{Name: "users_email_idx", Columns: []string{"lower(email)"}, Unique: true, Method: "btree"}}
+	if !reflect.DeepEqual(users.Indexes, wantIndexes) {
+		t.Errorf("indexes = %+v, want %+v", users.Indexes, wantIndexes)
+	}
+	if users.Description != "Registered users" || users.Columns[0].Description != "Login email" || len(users.ForeignKeys) != 0 {
+		t.Errorf("users = %+v", users)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestBegin is a unit test function that tests committing and rolling back transactions of the Postgres struct.
+// Unsupported isolation levels are rejected before a transaction is started.
+func TestBegin(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO users (name) VALUES ($1)")).WithArgs("ann").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM users")).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectRollback()
+
+	p := &Postgres{Client: db, Config: &config.Config{}}
+	tx, err := p.Begin(context.Background(), types.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if n, err := tx.Exec(context.Background(), "INSERT INTO users (name) VALUES ($1)", "ann"); err != nil || n != 1 {
+		t.Errorf("Exec() = %d, %v, want 1 row", n, err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Errorf("error committing transaction: %s", err)
+	}
+
+	tx, err = p.Begin(context.Background(), types.TxOptions{})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if n, err := tx.Exec(context.Background(), "DELETE FROM users"); err != nil || n != 3 {
+		t.Errorf("Exec() = %d, %v, want 3 rows", n, err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Errorf("error rolling back transaction: %s", err)
+	}
+
+	if _, err := p.Begin(context.Background(), types.TxOptions{Isolation: sql.LevelSnapshot}); err == nil {
+		t.Error("expected an error for an unsupported isolation level")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestBeginReadOnly is a unit test function that tests that read-only transactions of the Postgres struct run reads
+// and reject writes before they are sent, whether requested by the options or by the read-only mode of the client.
+func TestBeginReadOnly(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM users WHERE id = $1")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	p := &Postgres{Client: db, Config: &config.Config{}}
+	tx, err := p.Begin(context.Background(), types.TxOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if _, err := tx.Execute(context.Background(), "SELECT id FROM users WHERE id = $1", 1); err != nil {
+		t.Errorf("error executing statement: %s", err)
+	}
+	if _, err := tx.Exec(context.Background(), "DELETE FROM users"); err == nil {
+		t.Error("expected an error for a write in a read-only transaction")
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Errorf("error rolling back transaction: %s", err)
+	}
+
+	p.SetReadOnly(true)
+	tx, err = p.Begin(context.Background(), types.TxOptions{})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if _, err := tx.Exec(context.Background(), "INSERT INTO users (name) VALUES ($1)", "ann"); err == nil {
+		t.Error("expected an error for a write in read-only mode")
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Errorf("error rolling back transaction: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSession is a unit test function that tests that the statements of a session of the Postgres struct,
+// and of the transactions started on it, run on the pinned connection.
+func TestSession(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectExec(regexp.QuoteMeta("SET search_path TO sales")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM users WHERE id = $1")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO users (name) VALUES ($1)")).WithArgs("ann").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	p := &Postgres{Client: db, Config: &config.Config{}}
+	session, err := p.Session(context.Background())
+	if err != nil {
+		t.Fatalf("error opening session: %s", err)
+	}
+	defer func() {
+		if err := session.Close(); err != nil {
+			t.Errorf("error closing session: %s", err)
+		}
+	}()
+
+	if _, err := session.Exec(context.Background(), "SET search_path TO sales"); err != nil {
+		t.Errorf("error executing statement: %s", err)
+	}
+	it, err := session.Query(context.Background(), "SELECT id FROM users WHERE id = $1", 1)
+	if err != nil {
+		t.Fatalf("error querying: %s", err)
+	}
+	result, err := types.ReadAll(it)
+	if err != nil {
+		t.Fatalf("error reading rows: %s", err)
+	}
+	if result.RowCount != 1 {
+		t.Errorf("RowCount = %d, want 1", result.RowCount)
+	}
+
+	tx, err := session.Begin(context.Background(), types.TxOptions{})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if _, err := tx.Exec(context.Background(), "INSERT INTO users (name) VALUES ($1)", "ann"); err != nil {
+		t.Errorf("error executing statement: %s", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Errorf("error committing transaction: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestExecuteScript is a unit test function that tests running a script with a $$ function body one statement at a
+// time, stopping at the first failing statement or running the rest of the script after it.
+func TestExecuteScript(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	body := "CREATE FUNCTION touch() RETURNS void AS $$ BEGIN UPDATE users SET seen = now(); END; $$ LANGUAGE plpgsql"
+	script := body + ";\nSELECT touch();\nDELETE FROM missing;\nUPDATE users SET seen = NULL;"
+	mock.ExpectExec(regexp.QuoteMeta(body)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT touch()")).WillReturnRows(sqlmock.NewRows([]string{"touch"}).AddRow(""))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM missing")).WillReturnError(fmt.Errorf(`relation "missing" does not exist`))
+
+	p := &Postgres{Client: db, Config: &config.Config{}}
+	result, err := p.ExecuteScript(context.Background(), script, types.ScriptOptions{Mode: types.StopOnError})
+	if err == nil || len(result.Statements) != 3 || result.Failed != 1 || result.Statements[1].Result == nil {
+		t.Errorf("ExecuteScript() = %+v, %v, want to stop at the third statement", result, err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(body)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT touch()")).WillReturnRows(sqlmock.NewRows([]string{"touch"}).AddRow(""))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM missing")).WillReturnError(fmt.Errorf(`relation "missing" does not exist`))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET seen = NULL")).WillReturnResult(sqlmock.NewResult(0, 4))
+	result, err = p.ExecuteScript(context.Background(), script, types.ScriptOptions{Mode: types.ContinueOnError})
+	if err != nil || len(result.Statements) != 4 || result.Failed != 1 || result.Statements[3].RowsAffected != 4 {
+		t.Errorf("ExecuteScript() = %+v, %v, want to run all 4 statements", result, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSetReadOnly is a unit test function that tests that the read-only mode of a Postgres client does not change
+// the mode of the other clients sharing its configuration.
+func TestSetReadOnly(t *testing.T) {
+	cfg := &config.Config{}
+	p, other := &Postgres{Config: cfg}, &Postgres{Config: cfg}
+	p.SetReadOnly(true)
+	if !p.Config.ReadOnly || other.Config.ReadOnly || cfg.ReadOnly {
+		t.Errorf("SetReadOnly(true) changed the shared configuration %+v", cfg)
+	}
+}
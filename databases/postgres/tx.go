@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/thesaas-company/xray/governor"
+	"github.com/thesaas-company/xray/script"
+	"github.com/thesaas-company/xray/session"
+	"github.com/thesaas-company/xray/types"
+)
+
+// postgresIsolation lists the isolation levels of Postgres, READ UNCOMMITTED behaves as READ COMMITTED.
+var postgresIsolation = []sql.IsolationLevel{sql.LevelReadUncommitted, sql.LevelReadCommitted, sql.LevelRepeatableRead, sql.LevelSerializable}
+
+// Begin starts a transaction. Postgres supports the standard isolation levels and read-only transactions.
+func (p *Postgres) Begin(ctx context.Context, opts types.TxOptions) (types.Tx, error) {
+	return session.Begin(ctx, p.Client, p.sessionConfig(), opts)
+}
+
+// Session pins a connection of the pool, so that SET options and temporary tables hold between statements.
+func (p *Postgres) Session(ctx context.Context) (types.Session, error) {
+	return session.Open(ctx, p.Client, p.sessionConfig())
+}
+
+// ExecuteScript runs the statements of a script one after the other in a session, $$ bodies are kept whole.
+func (p *Postgres) ExecuteScript(ctx context.Context, query string, opts types.ScriptOptions) (types.ScriptResult, error) {
+	return script.Run(ctx, p, types.Postgres, query, opts)
+}
+
+// sessionConfig describes how the statements of transactions and sessions run.
+func (p *Postgres) sessionConfig() session.Config {
+	return session.Config{
+		DbType:     types.Postgres,
+		Convert:    newValueConverter(types.BinaryEncoding(p.Config.BinaryEncoding)),
+		Limits:     governor.Limits{MaxRows: p.Config.MaxRows, MaxBytes: p.Config.MaxBytes},
+		ReadOnly:   p.Config.ReadOnly,
+		Isolation:  postgresIsolation,
+		ReadOnlyTx: true,
+	}
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"strings"
+	"time"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// newValueConverter returns the converter for values scanned from PostgreSQL.
+// Numbers, booleans and JSON keep their type, temporal values become RFC3339 strings,
+// NUMERIC stays an exact decimal string and NULL stays nil.
+// Values of binary columns are encoded with the given encoding; textual values are never altered.
+func newValueConverter(binary types.BinaryEncoding) types.ValueConverter {
+	return func(column types.ColumnMeta, v interface{}) (interface{}, error) {
+		return convertValue(column, v, binary)
+	}
+}
+
+// convertValue converts a single value scanned from PostgreSQL.
+func convertValue(column types.ColumnMeta, val interface{}, binary types.BinaryEncoding) (interface{}, error) {
+	typeName := strings.ToUpper(column.DatabaseType)
+	switch v := val.(type) {
+	case nil:
+		return nil, nil
+	case float64:
+		return types.FloatValue(v), nil
+	case time.Time:
+		switch typeName {
+		case "DATE":
+			return v.Format(types.DateLayout), nil
+		case "TIME", "TIMETZ":
+			return v.Format(types.TimeLayout), nil
+		}
+		return types.TimestampValue(v), nil
+	case []byte:
+		if isBinaryType(typeName) {
+			return binary.Encode(v)
+		}
+		return convertBytes(typeName, v), nil
+	default:
+		// int64, bool and string are already JSON friendly
+		return v, nil
+	}
+}
+
+// convertBytes converts the raw bytes lib/pq returns for types it does not decode itself.
+func convertBytes(typeName string, v []byte) interface{} {
+	switch typeName {
+	case "NUMERIC", "DECIMAL", "MONEY":
+		return string(v)
+	case "JSON", "JSONB":
+		return types.JSONValue(v)
+	}
+	return string(v)
+}
+
+// isBinaryType reports whether the PostgreSQL column type holds binary data.
+func isBinaryType(typeName string) bool {
+	switch typeName {
+	case "BYTEA":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestConvertValue is a unit test function that tests how PostgreSQL values are mapped to JSON friendly values.
+func TestConvertValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		typeName string
+		binary   types.BinaryEncoding
+		value    interface{}
+		want     interface{}
+	}{
+		{name: "null", typeName: "TEXT", value: nil, want: nil},
+		{name: "base64 looking text", typeName: "TEXT", value: "test", want: "test"},
+		{name: "base64 looking unknown type", typeName: "", value: []byte("abcd"), want: "abcd"},
+		{name: "numeric", typeName: "NUMERIC", value: []byte("10.10"), want: "10.10"},
+		{name: "jsonb", typeName: "JSONB", value: []byte(`{"a":1}`), want: json.RawMessage(`{"a":1}`)},
+		{name: "bytea", typeName: "BYTEA", value: []byte("test"), want: "dGVzdA=="},
+		{name: "bytea as hex", typeName: "BYTEA", binary: types.BinaryHex, value: []byte("test"), want: "74657374"},
+		{name: "bytea omitted", typeName: "BYTEA", binary: types.BinaryOmit, value: []byte("test"), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertValue(types.ColumnMeta{DatabaseType: tt.typeName}, tt.value, tt.binary)
+			if err != nil {
+				t.Fatalf("convertValue() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("convertValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestConvertValueUnsupportedEncoding is a unit test function that tests that an unknown binary encoding is reported.
+func TestConvertValueUnsupportedEncoding(t *testing.T) {
+	_, err := convertValue(types.ColumnMeta{DatabaseType: "BYTEA"}, []byte("test"), "base32")
+	if err == nil {
+		t.Fatal("expected an error for an unsupported binary encoding")
+	}
+}
@@ -0,0 +1,88 @@
+package redshift
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/diff"
+	"github.com/thesaas-company/xray/types"
+)
+
+// GenerateAlterTableQueries generates the ALTER TABLE statements turning the from table into the to table.
+// Redshift can only widen VARCHAR columns in place and cannot change nullability or defaults of existing
+// columns, those changes are reported as warnings. The sort key is changed with ALTER SORTKEY.
+func (r *Redshift) GenerateAlterTableQueries(from, to types.Table) types.Migration {
+	var m types.Migration
+	d := diff.Tables(from, to, types.Redshift, types.Redshift)
+	table := r.quoteTableName(to.Schema, to.Name)
+
+	if d.PrimaryKey != nil && d.PrimaryKey.From != "" {
+		name := diff.PrimaryKeyName(from)
+		if name == "" {
+			name = from.Name + "_pkey"
+		}
+		m.Add("ALTER TABLE " + table + " DROP CONSTRAINT " + dialect.QuoteIdentifier(types.Redshift, name) + ";")
+	}
+
+	for _, column := range d.RemovedColumns {
+		m.Add("ALTER TABLE " + table + " DROP COLUMN " + dialect.QuoteIdentifier(types.Redshift, column.Name) + ";")
+		m.Warn(fmt.Sprintf("dropping column %s deletes its data", column.Name))
+	}
+	for _, column := range d.AddedColumns {
+		query := "ALTER TABLE " + table + " ADD COLUMN " + dialect.QuoteIdentifier(types.Redshift, column.Name) + " " + convertTypeToRedshift(strings.ToUpper(column.Type))
+		if column.DefaultValue.Valid {
+			query += " DEFAULT " + column.DefaultValue.String
+		}
+		if column.IsNullable == "NO" {
+			query += " NOT NULL"
+			if !column.DefaultValue.Valid {
+				m.Warn(fmt.Sprintf("adding NOT NULL column %s without a default fails on a non-empty table", column.Name))
+			}
+		}
+		m.Add(query + ";")
+	}
+	for _, column := range d.ChangedColumns {
+		for _, change := range column.Changes {
+			switch change.Field {
+			case diff.FieldType:
+				if strings.HasPrefix(change.To, "varchar") && diff.Widens(types.Redshift, column.From, column.To) {
+					m.Add("ALTER TABLE " + table + " ALTER COLUMN " + dialect.QuoteIdentifier(types.Redshift, column.Name) + " TYPE " + strings.ToUpper(change.To) + ";")
+				} else {
+					m.Warn(fmt.Sprintf("Redshift cannot change the type of column %s from %s to %s in place, the table has to be recreated", column.Name, change.From, change.To))
+				}
+			case diff.FieldNullable:
+				m.Warn(fmt.Sprintf("Redshift cannot change the nullability of column %s in place, the table has to be recreated", column.Name))
+			case diff.FieldDefault:
+				m.Warn(fmt.Sprintf("Redshift cannot change the default of column %s in place, the table has to be recreated", column.Name))
+			}
+		}
+	}
+
+	if d.PrimaryKey != nil && d.PrimaryKey.To != "" {
+		m.Add("ALTER TABLE " + table + " ADD PRIMARY KEY (" + strings.Join(dialect.QuoteColumns(types.Redshift, to, diff.PrimaryKeyColumns(to)), ", ") + ");")
+	}
+
+	// the only index like object of Redshift is the sort key
+	var sortKey *types.Index
+	for i, index := range d.AddedIndexes {
+		if index.Name == "sortkey" {
+			sortKey = &d.AddedIndexes[i]
+		} else {
+			m.Warn(fmt.Sprintf("Redshift has no indexes, index %s is not created", index.Name))
+		}
+	}
+	switch {
+	case sortKey != nil && sortKey.Method == "interleaved":
+		m.Warn("Redshift cannot change an interleaved sort key in place, the table has to be recreated")
+	case sortKey != nil:
+		m.Add("ALTER TABLE " + table + " ALTER SORTKEY (" + strings.Join(dialect.QuoteColumns(types.Redshift, to, sortKey.Columns), ", ") + ");")
+	default:
+		for _, index := range d.RemovedIndexes {
+			if index.Name == "sortkey" {
+				m.Add("ALTER TABLE " + table + " ALTER SORTKEY NONE;")
+			}
+		}
+	}
+	return m
+}
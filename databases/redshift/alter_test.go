@@ -0,0 +1,77 @@
+package redshift
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thesaas-company/xray/config"
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestGenerateAlterTableQueries is a unit test function that tests the GenerateAlterTableQueries method of the Redshift struct.
+func TestGenerateAlterTableQueries(t *testing.T) {
+	from := types.Table{
+		Name: "events",
+		Columns: []types.Column{
+			{Name: "id", Type: "bigint", IsNullable: "NO"},
+			{Name: "name", Type: "character varying(64)", IsNullable: "YES"},
+			{Name: "amount", Type: "integer", IsNullable: "YES"},
+		},
+		Indexes: []types.Index{{Name: "sortkey", Columns: []string{"id"}, Method: "compound"}},
+	}
+	to := types.Table{
+		Name: "events",
+		Columns: []types.Column{
+			{Name: "id", Type: "bigint", IsNullable: "NO"},
+			{Name: "name", Type: "character varying(256)", IsNullable: "NO"},
+			{Name: "amount", Type: "bigint", IsNullable: "YES"},
+		},
+		Indexes: []types.Index{{Name: "sortkey", Columns: []string{"id", "name"}, Method: "compound"}},
+	}
+
+	r := &Redshift{Config: config.Config{Database: "dev", Schema: "public"}}
+	m := r.GenerateAlterTableQueries(from, to)
+
+	wantStatements := []string{
+		`ALTER TABLE "dev"."public"."events" ALTER COLUMN "name" TYPE VARCHAR(256);`,
+		`ALTER TABLE "dev"."public"."events" ALTER SORTKEY ("id", "name");`,
+	}
+	if !reflect.DeepEqual(m.Statements, wantStatements) {
+		t.Errorf("statements = %#v, want %#v", m.Statements, wantStatements)
+	}
+	wantWarnings := []string{
+		"Redshift cannot change the nullability of column name in place, the table has to be recreated",
+		"Redshift cannot change the type of column amount from integer to bigint in place, the table has to be recreated",
+	}
+	if !reflect.DeepEqual(m.Warnings, wantWarnings) {
+		t.Errorf("warnings = %#v, want %#v", m.Warnings, wantWarnings)
+	}
+}
+
+// TestGenerateAlterTableQueriesQuotedNames is a unit test function that tests that reserved words, mixed case names
+// and quote characters in table, column and index names are quoted.
+func TestGenerateAlterTableQueriesQuotedNames(t *testing.T) {
+	from := types.Table{
+		Name:    "Order",
+		Schema:  "Sales",
+		Columns: []types.Column{{Name: "id", Type: "integer", IsNullable: "NO", IsPrimary: true}, {Name: "Group", Type: "varchar", IsNullable: "YES"}},
+	}
+	to := types.Table{
+		Name:    "Order",
+		Schema:  "Sales",
+		Columns: []types.Column{{Name: "id", Type: "integer", IsNullable: "NO", IsPrimary: true}, {Name: `Select"s`, Type: "varchar", IsNullable: "YES"}},
+		Indexes: []types.Index{{Name: "sortkey", Columns: []string{`Select"s`}, Method: "compound"}},
+	}
+
+	r := &Redshift{Config: config.Config{Database: "Dev"}}
+	m := r.GenerateAlterTableQueries(from, to)
+
+	want := []string{
+		`ALTER TABLE "dev"."Sales"."Order" DROP COLUMN "Group";`,
+		`ALTER TABLE "dev"."Sales"."Order" ADD COLUMN "Select""s" VARCHAR;`,
+		`ALTER TABLE "dev"."Sales"."Order" ALTER SORTKEY ("Select""s");`,
+	}
+	if !reflect.DeepEqual(m.Statements, want) {
+		t.Errorf("statements = %#v, want %#v", m.Statements, want)
+	}
+}
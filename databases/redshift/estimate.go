@@ -0,0 +1,24 @@
+package redshift
+
+import (
+	"context"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// Redshift_Explain_query plans a statement without running it, Redshift returns the plan as one row per line of text.
+const Redshift_Explain_query = "EXPLAIN %s"
+
+// Estimate returns the cost and row count the Redshift planner expects for a single statement, without running it.
+// They are read from the top node of the plan.
+func (r *Redshift) Estimate(ctx context.Context, query string) (types.CostEstimate, error) {
+	plan, err := r.Explain(ctx, query, false)
+	if err != nil {
+		return types.CostEstimate{}, err
+	}
+	return types.CostEstimate{
+		Rows: int64(plan.Root.EstimatedRows),
+		Cost: plan.Root.EstimatedCost,
+		Plan: plan.Raw,
+	}, nil
+}
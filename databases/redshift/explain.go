@@ -0,0 +1,51 @@
+package redshift
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/explain"
+	"github.com/thesaas-company/xray/types"
+)
+
+// Explain returns the plan of a single statement. Redshift has neither EXPLAIN ANALYZE nor a JSON format,
+// the text plan is parsed instead and analyze is refused.
+func (r *Redshift) Explain(ctx context.Context, query string, analyze bool) (types.Plan, error) {
+	if analyze {
+		return types.Plan{}, fmt.Errorf("error explaining query: redshift does not support EXPLAIN ANALYZE")
+	}
+	statement, err := dialect.SingleStatement(types.Redshift, query)
+	if err != nil {
+		return types.Plan{}, fmt.Errorf("error explaining query: %v", err)
+	}
+
+	rows, err := r.Client.QueryContext(ctx, fmt.Sprintf(Redshift_Explain_query, statement.Text))
+	if err != nil {
+		return types.Plan{}, fmt.Errorf("error explaining query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var lines []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return types.Plan{}, fmt.Errorf("error scanning query plan: %v", err)
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return types.Plan{}, fmt.Errorf("error iterating query plan: %v", err)
+	}
+
+	plan := types.Plan{Format: "text", Raw: strings.Join(lines, "\n")}
+	if plan.Root, err = explain.ParseText(plan.Raw); err != nil {
+		return types.Plan{}, err
+	}
+	return plan, nil
+}
@@ -3,14 +3,19 @@ package redshift
 import (
 	"context"
 	"database/sql"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
 	_ "github.com/lib/pq"
 	"github.com/thesaas-company/xray/config"
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/governor"
+	"github.com/thesaas-company/xray/registry"
+	"github.com/thesaas-company/xray/snapshot"
 	"github.com/thesaas-company/xray/types"
 )
 
@@ -18,10 +23,74 @@ import (
 var DB_PASSWORD = "DB_PASSWORD"
 
 // Redshift_Schema_query is the SQL query used to describe a table schema in Redshift.
+// It reads the same catalog columns as pg_table_def, which only shows schemas on the search path.
 // Redshift_Tables_query is the SQL query used to list all tables in a schema in Redshift.
+// Redshift_Schemas_query is the SQL query used to list the user schemas of a database in Redshift.
 const (
-	Redshift_Schema_query = `SELECT "column", type, encoding, distkey, sortkey, "notnull"  FROM pg_table_def WHERE schemaname = '%s' AND tablename = '%s';`
-	Redshift_Tables_query = "SHOW TABLES FROM SCHEMA %s.public;"
+	Redshift_Schema_query = `SELECT a.attname, format_type(a.atttypid, a.atttypmod), format_encoding(a.attencodingtype::integer), a.attisdistkey, a.attsortkeyord, a.attnotnull
+	FROM pg_attribute a
+	JOIN pg_class c ON c.oid = a.attrelid
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	WHERE n.nspname = $1 AND c.relname = $2 AND a.attnum > 0 AND NOT a.attisdropped
+	ORDER BY a.attnum;`
+	Redshift_Tables_query  = "SHOW TABLES FROM SCHEMA %s.%s;"
+	Redshift_Schemas_query = `SELECT schema_name FROM svv_all_schemas
+	WHERE database_name = $1 AND schema_name NOT IN ('information_schema', 'pg_catalog', 'pg_internal', 'pg_automv') AND schema_name NOT LIKE 'pg\_temp%'
+	ORDER BY schema_name;`
+	// Redshift_Foreign_Keys_query is the SQL query used to list the foreign key columns of a table in Redshift.
+	// Redshift accepts but does not enforce foreign keys, so these are the declared constraints.
+	Redshift_Foreign_Keys_query = `SELECT kcu.constraint_name, kcu.column_name, ref.table_schema, ref.table_name, ref.column_name, rc.delete_rule, rc.update_rule
+	FROM information_schema.table_constraints tc
+	JOIN information_schema.key_column_usage kcu ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name
+	JOIN information_schema.referential_constraints rc ON rc.constraint_schema = tc.constraint_schema AND rc.constraint_name = tc.constraint_name
+	JOIN information_schema.key_column_usage ref ON ref.constraint_schema = rc.unique_constraint_schema AND ref.constraint_name = rc.unique_constraint_name AND ref.ordinal_position = kcu.ordinal_position
+	WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = $1 AND tc.table_name = $2
+	ORDER BY kcu.constraint_name, kcu.ordinal_position;`
+	// Redshift_Comments_query is the SQL query used to read the comments of a table and its columns in Redshift.
+	// The table comment is returned with an empty column name.
+	Redshift_Comments_query = `SELECT COALESCE(a.attname, ''), d.description
+	FROM pg_description d
+	JOIN pg_class c ON c.oid = d.objoid
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	LEFT JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum = d.objsubid AND d.objsubid > 0
+	WHERE n.nspname = $1 AND c.relname = $2;`
+	// Redshift_Table_Stats_query is the SQL query used to read the row count and size of a table in Redshift.
+	// SVV_TABLE_INFO reports the size in 1 MB blocks and leaves out tables without data.
+	Redshift_Table_Stats_query = `SELECT COALESCE(tbl_rows, 0)::bigint, COALESCE(size, 0)::bigint * 1048576 FROM svv_table_info WHERE "schema" = $1 AND "table" = $2;`
+	// Redshift_List_Tables_query is the SQL query used to list the tables and views of a schema in Redshift with the view definitions.
+	// The backing tables of materialized views are left out, the views themselves are listed.
+	Redshift_List_Tables_query = `SELECT c.relname, n.nspname, CASE WHEN c.relkind = 'v' THEN 'view' ELSE 'table' END, CASE WHEN c.relkind = 'v' THEN pg_get_viewdef(c.oid, true) ELSE '' END
+	FROM pg_class c
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	WHERE n.nspname = $1 AND c.relkind IN ('r', 'v') AND c.relname NOT LIKE 'mv\_tbl\_\_%'
+	ORDER BY c.relname;`
+	// Redshift_Materialized_Views_query is the SQL query used to list the materialized views of a schema in Redshift.
+	Redshift_Materialized_Views_query = `SELECT name FROM svv_mv_info WHERE database_name = $1 AND schema_name = $2;`
+	// Redshift_External_Tables_query is the SQL query used to list the Redshift Spectrum external tables of a schema.
+	Redshift_External_Tables_query = `SELECT tablename, schemaname FROM svv_external_tables WHERE redshift_database_name = $1 AND schemaname = $2 ORDER BY tablename;`
+	// Redshift_Snapshot_Columns_query is the SQL query used to read the columns of every table and view of a schema in Redshift at once.
+	Redshift_Snapshot_Columns_query = `SELECT c.relname, a.attname, format_type(a.atttypid, a.atttypmod), format_encoding(a.attencodingtype::integer), a.attisdistkey, a.attsortkeyord, a.attnotnull
+	FROM pg_attribute a
+	JOIN pg_class c ON c.oid = a.attrelid
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	WHERE n.nspname = $1 AND c.relkind IN ('r', 'v') AND a.attnum > 0 AND NOT a.attisdropped
+	ORDER BY c.relname, a.attnum;`
+	// Redshift_Snapshot_Foreign_Keys_query is the SQL query used to list the foreign key columns of every table of a schema in Redshift at once.
+	Redshift_Snapshot_Foreign_Keys_query = `SELECT tc.table_name, kcu.constraint_name, kcu.column_name, ref.table_schema, ref.table_name, ref.column_name, rc.delete_rule, rc.update_rule
+	FROM information_schema.table_constraints tc
+	JOIN information_schema.key_column_usage kcu ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name
+	JOIN information_schema.referential_constraints rc ON rc.constraint_schema = tc.constraint_schema AND rc.constraint_name = tc.constraint_name
+	JOIN information_schema.key_column_usage ref ON ref.constraint_schema = rc.unique_constraint_schema AND ref.constraint_name = rc.unique_constraint_name AND ref.ordinal_position = kcu.ordinal_position
+	WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = $1
+	ORDER BY tc.table_name, kcu.constraint_name, kcu.ordinal_position;`
+	// Redshift_Snapshot_Comments_query is the SQL query used to read the comments of every table and column of a schema in Redshift at once.
+	// Table comments are returned with an empty column name.
+	Redshift_Snapshot_Comments_query = `SELECT c.relname, COALESCE(a.attname, ''), d.description
+	FROM pg_description d
+	JOIN pg_class c ON c.oid = d.objoid
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	LEFT JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum = d.objsubid AND d.objsubid > 0
+	WHERE n.nspname = $1;`
 )
 
 // Redshift is a Redshift implementation of the ISQL interface.
@@ -30,8 +99,15 @@ type Redshift struct {
 	Config config.Config
 }
 
+func init() {
+	registry.Register(types.Redshift.String(), registry.Factory{
+		New:           NewRedshift,
+		NewWithConfig: NewRedshiftWithConfig,
+	})
+}
+
 // NewRedshift creates a new Redshift client with the given sql.DB.
-func NewRedshift(client *sql.DB) (types.ISQL, error) {
+func NewRedshift(client *sql.DB) (types.ISQLContext, error) {
 	return &Redshift{
 		Client: client,
 		Config: config.Config{},
@@ -41,7 +117,7 @@ func NewRedshift(client *sql.DB) (types.ISQL, error) {
 // NewRedshiftWithConfig creates a new Redshift client with the given configuration.
 // It returns an error if the DB_PASSWORD environment variable is not set.
 // It uses the postgres driver to connect to the database.
-func NewRedshiftWithConfig(cfg *config.Config) (types.ISQL, error) {
+func NewRedshiftWithConfig(cfg *config.Config) (types.ISQLContext, error) {
 	if os.Getenv(DB_PASSWORD) == "" || len(os.Getenv(DB_PASSWORD)) == 0 {
 		return nil, fmt.Errorf("please set %s env variable for the database", DB_PASSWORD)
 	}
@@ -62,18 +138,33 @@ func NewRedshiftWithConfig(cfg *config.Config) (types.ISQL, error) {
 // Schema returns the schema of a table in Redshift.
 // It takes the table name as an argument and returns a Table struct and an error.
 func (r *Redshift) Schema(table string) (types.Table, error) {
-	if len(r.Config.Schema) == 0 {
-		r.Config.Schema = "public"
+	return r.SchemaContext(context.Background(), table)
+}
+
+// SchemaContext returns the schema of a table in Redshift.
+// The query is cancelled when the context is done.
+func (r *Redshift) SchemaContext(ctx context.Context, table string) (types.Table, error) {
+	name := types.ParseTableName(table)
+	if name.Catalog != "" && r.Config.Database != "" && name.Catalog != r.Config.Database {
+		return types.Table{}, fmt.Errorf("table %s is not in the connected database %s", name, r.Config.Database)
+	}
+	schema := name.Schema
+	if schema == "" {
+		schema = r.schema()
 	}
 
-	query := fmt.Sprintf(Redshift_Schema_query, r.Config.Schema, table)
-	ctx := context.Background()
-	rows, err := r.Client.QueryContext(ctx, query)
+	rows, err := r.Client.QueryContext(ctx, Redshift_Schema_query, schema, name.Name)
 	if err != nil {
 		return types.Table{}, fmt.Errorf("error executing query: %v", err)
 	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
 
 	var columns []types.Column
+	var sortKeys []sortKeyColumn
 	for rows.Next() {
 		var column types.Column
 		var encoding string
@@ -92,29 +183,187 @@ func (r *Redshift) Schema(table string) (types.Table, error) {
 		}
 		column.Metatags = []string{encoding, fmt.Sprintf("distkey:%v", distkey), fmt.Sprintf("sortkey:%d", sortkey), fmt.Sprintf("notnull:%v", notnull)}
 		columns = append(columns, column)
+		if sortkey != 0 {
+			sortKeys = append(sortKeys, sortKeyColumn{name: column.Name, position: sortkey})
+		}
 	}
 
 	if err := rows.Err(); err != nil {
 		return types.Table{}, fmt.Errorf("error iterating over rows: %v", err)
 	}
 
+	foreignKeys, err := r.foreignKeys(ctx, schema, name.Name)
+	if err != nil {
+		return types.Table{}, err
+	}
+
+	// Redshift has no indexes, the sort key plays their role
+	indexes := sortKeyIndexes(sortKeys)
+	types.ApplyIndexes(columns, indexes)
+
+	comments, err := r.comments(ctx, schema, name.Name)
+	if err != nil {
+		return types.Table{}, err
+	}
+	description := types.ApplyComments(columns, comments)
+
 	return types.Table{
-		Name:        table,
+		Name:        name.Name,
+		Catalog:     r.Config.Database,
+		Schema:      schema,
 		Columns:     columns,
 		ColumnCount: int64(len(columns)),
-		Description: "",
+		Description: description,
 		Metatags:    []string{},
+		ForeignKeys: foreignKeys,
+		Indexes:     indexes,
 	}, nil
 }
 
+// TableStats returns the row count and size of a table in Redshift.
+func (r *Redshift) TableStats(table string) (types.TableStats, error) {
+	return r.TableStatsContext(context.Background(), table)
+}
+
+// TableStatsContext returns the row count and size of a table in Redshift.
+// Tables without data are missing from SVV_TABLE_INFO and are reported as empty.
+// Redshift keeps no DDL or modification times in its catalog, so those are left unset.
+// The query is cancelled when the context is done.
+func (r *Redshift) TableStatsContext(ctx context.Context, table string) (types.TableStats, error) {
+	name := types.ParseTableName(table)
+	if name.Catalog != "" && r.Config.Database != "" && name.Catalog != r.Config.Database {
+		return types.TableStats{}, fmt.Errorf("table %s is not in the connected database %s", name, r.Config.Database)
+	}
+	schema := name.Schema
+	if schema == "" {
+		schema = r.schema()
+	}
+
+	stats := types.TableStats{Name: name.Name, Schema: schema}
+	row := r.Client.QueryRowContext(ctx, Redshift_Table_Stats_query, schema, name.Name)
+	if err := row.Scan(&stats.RowCount, &stats.Bytes); err != nil && err != sql.ErrNoRows {
+		return types.TableStats{}, fmt.Errorf("error reading table statistics: %v", err)
+	}
+	return stats, nil
+}
+
+// comments retrieves the comments of the given table and its columns.
+func (r *Redshift) comments(ctx context.Context, schema, table string) ([]types.Comment, error) {
+	rows, err := r.Client.QueryContext(ctx, Redshift_Comments_query, schema, table)
+	if err != nil {
+		return nil, fmt.Errorf("error executing comment query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var comments []types.Comment
+	for rows.Next() {
+		var c types.Comment
+		if err := rows.Scan(&c.Column, &c.Text); err != nil {
+			return nil, fmt.Errorf("error scanning comments: %v", err)
+		}
+		comments = append(comments, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over comments: %v", err)
+	}
+	return comments, nil
+}
+
+// schema returns the configured schema, defaulting to public.
+func (r *Redshift) schema() string {
+	if r.Config.Schema == "" {
+		return "public"
+	}
+	return r.Config.Schema
+}
+
+// sortKeyColumn is a column of a Redshift sort key with its position as reported by pg_table_def.
+type sortKeyColumn struct {
+	name     string
+	position int
+}
+
+// sortKeyIndexes returns the sort key of a table as an index.
+// Compound sort keys have positive positions, interleaved sort keys alternate between positive and negative ones.
+func sortKeyIndexes(columns []sortKeyColumn) []types.Index {
+	if len(columns) == 0 {
+		return []types.Index{}
+	}
+
+	method := "compound"
+	for _, c := range columns {
+		if c.position < 0 {
+			method = "interleaved"
+		}
+	}
+	sort.SliceStable(columns, func(i, j int) bool {
+		return abs(columns[i].position) < abs(columns[j].position)
+	})
+
+	index := types.Index{Name: "sortkey", Columns: make([]string, len(columns)), Method: method}
+	for i, c := range columns {
+		index.Columns[i] = c.name
+	}
+	return []types.Index{index}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// foreignKeys retrieves the foreign key constraints declared on the given table.
+func (r *Redshift) foreignKeys(ctx context.Context, schema, table string) ([]types.ForeignKey, error) {
+	rows, err := r.Client.QueryContext(ctx, Redshift_Foreign_Keys_query, schema, table)
+	if err != nil {
+		return nil, fmt.Errorf("error executing foreign key query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var columns []types.ForeignKeyColumn
+	for rows.Next() {
+		var c types.ForeignKeyColumn
+		if err := rows.Scan(&c.Constraint, &c.Column, &c.ReferencedSchema, &c.ReferencedTable, &c.ReferencedColumn, &c.OnDelete, &c.OnUpdate); err != nil {
+			return nil, fmt.Errorf("error scanning foreign keys: %v", err)
+		}
+		columns = append(columns, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over foreign keys: %v", err)
+	}
+	return types.GroupForeignKeys(columns), nil
+}
+
+// Tables returns a list of tables in the configured schema (public by default) of a Redshift database.
+// It takes the database name as an argument and returns a slice of table names.
 func (r *Redshift) Tables(databaseName string) ([]string, error) {
-	// ctx := context.Background()
-	query := fmt.Sprintf(Redshift_Tables_query, databaseName)
+	return r.TablesContext(context.Background(), databaseName)
+}
+
+// TablesContext returns a list of tables in the public schema of a Redshift database.
+// The query is cancelled when the context is done.
+func (r *Redshift) TablesContext(ctx context.Context, databaseName string) ([]string, error) {
+	query := fmt.Sprintf(Redshift_Tables_query, dialect.QuoteInputIdentifier(types.Redshift, databaseName), dialect.QuoteInputIdentifier(types.Redshift, r.schema()))
 
-	res, err := r.Client.Query(query)
+	res, err := r.Client.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("error executing query: %v", err)
 	}
+	defer func() {
+		if err := res.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
 
 	var tables []string
 
@@ -136,73 +385,178 @@ func (r *Redshift) Tables(databaseName string) ([]string, error) {
 
 }
 
-// Execute executes a query on Redshift.
-// It takes a query string as input and returns the result as a byte slice and an error.
-func (r *Redshift) Execute(query string) ([]byte, error) {
-	ctx := context.Background()
-	rows, err := r.Client.QueryContext(ctx, query)
+// ListTables returns the tables, views, materialized views and Spectrum external tables of the configured schema.
+// Late binding views have no recorded dependencies, so the dependencies are read from the view definitions.
+// The query is cancelled when the context is done.
+func (r *Redshift) ListTables(ctx context.Context, databaseName string, opts types.ListTablesOptions) ([]types.TableInfo, error) {
+	tables, err := r.listTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Includes(types.KindView) || opts.Includes(types.KindMaterializedView) {
+		views, err := r.materializedViews(ctx, databaseName)
+		if err != nil {
+			return nil, err
+		}
+		for i := range tables {
+			if tables[i].Kind == types.KindView && views[tables[i].Name] {
+				tables[i].Kind = types.KindMaterializedView
+			}
+		}
+	}
+
+	if opts.Includes(types.KindExternalTable) {
+		external, err := r.externalTables(ctx, databaseName)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, external...)
+	}
+
+	tables = types.FilterTables(tables, opts)
+	dialect.ApplyViewReferences(types.Redshift, tables)
+	return tables, nil
+}
+
+// listTables retrieves the local tables and views of the configured schema.
+func (r *Redshift) listTables(ctx context.Context) ([]types.TableInfo, error) {
+	rows, err := r.Client.QueryContext(ctx, Redshift_List_Tables_query, r.schema())
 	if err != nil {
 		return nil, fmt.Errorf("error executing query: %v", err)
 	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var tables []types.TableInfo
+	for rows.Next() {
+		var table types.TableInfo
+		if err := rows.Scan(&table.Name, &table.Schema, &table.Kind, &table.Definition); err != nil {
+			return nil, fmt.Errorf("error scanning result: %v", err)
+		}
+		tables = append(tables, table)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over result: %v", err)
+	}
+
+	return tables, nil
+}
 
-	// getting the column names
-	columns, err := rows.Columns()
+// materializedViews retrieves the names of the materialized views of the configured schema.
+func (r *Redshift) materializedViews(ctx context.Context, databaseName string) (map[string]bool, error) {
+	rows, err := r.Client.QueryContext(ctx, Redshift_Materialized_Views_query, databaseName, r.schema())
 	if err != nil {
-		return nil, fmt.Errorf("error getting columns: %v", err)
+		return nil, fmt.Errorf("error executing materialized view query: %v", err)
 	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
 
-	// Scan the result into a slice of slices
-	var results [][]interface{}
+	views := map[string]bool{}
 	for rows.Next() {
-		// create a slice of values and pointers
-		values := make([]interface{}, len(columns))
-		pointers := make([]interface{}, len(columns))
-		for i := range values {
-			//  create a slice of pointers to the values
-			pointers[i] = &values[i]
-		}
-
-		if err := rows.Scan(pointers...); err != nil {
-			return nil, fmt.Errorf("error scanning row: %v", err)
-		}
-
-		// Decode base64 data
-		stringRow := make([]interface{}, len(values))
-		for i, val := range values {
-			switch v := val.(type) {
-			case []byte:
-				strVal := string(v)
-				if isBase64(strVal) {
-					decoded, err := base64.StdEncoding.DecodeString(strVal)
-					if err != nil {
-						return nil, fmt.Errorf("error decoding base64 data: %v", err)
-					}
-					stringRow[i] = string(decoded)
-				} else {
-					stringRow[i] = strVal
-				}
-			case string:
-				stringRow[i] = v
-			case nil:
-				stringRow[i] = nil
-			default:
-				stringRow[i] = fmt.Sprintf("%v", v)
-			}
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("error scanning materialized views: %v", err)
 		}
-		results = append(results, stringRow)
+		views[name] = true
+	}
 
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over materialized views: %v", err)
+	}
+
+	return views, nil
+}
+
+// externalTables retrieves the Spectrum external tables of the configured schema.
+func (r *Redshift) externalTables(ctx context.Context, databaseName string) ([]types.TableInfo, error) {
+	rows, err := r.Client.QueryContext(ctx, Redshift_External_Tables_query, databaseName, r.schema())
+	if err != nil {
+		return nil, fmt.Errorf("error executing external table query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var tables []types.TableInfo
+	for rows.Next() {
+		table := types.TableInfo{Kind: types.KindExternalTable}
+		if err := rows.Scan(&table.Name, &table.Schema); err != nil {
+			return nil, fmt.Errorf("error scanning external tables: %v", err)
+		}
+		tables = append(tables, table)
 	}
 
-	// Check for errors from iterating over rows
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %v", err)
+		return nil, fmt.Errorf("error iterating over external tables: %v", err)
 	}
 
-	// Convert the result to JSON
-	queryResult := types.QueryResult{
-		Columns: columns,
-		Rows:    results,
+	return tables, nil
+}
+
+// Schemas returns the user schemas of a Redshift database.
+// The query is cancelled when the context is done.
+func (r *Redshift) Schemas(ctx context.Context, databaseName string) ([]string, error) {
+	rows, err := r.Client.QueryContext(ctx, Redshift_Schemas_query, databaseName)
+	if err != nil {
+		return nil, fmt.Errorf("error executing query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var schemas []string
+	for rows.Next() {
+		var schema string
+		if err := rows.Scan(&schema); err != nil {
+			return nil, fmt.Errorf("error scanning result: %v", err)
+		}
+		schemas = append(schemas, schema)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over result: %v", err)
+	}
+
+	return schemas, nil
+}
+
+// Execute executes a query on Redshift.
+// It takes a query string as input and returns the result as a byte slice and an error.
+func (r *Redshift) Execute(query string) ([]byte, error) {
+	return r.ExecuteContext(context.Background(), query)
+}
+
+// ExecuteContext executes a query on Redshift.
+// The query is cancelled when the context is done.
+func (r *Redshift) ExecuteContext(ctx context.Context, query string, args ...interface{}) ([]byte, error) {
+	return r.ExecutePage(ctx, query, "", args...)
+}
+
+// ExecutePage executes the given SQL query and returns the page of its result selected by the page token as JSON,
+// the first page for an empty token. The page is limited by the MaxRows and MaxBytes of the configuration,
+// the NextPageToken of a truncated result selects the next page.
+func (r *Redshift) ExecutePage(ctx context.Context, query, pageToken string, args ...interface{}) ([]byte, error) {
+	start := time.Now()
+	limits := governor.Limits{MaxRows: r.Config.MaxRows, MaxBytes: r.Config.MaxBytes}
+	queryResult, err := governor.Execute(ctx, r, types.Redshift, limits, query, pageToken, args...)
+	if err != nil {
+		return nil, err
 	}
+	queryResult.Time = time.Since(start).Milliseconds()
+
+	// Convert the result to JSON
 	jsonData, err := json.Marshal(queryResult)
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling json: %v", err)
@@ -211,25 +565,188 @@ func (r *Redshift) Execute(query string) ([]byte, error) {
 	return jsonData, nil
 }
 
-func isBase64(s string) bool {
-	if len(s)%4 != 0 {
-		return false
+// Query executes a query on Redshift and returns an iterator over its rows.
+// The rows are read from the database as the iterator advances.
+func (r *Redshift) Query(ctx context.Context, query string, args ...interface{}) (types.RowIterator, error) {
+	query, args, err := dialect.Bind(types.Redshift, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error binding query arguments: %v", err)
+	}
+	if r.Config.ReadOnly {
+		// writes hidden in functions are rejected by the read-only transaction
+		if err := dialect.CheckReadOnly(types.Redshift, query); err != nil {
+			return nil, err
+		}
+		return types.QueryReadOnly(ctx, r.Client, newValueConverter(types.BinaryEncoding(r.Config.BinaryEncoding)), query, args...)
+	}
+	rows, err := r.Client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing query: %v", err)
+	}
+
+	it, err := types.NewRowIterator(rows, newValueConverter(types.BinaryEncoding(r.Config.BinaryEncoding)))
+	if err != nil {
+		_ = rows.Close()
+		return nil, err
 	}
-	// Try to decode the string
-    _, err := base64.StdEncoding.DecodeString(s)
-    // If decoding succeeds, err will be nil, and the function will return true
-    // If decoding fails, err will not be nil, and the function will return false
-	// Also we do not have access to decoded value, so we are not using it
-	return err == nil
+	return it, nil
+}
+
+// SetReadOnly enables or disables the read-only mode of the client, see config.Config.ReadOnly.
+func (r *Redshift) SetReadOnly(readOnly bool) {
+	r.Config.ReadOnly = readOnly
+}
+
+// Snapshot reads the schema of every table of the given database matching the options;
+// the columns, sort keys, foreign keys and comments of the configured schema are read in bulk from the catalog.
+func (r *Redshift) Snapshot(ctx context.Context, databaseName string, opts types.SnapshotOptions) (types.DatabaseSchema, error) {
+	return snapshot.Take(ctx, r, types.Redshift, databaseName, opts)
+}
+
+// BulkSchemas reads the schemas of the given tables of the configured schema with three queries in total,
+// instead of three queries per table. External tables have no columns in the catalog of the database.
+func (r *Redshift) BulkSchemas(ctx context.Context, databaseName string, tables []types.TableInfo) ([]types.Table, error) {
+	schema := r.schema()
+	rows, err := r.Client.QueryContext(ctx, Redshift_Snapshot_Columns_query, schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	columns := map[string][]types.Column{}
+	sortKeys := map[string][]sortKeyColumn{}
+	for rows.Next() {
+		var table string
+		var column types.Column
+		var encoding string
+		var distkey bool
+		var sortkey int
+		var notnull bool
+		if err := rows.Scan(&table, &column.Name, &column.Type, &encoding, &distkey, &sortkey, &notnull); err != nil {
+			return nil, fmt.Errorf("error scanning rows: %v", err)
+		}
+		column.Metatags = []string{encoding, fmt.Sprintf("distkey:%v", distkey), fmt.Sprintf("sortkey:%d", sortkey), fmt.Sprintf("notnull:%v", notnull)}
+		columns[table] = append(columns[table], column)
+		if sortkey != 0 {
+			sortKeys[table] = append(sortKeys[table], sortKeyColumn{name: column.Name, position: sortkey})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %v", err)
+	}
+
+	foreignKeys, err := r.schemaForeignKeys(ctx, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := r.schemaComments(ctx, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.Table, len(tables))
+	for i, t := range tables {
+		tableColumns := columns[t.Name]
+		indexes := sortKeyIndexes(sortKeys[t.Name])
+		types.ApplyIndexes(tableColumns, indexes)
+		description := types.ApplyComments(tableColumns, comments[t.Name])
+
+		result[i] = types.Table{
+			Name:        t.Name,
+			Catalog:     r.Config.Database,
+			Schema:      t.Schema,
+			Columns:     tableColumns,
+			ColumnCount: int64(len(tableColumns)),
+			Description: description,
+			Metatags:    []string{},
+			ForeignKeys: types.GroupForeignKeys(foreignKeys[t.Name]),
+			Indexes:     indexes,
+		}
+	}
+	return result, nil
+}
+
+// schemaForeignKeys retrieves the foreign key columns of every table of a schema, keyed by table name.
+func (r *Redshift) schemaForeignKeys(ctx context.Context, schema string) (map[string][]types.ForeignKeyColumn, error) {
+	rows, err := r.Client.QueryContext(ctx, Redshift_Snapshot_Foreign_Keys_query, schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing foreign key query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	columns := map[string][]types.ForeignKeyColumn{}
+	for rows.Next() {
+		var table string
+		var c types.ForeignKeyColumn
+		if err := rows.Scan(&table, &c.Constraint, &c.Column, &c.ReferencedSchema, &c.ReferencedTable, &c.ReferencedColumn, &c.OnDelete, &c.OnUpdate); err != nil {
+			return nil, fmt.Errorf("error scanning foreign keys: %v", err)
+		}
+		columns[table] = append(columns[table], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over foreign keys: %v", err)
+	}
+	return columns, nil
+}
+
+// schemaComments retrieves the comments of every table and column of a schema, keyed by table name.
+func (r *Redshift) schemaComments(ctx context.Context, schema string) (map[string][]types.Comment, error) {
+	rows, err := r.Client.QueryContext(ctx, Redshift_Snapshot_Comments_query, schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing comment query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	comments := map[string][]types.Comment{}
+	for rows.Next() {
+		var table string
+		var c types.Comment
+		if err := rows.Scan(&table, &c.Column, &c.Text); err != nil {
+			return nil, fmt.Errorf("error scanning comments: %v", err)
+		}
+		comments[table] = append(comments[table], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over comments: %v", err)
+	}
+	return comments, nil
+}
+
+// quoteTableName quotes a table name qualified with the configured database and the given schema, or the
+// configured schema when it is empty, leaving out the parts that are not known.
+func (r *Redshift) quoteTableName(schema, table string) string {
+	if schema == "" {
+		// the configured schema is written as it would be unquoted
+		schema = dialect.FoldIdentifier(types.Redshift, r.Config.Schema)
+	}
+	return dialect.QuoteTableName(types.Redshift, types.TableName{
+		Catalog: dialect.FoldIdentifier(types.Redshift, r.Config.Database),
+		Schema:  schema,
+		Name:    table,
+	})
 }
 
 // GenerateCreateTableQuery generates a CREATE TABLE query for Redshift.
 // It takes a Table struct as an argument and returns a string.
 func (r *Redshift) GenerateCreateTableQuery(table types.Table) string {
-	query := fmt.Sprintf("CREATE TABLE %s.%s.%s (", r.Config.Database, r.Config.Schema, table.Name)
+	name := r.quoteTableName("", table.Name)
+	query := "CREATE TABLE " + name + " ("
 	for i, column := range table.Columns {
 		colType := strings.ToUpper(column.Type)
-		query += column.Name + " " + convertTypeToRedshift(colType)
+		query += dialect.QuoteIdentifier(types.Redshift, column.Name) + " " + convertTypeToRedshift(colType)
 
 		if column.IsPrimary {
 			query += " PRIMARY KEY"
@@ -247,6 +764,16 @@ func (r *Redshift) GenerateCreateTableQuery(table types.Table) string {
 		}
 	}
 	query += ");"
+
+	// comments are set with separate statements
+	if table.Description != "" {
+		query += " COMMENT ON TABLE " + name + " IS " + dialect.QuoteString(types.Redshift, table.Description) + ";"
+	}
+	for _, column := range table.Columns {
+		if column.Description != "" {
+			query += " COMMENT ON COLUMN " + name + "." + dialect.QuoteIdentifier(types.Redshift, column.Name) + " IS " + dialect.QuoteString(types.Redshift, column.Description) + ";"
+		}
+	}
 	return query
 }
 
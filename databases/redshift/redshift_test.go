@@ -1,6 +1,7 @@
 package redshift
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/thesaas-company/xray/config"
 	"github.com/thesaas-company/xray/types"
 )
 
@@ -40,8 +42,14 @@ func TestSchema(t *testing.T) {
 	columns := []string{"column", "type", "encoding", "distkey", "sortkey", "notnull"}
 	mockRows := sqlmock.NewRows(columns).AddRow("id", "int", "utf8", true, 1, true)
 	// set the expected return values for the query
-	expectedQuery := fmt.Sprintf(Redshift_Schema_query, "public", table_name)
-	mock.ExpectQuery(regexp.QuoteMeta(expectedQuery)).WillReturnRows(mockRows)
+	mock.ExpectQuery(regexp.QuoteMeta(Redshift_Schema_query)).WithArgs("public", table_name).WillReturnRows(mockRows)
+	fkRows := sqlmock.NewRows([]string{"constraint_name", "column_name", "referenced_schema", "referenced_table", "referenced_column", "on_delete", "on_update"}).
+		AddRow("user_team_fkey", "team_id", "public", "team", "id", "NO ACTION", "NO ACTION")
+	mock.ExpectQuery(regexp.QuoteMeta(Redshift_Foreign_Keys_query)).WithArgs("public", table_name).WillReturnRows(fkRows)
+	commentRows := sqlmock.NewRows([]string{"column_name", "description"}).
+		AddRow("", "Registered users").
+		AddRow("id", "Surrogate key")
+	mock.ExpectQuery(regexp.QuoteMeta(Redshift_Comments_query)).WithArgs("public", table_name).WillReturnRows(commentRows)
 
 	// we then create a new instance of our Redshift object and test the function
 	r, err := NewRedshift(db)
@@ -53,6 +61,32 @@ func TestSchema(t *testing.T) {
 		t.Errorf("error executing query : %v", err)
 	}
 
+	wantForeignKeys := []types.ForeignKey{{
+		Name:              "user_team_fkey",
+		Columns:           []string{"team_id"},
+		ReferencedSchema:  "public",
+		ReferencedTable:   "team",
+		ReferencedColumns: []string{"id"},
+		OnDelete:          "NO ACTION",
+		OnUpdate:          "NO ACTION",
+	}}
+	if !reflect.DeepEqual(response.ForeignKeys, wantForeignKeys) {
+		t.Errorf("foreign keys = %+v, want %+v", response.ForeignKeys, wantForeignKeys)
+	}
+	if response.Description != "Registered users" || response.Columns[0].Description != "Surrogate key" {
+		t.Errorf("descriptions = %q, %q", response.Description, response.Columns[0].Description)
+	}
+
+	wantIndexes := []types.Index{
+		{Name: "sortkey", Columns: []string{"id"}, Method: "compound"},
+	}
+	if !reflect.DeepEqual(response.Indexes, wantIndexes) {
+		t.Errorf("indexes = %+v, want %+v", response.Indexes, wantIndexes)
+	}
+	if column := response.Columns[0]; column.IsIndex != true || column.IsUnique.String != "NO" {
+		t.Errorf("column %s: IsIndex = %v, IsUnique = %q", column.Name, column.IsIndex, column.IsUnique.String)
+	}
+
 	fmt.Printf("Table schema: %+v\n", response)
 
 	if err := mock.ExpectationsWereMet(); err != nil {
@@ -117,7 +151,7 @@ func TestTables(t *testing.T) {
 		AddRow(DatabaseName, "public", tableList[0], "BASE TABLE", nil, nil).
 		AddRow(DatabaseName, "public", tableList[1], "BASE TABLE", nil, nil).
 		AddRow(DatabaseName, "public", tableList[2], "BASE TABLE", nil, nil)
-	expectedQuery := fmt.Sprintf(Redshift_Tables_query, DatabaseName)
+	expectedQuery := fmt.Sprintf(Redshift_Tables_query, `"`+DatabaseName+`"`, `"public"`)
 	mock.ExpectQuery(regexp.QuoteMeta(expectedQuery)).WillReturnRows(rows)
 
 	r, err := NewRedshift(db) // create a new instance of our Postgres object
@@ -139,6 +173,86 @@ func TestTables(t *testing.T) {
 	}
 }
 
+// TestListTables is a unit test function that tests the ListTables method of the Redshift struct.
+// It checks that materialized views and Spectrum external tables are told apart and that view dependencies are filled in.
+func TestListTables(t *testing.T) {
+	db, mock := MockDB() // create a new mock database connection
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	rows := sqlmock.NewRows([]string{"relname", "nspname", "kind", "definition"}).
+		AddRow("orders", "public", "table", "").
+		AddRow("daily_orders", "public", "view", " SELECT orders.day, count(*) AS count FROM orders GROUP BY orders.day;").
+		AddRow("recent_orders", "public", "view", " SELECT * FROM public.orders o JOIN spectrum.events e ON e.order_id = o.id;")
+	mock.ExpectQuery(regexp.QuoteMeta(Redshift_List_Tables_query)).WithArgs("public").WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(Redshift_Materialized_Views_query)).WithArgs("dev", "public").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("daily_orders"))
+	mock.ExpectQuery(regexp.QuoteMeta(Redshift_External_Tables_query)).WithArgs("dev", "public").
+		WillReturnRows(sqlmock.NewRows([]string{"tablename", "schemaname"}).AddRow("clicks", "public"))
+
+	r, err := NewRedshift(db)
+	if err != nil {
+		t.Errorf("error initialising redshift: %s", err)
+	}
+	tables, err := r.ListTables(context.Background(), "dev", types.ListTablesOptions{})
+	if err != nil {
+		t.Fatalf("error listing tables: %s", err)
+	}
+
+	expected := []types.TableInfo{
+		{Name: "orders", Schema: "public", Kind: types.KindTable},
+		{Name: "daily_orders", Schema: "public", Kind: types.KindMaterializedView, Definition: " SELECT orders.day, count(*) AS count FROM orders GROUP BY orders.day;",
+			Dependencies: []types.TableName{{Schema: "public", Name: "orders"}}},
+		{Name: "recent_orders", Schema: "public", Kind: types.KindView, Definition: " SELECT * FROM public.orders o JOIN spectrum.events e ON e.order_id = o.id;",
+			Dependencies: []types.TableName{{Schema: "public", Name: "orders"}, {Schema: "spectrum", Name: "events"}}},
+		{Name: "clicks", Schema: "public", Kind: types.KindExternalTable},
+	}
+	if !reflect.DeepEqual(tables, expected) {
+		t.Errorf("expected: %+v, got: %+v", expected, tables)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestListTablesKinds is a unit test function that tests filtering the ListTables method of the Redshift struct by kind.
+// Only the queries needed for the requested kinds are run.
+func TestListTablesKinds(t *testing.T) {
+	db, mock := MockDB() // create a new mock database connection
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	rows := sqlmock.NewRows([]string{"relname", "nspname", "kind", "definition"}).
+		AddRow("orders", "public", "table", "").
+		AddRow("recent_orders", "public", "view", " SELECT * FROM orders;")
+	mock.ExpectQuery(regexp.QuoteMeta(Redshift_List_Tables_query)).WithArgs("public").WillReturnRows(rows)
+
+	r, err := NewRedshift(db)
+	if err != nil {
+		t.Errorf("error initialising redshift: %s", err)
+	}
+	tables, err := r.ListTables(context.Background(), "dev", types.ListTablesOptions{Kinds: []types.TableKind{types.KindTable}})
+	if err != nil {
+		t.Fatalf("error listing tables: %s", err)
+	}
+
+	expected := []types.TableInfo{{Name: "orders", Schema: "public", Kind: types.KindTable}}
+	if !reflect.DeepEqual(tables, expected) {
+		t.Errorf("expected: %+v, got: %+v", expected, tables)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 // TestGenerateCreateTableQuery is a unit test function that tests the GenerateCreateTableQuery method of the Redshift struct.
 // It creates a mock instance of Redshift, sets the expected return values, and calls the method under test.
 // It then asserts the expected return values and checks if the method was called with the correct arguments.
@@ -183,7 +297,7 @@ func TestGenerateCreateTableQuery(t *testing.T) {
 
 	fmt.Printf("Create table query: %v\n", query)
 
-	expectedQuery := `CREATE TABLE ..user (id INTEGER PRIMARY KEY IDENTITY({0 false}, {0 false}) NOT NULL, name VARCHAR(255) NOT NULL, age INTEGER);`
+	expectedQuery := `CREATE TABLE "user" ("id" INTEGER PRIMARY KEY IDENTITY({0 false}, {0 false}) NOT NULL, "name" VARCHAR(255) NOT NULL, "age" INTEGER);`
 	if query != expectedQuery {
 		t.Errorf("Expected '%s', but got '%s'", expectedQuery, query)
 	}
@@ -193,3 +307,405 @@ func TestGenerateCreateTableQuery(t *testing.T) {
 	}
 
 }
+
+// TestGenerateCreateTableQueryComments is a unit test function that tests that the GenerateCreateTableQuery method of the
+// Redshift struct emits the table and column descriptions as COMMENT statements.
+func TestGenerateCreateTableQueryComments(t *testing.T) {
+	r := &Redshift{Config: config.Config{Database: "dev", Schema: "public"}}
+	table := types.Table{
+		Name:        "user",
+		Description: "Registered users",
+		Columns: []types.Column{
+			{Name: "id", Type: "INTEGER", Description: "Surrogate key"},
+			{Name: "age", Type: "INTEGER"},
+		},
+	}
+
+	query := r.GenerateCreateTableQuery(table)
+
+	expectedQuery := `CREATE TABLE "dev"."public"."user" ("id" INTEGER, "age" INTEGER);` +
+		` COMMENT ON TABLE "dev"."public"."user" IS 'Registered users';` +
+		` COMMENT ON COLUMN "dev"."public"."user"."id" IS 'Surrogate key';`
+	if query != expectedQuery {
+		t.Errorf("Expected '%s', but got '%s'", expectedQuery, query)
+	}
+}
+
+// TestTableStats is a unit test function that tests the TableStats method of the Redshift struct.
+// Tables without data are missing from SVV_TABLE_INFO and are reported as empty.
+func TestTableStats(t *testing.T) {
+	db, mock := MockDB() // create a new mock database connection
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectQuery(regexp.QuoteMeta(Redshift_Table_Stats_query)).WithArgs("public", "events").
+		WillReturnRows(sqlmock.NewRows([]string{"tbl_rows", "size"}).AddRow(5000000, 3*1048576))
+	mock.ExpectQuery(regexp.QuoteMeta(Redshift_Table_Stats_query)).WithArgs("public", "empty").
+		WillReturnRows(sqlmock.NewRows([]string{"tbl_rows", "size"}))
+
+	r, err := NewRedshift(db)
+	if err != nil {
+		t.Errorf("error initialising redshift: %s", err)
+	}
+
+	stats, err := r.TableStats("events")
+	if err != nil {
+		t.Fatalf("error reading table statistics: %s", err)
+	}
+	expected := types.TableStats{Name: "events", Schema: "public", RowCount: 5000000, Bytes: 3 * 1048576}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("expected: %+v, got: %+v", expected, stats)
+	}
+
+	stats, err = r.TableStats("public.empty")
+	if err != nil {
+		t.Fatalf("error reading table statistics: %s", err)
+	}
+	expected = types.TableStats{Name: "empty", Schema: "public"}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("expected: %+v, got: %+v", expected, stats)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestHostileNames is a unit test function that tests that hostile database and table names are quoted or sent as parameters.
+func TestHostileNames(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SHOW TABLES FROM SCHEMA "dev""; DROP TABLE users; --"."public";`)).
+		WillReturnError(fmt.Errorf("database does not exist"))
+	mock.ExpectQuery(regexp.QuoteMeta(Redshift_Schema_query)).WithArgs("public", "users' OR '1'='1").
+		WillReturnError(fmt.Errorf("relation does not exist"))
+
+	r := &Redshift{Client: db, Config: config.Config{}}
+	if _, err := r.Tables(`dev"; DROP TABLE users; --`); err == nil {
+		t.Error("expected an error")
+	}
+	if _, err := r.Schema("users' OR '1'='1"); err == nil {
+		t.Error("expected an error")
+	}
+	// configured names resolve like unquoted names
+	mock.ExpectQuery(regexp.QuoteMeta(`SHOW TABLES FROM SCHEMA "dev"."sales";`)).
+		WillReturnError(fmt.Errorf("schema does not exist"))
+	r.Config.Schema = "Sales"
+	if _, err := r.Tables("Dev"); err == nil {
+		t.Error("expected an error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestEstimate is a unit test function that tests reading the cost and row count of a query from the top node of its plan.
+func TestEstimate(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectQuery(regexp.QuoteMeta("EXPLAIN SELECT region, COUNT(*) FROM sales GROUP BY region")).
+		WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).
+			AddRow("XN HashAggregate  (cost=1250.00..1250.05 rows=20 width=12)").
+			AddRow("  ->  XN Seq Scan on sales  (cost=0.00..1000.00 rows=100000 width=12)"))
+
+	r := &Redshift{Client: db, Config: config.Config{}}
+	estimate, err := r.Estimate(context.Background(), "SELECT region, COUNT(*) FROM sales GROUP BY region")
+	if err != nil {
+		t.Fatalf("error estimating the query: %s", err)
+	}
+	if estimate.Cost != 1250.05 || estimate.Rows != 20 {
+		t.Errorf("Estimate() = %+v, want cost 1250.05 and 20 rows", estimate)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestExplain is a unit test function that tests converting the text plan of Redshift to a plan tree.
+func TestExplain(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectQuery(regexp.QuoteMeta("EXPLAIN SELECT region, COUNT(*) FROM sales GROUP BY region")).
+		WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).
+			AddRow("XN HashAggregate  (cost=1250.00..1250.05 rows=20 width=12)").
+			AddRow("  ->  XN Seq Scan on sales  (cost=0.00..1000.00 rows=100000 width=12)"))
+
+	r := &Redshift{Client: db, Config: config.Config{}}
+	plan, err := r.Explain(context.Background(), "SELECT region, COUNT(*) FROM sales GROUP BY region", false)
+	if err != nil {
+		t.Fatalf("error explaining the query: %s", err)
+	}
+	want := &types.PlanNode{Type: "XN HashAggregate", EstimatedRows: 20, EstimatedCost: 1250.05, Children: []*types.PlanNode{
+		{Type: "XN Seq Scan on sales", Relation: "sales", EstimatedRows: 100000, EstimatedCost: 1000},
+	}}
+	if !reflect.DeepEqual(plan.Root, want) {
+		t.Errorf("Explain() = %+v, want %+v", plan.Root, want)
+	}
+
+	if _, err := r.Explain(context.Background(), "SELECT 1", true); err == nil {
+		t.Error("expected an error for EXPLAIN ANALYZE")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSnapshot is a unit test function that tests that the Snapshot method of the Redshift struct reads the
+// columns, sort keys, foreign keys and comments of every table of the schema with one query each.
+func TestSnapshot(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	tableRows := sqlmock.NewRows([]string{"relname", "nspname", "kind", "definition"}).
+		AddRow("orders", "sales", "table", "").
+		AddRow("users", "sales", "table", "")
+	mock.ExpectQuery(regexp.QuoteMeta(Redshift_List_Tables_query)).WithArgs("sales").WillReturnRows(tableRows)
+	columnRows := sqlmock.NewRows([]string{"relname", "attname", "type", "encoding", "distkey", "sortkey", "notnull"}).
+		AddRow("orders", "id", "integer", "az64", true, 0, true).
+		AddRow("orders", "user_id", "integer", "az64", false, 2, true).
+		AddRow("orders", "day", "date", "az64", false, 1, false).
+		AddRow("users", "id", "integer", "az64", false, 0, true)
+	mock.ExpectQuery(regexp.QuoteMeta(Redshift_Snapshot_Columns_query)).WithArgs("sales").WillReturnRows(columnRows)
+	fkRows := sqlmock.NewRows([]string{"table_name", "constraint_name", "column_name", "ref_schema", "ref_table", "ref_column", "delete_rule", "update_rule"}).
+		AddRow("orders", "orders_user_id_fkey", "user_id", "sales", "users", "id", "NO ACTION", "NO ACTION")
+	mock.ExpectQuery(regexp.QuoteMeta(Redshift_Snapshot_Foreign_Keys_query)).WithArgs("sales").WillReturnRows(fkRows)
+	commentRows := sqlmock.NewRows([]string{"relname", "attname", "description"}).
+		AddRow("users", "", "Registered users")
+	mock.ExpectQuery(regexp.QuoteMeta(Redshift_Snapshot_Comments_query)).WithArgs("sales").WillReturnRows(commentRows)
+
+	r := &Redshift{Client: db, Config: config.Config{Database: "dev", Schema: "sales"}}
+	schema, err := r.Snapshot(context.Background(), "dev", types.SnapshotOptions{Kinds: []types.TableKind{types.KindTable}})
+	if err != nil {
+		t.Fatalf("error taking snapshot: %s", err)
+	}
+
+	if len(schema.Tables) != 2 {
+		t.Fatalf("expected 2 tables, got %d", len(schema.Tables))
+	}
+	orders, users := schema.Tables[0], schema.Tables[1]
+	wantIndexes := []types.Index{{Name: "sortkey", Columns: []string{"day", "user_id"}, Method: "compound"}}
+	if !reflect.DeepEqual(orders.Indexes, wantIndexes) {
+		t.Errorf("indexes = %+v, want %+v", orders.Indexes, wantIndexes)
+	}
+	if orders.Catalog != "dev" || orders.ColumnCount != 3 || len(orders.ForeignKeys) != 1 || orders.ForeignKeys[0].ReferencedTable != "users" {
+		t.Errorf("orders = %+v", orders)
+	}
+	if users.Description != "Registered users" || len(users.Indexes) != 0 || len(users.ForeignKeys) != 0 {
+		t.Errorf("users = %+v", users)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestBegin is a unit test function that tests committing and rolling back transactions of the Redshift struct.
+// Unsupported isolation levels are rejected before a transaction is started.
+func TestBegin(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO users (name) VALUES ($1)")).WithArgs("ann").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM users")).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectRollback()
+
+	r := &Redshift{Client: db}
+	tx, err := r.Begin(context.Background(), types.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if n, err := tx.Exec(context.Background(), "INSERT INTO users (name) VALUES ($1)", "ann"); err != nil || n != 1 {
+		t.Errorf("Exec() = %d, %v, want 1 row", n, err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Errorf("error committing transaction: %s", err)
+	}
+
+	tx, err = r.Begin(context.Background(), types.TxOptions{})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if n, err := tx.Exec(context.Background(), "DELETE FROM users"); err != nil || n != 3 {
+		t.Errorf("Exec() = %d, %v, want 3 rows", n, err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Errorf("error rolling back transaction: %s", err)
+	}
+
+	if _, err := r.Begin(context.Background(), types.TxOptions{Isolation: sql.LevelReadUncommitted}); err == nil {
+		t.Error("expected an error for an unsupported isolation level")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestBeginReadOnly is a unit test function that tests that read-only transactions of the Redshift struct run reads
+// and reject writes before they are sent, whether requested by the options or by the read-only mode of the client.
+func TestBeginReadOnly(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM users WHERE id = $1")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	r := &Redshift{Client: db}
+	tx, err := r.Begin(context.Background(), types.TxOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if _, err := tx.Execute(context.Background(), "SELECT id FROM users WHERE id = $1", 1); err != nil {
+		t.Errorf("error executing statement: %s", err)
+	}
+	if _, err := tx.Exec(context.Background(), "DELETE FROM users"); err == nil {
+		t.Error("expected an error for a write in a read-only transaction")
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Errorf("error rolling back transaction: %s", err)
+	}
+
+	r.SetReadOnly(true)
+	tx, err = r.Begin(context.Background(), types.TxOptions{})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if _, err := tx.Exec(context.Background(), "INSERT INTO users (name) VALUES ($1)", "ann"); err == nil {
+		t.Error("expected an error for a write in read-only mode")
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Errorf("error rolling back transaction: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSession is a unit test function that tests that the statements of a session of the Redshift struct,
+// and of the transactions started on it, run on the pinned connection.
+func TestSession(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectExec(regexp.QuoteMeta("SET search_path TO sales")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM users WHERE id = $1")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO users (name) VALUES ($1)")).WithArgs("ann").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	r := &Redshift{Client: db}
+	session, err := r.Session(context.Background())
+	if err != nil {
+		t.Fatalf("error opening session: %s", err)
+	}
+	defer func() {
+		if err := session.Close(); err != nil {
+			t.Errorf("error closing session: %s", err)
+		}
+	}()
+
+	if _, err := session.Exec(context.Background(), "SET search_path TO sales"); err != nil {
+		t.Errorf("error executing statement: %s", err)
+	}
+	it, err := session.Query(context.Background(), "SELECT id FROM users WHERE id = $1", 1)
+	if err != nil {
+		t.Fatalf("error querying: %s", err)
+	}
+	result, err := types.ReadAll(it)
+	if err != nil {
+		t.Fatalf("error reading rows: %s", err)
+	}
+	if result.RowCount != 1 {
+		t.Errorf("RowCount = %d, want 1", result.RowCount)
+	}
+
+	tx, err := session.Begin(context.Background(), types.TxOptions{})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if _, err := tx.Exec(context.Background(), "INSERT INTO users (name) VALUES ($1)", "ann"); err != nil {
+		t.Errorf("error executing statement: %s", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Errorf("error committing transaction: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSchemas is a unit test function that tests the Schemas method of the Redshift struct.
+func TestSchemas(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	rows := sqlmock.NewRows([]string{"schema_name"}).AddRow("public").AddRow("sales")
+	mock.ExpectQuery(regexp.QuoteMeta(Redshift_Schemas_query)).WithArgs("dev").WillReturnRows(rows)
+
+	r, err := NewRedshift(db)
+	if err != nil {
+		t.Errorf("error initialising redshift: %s", err)
+	}
+	schemas, err := r.Schemas(context.Background(), "dev")
+	if err != nil {
+		t.Errorf("error retrieving schemas: %s", err)
+	}
+
+	expected := []string{"public", "sales"}
+	if !reflect.DeepEqual(schemas, expected) {
+		t.Errorf("expected: %v, got: %v", expected, schemas)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,39 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/thesaas-company/xray/governor"
+	"github.com/thesaas-company/xray/script"
+	"github.com/thesaas-company/xray/session"
+	"github.com/thesaas-company/xray/types"
+)
+
+// Begin starts a transaction. Every Redshift transaction is serializable, or uses snapshot isolation when the
+// database is configured for it, so only the default level and SERIALIZABLE are accepted; read-only transactions are supported.
+func (r *Redshift) Begin(ctx context.Context, opts types.TxOptions) (types.Tx, error) {
+	return session.Begin(ctx, r.Client, r.sessionConfig(), opts)
+}
+
+// Session pins a connection of the pool, so that SET options and temporary tables hold between statements.
+func (r *Redshift) Session(ctx context.Context) (types.Session, error) {
+	return session.Open(ctx, r.Client, r.sessionConfig())
+}
+
+// ExecuteScript runs the statements of a script one after the other in a session.
+func (r *Redshift) ExecuteScript(ctx context.Context, query string, opts types.ScriptOptions) (types.ScriptResult, error) {
+	return script.Run(ctx, r, types.Redshift, query, opts)
+}
+
+// sessionConfig describes how the statements of transactions and sessions run.
+func (r *Redshift) sessionConfig() session.Config {
+	return session.Config{
+		DbType:           types.Redshift,
+		Convert:          newValueConverter(types.BinaryEncoding(r.Config.BinaryEncoding)),
+		Limits:           governor.Limits{MaxRows: r.Config.MaxRows, MaxBytes: r.Config.MaxBytes},
+		ReadOnly:         r.Config.ReadOnly,
+		DefaultIsolation: sql.LevelSerializable,
+		ReadOnlyTx:       true,
+	}
+}
@@ -0,0 +1,50 @@
+package redshift
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestConvertValue is a unit test function that tests how Redshift values are mapped to JSON friendly values.
+func TestConvertValue(t *testing.T) {
+	moment := time.Date(2024, 5, 1, 12, 30, 15, 0, time.UTC)
+	tests := []struct {
+		name     string
+		typeName string
+		binary   types.BinaryEncoding
+		value    interface{}
+		want     interface{}
+	}{
+		{name: "null", typeName: "VARCHAR", value: nil, want: nil},
+		{name: "integer", typeName: "INT8", value: int64(7), want: int64(7)},
+		{name: "boolean", typeName: "BOOL", value: true, want: true},
+		{name: "nan float", typeName: "FLOAT8", value: math.NaN(), want: "NaN"},
+		{name: "base64 looking varchar", typeName: "VARCHAR", value: "test", want: "test"},
+		{name: "numeric", typeName: "NUMERIC", value: []byte("10.10"), want: "10.10"},
+		{name: "super", typeName: "SUPER", value: []byte(`{"a":[1,2]}`), want: json.RawMessage(`{"a":[1,2]}`)},
+		{name: "nested super of unknown type", typeName: "", value: []byte(`[1,"b"]`), want: json.RawMessage(`[1,"b"]`)},
+		{name: "base64 looking unknown type", typeName: "", value: []byte("abcd"), want: "abcd"},
+		{name: "date", typeName: "DATE", value: moment, want: "2024-05-01"},
+		{name: "timetz", typeName: "TIMETZ", value: moment, want: "12:30:15"},
+		{name: "timestamptz", typeName: "TIMESTAMPTZ", value: moment, want: "2024-05-01T12:30:15Z"},
+		{name: "varbyte", typeName: "VARBYTE", value: []byte("test"), want: "dGVzdA=="},
+		{name: "varbyte as hex", typeName: "VARBYTE", binary: types.BinaryHex, value: []byte("test"), want: "74657374"},
+		{name: "geometry omitted", typeName: "GEOMETRY", binary: types.BinaryOmit, value: []byte("test"), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertValue(types.ColumnMeta{DatabaseType: tt.typeName}, tt.value, tt.binary)
+			if err != nil {
+				t.Fatalf("convertValue() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("convertValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
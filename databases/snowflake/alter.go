@@ -0,0 +1,91 @@
+package snowflake
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/diff"
+	"github.com/thesaas-company/xray/types"
+)
+
+// GenerateAlterTableQueries generates the ALTER TABLE statements turning the from table into the to table.
+// Snowflake only widens text columns and numbers in place and cannot set a new default on an existing
+// column, those changes are reported as warnings. The clustering key is changed with CLUSTER BY.
+func (s *Snowflake) GenerateAlterTableQueries(from, to types.Table) types.Migration {
+	var m types.Migration
+	d := diff.Tables(from, to, types.Snowflake, types.Snowflake)
+	table := dialect.QuoteTableName(types.Snowflake, types.TableName{Catalog: to.Catalog, Schema: to.Schema, Name: to.Name})
+
+	if d.PrimaryKey != nil && d.PrimaryKey.From != "" {
+		m.Add("ALTER TABLE " + table + " DROP PRIMARY KEY;")
+	}
+
+	for _, column := range d.RemovedColumns {
+		m.Add("ALTER TABLE " + table + " DROP COLUMN " + dialect.QuoteIdentifier(types.Snowflake, column.Name) + ";")
+		m.Warn(fmt.Sprintf("dropping column %s deletes its data", column.Name))
+	}
+	for _, column := range d.AddedColumns {
+		query := "ALTER TABLE " + table + " ADD COLUMN " + dialect.QuoteIdentifier(types.Snowflake, column.Name) + " " + strings.ToUpper(column.Type)
+		if column.DefaultValue.Valid {
+			query += " DEFAULT " + column.DefaultValue.String
+		}
+		if column.IsNullable == "NO" {
+			query += " NOT NULL"
+			if !column.DefaultValue.Valid {
+				m.Warn(fmt.Sprintf("adding NOT NULL column %s without a default fails on a non-empty table", column.Name))
+			}
+		}
+		if column.Description != "" {
+			query += " COMMENT " + dialect.QuoteString(types.Snowflake, column.Description)
+		}
+		m.Add(query + ";")
+	}
+	for _, column := range d.ChangedColumns {
+		alter := "ALTER TABLE " + table + " ALTER COLUMN " + dialect.QuoteIdentifier(types.Snowflake, column.Name)
+		for _, change := range column.Changes {
+			switch change.Field {
+			case diff.FieldType:
+				if diff.Widens(types.Snowflake, column.From, column.To) {
+					m.Add(alter + " SET DATA TYPE " + strings.ToUpper(column.To.Type) + ";")
+				} else {
+					m.Warn(fmt.Sprintf("Snowflake cannot change the type of column %s from %s to %s in place, the table has to be recreated", column.Name, change.From, change.To))
+				}
+			case diff.FieldNullable:
+				if change.To == "NO" {
+					m.Add(alter + " SET NOT NULL;")
+					m.Warn(fmt.Sprintf("setting column %s NOT NULL fails if it contains NULL values", column.Name))
+				} else {
+					m.Add(alter + " DROP NOT NULL;")
+				}
+			case diff.FieldDefault:
+				if column.To.DefaultValue.Valid {
+					m.Warn(fmt.Sprintf("Snowflake cannot set a new default on existing column %s", column.Name))
+				} else {
+					m.Add(alter + " DROP DEFAULT;")
+				}
+			}
+		}
+	}
+
+	if d.PrimaryKey != nil && d.PrimaryKey.To != "" {
+		m.Add("ALTER TABLE " + table + " ADD PRIMARY KEY (" + strings.Join(dialect.QuoteColumns(types.Snowflake, to, diff.PrimaryKeyColumns(to)), ", ") + ");")
+	}
+
+	// the only index like object of Snowflake is the clustering key
+	clustered := false
+	for _, index := range d.AddedIndexes {
+		if index.Method == "clustering" {
+			clustered = true
+			m.Add("ALTER TABLE " + table + " CLUSTER BY (" + strings.Join(dialect.QuoteColumns(types.Snowflake, to, index.Columns), ", ") + ");")
+		} else {
+			m.Warn(fmt.Sprintf("Snowflake has no indexes, index %s is not created", index.Name))
+		}
+	}
+	for _, index := range d.RemovedIndexes {
+		if index.Method == "clustering" && !clustered {
+			m.Add("ALTER TABLE " + table + " DROP CLUSTERING KEY;")
+		}
+	}
+	return m
+}
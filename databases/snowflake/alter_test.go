@@ -0,0 +1,81 @@
+package snowflake
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestGenerateAlterTableQueries is a unit test function that tests the GenerateAlterTableQueries method of the Snowflake struct.
+func TestGenerateAlterTableQueries(t *testing.T) {
+	from := types.Table{
+		Name: "ORDERS",
+		Columns: []types.Column{
+			{Name: "ID", Type: "NUMBER(38,0)", IsNullable: "NO"},
+			{Name: "NOTE", Type: "VARCHAR(100)", IsNullable: "YES", DefaultValue: sql.NullString{String: "'n/a'", Valid: true}},
+			{Name: "TOTAL", Type: "NUMBER(10,2)", IsNullable: "YES"},
+		},
+		Indexes: []types.Index{{Name: "clustering_key", Columns: []string{"ID"}, Method: "clustering"}},
+	}
+	to := types.Table{
+		Name: "ORDERS",
+		Columns: []types.Column{
+			{Name: "ID", Type: "NUMBER(38,0)", IsNullable: "NO", IsPrimary: true},
+			{Name: "NOTE", Type: "VARCHAR(500)", IsNullable: "YES"},
+			{Name: "TOTAL", Type: "NUMBER(10,4)", IsNullable: "NO"},
+		},
+	}
+
+	s := &Snowflake{}
+	m := s.GenerateAlterTableQueries(from, to)
+
+	wantStatements := []string{
+		`ALTER TABLE "ORDERS" ALTER COLUMN "NOTE" SET DATA TYPE VARCHAR(500);`,
+		`ALTER TABLE "ORDERS" ALTER COLUMN "NOTE" DROP DEFAULT;`,
+		`ALTER TABLE "ORDERS" ALTER COLUMN "TOTAL" SET NOT NULL;`,
+		`ALTER TABLE "ORDERS" ADD PRIMARY KEY ("ID");`,
+		`ALTER TABLE "ORDERS" DROP CLUSTERING KEY;`,
+	}
+	if !reflect.DeepEqual(m.Statements, wantStatements) {
+		t.Errorf("statements = %#v, want %#v", m.Statements, wantStatements)
+	}
+	wantWarnings := []string{
+		"Snowflake cannot change the type of column TOTAL from decimal(10,2) to decimal(10,4) in place, the table has to be recreated",
+		"setting column TOTAL NOT NULL fails if it contains NULL values",
+	}
+	if !reflect.DeepEqual(m.Warnings, wantWarnings) {
+		t.Errorf("warnings = %#v, want %#v", m.Warnings, wantWarnings)
+	}
+}
+
+// TestGenerateAlterTableQueriesQuotedNames is a unit test function that tests that reserved words, mixed case names
+// and quote characters in table, column and index names are quoted.
+func TestGenerateAlterTableQueriesQuotedNames(t *testing.T) {
+	from := types.Table{
+		Name:    "Order",
+		Catalog: "shop",
+		Schema:  "sales",
+		Columns: []types.Column{{Name: "id", Type: "NUMBER", IsNullable: "NO", IsPrimary: true}, {Name: "Group", Type: "TEXT", IsNullable: "YES"}},
+	}
+	to := types.Table{
+		Name:    "Order",
+		Catalog: "shop",
+		Schema:  "sales",
+		Columns: []types.Column{{Name: "id", Type: "NUMBER", IsNullable: "NO", IsPrimary: true}, {Name: `Select"s`, Type: "TEXT", IsNullable: "YES"}},
+		Indexes: []types.Index{{Name: "clustering_key", Columns: []string{`Select"s`, "SUBSTRING(id, 1, 3)"}, Method: "clustering"}},
+	}
+
+	s := &Snowflake{}
+	m := s.GenerateAlterTableQueries(from, to)
+
+	want := []string{
+		`ALTER TABLE "shop"."sales"."Order" DROP COLUMN "Group";`,
+		`ALTER TABLE "shop"."sales"."Order" ADD COLUMN "Select""s" TEXT;`,
+		`ALTER TABLE "shop"."sales"."Order" CLUSTER BY ("Select""s", SUBSTRING(id, 1, 3));`,
+	}
+	if !reflect.DeepEqual(m.Statements, want) {
+		t.Errorf("statements = %#v, want %#v", m.Statements, want)
+	}
+}
@@ -0,0 +1,49 @@
+package snowflake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/types"
+)
+
+// SNOWFLAKE_EXPLAIN_QUERY plans a statement without running it and returns the plan as a single JSON value.
+const SNOWFLAKE_EXPLAIN_QUERY = "EXPLAIN USING JSON %s"
+
+// snowflakePlan is the part of the JSON plan read by Estimate and Explain.
+type snowflakePlan struct {
+	GlobalStats struct {
+		BytesAssigned int64 `json:"bytesAssigned"`
+	} `json:"GlobalStats"`
+	Operations [][]struct {
+		ID        int      `json:"id"`
+		Parent    *int     `json:"parent"`
+		Operation string   `json:"operation"`
+		Objects   []string `json:"objects"`
+	} `json:"Operations"`
+}
+
+// Estimate returns the number of bytes Snowflake expects to scan for a single statement, without running it.
+// The bytes are those of the micro-partitions left after pruning; Snowflake plans report no cost or row count.
+func (s *Snowflake) Estimate(ctx context.Context, query string) (types.CostEstimate, error) {
+	statement, err := dialect.SingleStatement(types.Snowflake, query)
+	if err != nil {
+		return types.CostEstimate{}, fmt.Errorf("error estimating query: %v", err)
+	}
+
+	var plan string
+	if err := s.Client.QueryRowContext(ctx, fmt.Sprintf(SNOWFLAKE_EXPLAIN_QUERY, statement.Text)).Scan(&plan); err != nil {
+		return types.CostEstimate{}, fmt.Errorf("error explaining query: %v", err)
+	}
+
+	var parsed snowflakePlan
+	if err := json.Unmarshal([]byte(plan), &parsed); err != nil {
+		return types.CostEstimate{}, fmt.Errorf("error parsing query plan: %v", err)
+	}
+	return types.CostEstimate{
+		BytesProcessed: parsed.GlobalStats.BytesAssigned,
+		Plan:           plan,
+	}, nil
+}
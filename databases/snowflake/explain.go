@@ -0,0 +1,189 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/types"
+)
+
+// SNOWFLAKE_OPERATOR_STATS_QUERY reads the operators of the last query of the session with the rows they returned.
+const SNOWFLAKE_OPERATOR_STATS_QUERY = `SELECT STEP_ID, OPERATOR_ID, PARENT_OPERATORS, OPERATOR_TYPE, OPERATOR_STATISTICS, OPERATOR_ATTRIBUTES
+FROM TABLE(GET_QUERY_OPERATOR_STATS(LAST_QUERY_ID()))
+ORDER BY STEP_ID, OPERATOR_ID`
+
+// snowflakeOperator is an operator of an executed query, read from GET_QUERY_OPERATOR_STATS.
+type snowflakeOperator struct {
+	Step       int     `json:"step_id"`
+	ID         int     `json:"operator_id"`
+	Parents    []int   `json:"parent_operators"`
+	Type       string  `json:"operator_type"`
+	OutputRows float64 `json:"output_rows"`
+	Table      string  `json:"table_name"`
+}
+
+// Explain returns the plan of a single statement. Snowflake plans hold no row or cost estimates, only the operations
+// and the tables they read. With analyze the statement runs inside a transaction that is rolled back and the plan
+// holds the rows each operator returned; Snowflake does not report the time of an operator.
+func (s *Snowflake) Explain(ctx context.Context, query string, analyze bool) (types.Plan, error) {
+	statement, err := dialect.SingleStatement(types.Snowflake, query)
+	if err != nil {
+		return types.Plan{}, fmt.Errorf("error explaining query: %v", err)
+	}
+	if analyze {
+		return s.explainAnalyze(ctx, statement.Text)
+	}
+
+	var raw string
+	if err := s.Client.QueryRowContext(ctx, fmt.Sprintf(SNOWFLAKE_EXPLAIN_QUERY, statement.Text)).Scan(&raw); err != nil {
+		return types.Plan{}, fmt.Errorf("error explaining query: %v", err)
+	}
+	var parsed snowflakePlan
+	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		return types.Plan{}, fmt.Errorf("error parsing query plan: %v", err)
+	}
+	if len(parsed.Operations) == 0 {
+		return types.Plan{}, fmt.Errorf("error parsing query plan: empty plan")
+	}
+
+	// the operations are listed flat, each one naming the id of its parent
+	nodes := make(map[int]*types.PlanNode)
+	for _, op := range parsed.Operations[0] {
+		node := &types.PlanNode{Type: op.Operation}
+		if len(op.Objects) > 0 {
+			node.Relation = op.Objects[0]
+		}
+		nodes[op.ID] = node
+	}
+	var root *types.PlanNode
+	for _, op := range parsed.Operations[0] {
+		if op.Parent != nil {
+			if parent, ok := nodes[*op.Parent]; ok {
+				parent.Children = append(parent.Children, nodes[op.ID])
+				continue
+			}
+		}
+		if root == nil {
+			root = nodes[op.ID]
+		}
+	}
+	if root == nil {
+		return types.Plan{}, fmt.Errorf("error parsing query plan: no root operation")
+	}
+	return types.Plan{Root: root, Format: "json", Raw: raw}, nil
+}
+
+// explainAnalyze runs a statement and reads the statistics of its operators on the same connection,
+// LAST_QUERY_ID only sees the queries of its own session.
+func (s *Snowflake) explainAnalyze(ctx context.Context, query string) (types.Plan, error) {
+	if s.Config.ReadOnly {
+		if err := dialect.CheckReadOnly(types.Snowflake, query); err != nil {
+			return types.Plan{}, err
+		}
+	}
+
+	tx, err := s.Client.BeginTx(ctx, nil)
+	if err != nil {
+		return types.Plan{}, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			fmt.Println(err)
+		}
+	}()
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return types.Plan{}, fmt.Errorf("error executing sql statement: %v", err)
+	}
+	// the results are discarded, only the statistics of the operators are read
+	for rows.Next() {
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return types.Plan{}, fmt.Errorf("error iterating rows: %v", err)
+	}
+	if err := rows.Close(); err != nil {
+		return types.Plan{}, fmt.Errorf("error closing rows: %v", err)
+	}
+
+	operators, err := readOperators(ctx, tx)
+	if err != nil {
+		return types.Plan{}, err
+	}
+	raw, err := json.Marshal(operators)
+	if err != nil {
+		return types.Plan{}, fmt.Errorf("error marshaling json: %v", err)
+	}
+
+	type key struct{ step, id int }
+	nodes := make(map[key]*types.PlanNode)
+	for _, op := range operators {
+		nodes[key{op.Step, op.ID}] = &types.PlanNode{Type: op.Type, Relation: op.Table, ActualRows: op.OutputRows}
+	}
+	var root *types.PlanNode
+	for _, op := range operators {
+		node := nodes[key{op.Step, op.ID}]
+		if len(op.Parents) > 0 {
+			if parent, ok := nodes[key{op.Step, op.Parents[0]}]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		if root == nil {
+			root = node
+		}
+	}
+	if root == nil {
+		return types.Plan{}, fmt.Errorf("error explaining query: no operator statistics returned")
+	}
+	return types.Plan{Root: root, Analyzed: true, Format: "json", Raw: string(raw)}, nil
+}
+
+// readOperators reads the operator statistics of the last query, the VARIANT columns are returned as JSON text.
+func readOperators(ctx context.Context, tx *sql.Tx) ([]snowflakeOperator, error) {
+	rows, err := tx.QueryContext(ctx, SNOWFLAKE_OPERATOR_STATS_QUERY)
+	if err != nil {
+		return nil, fmt.Errorf("error reading operator statistics: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var operators []snowflakeOperator
+	for rows.Next() {
+		var op snowflakeOperator
+		var parents, statistics, attributes sql.NullString
+		if err := rows.Scan(&op.Step, &op.ID, &parents, &op.Type, &statistics, &attributes); err != nil {
+			return nil, fmt.Errorf("error scanning operator statistics: %v", err)
+		}
+		var stats struct {
+			OutputRows float64 `json:"output_rows"`
+		}
+		var attrs struct {
+			TableName string `json:"table_name"`
+		}
+		for _, v := range []struct {
+			text string
+			into interface{}
+		}{{parents.String, &op.Parents}, {statistics.String, &stats}, {attributes.String, &attrs}} {
+			if v.text == "" {
+				continue
+			}
+			if err := json.Unmarshal([]byte(v.text), v.into); err != nil {
+				return nil, fmt.Errorf("error parsing operator statistics: %v", err)
+			}
+		}
+		op.OutputRows, op.Table = stats.OutputRows, attrs.TableName
+		operators = append(operators, op)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating operator statistics: %v", err)
+	}
+	return operators, nil
+}
@@ -1,16 +1,22 @@
 package snowflake
 
 import (
+	"context"
 	"database/sql"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	sf "github.com/snowflakedb/gosnowflake"
 	"github.com/thesaas-company/xray/config"
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/governor"
+	"github.com/thesaas-company/xray/registry"
+	"github.com/thesaas-company/xray/snapshot"
 	"github.com/thesaas-company/xray/types"
 )
 
@@ -25,13 +31,48 @@ var DB_PASSWORD string = "DB_PASSWORD"
 
 const (
 	// SNOWFLAKE_TABLES_LIST_QUERY is the query to list tables in Snowflake.
-	SNOWFLAKE_TABLES_LIST_QUERY = "SELECT table_name FROM %s.information_schema.tables WHERE table_schema = '%s';"
+	// It is formatted with the information schema of the database; an empty schema argument selects the current schema.
+	SNOWFLAKE_TABLES_LIST_QUERY = "SELECT table_name FROM %s.tables WHERE table_schema = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA());"
+	// SNOWFLAKE_SCHEMA_LIST_QUERY is the query to list the schemas of a Snowflake database.
+	// It is formatted with the information schema of the database.
+	SNOWFLAKE_SCHEMA_LIST_QUERY = "SELECT schema_name FROM %s.schemata WHERE schema_name <> 'INFORMATION_SCHEMA' ORDER BY schema_name;"
+	// SNOWFLAKE_LIST_TABLES_QUERY is the query to list the tables, views, materialized views and external tables of a schema in Snowflake.
+	// It is formatted with the information schema of the database.
+	SNOWFLAKE_LIST_TABLES_QUERY = "SELECT t.table_name::TEXT, t.table_schema::TEXT, CASE t.table_type WHEN 'VIEW' THEN 'view' WHEN 'MATERIALIZED VIEW' THEN 'materialized_view' WHEN 'EXTERNAL TABLE' THEN 'external_table' ELSE 'table' END, COALESCE(v.view_definition, '')::TEXT FROM %[1]s.tables t LEFT JOIN %[1]s.views v ON v.table_schema = t.table_schema AND v.table_name = t.table_name WHERE t.table_schema::TEXT = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA()) ORDER BY t.table_name;"
 	// SNOWFLAKE_SCHEMA_QUERY is the query to retrieve schema information for a table in Snowflake.
-	SNOWFLAKE_SCHEMA_QUERY = "SELECT column_name::TEXT, data_type::TEXT FROM information_schema.columns WHERE table_name::TEXT = ?;"
+	// It is formatted with the information schema to read from; an empty schema argument selects the current schema.
+	SNOWFLAKE_SCHEMA_QUERY = "SELECT column_name::TEXT, data_type::TEXT, character_maximum_length, numeric_precision, numeric_scale FROM %s.columns WHERE table_name::TEXT = ? AND table_schema::TEXT = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA()) ORDER BY ordinal_position;"
+	// SNOWFLAKE_FOREIGN_KEYS_QUERY is the query to list the declared foreign key columns of a table in Snowflake.
+	SNOWFLAKE_FOREIGN_KEYS_QUERY = "SHOW IMPORTED KEYS IN TABLE %s;"
+	// SNOWFLAKE_CLUSTERING_KEY_QUERY is the query to retrieve the clustering key of a table in Snowflake.
+	SNOWFLAKE_CLUSTERING_KEY_QUERY = "SELECT clustering_key FROM %s.tables WHERE table_name::TEXT = ? AND table_schema::TEXT = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA());"
+	// SNOWFLAKE_COMMENTS_QUERY is the query to read the comments of a table and its columns in Snowflake.
+	// It is formatted with the information schema of the database, the table comment is returned with an empty column name.
+	SNOWFLAKE_COMMENTS_QUERY = "SELECT '', comment::TEXT FROM %[1]s.tables WHERE table_name::TEXT = ? AND table_schema::TEXT = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA()) AND comment IS NOT NULL UNION ALL SELECT column_name::TEXT, comment::TEXT FROM %[1]s.columns WHERE table_name::TEXT = ? AND table_schema::TEXT = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA()) AND comment IS NOT NULL;"
+	// SNOWFLAKE_SNAPSHOT_COLUMNS_QUERY is the query to read the columns of every table of a schema in Snowflake at once.
+	SNOWFLAKE_SNAPSHOT_COLUMNS_QUERY = "SELECT table_name::TEXT, column_name::TEXT, data_type::TEXT, character_maximum_length, numeric_precision, numeric_scale FROM %s.columns WHERE table_schema::TEXT = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA()) ORDER BY table_name, ordinal_position;"
+	// SNOWFLAKE_SNAPSHOT_CLUSTERING_KEYS_QUERY is the query to read the clustering keys of every table of a schema in Snowflake at once.
+	SNOWFLAKE_SNAPSHOT_CLUSTERING_KEYS_QUERY = "SELECT table_name::TEXT, clustering_key::TEXT FROM %s.tables WHERE table_schema::TEXT = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA()) AND clustering_key IS NOT NULL;"
+	// SNOWFLAKE_SNAPSHOT_COMMENTS_QUERY is the query to read the comments of every table and column of a schema in Snowflake at once.
+	// Table comments are returned with an empty column name.
+	SNOWFLAKE_SNAPSHOT_COMMENTS_QUERY = "SELECT table_name::TEXT, '', comment::TEXT FROM %[1]s.tables WHERE table_schema::TEXT = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA()) AND comment IS NOT NULL UNION ALL SELECT table_name::TEXT, column_name::TEXT, comment::TEXT FROM %[1]s.columns WHERE table_schema::TEXT = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA()) AND comment IS NOT NULL;"
+	// SNOWFLAKE_SNAPSHOT_FOREIGN_KEYS_QUERY is the query to list the declared foreign key columns of every table of a schema in Snowflake.
+	// An empty schema name selects the current schema.
+	SNOWFLAKE_SNAPSHOT_FOREIGN_KEYS_QUERY = "SHOW IMPORTED KEYS IN SCHEMA %s;"
+	// SNOWFLAKE_TABLE_STATS_QUERY is the query to read the row count, size and modification times of a table in Snowflake.
+	// It is formatted with the information schema of the database.
+	SNOWFLAKE_TABLE_STATS_QUERY = "SELECT COALESCE(row_count, 0), COALESCE(bytes, 0), last_ddl, last_altered FROM %s.tables WHERE table_name::TEXT = ? AND table_schema::TEXT = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA());"
 )
 
+func init() {
+	registry.Register(types.Snowflake.String(), registry.Factory{
+		New:           NewSnowflake,
+		NewWithConfig: NewSnowflakeWithConfig,
+	})
+}
+
 // NewSnowflake creates a new Snowflake object with an initialized database client and configuration.
-func NewSnowflake(dbClient *sql.DB) (types.ISQL, error) {
+func NewSnowflake(dbClient *sql.DB) (types.ISQLContext, error) {
 	return &Snowflake{
 		Client: dbClient,
 		Config: &config.Config{},
@@ -39,7 +80,7 @@ func NewSnowflake(dbClient *sql.DB) (types.ISQL, error) {
 }
 
 // NewSnowflakeWithConfig creates a new Snowflake object with an initialized database client and configuration.
-func NewSnowflakeWithConfig(config *config.Config) (types.ISQL, error) {
+func NewSnowflakeWithConfig(config *config.Config) (types.ISQLContext, error) {
 	if os.Getenv(DB_PASSWORD) == "" || len(os.Getenv(DB_PASSWORD)) == 0 {
 		return nil, fmt.Errorf("please set %s env variable for the database", DB_PASSWORD)
 	}
@@ -76,9 +117,16 @@ func NewSnowflakeWithConfig(config *config.Config) (types.ISQL, error) {
 // Schema returns the schema of a table in Snowflake.
 // It takes the table name as an argument and returns a Table struct and an error if any.
 func (s *Snowflake) Schema(table string) (types.Table, error) {
+	return s.SchemaContext(context.Background(), table)
+}
+
+// SchemaContext returns the schema of a table in Snowflake.
+// The query is cancelled when the context is done.
+func (s *Snowflake) SchemaContext(ctx context.Context, table string) (types.Table, error) {
 	var res types.Table
 
-	rows, err := s.Client.Query(SNOWFLAKE_SCHEMA_QUERY, table)
+	name := s.qualify(types.ParseTableName(table))
+	rows, err := s.Client.QueryContext(ctx, fmt.Sprintf(SNOWFLAKE_SCHEMA_QUERY, informationSchema(name.Catalog)), name.Name, name.Schema)
 	if err != nil {
 		return res, fmt.Errorf("error executing sql statement: %v", err)
 	}
@@ -91,10 +139,9 @@ func (s *Snowflake) Schema(table string) (types.Table, error) {
 	var columns []types.Column
 	for rows.Next() {
 		var column types.Column
-		if err := rows.Scan(&column.Name, &column.Type); err != nil {
+		if err := rows.Scan(&column.Name, &column.Type, &column.CharacterMaximumLength, &column.NumericPrecision, &column.NumericScale); err != nil {
 			return res, fmt.Errorf("error scanning rows: %v", err)
 		}
-		column.Description = ""      // default description
 		column.Metatags = []string{} // default metatags as an empty string slice
 		column.Metatags = append(column.Metatags, column.Name)
 		column.Visibility = true // default visibility
@@ -106,21 +153,265 @@ func (s *Snowflake) Schema(table string) (types.Table, error) {
 		return res, fmt.Errorf("error iterating over rows: %v", err)
 	}
 
+	foreignKeys, err := s.foreignKeys(ctx, name)
+	if err != nil {
+		return res, err
+	}
+
+	// Snowflake has no indexes, the clustering key plays their role
+	indexes, err := s.clusteringKey(ctx, name)
+	if err != nil {
+		return res, err
+	}
+	types.ApplyIndexes(columns, indexes)
+
+	comments, err := s.comments(ctx, name)
+	if err != nil {
+		return res, err
+	}
+	description := types.ApplyComments(columns, comments)
+
 	return types.Table{
-		Name:        table,
+		Name:        name.Name,
+		Catalog:     name.Catalog,
+		Schema:      name.Schema,
 		Columns:     columns,
 		ColumnCount: int64(len(columns)),
-		Description: "",
+		Description: description,
 		Metatags:    []string{},
+		ForeignKeys: foreignKeys,
+		Indexes:     indexes,
 	}, nil
 }
 
+// qualify fills in the configured database and schema for the parts missing from the table name.
+// When neither is known the session defaults apply.
+func (s *Snowflake) qualify(name types.TableName) types.TableName {
+	if name.Catalog == "" {
+		name.Catalog = s.Config.Database
+	}
+	if name.Schema == "" {
+		name.Schema = s.Config.Schema
+	}
+	return name
+}
+
+// informationSchema returns the information schema of the given database, or of the current database when it is empty.
+// The database name comes from the configuration or the user, so it is folded to upper case like an unquoted name.
+func informationSchema(database string) string {
+	if database == "" {
+		return "information_schema"
+	}
+	return dialect.QuoteInputIdentifier(types.Snowflake, database) + ".information_schema"
+}
+
+// quoteTableName quotes a table name whose database and schema come from the configuration or the user, they are
+// folded to upper case like unquoted names while the table name is matched exactly.
+func quoteTableName(name types.TableName) string {
+	name.Catalog = dialect.FoldIdentifier(types.Snowflake, name.Catalog)
+	name.Schema = dialect.FoldIdentifier(types.Snowflake, name.Schema)
+	return dialect.QuoteTableName(types.Snowflake, name)
+}
+
+// TableStats returns the row count, size and modification times of a table in Snowflake.
+func (s *Snowflake) TableStats(table string) (types.TableStats, error) {
+	return s.TableStatsContext(context.Background(), table)
+}
+
+// TableStatsContext returns the row count, size and modification times of a table in Snowflake.
+// LAST_ALTERED covers both DDL and DML, it is reported as the last modification.
+// The query is cancelled when the context is done.
+func (s *Snowflake) TableStatsContext(ctx context.Context, table string) (types.TableStats, error) {
+	name := s.qualify(types.ParseTableName(table))
+
+	stats := types.TableStats{Name: name.Name, Schema: name.Schema}
+	query := fmt.Sprintf(SNOWFLAKE_TABLE_STATS_QUERY, informationSchema(name.Catalog))
+	row := s.Client.QueryRowContext(ctx, query, name.Name, name.Schema)
+	if err := row.Scan(&stats.RowCount, &stats.Bytes, &stats.LastDDL, &stats.LastModified); err != nil {
+		return types.TableStats{}, fmt.Errorf("error reading table statistics: %v", err)
+	}
+	return stats, nil
+}
+
+// comments retrieves the comments of the given table and its columns.
+func (s *Snowflake) comments(ctx context.Context, table types.TableName) ([]types.Comment, error) {
+	query := fmt.Sprintf(SNOWFLAKE_COMMENTS_QUERY, informationSchema(table.Catalog))
+	rows, err := s.Client.QueryContext(ctx, query, table.Name, table.Schema, table.Name, table.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing comment query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	var comments []types.Comment
+	for rows.Next() {
+		var c types.Comment
+		if err := rows.Scan(&c.Column, &c.Text); err != nil {
+			return nil, fmt.Errorf("error scanning comments: %v", err)
+		}
+		comments = append(comments, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over comments: %v", err)
+	}
+	return comments, nil
+}
+
+// clusteringKey retrieves the clustering key of the given table as an index.
+func (s *Snowflake) clusteringKey(ctx context.Context, table types.TableName) ([]types.Index, error) {
+	var key sql.NullString
+	query := fmt.Sprintf(SNOWFLAKE_CLUSTERING_KEY_QUERY, informationSchema(table.Catalog))
+	err := s.Client.QueryRowContext(ctx, query, table.Name, table.Schema).Scan(&key)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("error executing clustering key query: %v", err)
+	}
+
+	columns := clusteringColumns(key.String)
+	if len(columns) == 0 {
+		return []types.Index{}, nil
+	}
+	return []types.Index{{Name: "clustering_key", Columns: columns, Method: "clustering"}}, nil
+}
+
+// clusteringColumns splits a clustering key such as LINEAR(a, SUBSTRING(b, 1, 3)) into its expressions.
+func clusteringColumns(key string) []string {
+	key = strings.TrimSpace(key)
+	if open := strings.Index(key, "("); open >= 0 && strings.HasSuffix(key, ")") {
+		key = key[open+1 : len(key)-1]
+	}
+
+	var columns []string
+	depth, start := 0, 0
+	for i, c := range key {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				columns = append(columns, strings.TrimSpace(key[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if last := strings.TrimSpace(key[start:]); last != "" {
+		columns = append(columns, last)
+	}
+	return columns
+}
+
+// foreignKeys retrieves the foreign key constraints declared on the given table.
+// Snowflake does not enforce foreign keys, so these are the declared constraints.
+func (s *Snowflake) foreignKeys(ctx context.Context, table types.TableName) ([]types.ForeignKey, error) {
+	keys, err := s.importedKeys(ctx, fmt.Sprintf(SNOWFLAKE_FOREIGN_KEYS_QUERY, quoteTableName(table)))
+	if err != nil {
+		return nil, err
+	}
+	// SHOW IMPORTED KEYS IN TABLE only returns the keys of that one table
+	for _, foreignKeys := range keys {
+		return foreignKeys, nil
+	}
+	return []types.ForeignKey{}, nil
+}
+
+// importedKeys runs a SHOW IMPORTED KEYS command and returns the foreign keys it lists, keyed by the name of the referencing table.
+func (s *Snowflake) importedKeys(ctx context.Context, query string) (map[string][]types.ForeignKey, error) {
+	rows, err := s.Client.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error executing foreign key query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	// SHOW commands return a fixed set of columns, so they are looked up by name
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("error getting foreign key columns: %v", err)
+	}
+	index := map[string]int{}
+	for i, name := range names {
+		index[strings.ToLower(name)] = i
+	}
+
+	type keyColumn struct {
+		types.ForeignKeyColumn
+		table    string
+		sequence int
+	}
+	var keyColumns []keyColumn
+	for rows.Next() {
+		values := make([]sql.NullString, len(names))
+		pointers := make([]interface{}, len(names))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("error scanning foreign keys: %v", err)
+		}
+		get := func(name string) string {
+			if i, ok := index[name]; ok {
+				return values[i].String
+			}
+			return ""
+		}
+		sequence, _ := strconv.Atoi(get("key_sequence"))
+		keyColumns = append(keyColumns, keyColumn{
+			ForeignKeyColumn: types.ForeignKeyColumn{
+				Constraint:       get("fk_name"),
+				Column:           get("fk_column_name"),
+				ReferencedSchema: get("pk_schema_name"),
+				ReferencedTable:  get("pk_table_name"),
+				ReferencedColumn: get("pk_column_name"),
+				OnDelete:         get("delete_rule"),
+				OnUpdate:         get("update_rule"),
+			},
+			table:    get("fk_table_name"),
+			sequence: sequence,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over foreign keys: %v", err)
+	}
+
+	sort.SliceStable(keyColumns, func(i, j int) bool {
+		if keyColumns[i].table != keyColumns[j].table {
+			return keyColumns[i].table < keyColumns[j].table
+		}
+		if keyColumns[i].Constraint != keyColumns[j].Constraint {
+			return keyColumns[i].Constraint < keyColumns[j].Constraint
+		}
+		return keyColumns[i].sequence < keyColumns[j].sequence
+	})
+	columns := map[string][]types.ForeignKeyColumn{}
+	for _, c := range keyColumns {
+		columns[c.table] = append(columns[c.table], c.ForeignKeyColumn)
+	}
+	keys := map[string][]types.ForeignKey{}
+	for table, tableColumns := range columns {
+		keys[table] = types.GroupForeignKeys(tableColumns)
+	}
+	return keys, nil
+}
+
 // Tables returns a list of tables in a Snowflake database.
 // It takes the database name as an argument and returns a slice of table names.
 func (s *Snowflake) Tables(databaseName string) ([]string, error) {
+	return s.TablesContext(context.Background(), databaseName)
+}
 
-	query := fmt.Sprintf(SNOWFLAKE_TABLES_LIST_QUERY, databaseName, s.Config.Schema)
-	rows, err := s.Client.Query(query)
+// TablesContext returns a list of tables in a Snowflake database, the configured database when the name is empty.
+// The query is cancelled when the context is done.
+func (s *Snowflake) TablesContext(ctx context.Context, databaseName string) ([]string, error) {
+	schema := s.qualify(types.TableName{Catalog: databaseName})
+	query := fmt.Sprintf(SNOWFLAKE_TABLES_LIST_QUERY, informationSchema(schema.Catalog))
+	rows, err := s.Client.QueryContext(ctx, query, schema.Schema)
 	if err != nil {
 		return nil, fmt.Errorf("error executing sql statement and querying tables list: %v", err)
 	}
@@ -147,11 +438,17 @@ func (s *Snowflake) Tables(databaseName string) ([]string, error) {
 	return tables, nil
 }
 
-// Execute executes a query on a Snowflake database and returns the result as a JSON byte slice.
-func (s *Snowflake) Execute(query string) ([]byte, error) {
-	rows, err := s.Client.Query(query)
+// ListTables returns the tables, views, materialized views and external tables of the configured schema, in the
+// configured database when the database name is empty.
+// Snowflake only records view dependencies in the account usage views, which lag behind and need extra
+// privileges, so the dependencies are read from the view definitions instead.
+// The query is cancelled when the context is done.
+func (s *Snowflake) ListTables(ctx context.Context, databaseName string, opts types.ListTablesOptions) ([]types.TableInfo, error) {
+	schema := s.qualify(types.TableName{Catalog: databaseName})
+	query := fmt.Sprintf(SNOWFLAKE_LIST_TABLES_QUERY, informationSchema(schema.Catalog))
+	rows, err := s.Client.QueryContext(ctx, query, schema.Schema)
 	if err != nil {
-		return nil, fmt.Errorf("error executing sql statement: %v", err)
+		return nil, fmt.Errorf("error executing sql statement and querying tables list: %v", err)
 	}
 	defer func() {
 		if err := rows.Close(); err != nil {
@@ -159,61 +456,80 @@ func (s *Snowflake) Execute(query string) ([]byte, error) {
 		}
 	}()
 
-	columns, err := rows.Columns()
-	if err != nil {
-		return nil, fmt.Errorf("error getting columns: %v", err)
+	var tables []types.TableInfo
+	for rows.Next() {
+		var table types.TableInfo
+		if err := rows.Scan(&table.Name, &table.Schema, &table.Kind, &table.Definition); err != nil {
+			return nil, fmt.Errorf("error scanning tables: %v", err)
+		}
+		tables = append(tables, table)
 	}
 
-	// Scan the result into a slice of slices
-	var results [][]interface{}
-	for rows.Next() {
-		values := make([]interface{}, len(columns))
-		pointers := make([]interface{}, len(columns))
-		for i := range values {
-			pointers[i] = &values[i]
+	// checking for errors in iterating over rows
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows:%v", err)
+	}
+
+	tables = types.FilterTables(tables, opts)
+	dialect.ApplyViewReferences(types.Snowflake, tables)
+	return tables, nil
+}
+
+// Schemas returns the schemas of a Snowflake database, the configured database when the name is empty.
+// The query is cancelled when the context is done.
+func (s *Snowflake) Schemas(ctx context.Context, databaseName string) ([]string, error) {
+	query := fmt.Sprintf(SNOWFLAKE_SCHEMA_LIST_QUERY, informationSchema(s.qualify(types.TableName{Catalog: databaseName}).Catalog))
+	rows, err := s.Client.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement and querying schemas list: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
 		}
+	}()
 
-		if err := rows.Scan(pointers...); err != nil {
-			return nil, fmt.Errorf("error scanning row: %v", err)
-		}
-
-		// Decode base64 data
-		stringRow := make([]interface{}, len(values))
-		for i, val := range values {
-			switch v := val.(type) {
-			case []byte:
-				strVal := string(v)
-				if isBase64(strVal) {
-					decoded, err := base64.StdEncoding.DecodeString(strVal)
-					if err != nil {
-						return nil, fmt.Errorf("error decoding base64 data: %v", err)
-					}
-					stringRow[i] = string(decoded)
-				} else {
-					stringRow[i] = strVal
-				}
-			case string:
-				stringRow[i] = v
-			case nil:
-				stringRow[i] = nil
-			default:
-				stringRow[i] = fmt.Sprintf("%v", v)
-			}
+	var schemas []string
+	for rows.Next() {
+		var schema string
+		if err := rows.Scan(&schema); err != nil {
+			return nil, fmt.Errorf("error scanning schemas: %v", err)
 		}
-		results = append(results, stringRow)
+		schemas = append(schemas, schema)
 	}
 
-	// Check for errors from iterating over rows
+	// checking for errors in iterating over rows
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %v", err)
+		return nil, fmt.Errorf("error iterating over rows:%v", err)
 	}
 
-	// Convert the result to JSON
-	queryResult := types.QueryResult{
-		Columns: columns,
-		Rows:    results,
+	return schemas, nil
+}
+
+// Execute executes a query on a Snowflake database and returns the result as a JSON byte slice.
+func (s *Snowflake) Execute(query string) ([]byte, error) {
+	return s.ExecuteContext(context.Background(), query)
+}
+
+// ExecuteContext executes a query on a Snowflake database and returns the result as a JSON byte slice.
+// The query is cancelled when the context is done.
+func (s *Snowflake) ExecuteContext(ctx context.Context, query string, args ...interface{}) ([]byte, error) {
+	return s.ExecutePage(ctx, query, "", args...)
+}
+
+// ExecutePage executes the given SQL query and returns the page of its result selected by the page token as JSON,
+// the first page for an empty token. The page is limited by the MaxRows and MaxBytes of the configuration,
+// the NextPageToken of a truncated result selects the next page.
+func (s *Snowflake) ExecutePage(ctx context.Context, query, pageToken string, args ...interface{}) ([]byte, error) {
+	start := time.Now()
+	limits := governor.Limits{MaxRows: s.Config.MaxRows, MaxBytes: s.Config.MaxBytes}
+	queryResult, err := governor.Execute(ctx, s, types.Snowflake, limits, query, pageToken, args...)
+	if err != nil {
+		return nil, err
 	}
+	queryResult.Time = time.Since(start).Milliseconds()
 
+	// Convert the result to JSON
 	jsonData, err := json.Marshal(queryResult)
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling json: %v", err)
@@ -222,17 +538,176 @@ func (s *Snowflake) Execute(query string) ([]byte, error) {
 	return jsonData, nil
 }
 
-// isBase64 checks if a string is a valid base64 string.
-func isBase64(s string) bool {
-	if len(s)%4 != 0 {
-		return false
-	}
-	// Try to decode the string
-	_, err := base64.StdEncoding.DecodeString(s)
-	// If decoding succeeds, err will be nil, and the function will return true
-	// If decoding fails, err will not be nil, and the function will return false
-	// Also we do not have access to decoded value, so we are not using it
-	return err == nil
+// Query executes a query on a Snowflake database and returns an iterator over its rows.
+// The rows are read from the database as the iterator advances.
+func (s *Snowflake) Query(ctx context.Context, query string, args ...interface{}) (types.RowIterator, error) {
+	query, args, err := dialect.Bind(types.Snowflake, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error binding query arguments: %v", err)
+	}
+	if s.Config.ReadOnly {
+		if err := dialect.CheckReadOnly(types.Snowflake, query); err != nil {
+			return nil, err
+		}
+	}
+	rows, err := s.Client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+
+	it, err := types.NewRowIterator(rows, newValueConverter(types.BinaryEncoding(s.Config.BinaryEncoding)))
+	if err != nil {
+		_ = rows.Close()
+		return nil, err
+	}
+	return it, nil
+}
+
+// SetReadOnly enables or disables the read-only mode of the client, see config.Config.ReadOnly.
+// The configuration is copied, clients created with the same configuration keep their mode.
+func (s *Snowflake) SetReadOnly(readOnly bool) {
+	cfg := *s.Config
+	cfg.ReadOnly = readOnly
+	s.Config = &cfg
+}
+
+// Snapshot reads the schema of every table of the given database matching the options;
+// the columns, clustering keys and comments of the configured schema are read in bulk from information_schema.
+func (s *Snowflake) Snapshot(ctx context.Context, databaseName string, opts types.SnapshotOptions) (types.DatabaseSchema, error) {
+	return snapshot.Take(ctx, s, types.Snowflake, databaseName, opts)
+}
+
+// BulkSchemas reads the schemas of the given tables of the configured schema with four queries in total,
+// instead of four queries per table.
+func (s *Snowflake) BulkSchemas(ctx context.Context, databaseName string, tables []types.TableInfo) ([]types.Table, error) {
+	schema := s.qualify(types.TableName{Catalog: databaseName})
+	infoSchema := informationSchema(schema.Catalog)
+
+	rows, err := s.Client.QueryContext(ctx, fmt.Sprintf(SNOWFLAKE_SNAPSHOT_COLUMNS_QUERY, infoSchema), schema.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	columns := map[string][]types.Column{}
+	for rows.Next() {
+		var table string
+		var column types.Column
+		if err := rows.Scan(&table, &column.Name, &column.Type, &column.CharacterMaximumLength, &column.NumericPrecision, &column.NumericScale); err != nil {
+			return nil, fmt.Errorf("error scanning rows: %v", err)
+		}
+		column.Metatags = []string{column.Name}
+		column.Visibility = true // default visibility
+		columns[table] = append(columns[table], column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %v", err)
+	}
+
+	// the schema qualified name of the schema itself, empty for the current schema
+	schemaName := ""
+	if schema.Schema != "" {
+		schemaName = quoteTableName(types.TableName{Catalog: schema.Catalog, Schema: schema.Schema})
+	}
+	foreignKeys, err := s.importedKeys(ctx, fmt.Sprintf(SNOWFLAKE_SNAPSHOT_FOREIGN_KEYS_QUERY, schemaName))
+	if err != nil {
+		return nil, err
+	}
+
+	clusteringKeys, err := s.schemaClusteringKeys(ctx, infoSchema, schema.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.schemaComments(ctx, infoSchema, schema.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.Table, len(tables))
+	for i, t := range tables {
+		tableColumns := columns[t.Name]
+		indexes := []types.Index{}
+		if keyColumns := clusteringColumns(clusteringKeys[t.Name]); len(keyColumns) > 0 {
+			indexes = append(indexes, types.Index{Name: "clustering_key", Columns: keyColumns, Method: "clustering"})
+		}
+		types.ApplyIndexes(tableColumns, indexes)
+		description := types.ApplyComments(tableColumns, comments[t.Name])
+
+		tableForeignKeys := foreignKeys[t.Name]
+		if tableForeignKeys == nil {
+			tableForeignKeys = []types.ForeignKey{}
+		}
+		result[i] = types.Table{
+			Name:        t.Name,
+			Catalog:     schema.Catalog,
+			Schema:      t.Schema,
+			Columns:     tableColumns,
+			ColumnCount: int64(len(tableColumns)),
+			Description: description,
+			Metatags:    []string{},
+			ForeignKeys: tableForeignKeys,
+			Indexes:     indexes,
+		}
+	}
+	return result, nil
+}
+
+// schemaClusteringKeys retrieves the clustering keys of every table of a schema, keyed by table name.
+func (s *Snowflake) schemaClusteringKeys(ctx context.Context, infoSchema, schema string) (map[string]string, error) {
+	rows, err := s.Client.QueryContext(ctx, fmt.Sprintf(SNOWFLAKE_SNAPSHOT_CLUSTERING_KEYS_QUERY, infoSchema), schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing clustering key query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	keys := map[string]string{}
+	for rows.Next() {
+		var table, key string
+		if err := rows.Scan(&table, &key); err != nil {
+			return nil, fmt.Errorf("error scanning clustering keys: %v", err)
+		}
+		keys[table] = key
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over clustering keys: %v", err)
+	}
+	return keys, nil
+}
+
+// schemaComments retrieves the comments of every table and column of a schema, keyed by table name.
+func (s *Snowflake) schemaComments(ctx context.Context, infoSchema, schema string) (map[string][]types.Comment, error) {
+	rows, err := s.Client.QueryContext(ctx, fmt.Sprintf(SNOWFLAKE_SNAPSHOT_COMMENTS_QUERY, infoSchema), schema, schema)
+	if err != nil {
+		return nil, fmt.Errorf("error executing comment query: %v", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	comments := map[string][]types.Comment{}
+	for rows.Next() {
+		var table string
+		var c types.Comment
+		if err := rows.Scan(&table, &c.Column, &c.Text); err != nil {
+			return nil, fmt.Errorf("error scanning comments: %v", err)
+		}
+		comments[table] = append(comments[table], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over comments: %v", err)
+	}
+	return comments, nil
 }
 
 // GenerateCreateTableQuery generates a CREATE TABLE query for Snowflake.
@@ -257,10 +732,17 @@ func (s *Snowflake) GenerateCreateTableQuery(table types.Table) string {
 		if column.IsNullable == "NO" && !column.IsPrimary {
 			query += " NOT NULL"
 		}
+		if column.Description != "" {
+			query += " COMMENT " + dialect.QuoteString(types.Snowflake, column.Description)
+		}
 		if i < len(table.Columns)-1 {
 			query += ", "
 		}
 	}
-	query += ");"
+	query += ")"
+	if table.Description != "" {
+		query += " COMMENT = " + dialect.QuoteString(types.Snowflake, table.Description)
+	}
+	query += ";"
 	return query
 }
@@ -1,12 +1,14 @@
 package snowflake
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/thesaas-company/xray/config"
@@ -37,10 +39,19 @@ func TestSchema(t *testing.T) {
 	table_name := "user"
 
 	// mock rows to be returned by the query
-	columns := []string{"name", "type"}
-	mockRows := sqlmock.NewRows(columns).AddRow("id", "int").AddRow("name", "varchar")
+	columns := []string{"name", "type", "character_maximum_length", "numeric_precision", "numeric_scale"}
+	mockRows := sqlmock.NewRows(columns).AddRow("id", "NUMBER", nil, 38, 0).AddRow("name", "TEXT", 16777216, nil, nil)
 	// set the expected return values for the query
-	mock.ExpectQuery(regexp.QuoteMeta(SNOWFLAKE_SCHEMA_QUERY)).WithArgs(table_name).WillReturnRows(mockRows)
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_SCHEMA_QUERY, "information_schema"))).WithArgs(table_name, "").WillReturnRows(mockRows)
+	// SHOW IMPORTED KEYS returns its rows in no particular order
+	fkRows := sqlmock.NewRows([]string{"created_on", "pk_database_name", "pk_schema_name", "pk_table_name", "pk_column_name", "fk_database_name", "fk_schema_name", "fk_table_name", "fk_column_name", "key_sequence", "update_rule", "delete_rule", "fk_name", "pk_name"}).
+		AddRow("2024-05-01", "DB", "PUBLIC", "TEAM", "ID", "DB", "PUBLIC", "USER", "TEAM_ID", "2", "NO ACTION", "CASCADE", "FK_USER_TEAM", "PK_TEAM").
+		AddRow("2024-05-01", "DB", "PUBLIC", "TEAM", "ORG_ID", "DB", "PUBLIC", "USER", "ORG_ID", "1", "NO ACTION", "CASCADE", "FK_USER_TEAM", "PK_TEAM")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_FOREIGN_KEYS_QUERY, `"`+table_name+`"`))).WillReturnRows(fkRows)
+	clusteringRows := sqlmock.NewRows([]string{"clustering_key"}).AddRow("LINEAR(id, SUBSTRING(name, 1, 3))")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_CLUSTERING_KEY_QUERY, "information_schema"))).WithArgs(table_name, "").WillReturnRows(clusteringRows)
+	commentRows := sqlmock.NewRows([]string{"column_name", "comment"}).AddRow("", "Registered users").AddRow("id", "Surrogate key")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_COMMENTS_QUERY, "information_schema"))).WithArgs(table_name, "", table_name, "").WillReturnRows(commentRows)
 
 	s, err := NewSnowflake(db) // create a new instance of our Snowflake object
 	if err != nil {
@@ -52,6 +63,41 @@ func TestSchema(t *testing.T) {
 		t.Errorf("error executing query : %v", err)
 	}
 
+	wantForeignKeys := []types.ForeignKey{{
+		Name:              "FK_USER_TEAM",
+		Columns:           []string{"ORG_ID", "TEAM_ID"},
+		ReferencedSchema:  "PUBLIC",
+		ReferencedTable:   "TEAM",
+		ReferencedColumns: []string{"ORG_ID", "ID"},
+		OnDelete:          "CASCADE",
+		OnUpdate:          "NO ACTION",
+	}}
+	if !reflect.DeepEqual(res.ForeignKeys, wantForeignKeys) {
+		t.Errorf("foreign keys = %+v, want %+v", res.ForeignKeys, wantForeignKeys)
+	}
+
+	wantIndexes := []types.Index{
+		{Name: "clustering_key", Columns: []string{"id", "SUBSTRING(name, 1, 3)"}, Method: "clustering"},
+	}
+	if !reflect.DeepEqual(res.Indexes, wantIndexes) {
+		t.Errorf("indexes = %+v, want %+v", res.Indexes, wantIndexes)
+	}
+	if column := res.Columns[0]; column.IsIndex != true || column.IsUnique.String != "NO" {
+		t.Errorf("column %s: IsIndex = %v, IsUnique = %q", column.Name, column.IsIndex, column.IsUnique.String)
+	}
+	if column := res.Columns[1]; column.IsIndex != false || column.IsUnique.String != "NO" {
+		t.Errorf("column %s: IsIndex = %v, IsUnique = %q", column.Name, column.IsIndex, column.IsUnique.String)
+	}
+	if res.Description != "Registered users" || res.Columns[0].Description != "Surrogate key" {
+		t.Errorf("descriptions = %q, %q", res.Description, res.Columns[0].Description)
+	}
+	if column := res.Columns[0]; column.NumericPrecision.Int64 != 38 || !column.NumericScale.Valid {
+		t.Errorf("column %s: NumericPrecision = %v, NumericScale = %v", column.Name, column.NumericPrecision, column.NumericScale)
+	}
+	if column := res.Columns[1]; column.CharacterMaximumLength.Int64 != 16777216 {
+		t.Errorf("column %s: CharacterMaximumLength = %v", column.Name, column.CharacterMaximumLength)
+	}
+
 	fmt.Printf("Table schema %+v\n", res)
 
 	if err := mock.ExpectationsWereMet(); err != nil {
@@ -116,8 +162,8 @@ func TestTables(t *testing.T) {
         AddRow(tableList[0]).
         AddRow(tableList[1]).
         AddRow(tableList[2])
-    query := fmt.Sprintf(SNOWFLAKE_TABLES_LIST_QUERY, databaseName, schemaName)
-    mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(rows)
+    query := fmt.Sprintf(SNOWFLAKE_TABLES_LIST_QUERY, `"TEST".information_schema`)
+    mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(schemaName).WillReturnRows(rows)
 
     s := &Snowflake{Client: db, Config: &config.Config{Schema: schemaName}} // create a new instance of our Snowflake object
 
@@ -188,3 +234,434 @@ func TestGenerateCreateTableQuery(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+// TestGenerateCreateTableQueryComments is a unit test function that tests that the GenerateCreateTableQuery method of the
+// Snowflake struct emits the table and column descriptions as COMMENT clauses.
+func TestGenerateCreateTableQueryComments(t *testing.T) {
+	table := types.Table{
+		Name:        "user",
+		Description: "Registered users",
+		Columns: []types.Column{
+			{Name: "id", Type: "int", Description: "User's key"},
+			{Name: "age", Type: "int"},
+		},
+	}
+
+	s := &Snowflake{}
+	query := s.GenerateCreateTableQuery(table)
+
+	expectedQuery := "CREATE TABLE user (id INT COMMENT 'User''s key', age INT) COMMENT = 'Registered users';"
+	if query != expectedQuery {
+		t.Errorf("Expected '%s', but got '%s'", expectedQuery, query)
+	}
+}
+
+// TestSnapshot is a unit test function that tests that the Snapshot method of the Snowflake struct reads the
+// whole schema with bulk queries.
+func TestSnapshot(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	tableRows := sqlmock.NewRows([]string{"table_name", "table_schema", "kind", "definition"}).
+		AddRow("ORDERS", "PUBLIC", "table", "").
+		AddRow("USERS", "PUBLIC", "table", "")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_LIST_TABLES_QUERY, `"DB".information_schema`))).WithArgs("PUBLIC").WillReturnRows(tableRows)
+	columnRows := sqlmock.NewRows([]string{"table_name", "column_name", "data_type", "character_maximum_length", "numeric_precision", "numeric_scale"}).
+		AddRow("ORDERS", "ID", "NUMBER", nil, 38, 0).
+		AddRow("ORDERS", "USER_ID", "NUMBER", nil, 38, 0).
+		AddRow("USERS", "ID", "NUMBER", nil, 12, 2)
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_SNAPSHOT_COLUMNS_QUERY, `"DB".information_schema`))).WithArgs("PUBLIC").WillReturnRows(columnRows)
+	fkRows := sqlmock.NewRows([]string{"pk_schema_name", "pk_table_name", "pk_column_name", "fk_table_name", "fk_column_name", "key_sequence", "update_rule", "delete_rule", "fk_name"}).
+		AddRow("PUBLIC", "USERS", "ID", "ORDERS", "USER_ID", "1", "NO ACTION", "CASCADE", "FK_ORDERS_USER")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_SNAPSHOT_FOREIGN_KEYS_QUERY, `"DB"."PUBLIC"`))).WillReturnRows(fkRows)
+	clusteringRows := sqlmock.NewRows([]string{"table_name", "clustering_key"}).AddRow("ORDERS", "LINEAR(USER_ID)")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_SNAPSHOT_CLUSTERING_KEYS_QUERY, `"DB".information_schema`))).WithArgs("PUBLIC").WillReturnRows(clusteringRows)
+	commentRows := sqlmock.NewRows([]string{"table_name", "column_name", "comment"}).AddRow("USERS", "", "Registered users")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_SNAPSHOT_COMMENTS_QUERY, `"DB".information_schema`))).WithArgs("PUBLIC", "PUBLIC").WillReturnRows(commentRows)
+
+	s := &Snowflake{Client: db, Config: &config.Config{Database: "DB", Schema: "PUBLIC"}}
+	schema, err := s.Snapshot(context.Background(), "DB", types.SnapshotOptions{})
+	if err != nil {
+		t.Fatalf("error taking snapshot: %s", err)
+	}
+
+	if len(schema.Tables) != 2 {
+		t.Fatalf("expected 2 tables, got %d", len(schema.Tables))
+	}
+	orders, users := schema.Tables[0], schema.Tables[1]
+	wantForeignKeys := []types.ForeignKey{{
+		Name:              "FK_ORDERS_USER",
+		Columns:           []string{"USER_ID"},
+		ReferencedSchema:  "PUBLIC",
+		ReferencedTable:   "USERS",
+		ReferencedColumns: []string{"ID"},
+		OnDelete:          "CASCADE",
+		OnUpdate:          "NO ACTION",
+	}}
+	if !reflect.DeepEqual(orders.ForeignKeys, wantForeignKeys) {
+		t.Errorf("foreign keys = %+v, want %+v", orders.ForeignKeys, wantForeignKeys)
+	}
+	if len(orders.Indexes) != 1 || !orders.Columns[1].IsIndex {
+		t.Errorf("indexes = %+v", orders.Indexes)
+	}
+	if users.Description != "Registered users" || len(users.ForeignKeys) != 0 || len(users.Columns) != 1 {
+		t.Errorf("users = %+v", users)
+	}
+	if column := users.Columns[0]; column.NumericPrecision.Int64 != 12 || column.NumericScale.Int64 != 2 {
+		t.Errorf("column %s: NumericPrecision = %v, NumericScale = %v", column.Name, column.NumericPrecision, column.NumericScale)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestHostileNames is a unit test function that tests that hostile database and table names are quoted.
+func TestHostileNames(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_TABLES_LIST_QUERY, `"x""; DROP DATABASE prod; --".information_schema`))).
+		WithArgs("public' OR '1'='1").WillReturnError(fmt.Errorf("database does not exist"))
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_SCHEMA_LIST_QUERY, `"a.b".information_schema`))).
+		WillReturnError(fmt.Errorf("database does not exist"))
+
+	s := &Snowflake{Client: db, Config: &config.Config{Schema: "public' OR '1'='1"}}
+	if _, err := s.Tables(`x"; DROP DATABASE prod; --`); err == nil {
+		t.Error("expected an error")
+	}
+	if _, err := s.Schemas(context.Background(), "a.b"); err == nil {
+		t.Error("expected an error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestEstimate is a unit test function that tests reading the bytes a query scans from its JSON plan.
+func TestEstimate(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	plan := `{"GlobalStats":{"partitionsTotal":120,"partitionsAssigned":8,"bytesAssigned":52428800},"Operations":[[{"id":0,"operation":"Result"}]]}`
+	mock.ExpectQuery(regexp.QuoteMeta("EXPLAIN USING JSON SELECT * FROM events WHERE day = '2024-01-01'")).
+		WillReturnRows(sqlmock.NewRows([]string{"content"}).AddRow(plan))
+
+	s := &Snowflake{Client: db, Config: &config.Config{}}
+	estimate, err := s.Estimate(context.Background(), "SELECT * FROM events WHERE day = '2024-01-01'")
+	if err != nil {
+		t.Fatalf("error estimating the query: %s", err)
+	}
+	if estimate.BytesProcessed != 52428800 || estimate.Plan != plan {
+		t.Errorf("Estimate() = %+v, want 52428800 bytes", estimate)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestExplain is a unit test function that tests converting the JSON plan and the operator statistics of Snowflake to plan trees.
+func TestExplain(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	query := "SELECT day, COUNT(*) FROM events GROUP BY day"
+	plan := `{"GlobalStats":{"bytesAssigned":1024},"Operations":[[{"id":0,"operation":"Result"},{"id":1,"parent":0,"operation":"Aggregate"},{"id":2,"parent":1,"operation":"TableScan","objects":["DB.PUBLIC.EVENTS"]}]]}`
+	mock.ExpectQuery(regexp.QuoteMeta("EXPLAIN USING JSON " + query)).
+		WillReturnRows(sqlmock.NewRows([]string{"content"}).AddRow(plan))
+
+	s := &Snowflake{Client: db, Config: &config.Config{}}
+	got, err := s.Explain(context.Background(), query, false)
+	if err != nil {
+		t.Fatalf("error explaining the query: %s", err)
+	}
+	want := &types.PlanNode{Type: "Result", Children: []*types.PlanNode{
+		{Type: "Aggregate", Children: []*types.PlanNode{{Type: "TableScan", Relation: "DB.PUBLIC.EVENTS"}}},
+	}}
+	if !reflect.DeepEqual(got.Root, want) {
+		t.Errorf("Explain() = %+v, want %+v", got.Root, want)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"day", "count"}).AddRow("2024-01-01", 3))
+	mock.ExpectQuery(regexp.QuoteMeta(SNOWFLAKE_OPERATOR_STATS_QUERY)).WillReturnRows(
+		sqlmock.NewRows([]string{"STEP_ID", "OPERATOR_ID", "PARENT_OPERATORS", "OPERATOR_TYPE", "OPERATOR_STATISTICS", "OPERATOR_ATTRIBUTES"}).
+			AddRow(1, 0, nil, "Result", `{"output_rows": 1}`, nil).
+			AddRow(1, 1, "[0]", "Aggregate", `{"output_rows": 1}`, nil).
+			AddRow(1, 2, "[1]", "TableScan", `{"output_rows": 3}`, `{"table_name": "DB.PUBLIC.EVENTS"}`))
+	mock.ExpectRollback()
+
+	got, err = s.Explain(context.Background(), query, true)
+	if err != nil {
+		t.Fatalf("error explaining the query: %s", err)
+	}
+	want = &types.PlanNode{Type: "Result", ActualRows: 1, Children: []*types.PlanNode{
+		{Type: "Aggregate", ActualRows: 1, Children: []*types.PlanNode{{Type: "TableScan", Relation: "DB.PUBLIC.EVENTS", ActualRows: 3}}},
+	}}
+	if !got.Analyzed || !reflect.DeepEqual(got.Root, want) {
+		t.Errorf("Explain() = %+v, want %+v", got.Root, want)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestBegin is a unit test function that tests starting an explicit transaction, READ COMMITTED is the only isolation level.
+func TestBegin(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO events (day) VALUES (?)")).WithArgs("2024-01-01").
+		WillReturnRows(sqlmock.NewRows([]string{"number of rows inserted"}).AddRow(1))
+	mock.ExpectCommit()
+
+	s := &Snowflake{Client: db, Config: &config.Config{}}
+	tx, err := s.Begin(context.Background(), types.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if _, err := tx.Execute(context.Background(), "INSERT INTO events (day) VALUES (?)", "2024-01-01"); err != nil {
+		t.Fatalf("error executing statement: %s", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Errorf("error committing transaction: %s", err)
+	}
+
+	if _, err := s.Begin(context.Background(), types.TxOptions{Isolation: sql.LevelSerializable}); err == nil {
+		t.Error("expected an error for an unsupported isolation level")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestExecuteReadOnly is a unit test function that tests that read-only mode rejects writes before they reach the database.
+func TestExecuteReadOnly(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	query := "SELECT COUNT(*) FROM events"
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(3))
+
+	s := &Snowflake{Client: db, Config: &config.Config{ReadOnly: true}}
+	if _, err := s.Execute(query); err != nil {
+		t.Errorf("error executing query: %s", err)
+	}
+	// Snowflake has no read-only transaction, statements following a SELECT are rejected
+	for _, write := range []string{
+		"SELECT 1 UNDROP TABLE events",
+		"SELECT 1 USE ROLE ACCOUNTADMIN",
+		"SELECT 1 GRANT ROLE ACCOUNTADMIN TO USER intruder",
+	} {
+		if _, err := s.Execute(write); err == nil {
+			t.Errorf("Execute(%q): expected an error", write)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestTableStats is a unit test function that tests reading the row count, size and modification times of a table.
+func TestTableStats(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	lastDDL := time.Date(2024, 4, 2, 8, 0, 0, 0, time.UTC)
+	lastAltered := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"row_count", "bytes", "last_ddl", "last_altered"}).AddRow(5000, 1048576, lastDDL, lastAltered)
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_TABLE_STATS_QUERY, `"DB".information_schema`))).
+		WithArgs("ORDERS", "SALES").WillReturnRows(rows)
+
+	s := &Snowflake{Client: db, Config: &config.Config{Database: "DB", Schema: "PUBLIC"}}
+	stats, err := s.TableStats("SALES.ORDERS")
+	if err != nil {
+		t.Fatalf("error reading table statistics: %s", err)
+	}
+
+	expected := types.TableStats{
+		Name:         "ORDERS",
+		Schema:       "SALES",
+		RowCount:     5000,
+		Bytes:        1048576,
+		LastDDL:      sql.NullTime{Time: lastDDL, Valid: true},
+		LastModified: sql.NullTime{Time: lastAltered, Valid: true},
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("expected: %+v, got: %+v", expected, stats)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestLowerCaseConfiguredDatabase is a unit test function that tests that a lower case database name from the
+// configuration resolves like an unquoted name, while table names read from the catalog are matched exactly.
+func TestLowerCaseConfiguredDatabase(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_SCHEMA_QUERY, `"MYDB".information_schema`))).
+		WithArgs("Orders", "sales").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "character_maximum_length", "numeric_precision", "numeric_scale"}).AddRow("ID", "NUMBER", nil, 38, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_FOREIGN_KEYS_QUERY, `"MYDB"."SALES"."Orders"`))).
+		WillReturnRows(sqlmock.NewRows([]string{"pk_schema_name", "pk_table_name", "pk_column_name", "fk_table_name", "fk_column_name", "key_sequence", "update_rule", "delete_rule", "fk_name"}))
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_CLUSTERING_KEY_QUERY, `"MYDB".information_schema`))).
+		WithArgs("Orders", "sales").WillReturnRows(sqlmock.NewRows([]string{"clustering_key"}))
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_COMMENTS_QUERY, `"MYDB".information_schema`))).
+		WillReturnRows(sqlmock.NewRows([]string{"column_name", "comment"}))
+
+	s := &Snowflake{Client: db, Config: &config.Config{Database: "mydb", Schema: "sales"}}
+	if _, err := s.Schema("Orders"); err != nil {
+		t.Errorf("error executing query: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestListTables is a unit test function that tests the ListTables method of the Snowflake struct.
+// The kinds are filtered and the dependencies of the views are read from their definitions.
+func TestListTables(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	rows := sqlmock.NewRows([]string{"table_name", "table_schema", "kind", "definition"}).
+		AddRow("EVENTS", "PUBLIC", "external_table", "").
+		AddRow("ORDERS", "PUBLIC", "table", "").
+		AddRow("ORDER_TOTALS", "PUBLIC", "materialized_view", "create materialized view ORDER_TOTALS as select USER_ID, sum(TOTAL) from ORDERS group by USER_ID;").
+		AddRow("RECENT_ORDERS", "PUBLIC", "view", "create view RECENT_ORDERS as select * from ORDERS o join CRM.USERS u on u.ID = o.USER_ID;")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_LIST_TABLES_QUERY, `"DB".information_schema`))).WithArgs("PUBLIC").WillReturnRows(rows)
+
+	s := &Snowflake{Client: db, Config: &config.Config{Database: "DB", Schema: "PUBLIC"}}
+	tables, err := s.ListTables(context.Background(), "", types.ListTablesOptions{Kinds: []types.TableKind{types.KindTable, types.KindView, types.KindMaterializedView}})
+	if err != nil {
+		t.Fatalf("error listing tables: %s", err)
+	}
+
+	expected := []types.TableInfo{
+		{Name: "ORDERS", Schema: "PUBLIC", Kind: types.KindTable},
+		{Name: "ORDER_TOTALS", Schema: "PUBLIC", Kind: types.KindMaterializedView, Definition: "create materialized view ORDER_TOTALS as select USER_ID, sum(TOTAL) from ORDERS group by USER_ID;",
+			Dependencies: []types.TableName{{Schema: "PUBLIC", Name: "ORDERS"}}},
+		{Name: "RECENT_ORDERS", Schema: "PUBLIC", Kind: types.KindView, Definition: "create view RECENT_ORDERS as select * from ORDERS o join CRM.USERS u on u.ID = o.USER_ID;",
+			Dependencies: []types.TableName{{Schema: "PUBLIC", Name: "ORDERS"}, {Schema: "CRM", Name: "USERS"}}},
+	}
+	if !reflect.DeepEqual(tables, expected) {
+		t.Errorf("expected: %+v, got: %+v", expected, tables)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestExecuteScript is a unit test function that tests running a script with a $$ procedure body, the rows
+// returned by the procedure call are kept.
+func TestExecuteScript(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	body := "CREATE PROCEDURE recent_events() RETURNS TABLE (day DATE) LANGUAGE SQL AS $$ BEGIN LET r RESULTSET := (SELECT day FROM events); RETURN TABLE(r); END; $$"
+	mock.ExpectExec(regexp.QuoteMeta(body)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("CALL recent_events()")).WillReturnRows(sqlmock.NewRows([]string{"DAY"}).AddRow("2024-01-01"))
+
+	s := &Snowflake{Client: db, Config: &config.Config{}}
+	result, err := s.ExecuteScript(context.Background(), body+";\nCALL recent_events();", types.ScriptOptions{})
+	if err != nil {
+		t.Fatalf("error executing script: %s", err)
+	}
+	if len(result.Statements) != 2 || result.Statements[1].Result == nil {
+		t.Errorf("ExecuteScript() = %+v, want 2 statements and the rows of the procedure", result)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSchemas is a unit test function that tests the Schemas method of the Snowflake struct, the configured database is listed when the name is empty.
+func TestSchemas(t *testing.T) {
+	db, mock := MockDB()
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	rows := sqlmock.NewRows([]string{"schema_name"}).AddRow("PUBLIC").AddRow("SALES")
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(SNOWFLAKE_SCHEMA_LIST_QUERY, `"DB".information_schema`))).WillReturnRows(rows)
+
+	s := &Snowflake{Client: db, Config: &config.Config{Database: "DB"}}
+	schemas, err := s.Schemas(context.Background(), "")
+	if err != nil {
+		t.Errorf("error retrieving schemas: %s", err)
+	}
+
+	expected := []string{"PUBLIC", "SALES"}
+	if !reflect.DeepEqual(schemas, expected) {
+		t.Errorf("expected: %v, got: %v", expected, schemas)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSetReadOnly is a unit test function that tests that the read-only mode of a Snowflake client does not change
+// the mode of the other clients sharing its configuration.
+func TestSetReadOnly(t *testing.T) {
+	cfg := &config.Config{}
+	s, other := &Snowflake{Config: cfg}, &Snowflake{Config: cfg}
+	s.SetReadOnly(true)
+	if !s.Config.ReadOnly || other.Config.ReadOnly || cfg.ReadOnly {
+		t.Errorf("SetReadOnly(true) changed the shared configuration %+v", cfg)
+	}
+}
@@ -0,0 +1,40 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/thesaas-company/xray/governor"
+	"github.com/thesaas-company/xray/script"
+	"github.com/thesaas-company/xray/session"
+	"github.com/thesaas-company/xray/types"
+)
+
+// Begin starts an explicit transaction with BEGIN. Snowflake transactions are READ COMMITTED, so only the default
+// level and READ COMMITTED are accepted; read-only transactions are checked with dialect.CheckReadOnly.
+// DDL statements commit the open transaction in Snowflake.
+func (s *Snowflake) Begin(ctx context.Context, opts types.TxOptions) (types.Tx, error) {
+	return session.Begin(ctx, s.Client, s.sessionConfig(), opts)
+}
+
+// Session pins a connection of the pool, so that SET options and temporary tables hold between statements.
+func (s *Snowflake) Session(ctx context.Context) (types.Session, error) {
+	return session.Open(ctx, s.Client, s.sessionConfig())
+}
+
+// ExecuteScript runs the statements of a script one after the other in a session, $$ bodies are kept whole.
+func (s *Snowflake) ExecuteScript(ctx context.Context, query string, opts types.ScriptOptions) (types.ScriptResult, error) {
+	return script.Run(ctx, s, types.Snowflake, query, opts)
+}
+
+// sessionConfig describes how the statements of transactions and sessions run.
+func (s *Snowflake) sessionConfig() session.Config {
+	return session.Config{
+		DbType:           types.Snowflake,
+		Convert:          newValueConverter(types.BinaryEncoding(s.Config.BinaryEncoding)),
+		Limits:           governor.Limits{MaxRows: s.Config.MaxRows, MaxBytes: s.Config.MaxBytes},
+		ReadOnly:         s.Config.ReadOnly,
+		DefaultIsolation: sql.LevelReadCommitted,
+		ReadOnlyTx:       false,
+	}
+}
@@ -0,0 +1,65 @@
+package snowflake
+
+import (
+	"strings"
+	"time"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// newValueConverter returns the converter for values scanned from Snowflake.
+// gosnowflake returns most values as strings, so they are parsed back using the column type:
+// FIXED columns become integers (or exact decimal strings when they have a scale), REAL becomes a float,
+// BOOLEAN a bool, VARIANT/OBJECT/ARRAY nested JSON and temporal values RFC3339 strings.
+// BINARY values are encoded with the given encoding; textual values are never altered.
+func newValueConverter(binary types.BinaryEncoding) types.ValueConverter {
+	return func(column types.ColumnMeta, v interface{}) (interface{}, error) {
+		return convertValue(column, v, binary)
+	}
+}
+
+// convertValue converts a single value scanned from Snowflake.
+func convertValue(column types.ColumnMeta, val interface{}, binary types.BinaryEncoding) (interface{}, error) {
+	typeName := strings.ToUpper(column.DatabaseType)
+	switch v := val.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return convertString(column, typeName, v), nil
+	case float64:
+		return types.FloatValue(v), nil
+	case time.Time:
+		switch typeName {
+		case "DATE":
+			return v.Format(types.DateLayout), nil
+		case "TIME":
+			return v.Format(types.TimeLayout), nil
+		}
+		return types.TimestampValue(v), nil
+	case []byte:
+		// gosnowflake only returns []byte for BINARY columns
+		return binary.Encode(v)
+	default:
+		// structured OBJECT, ARRAY and MAP values are already decoded by the driver
+		return v, nil
+	}
+}
+
+// convertString converts the string representation of a Snowflake value by its column type.
+func convertString(column types.ColumnMeta, typeName, v string) interface{} {
+	switch typeName {
+	case "FIXED", "NUMBER":
+		if column.Scale > 0 {
+			return v
+		}
+		return types.IntValue(v)
+	case "REAL", "FLOAT":
+		return types.ParseFloatValue(v)
+	case "BOOLEAN":
+		return types.BoolValue(v)
+	case "VARIANT", "OBJECT", "ARRAY", "MAP":
+		return types.JSONValue([]byte(v))
+	default:
+		return v
+	}
+}
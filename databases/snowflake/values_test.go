@@ -0,0 +1,44 @@
+package snowflake
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestConvertValue is a unit test function that tests how Snowflake values are mapped to JSON friendly values.
+func TestConvertValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		column types.ColumnMeta
+		binary types.BinaryEncoding
+		value  interface{}
+		want   interface{}
+	}{
+		{name: "null", column: types.ColumnMeta{DatabaseType: "TEXT"}, value: nil, want: nil},
+		{name: "integer", column: types.ColumnMeta{DatabaseType: "FIXED"}, value: "42", want: int64(42)},
+		{name: "decimal", column: types.ColumnMeta{DatabaseType: "FIXED", Precision: 10, Scale: 2}, value: "4.20", want: "4.20"},
+		{name: "real", column: types.ColumnMeta{DatabaseType: "REAL"}, value: "1.5", want: 1.5},
+		{name: "boolean", column: types.ColumnMeta{DatabaseType: "BOOLEAN"}, value: "1", want: true},
+		{name: "variant", column: types.ColumnMeta{DatabaseType: "VARIANT"}, value: `{"a": [1, 2]}`, want: json.RawMessage(`{"a": [1, 2]}`)},
+		{name: "date", column: types.ColumnMeta{DatabaseType: "DATE"}, value: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), want: "2024-05-01"},
+		{name: "timestamp", column: types.ColumnMeta{DatabaseType: "TIMESTAMP_NTZ"}, value: time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC), want: "2024-05-01T10:20:30Z"},
+		{name: "base64 looking text", column: types.ColumnMeta{DatabaseType: "TEXT"}, value: "abcd", want: "abcd"},
+		{name: "binary", column: types.ColumnMeta{DatabaseType: "BINARY"}, value: []byte("abcd"), want: "YWJjZA=="},
+		{name: "binary as hex", column: types.ColumnMeta{DatabaseType: "BINARY"}, binary: types.BinaryHex, value: []byte("abcd"), want: "61626364"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertValue(tt.column, tt.value, tt.binary)
+			if err != nil {
+				t.Fatalf("convertValue() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("convertValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package dialect
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// Bind rewrites the bind parameters of query into the native placeholder syntax of dbType and
+// returns the arguments in the order the driver expects them.
+//
+// Positional parameters may be written as ?, $1 or @p1 regardless of the target database.
+// Named parameters are written as @name or :name and supplied with sql.Named.
+// When a query uses numbered parameters ($1, @p1), a bare ? is left untouched so that operators
+// such as the Postgres JSONB ? operator keep working.
+func Bind(dbType types.DbType, query string, args []interface{}) (string, []interface{}, error) {
+	if len(args) == 0 {
+		return query, args, nil
+	}
+
+	var positional []interface{}
+	named := map[string]sql.NamedArg{}
+	for _, arg := range args {
+		if n, ok := arg.(sql.NamedArg); ok {
+			named[n.Name] = n
+			continue
+		}
+		positional = append(positional, arg)
+	}
+
+	tokens := Tokenize(dbType, query)
+	numbered := false
+	for _, t := range tokens {
+		if _, ok := numberedIndex(t); ok {
+			numbered = true
+			break
+		}
+	}
+
+	b := binder{dbType: dbType, positional: positional, named: named, namedIndex: map[string]int{}}
+	var out strings.Builder
+	next := 0
+	for _, t := range tokens {
+		if t.Kind != Placeholder {
+			out.WriteString(t.Text)
+			continue
+		}
+
+		if index, ok := numberedIndex(t); ok {
+			text, err := b.positionalRef(index)
+			if err != nil {
+				return "", nil, err
+			}
+			out.WriteString(text)
+			continue
+		}
+
+		if t.Text == "?" && !numbered {
+			text, err := b.positionalRef(next)
+			if err != nil {
+				return "", nil, err
+			}
+			next++
+			out.WriteString(text)
+			continue
+		}
+
+		if name, ok := namedRef(t); ok {
+			if _, found := named[name]; found {
+				out.WriteString(b.namedRef(name))
+				continue
+			}
+		}
+
+		out.WriteString(t.Text)
+	}
+
+	if b.positionalUsed && b.namedUsed && dbType == types.BigQuery {
+		return "", nil, fmt.Errorf("bigquery does not support mixing positional and named parameters")
+	}
+
+	return out.String(), b.args(), nil
+}
+
+// binder accumulates the rewritten arguments while Bind walks the query.
+type binder struct {
+	dbType     types.DbType
+	positional []interface{}
+	named      map[string]sql.NamedArg
+
+	ordered        []interface{}  // arguments in occurrence order for ? style databases
+	namedValues    []interface{}  // named argument values appended after the positional ones
+	namedIndex     map[string]int // output number assigned to each named argument
+	positionalUsed bool
+	namedUsed      bool
+}
+
+// positionalRef returns the native placeholder for the positional argument at index.
+func (b *binder) positionalRef(index int) (string, error) {
+	if index < 0 || index >= len(b.positional) {
+		return "", fmt.Errorf("query references parameter %d but only %d positional arguments were given", index+1, len(b.positional))
+	}
+	b.positionalUsed = true
+
+	switch b.dbType {
+	case types.Postgres, types.Redshift:
+		return "$" + strconv.Itoa(index+1), nil
+	case types.MSSQL:
+		return "@p" + strconv.Itoa(index+1), nil
+	default:
+		b.ordered = append(b.ordered, b.positional[index])
+		return "?", nil
+	}
+}
+
+// namedRef returns the native placeholder for the named argument.
+func (b *binder) namedRef(name string) string {
+	b.namedUsed = true
+	arg := b.named[name]
+
+	switch b.dbType {
+	case types.Postgres, types.Redshift:
+		number, ok := b.namedIndex[name]
+		if !ok {
+			b.namedValues = append(b.namedValues, arg.Value)
+			number = len(b.positional) + len(b.namedValues)
+			b.namedIndex[name] = number
+		}
+		return "$" + strconv.Itoa(number)
+	case types.MSSQL, types.BigQuery:
+		if _, ok := b.namedIndex[name]; !ok {
+			b.namedValues = append(b.namedValues, arg)
+			b.namedIndex[name] = len(b.namedValues)
+		}
+		return "@" + name
+	default:
+		b.ordered = append(b.ordered, arg.Value)
+		return "?"
+	}
+}
+
+// args returns the arguments to pass to the driver.
+func (b *binder) args() []interface{} {
+	switch b.dbType {
+	case types.Postgres, types.Redshift, types.MSSQL:
+		return append(append([]interface{}{}, b.positional...), b.namedValues...)
+	case types.BigQuery:
+		if b.namedUsed {
+			return b.namedValues
+		}
+		return b.ordered
+	default:
+		return b.ordered
+	}
+}
+
+// numberedIndex returns the zero based index of a $1 or @p1 placeholder.
+func numberedIndex(t Token) (int, bool) {
+	if t.Kind != Placeholder {
+		return 0, false
+	}
+	var digits string
+	switch {
+	case strings.HasPrefix(t.Text, "$"):
+		digits = t.Text[1:]
+	case len(t.Text) > 2 && (strings.HasPrefix(t.Text, "@p") || strings.HasPrefix(t.Text, "@P")):
+		digits = t.Text[2:]
+	default:
+		return 0, false
+	}
+	n, err := strconv.Atoi(digits)
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n - 1, true
+}
+
+// namedRef returns the name of a @name or :name placeholder.
+func namedRef(t Token) (string, bool) {
+	if strings.HasPrefix(t.Text, "@@") || len(t.Text) < 2 {
+		return "", false
+	}
+	if t.Text[0] == '@' || t.Text[0] == ':' {
+		return t.Text[1:], true
+	}
+	return "", false
+}
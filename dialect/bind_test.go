@@ -0,0 +1,134 @@
+package dialect
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestBind is a unit test function that tests the placeholder rewriting of Bind for every database.
+func TestBind(t *testing.T) {
+	tests := []struct {
+		name      string
+		dbType    types.DbType
+		query     string
+		args      []interface{}
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "no args leaves the query untouched",
+			dbType:    types.Postgres,
+			query:     "SELECT data ? 'key' FROM t",
+			wantQuery: "SELECT data ? 'key' FROM t",
+		},
+		{
+			name:      "question marks to postgres",
+			dbType:    types.Postgres,
+			query:     "SELECT * FROM t WHERE a = ? AND b = ?",
+			args:      []interface{}{1, "x"},
+			wantQuery: "SELECT * FROM t WHERE a = $1 AND b = $2",
+			wantArgs:  []interface{}{1, "x"},
+		},
+		{
+			name:      "dollar numbers to mysql reorder args",
+			dbType:    types.MySQL,
+			query:     "SELECT * FROM t WHERE a = $2 AND b = $1 AND c = $2",
+			args:      []interface{}{1, "x"},
+			wantQuery: "SELECT * FROM t WHERE a = ? AND b = ? AND c = ?",
+			wantArgs:  []interface{}{"x", 1, "x"},
+		},
+		{
+			name:      "question marks to mssql",
+			dbType:    types.MSSQL,
+			query:     "SELECT * FROM t WHERE a = ? AND b = ?",
+			args:      []interface{}{1, 2},
+			wantQuery: "SELECT * FROM t WHERE a = @p1 AND b = @p2",
+			wantArgs:  []interface{}{1, 2},
+		},
+		{
+			name:      "placeholders inside strings and comments are ignored",
+			dbType:    types.Redshift,
+			query:     "SELECT '?', \"a?\" FROM t -- ?\nWHERE a = ? /* $1 */",
+			args:      []interface{}{5},
+			wantQuery: "SELECT '?', \"a?\" FROM t -- ?\nWHERE a = $1 /* $1 */",
+			wantArgs:  []interface{}{5},
+		},
+		{
+			name:      "numbered placeholders keep the jsonb operator",
+			dbType:    types.Postgres,
+			query:     "SELECT * FROM t WHERE data ? 'key' AND id = $1",
+			args:      []interface{}{7},
+			wantQuery: "SELECT * FROM t WHERE data ? 'key' AND id = $1",
+			wantArgs:  []interface{}{7},
+		},
+		{
+			name:      "named params to postgres",
+			dbType:    types.Postgres,
+			query:     "SELECT * FROM t WHERE a = @id OR b = @id",
+			args:      []interface{}{sql.Named("id", 3)},
+			wantQuery: "SELECT * FROM t WHERE a = $1 OR b = $1",
+			wantArgs:  []interface{}{3},
+		},
+		{
+			name:      "named params to bigquery",
+			dbType:    types.BigQuery,
+			query:     "SELECT * FROM t WHERE a = @id AND b = :name",
+			args:      []interface{}{sql.Named("id", 3), sql.Named("name", "x")},
+			wantQuery: "SELECT * FROM t WHERE a = @id AND b = @name",
+			wantArgs:  []interface{}{sql.Named("id", 3), sql.Named("name", "x")},
+		},
+		{
+			name:      "named params to snowflake",
+			dbType:    types.Snowflake,
+			query:     "SELECT * FROM t WHERE a = @id",
+			args:      []interface{}{sql.Named("id", 3)},
+			wantQuery: "SELECT * FROM t WHERE a = ?",
+			wantArgs:  []interface{}{3},
+		},
+		{
+			name:      "unknown variables are left alone",
+			dbType:    types.MSSQL,
+			query:     "SELECT @@ROWCOUNT, @local WHERE a = ?",
+			args:      []interface{}{1},
+			wantQuery: "SELECT @@ROWCOUNT, @local WHERE a = @p1",
+			wantArgs:  []interface{}{1},
+		},
+		{
+			name:    "bigquery rejects mixed parameters",
+			dbType:  types.BigQuery,
+			query:   "SELECT * FROM t WHERE a = ? AND b = @id",
+			args:    []interface{}{1, sql.Named("id", 3)},
+			wantErr: true,
+		},
+		{
+			name:    "missing argument",
+			dbType:  types.MySQL,
+			query:   "SELECT * FROM t WHERE a = ? AND b = ?",
+			args:    []interface{}{1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotQuery, gotArgs, err := Bind(tt.dbType, tt.query, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bind() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotQuery != tt.wantQuery {
+				t.Errorf("Bind() query = %q, want %q", gotQuery, tt.wantQuery)
+			}
+			if len(gotArgs) != 0 || len(tt.wantArgs) != 0 {
+				if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+					t.Errorf("Bind() args = %v, want %v", gotArgs, tt.wantArgs)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package dialect
+
+import (
+	"strings"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// QuoteIdentifier returns name as a quoted identifier of the given database.
+// Names read from the catalog are always quoted: a quoted name is matched exactly, so reserved words such as
+// order stay names and mixed case names such as Users are not folded by Postgres, Redshift and Snowflake.
+// Names from the configuration or the user are quoted with QuoteInputIdentifier instead. The quote characters inside the name are escaped: Postgres, Redshift and Snowflake double
+// the double quotes, MySQL doubles the backticks, BigQuery escapes backticks and backslashes with a backslash
+// and SQL Server doubles the closing bracket of [name].
+func QuoteIdentifier(dbType types.DbType, name string) string {
+	switch dbType {
+	case types.MySQL:
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	case types.BigQuery:
+		return "`" + strings.NewReplacer(`\`, `\\`, "`", "\\`").Replace(name) + "`"
+	case types.MSSQL:
+		return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+	default:
+		return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+	}
+}
+
+// QuoteTableName quotes every part of a qualified table name with QuoteIdentifier and joins them with dots,
+// leaving out the empty parts.
+func QuoteTableName(dbType types.DbType, name types.TableName) string {
+	var parts []string
+	for _, part := range []string{name.Catalog, name.Schema, name.Name} {
+		if part != "" {
+			parts = append(parts, QuoteIdentifier(dbType, part))
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// QuoteColumns quotes the index or key columns that name a column of the table with QuoteIdentifier. The other
+// columns are expressions, such as lower(email), which the catalog reports as SQL and are returned as they are.
+func QuoteColumns(dbType types.DbType, table types.Table, columns []string) []string {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = column
+		for _, c := range table.Columns {
+			if c.Name == column {
+				quoted[i] = QuoteIdentifier(dbType, column)
+				break
+			}
+		}
+	}
+	return quoted
+}
+
+// QuoteInputIdentifier quotes a name given in the configuration or by the user, which is written the way it
+// would be written unquoted: it is folded with FoldIdentifier before it is quoted, so mydb still names the
+// Snowflake database MYDB.
+func QuoteInputIdentifier(dbType types.DbType, name string) string {
+	return QuoteIdentifier(dbType, FoldIdentifier(dbType, name))
+}
+
+// FoldIdentifier returns a plain name made of letters, digits and underscores the way the database stores it
+// when it is written without quotes: Postgres and Redshift fold it to lower case and Snowflake to upper case.
+// Other names, and the names of the other databases, are returned as they are.
+func FoldIdentifier(dbType types.DbType, name string) string {
+	if !plainIdentifier(name) {
+		return name
+	}
+	switch dbType {
+	case types.Postgres, types.Redshift:
+		return strings.ToLower(name)
+	case types.Snowflake:
+		return strings.ToUpper(name)
+	}
+	return name
+}
+
+// plainIdentifier reports whether name can be used as an identifier without quotes.
+func plainIdentifier(name string) bool {
+	if name == "" || isDigit(name[0]) {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if c := name[i]; !isLetter(c) && !isDigit(c) && c != '_' {
+			return false
+		}
+	}
+	return true
+}
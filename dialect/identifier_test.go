@@ -0,0 +1,130 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// hostileNames are table names that break out of an unquoted or naively quoted identifier, or that are
+// reserved words or folded to another case when they are left unquoted.
+var hostileNames = []string{
+	`users; DROP TABLE users; --`,
+	`a"b`,
+	"a`b",
+	`a]b`,
+	`a\`,
+	`x' OR '1'='1`,
+	`users/* comment */`,
+	`1st`,
+	`order`,
+	`user`,
+	`Users`,
+}
+
+// TestQuoteIdentifier is a unit test function that tests quoting identifiers for every database.
+func TestQuoteIdentifier(t *testing.T) {
+	tests := []struct {
+		dbType types.DbType
+		in     string
+		want   string
+	}{
+		{types.Postgres, "users", `"users"`},
+		{types.Postgres, "order", `"order"`},
+		{types.Postgres, "Users", `"Users"`},
+		{types.Snowflake, "Users", `"Users"`},
+		{types.Snowflake, "USER", `"USER"`},
+		{types.Redshift, "Order", `"Order"`},
+		{types.MySQL, "order", "`order`"},
+		{types.BigQuery, "select", "`select`"},
+		{types.MSSQL, "user", "[user]"},
+		{types.Postgres, "Order Items", `"Order Items"`},
+		{types.Postgres, `a"b`, `"a""b"`},
+		{types.Redshift, "my-table", `"my-table"`},
+		{types.Snowflake, `x"; DROP TABLE t; --`, `"x""; DROP TABLE t; --"`},
+		{types.MySQL, "a`b", "`a``b`"},
+		{types.BigQuery, "my-project", "`my-project`"},
+		{types.BigQuery, "a`b\\", "`a\\`b\\\\`"},
+		{types.MSSQL, "a]b", "[a]]b]"},
+		{types.MSSQL, "1st", "[1st]"},
+	}
+	for _, tt := range tests {
+		if got := QuoteIdentifier(tt.dbType, tt.in); got != tt.want {
+			t.Errorf("QuoteIdentifier(%s, %q) = %s, want %s", tt.dbType, tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestQuoteIdentifierHostile is a unit test function that tests that hostile names stay a single identifier
+// when the quoted name is tokenized with the rules of the same database.
+func TestQuoteIdentifierHostile(t *testing.T) {
+	for _, dbType := range []types.DbType{types.Postgres, types.MySQL, types.MSSQL, types.Snowflake, types.BigQuery, types.Redshift} {
+		for _, name := range hostileNames {
+			quoted := QuoteIdentifier(dbType, name)
+			tokens := Tokenize(dbType, "SELECT * FROM "+quoted+" WHERE 1 = 1")
+			significant := Significant(tokens)
+			if len(significant) != 8 || significant[3].Kind != QuotedIdent || significant[3].Text != quoted {
+				t.Errorf("QuoteIdentifier(%s, %q) = %s does not tokenize as one identifier: %+v", dbType, name, quoted, significant)
+			}
+		}
+	}
+}
+
+// TestQuoteTableName is a unit test function that tests quoting qualified table names.
+func TestQuoteTableName(t *testing.T) {
+	tests := []struct {
+		dbType types.DbType
+		name   types.TableName
+		want   string
+	}{
+		{types.Postgres, types.TableName{Schema: "sales", Name: "orders"}, `"sales"."orders"`},
+		{types.Postgres, types.TableName{Schema: "Sales", Name: "order"}, `"Sales"."order"`},
+		{types.Snowflake, types.TableName{Catalog: "db", Schema: "my schema", Name: "t"}, `"db"."my schema"."t"`},
+		{types.BigQuery, types.TableName{Catalog: "my-project", Schema: "sales", Name: "orders"}, "`my-project`.`sales`.`orders`"},
+		{types.MSSQL, types.TableName{Name: "x]; DROP TABLE y; --"}, "[x]]; DROP TABLE y; --]"},
+	}
+	for _, tt := range tests {
+		if got := QuoteTableName(tt.dbType, tt.name); got != tt.want {
+			t.Errorf("QuoteTableName(%s, %+v) = %s, want %s", tt.dbType, tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestQuoteInputIdentifier is a unit test function that tests that names from the configuration resolve as they
+// would unquoted.
+func TestQuoteInputIdentifier(t *testing.T) {
+	tests := []struct {
+		dbType types.DbType
+		in     string
+		want   string
+	}{
+		{types.Snowflake, "mydb", `"MYDB"`},
+		{types.Snowflake, "MyDb", `"MYDB"`},
+		{types.Snowflake, "my-db", `"my-db"`},
+		{types.Postgres, "Sales", `"sales"`},
+		{types.Redshift, "order", `"order"`},
+		{types.Redshift, `a"b`, `"a""b"`},
+		{types.MySQL, "Shop", "`Shop`"},
+		{types.MSSQL, "Shop", "[Shop]"},
+		{types.BigQuery, "Sales", "`Sales`"},
+	}
+	for _, tt := range tests {
+		if got := QuoteInputIdentifier(tt.dbType, tt.in); got != tt.want {
+			t.Errorf("QuoteInputIdentifier(%s, %q) = %s, want %s", tt.dbType, tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestQuoteColumns is a unit test function that tests quoting the column names of an index and leaving its
+// expressions alone.
+func TestQuoteColumns(t *testing.T) {
+	table := types.Table{Name: "users", Columns: []types.Column{{Name: "Email"}, {Name: "order"}}}
+	got := QuoteColumns(types.Postgres, table, []string{"order", "lower((\"Email\")::text)", "Email"})
+	want := []string{`"order"`, `lower(("Email")::text)`, `"Email"`}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("QuoteColumns() = %q, want %q", got, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,336 @@
+// Package dialect contains the SQL dialect specific helpers shared by the database drivers.
+package dialect
+
+import (
+	"strings"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TokenKind is the kind of a lexical SQL token.
+type TokenKind int
+
+// These constants represent the kinds of tokens produced by Tokenize.
+const (
+	Whitespace  TokenKind = iota // Whitespace is a run of spaces, tabs and newlines.
+	Comment                      // Comment is a line or block comment.
+	Word                         // Word is an unquoted keyword or identifier.
+	QuotedIdent                  // QuotedIdent is a quoted identifier such as "name", `name` or [name].
+	String                       // String is a string literal, including dollar quoted bodies.
+	Number                       // Number is a numeric literal.
+	Placeholder                  // Placeholder is a bind parameter such as ?, $1, @p1, @name or :name.
+	Punct                        // Punct is an operator or punctuation character.
+)
+
+// Token is a single lexical token of a SQL text.
+type Token struct {
+	Kind TokenKind // Kind is the kind of the token.
+	Text string    // Text is the raw text of the token.
+	Pos  int       // Pos is the byte offset of the token in the input.
+}
+
+// Upper returns the upper case text of a Word token.
+func (t Token) Upper() string {
+	return strings.ToUpper(t.Text)
+}
+
+// IsKeyword reports whether the token is the given keyword, ignoring case.
+func (t Token) IsKeyword(keyword string) bool {
+	return t.Kind == Word && strings.EqualFold(t.Text, keyword)
+}
+
+// Tokenize splits the SQL text into tokens using the quoting and comment rules of the given database.
+// Concatenating the text of all tokens yields the input unchanged.
+func Tokenize(dbType types.DbType, sql string) []Token {
+	l := lexer{dbType: dbType, src: sql}
+	for l.pos < len(l.src) {
+		l.next()
+	}
+	return l.tokens
+}
+
+// Significant returns the tokens that are neither whitespace nor comments.
+func Significant(tokens []Token) []Token {
+	var out []Token
+	for _, t := range tokens {
+		if t.Kind != Whitespace && t.Kind != Comment {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+type lexer struct {
+	dbType types.DbType
+	src    string
+	pos    int
+	tokens []Token
+}
+
+func (l *lexer) emit(kind TokenKind, end int) {
+	if end > len(l.src) {
+		end = len(l.src)
+	}
+	l.tokens = append(l.tokens, Token{Kind: kind, Text: l.src[l.pos:end], Pos: l.pos})
+	l.pos = end
+}
+
+func (l *lexer) peek(offset int) byte {
+	if i := l.pos + offset; i >= 0 && i < len(l.src) {
+		return l.src[i]
+	}
+	return 0
+}
+
+func (l *lexer) next() {
+	c := l.src[l.pos]
+	switch {
+	case isSpace(c):
+		end := l.pos
+		for end < len(l.src) && isSpace(l.src[end]) {
+			end++
+		}
+		l.emit(Whitespace, end)
+	case c == '-' && l.peek(1) == '-':
+		l.emit(Comment, l.lineEnd())
+	case c == '#' && (l.dbType == types.MySQL || l.dbType == types.BigQuery):
+		l.emit(Comment, l.lineEnd())
+	case c == '/' && l.peek(1) == '/' && l.dbType == types.Snowflake:
+		l.emit(Comment, l.lineEnd())
+	case c == '/' && l.peek(1) == '*':
+		l.emit(Comment, l.blockCommentEnd())
+	case c == '\'':
+		l.emit(String, l.quotedEnd(l.pos, '\'', l.backslashEscapes()))
+	case c == '"':
+		if l.dbType == types.MySQL || l.dbType == types.BigQuery {
+			l.emit(String, l.quotedEnd(l.pos, '"', true))
+		} else {
+			l.emit(QuotedIdent, l.quotedEnd(l.pos, '"', false))
+		}
+	case c == '`' && (l.dbType == types.MySQL || l.dbType == types.BigQuery):
+		l.emit(QuotedIdent, l.quotedEnd(l.pos, '`', l.dbType == types.BigQuery))
+	case c == '[' && l.dbType == types.MSSQL:
+		l.emit(QuotedIdent, l.quotedEnd(l.pos, ']', false))
+	case c == '$':
+		l.dollar()
+	case c == '?':
+		l.emit(Placeholder, l.pos+1)
+	case c == '@':
+		l.at()
+	case c == ':' && isIdentStart(l.peek(1)) && l.peek(-1) != ':':
+		end := l.pos + 1
+		for end < len(l.src) && isIdentPart(l.src[end]) {
+			end++
+		}
+		l.emit(Placeholder, end)
+	case isDigit(c) || (c == '.' && isDigit(l.peek(1))):
+		l.emit(Number, l.numberEnd())
+	case isIdentStart(c):
+		if end, ok := l.prefixedString(); ok {
+			l.emit(String, end)
+			return
+		}
+		end := l.pos
+		for end < len(l.src) && isIdentPart(l.src[end]) {
+			end++
+		}
+		l.emit(Word, end)
+	default:
+		l.emit(Punct, l.pos+1)
+	}
+}
+
+// backslashEscapes reports whether backslash escapes characters in string literals of the dialect.
+func (l *lexer) backslashEscapes() bool {
+	return l.dbType == types.MySQL || l.dbType == types.BigQuery || l.dbType == types.Snowflake
+}
+
+func (l *lexer) lineEnd() int {
+	if i := strings.IndexByte(l.src[l.pos:], '\n'); i >= 0 {
+		return l.pos + i
+	}
+	return len(l.src)
+}
+
+// blockCommentEnd returns the end of a block comment, honouring nesting in dialects that allow it.
+func (l *lexer) blockCommentEnd() int {
+	nested := l.dbType == types.Postgres || l.dbType == types.Redshift || l.dbType == types.MSSQL
+	depth := 0
+	for i := l.pos; i < len(l.src)-1; i++ {
+		switch {
+		case l.src[i] == '/' && l.src[i+1] == '*':
+			if depth == 0 || nested {
+				depth++
+			}
+			i++
+		case l.src[i] == '*' && l.src[i+1] == '/':
+			depth--
+			i++
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(l.src)
+}
+
+// quotedEnd returns the end of a quoted run starting at start.
+// A doubled quote character is always treated as an escaped quote.
+func (l *lexer) quotedEnd(start int, quote byte, backslash bool) int {
+	for i := start + 1; i < len(l.src); i++ {
+		switch l.src[i] {
+		case '\\':
+			if backslash {
+				i++
+			}
+		case quote:
+			if i+1 < len(l.src) && l.src[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(l.src)
+}
+
+// dollar lexes $1 placeholders and $tag$ ... $tag$ quoted bodies.
+func (l *lexer) dollar() {
+	if isDigit(l.peek(1)) && l.dbType != types.Snowflake {
+		end := l.pos + 1
+		for end < len(l.src) && isDigit(l.src[end]) {
+			end++
+		}
+		l.emit(Placeholder, end)
+		return
+	}
+	if l.dbType == types.Postgres || l.dbType == types.Redshift || l.dbType == types.Snowflake {
+		end := l.pos + 1
+		for end < len(l.src) && isIdentPart(l.src[end]) && l.src[end] != '$' {
+			end++
+		}
+		if end < len(l.src) && l.src[end] == '$' {
+			tag := l.src[l.pos : end+1]
+			if i := strings.Index(l.src[end+1:], tag); i >= 0 {
+				l.emit(String, end+1+i+len(tag))
+			} else {
+				l.emit(String, len(l.src))
+			}
+			return
+		}
+	}
+	l.emit(Punct, l.pos+1)
+}
+
+// at lexes @name and @p1 parameters as well as @@system variables.
+func (l *lexer) at() {
+	end := l.pos + 1
+	if l.peek(1) == '@' {
+		end++
+	}
+	for end < len(l.src) && isIdentPart(l.src[end]) {
+		end++
+	}
+	if end == l.pos+1 {
+		l.emit(Punct, end)
+		return
+	}
+	l.emit(Placeholder, end)
+}
+
+// prefixedString lexes string literals with a prefix such as E'..', N'..', r'..', b'..' and BigQuery triple quotes.
+func (l *lexer) prefixedString() (int, bool) {
+	i := l.pos
+	for i < len(l.src) && i-l.pos < 2 && isLetter(l.src[i]) {
+		i++
+	}
+	if i >= len(l.src) || (l.src[i] != '\'' && l.src[i] != '"') {
+		return 0, false
+	}
+	prefix := strings.ToUpper(l.src[l.pos:i])
+	quote := l.src[i]
+	switch l.dbType {
+	case types.BigQuery:
+		switch prefix {
+		case "R", "B", "RB", "BR":
+		default:
+			return 0, false
+		}
+		raw := strings.Contains(prefix, "R")
+		if strings.HasPrefix(l.src[i:], strings.Repeat(string(quote), 3)) {
+			return l.tripleQuotedEnd(i, quote, raw), true
+		}
+		return l.quotedEnd(i, quote, !raw), true
+	case types.Postgres, types.Redshift:
+		if quote == '\'' && (prefix == "E" || prefix == "B" || prefix == "X" || prefix == "U") {
+			return l.quotedEnd(i, quote, prefix == "E"), true
+		}
+	case types.MSSQL:
+		if quote == '\'' && prefix == "N" {
+			return l.quotedEnd(i, quote, false), true
+		}
+	case types.MySQL:
+		if quote == '\'' && (prefix == "N" || prefix == "X" || prefix == "B") {
+			return l.quotedEnd(i, quote, prefix == "N"), true
+		}
+	case types.Snowflake:
+		if quote == '\'' && prefix == "X" {
+			return l.quotedEnd(i, quote, false), true
+		}
+	}
+	return 0, false
+}
+
+func (l *lexer) tripleQuotedEnd(start int, quote byte, raw bool) int {
+	delim := strings.Repeat(string(quote), 3)
+	for i := start + 3; i < len(l.src); i++ {
+		if l.src[i] == '\\' && !raw {
+			i++
+			continue
+		}
+		if strings.HasPrefix(l.src[i:], delim) {
+			return i + 3
+		}
+	}
+	return len(l.src)
+}
+
+func (l *lexer) numberEnd() int {
+	end := l.pos
+	for end < len(l.src) && (isDigit(l.src[end]) || l.src[end] == '.') {
+		end++
+	}
+	if end < len(l.src) && (l.src[end] == 'e' || l.src[end] == 'E') {
+		exp := end + 1
+		if exp < len(l.src) && (l.src[exp] == '+' || l.src[exp] == '-') {
+			exp++
+		}
+		if exp < len(l.src) && isDigit(l.src[exp]) {
+			end = exp
+			for end < len(l.src) && isDigit(l.src[end]) {
+				end++
+			}
+		}
+	}
+	return end
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentStart(c byte) bool {
+	return isLetter(c) || c == '_' || c >= 0x80
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '$'
+}
@@ -0,0 +1,104 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// Paginate rewrites a query to return at most limit rows after skipping offset rows, using LIMIT and OFFSET or,
+// for SQL Server, TOP or OFFSET ... FETCH NEXT. It returns false and leaves the query alone when the query is not
+// a single SELECT, WITH, VALUES or TABLE query, or when it already has a row limit, an offset, a FOR clause or a
+// MySQL PROCEDURE clause at its outermost level. Unordered SQL Server set operations are paginated as a derived
+// table, and left alone when they follow a WITH clause that cannot move into one.
+func Paginate(dbType types.DbType, query string, limit, offset int64) (string, bool) {
+	tokens := Tokenize(dbType, query)
+	// trailing semicolons and comments are dropped, a line comment would swallow the appended clause
+	end := len(tokens)
+	for end > 0 && (tokens[end-1].Kind == Whitespace || tokens[end-1].Kind == Comment || tokens[end-1].Text == ";") {
+		end--
+	}
+	tokens = tokens[:end]
+
+	significant := Significant(tokens)
+	if len(significant) == 0 {
+		return query, false
+	}
+	kind, keyword := classify(dbType, significant)
+	if kind != ReadStatement {
+		return query, false
+	}
+	switch keyword {
+	case "SELECT", "WITH":
+	case "VALUES", "TABLE":
+		if dbType == types.MSSQL {
+			return query, false
+		}
+	default:
+		return query, false
+	}
+
+	// the clauses of the outermost query are the tokens outside parentheses
+	depth, mainSelect := 0, -1
+	ordered, setOperation := false, false
+	for i, t := range significant {
+		switch {
+		case t.Text == ";":
+			return query, false
+		case t.Text == "(":
+			depth++
+		case t.Text == ")":
+			depth--
+		case depth > 0 || t.Kind != Word:
+		case t.IsKeyword("LIMIT"), t.IsKeyword("FETCH"), t.IsKeyword("OFFSET"), t.IsKeyword("FOR"), t.IsKeyword("PROCEDURE"):
+			return query, false
+		case t.IsKeyword("TOP") && i > 0 && (significant[i-1].IsKeyword("SELECT") || significant[i-1].IsKeyword("DISTINCT") || significant[i-1].IsKeyword("ALL")):
+			return query, false
+		case t.IsKeyword("SELECT") && mainSelect < 0:
+			mainSelect = i
+		case t.IsKeyword("UNION"), t.IsKeyword("INTERSECT"), t.IsKeyword("EXCEPT"), t.IsKeyword("MINUS"):
+			setOperation = true
+		case t.IsKeyword("ORDER") && i+1 < len(significant) && significant[i+1].IsKeyword("BY"):
+			ordered = true
+		}
+	}
+
+	var text strings.Builder
+	for _, t := range tokens {
+		text.WriteString(t.Text)
+	}
+	rewritten := text.String()
+
+	if dbType != types.MSSQL {
+		rewritten += fmt.Sprintf(" LIMIT %d", limit)
+		if offset > 0 {
+			rewritten += fmt.Sprintf(" OFFSET %d", offset)
+		}
+		return rewritten, true
+	}
+
+	if offset == 0 && mainSelect >= 0 && !setOperation {
+		// TOP follows SELECT and its DISTINCT or ALL
+		after := significant[mainSelect]
+		if next := mainSelect + 1; next < len(significant) && (significant[next].IsKeyword("DISTINCT") || significant[next].IsKeyword("ALL")) {
+			after = significant[next]
+		}
+		at := after.Pos + len(after.Text)
+		return rewritten[:at] + fmt.Sprintf(" TOP (%d)", limit) + rewritten[at:], true
+	}
+	fetch := fmt.Sprintf(" OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", offset, limit)
+	// the ORDER BY of a set operation applies to all of it, without one OFFSET ... FETCH would only
+	// apply to its last query, so the set operation is selected from instead
+	if setOperation && !ordered {
+		if keyword == "WITH" {
+			return query, false
+		}
+		return "SELECT * FROM (" + rewritten + ") AS _xray ORDER BY (SELECT NULL)" + fetch, true
+	}
+	// OFFSET ... FETCH needs an ORDER BY, ordering by a constant leaves the row order to the database
+	if !ordered {
+		rewritten += " ORDER BY (SELECT NULL)"
+	}
+	return rewritten + fetch, true
+}
@@ -0,0 +1,57 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestPaginate is a unit test function that tests adding row limits and offsets to queries for every database.
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType types.DbType
+		query  string
+		offset int64
+		want   string
+		ok     bool
+	}{
+		{"limit", types.Postgres, "SELECT * FROM users;", 0, "SELECT * FROM users LIMIT 11", true},
+		{"trailing comment", types.MySQL, "SELECT * FROM users -- all of them", 0, "SELECT * FROM users LIMIT 11", true},
+		{"offset", types.BigQuery, "SELECT * FROM `p.d.t` ORDER BY id", 20, "SELECT * FROM `p.d.t` ORDER BY id LIMIT 11 OFFSET 20", true},
+		{"limit in subquery", types.Snowflake, "SELECT * FROM (SELECT * FROM t LIMIT 5) s", 0, "SELECT * FROM (SELECT * FROM t LIMIT 5) s LIMIT 11", true},
+		{"cte", types.Redshift, "WITH r AS (SELECT 1) SELECT * FROM r", 10, "WITH r AS (SELECT 1) SELECT * FROM r LIMIT 11 OFFSET 10", true},
+		{"values", types.Postgres, "VALUES (1), (2)", 0, "VALUES (1), (2) LIMIT 11", true},
+		{"mssql top", types.MSSQL, "SELECT DISTINCT name FROM users ORDER BY name", 0, "SELECT DISTINCT TOP (11) name FROM users ORDER BY name", true},
+		{"mssql cte top", types.MSSQL, "WITH r AS (SELECT TOP 5 * FROM t) SELECT * FROM r", 0, "WITH r AS (SELECT TOP 5 * FROM t) SELECT TOP (11) * FROM r", true},
+		{"mssql offset", types.MSSQL, "SELECT * FROM users ORDER BY id", 30, "SELECT * FROM users ORDER BY id OFFSET 30 ROWS FETCH NEXT 11 ROWS ONLY", true},
+		{"mssql union", types.MSSQL, "SELECT a FROM x UNION SELECT a FROM y", 0, "SELECT * FROM (SELECT a FROM x UNION SELECT a FROM y) AS _xray ORDER BY (SELECT NULL) OFFSET 0 ROWS FETCH NEXT 11 ROWS ONLY", true},
+		{"mssql union offset", types.MSSQL, "(SELECT a FROM x) EXCEPT (SELECT a FROM y);", 20, "SELECT * FROM ((SELECT a FROM x) EXCEPT (SELECT a FROM y)) AS _xray ORDER BY (SELECT NULL) OFFSET 20 ROWS FETCH NEXT 11 ROWS ONLY", true},
+		{"mssql ordered union", types.MSSQL, "SELECT a FROM x UNION ALL SELECT a FROM y ORDER BY a", 0, "SELECT a FROM x UNION ALL SELECT a FROM y ORDER BY a OFFSET 0 ROWS FETCH NEXT 11 ROWS ONLY", true},
+		{"mssql unordered offset", types.MSSQL, "SELECT * FROM users", 30, "SELECT * FROM users ORDER BY (SELECT NULL) OFFSET 30 ROWS FETCH NEXT 11 ROWS ONLY", true},
+		{"mssql cte union", types.MSSQL, "WITH r AS (SELECT 1 AS a) SELECT a FROM r UNION SELECT a FROM y", 0, "", false},
+		{"mysql procedure analyse", types.MySQL, "SELECT id FROM users PROCEDURE ANALYSE()", 0, "", false},
+		{"existing limit", types.Postgres, "SELECT * FROM users LIMIT 5", 0, "", false},
+		{"existing fetch", types.Postgres, "SELECT * FROM users FETCH FIRST 5 ROWS ONLY", 0, "", false},
+		{"existing top", types.Snowflake, "SELECT TOP 5 * FROM users", 0, "", false},
+		{"for update", types.Postgres, "SELECT * FROM users FOR UPDATE", 0, "", false},
+		{"for json", types.MSSQL, "SELECT * FROM users FOR JSON PATH", 0, "", false},
+		{"show", types.MySQL, "SHOW TABLES", 0, "", false},
+		{"write", types.Postgres, "DELETE FROM users", 0, "", false},
+		{"several statements", types.Postgres, "SELECT 1; SELECT 2", 0, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Paginate(tt.dbType, tt.query, 11, tt.offset)
+			if ok != tt.ok {
+				t.Fatalf("Paginate(%q) ok = %v, want %v", tt.query, ok, tt.ok)
+			}
+			if !ok {
+				tt.want = tt.query
+			}
+			if got != tt.want {
+				t.Errorf("Paginate(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package dialect
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// QuoteString returns s as a string literal of the given database.
+// MySQL treats backslashes in literals as escapes and BigQuery uses double quoted literals with C style escapes,
+// the other databases double the single quotes.
+func QuoteString(dbType types.DbType, s string) string {
+	switch dbType {
+	case types.MySQL:
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `''`).Replace(s) + "'"
+	case types.BigQuery:
+		return strconv.Quote(s)
+	case types.MSSQL:
+		return "N'" + strings.ReplaceAll(s, "'", "''") + "'"
+	default:
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+}
+
+// ColumnLength returns the maximum length of a character column as a type parameter such as (255), or the
+// precision and scale of a decimal column such as (10,2), for databases that report them separately from the
+// type. MSSQL reports -1 for (MAX). Integer columns also report a precision, it is only used for the decimal
+// types NUMERIC, DECIMAL and NUMBER. It returns an empty string when the type already has parameters or the
+// length is unknown.
+func ColumnLength(column types.Column) string {
+	if strings.Contains(column.Type, "(") {
+		return ""
+	}
+	if !column.CharacterMaximumLength.Valid {
+		return numericPrecision(column)
+	}
+	if column.CharacterMaximumLength.Int64 == -1 {
+		return "(MAX)"
+	}
+	if column.CharacterMaximumLength.Int64 <= 0 {
+		return ""
+	}
+	return "(" + strconv.FormatInt(column.CharacterMaximumLength.Int64, 10) + ")"
+}
+
+// numericPrecision returns the precision and scale of a decimal column as a type parameter such as (10,2).
+func numericPrecision(column types.Column) string {
+	if !column.NumericPrecision.Valid || column.NumericPrecision.Int64 <= 0 {
+		return ""
+	}
+	switch strings.ToUpper(strings.TrimSpace(column.Type)) {
+	case "NUMERIC", "DECIMAL", "DEC", "NUMBER":
+		return "(" + strconv.FormatInt(column.NumericPrecision.Int64, 10) + "," + strconv.FormatInt(column.NumericScale.Int64, 10) + ")"
+	}
+	return ""
+}
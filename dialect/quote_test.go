@@ -0,0 +1,49 @@
+package dialect
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestQuoteString is a unit test function that tests quoting string literals for every database.
+func TestQuoteString(t *testing.T) {
+	tests := []struct {
+		dbType types.DbType
+		in     string
+		want   string
+	}{
+		{types.Postgres, "it's", `'it''s'`},
+		{types.Snowflake, "plain", `'plain'`},
+		{types.MySQL, `C:\tmp 'x'`, `'C:\\tmp ''x'''`},
+		{types.MSSQL, "it's", `N'it''s'`},
+		{types.BigQuery, `say "hi"`, `"say \"hi\""`},
+	}
+	for _, tt := range tests {
+		if got := QuoteString(tt.dbType, tt.in); got != tt.want {
+			t.Errorf("QuoteString(%s, %q) = %s, want %s", tt.dbType, tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestColumnLength is a unit test function that tests the type parameter built from a separately reported column length or precision.
+func TestColumnLength(t *testing.T) {
+	tests := []struct {
+		column types.Column
+		want   string
+	}{
+		{types.Column{Type: "character varying", CharacterMaximumLength: sql.NullInt64{Int64: 20, Valid: true}}, "(20)"},
+		{types.Column{Type: "nvarchar", CharacterMaximumLength: sql.NullInt64{Int64: -1, Valid: true}}, "(MAX)"},
+		{types.Column{Type: "varchar(20)", CharacterMaximumLength: sql.NullInt64{Int64: 20, Valid: true}}, ""},
+		{types.Column{Type: "integer"}, ""},
+		{types.Column{Type: "NUMBER", NumericPrecision: sql.NullInt64{Int64: 10, Valid: true}, NumericScale: sql.NullInt64{Int64: 2, Valid: true}}, "(10,2)"},
+		{types.Column{Type: "numeric", NumericPrecision: sql.NullInt64{Int64: 12, Valid: true}, NumericScale: sql.NullInt64{Valid: true}}, "(12,0)"},
+		{types.Column{Type: "bigint", NumericPrecision: sql.NullInt64{Int64: 64, Valid: true}, NumericScale: sql.NullInt64{Valid: true}}, ""},
+	}
+	for _, tt := range tests {
+		if got := ColumnLength(tt.column); got != tt.want {
+			t.Errorf("ColumnLength(%+v) = %q, want %q", tt.column, got, tt.want)
+		}
+	}
+}
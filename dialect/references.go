@@ -0,0 +1,146 @@
+package dialect
+
+import (
+	"strings"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// clauseEnd are the keywords that end the FROM clause of a query.
+var clauseEnd = map[string]bool{
+	"WHERE": true, "GROUP": true, "HAVING": true, "ORDER": true, "LIMIT": true, "QUALIFY": true,
+	"WINDOW": true, "UNION": true, "INTERSECT": true, "EXCEPT": true, "MINUS": true, "FETCH": true,
+	"OFFSET": true, "SELECT": true,
+}
+
+// TableReferences returns the tables a query reads from, in order of first appearance.
+// It looks at the names following FROM and JOIN, including comma separated FROM lists, and
+// leaves out subqueries, table functions and the names of common table expressions.
+// It is used to find the dependencies of views on databases that do not record them.
+func TableReferences(dbType types.DbType, query string) []types.TableName {
+	tokens := Significant(Tokenize(dbType, query))
+
+	// names followed by AS ( are common table expressions, not tables
+	ctes := map[string]bool{}
+	for i := 0; i+2 < len(tokens); i++ {
+		if isIdent(tokens[i]) && tokens[i+1].IsKeyword("AS") && tokens[i+2].Text == "(" {
+			ctes[strings.ToLower(unquote(tokens[i].Text))] = true
+		}
+	}
+
+	var refs []types.TableName
+	seen := map[types.TableName]bool{}
+	// scopes tracks, per parenthesis depth, whether a SELECT started there and whether the
+	// tokens belong to its FROM clause, so that EXTRACT(x FROM y) is not taken for a table
+	scopes := []scope{{}}
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		s := &scopes[len(scopes)-1]
+		switch {
+		case t.Text == "(":
+			scopes = append(scopes, scope{})
+			continue
+		case t.Text == ")":
+			if len(scopes) > 1 {
+				scopes = scopes[:len(scopes)-1]
+			}
+			continue
+		case t.IsKeyword("SELECT"):
+			s.selected, s.inFrom = true, false
+			continue
+		case t.IsKeyword("FROM") && i > 0 && tokens[i-1].IsKeyword("DISTINCT"):
+			// IS DISTINCT FROM compares values
+			continue
+		case (t.IsKeyword("FROM") || t.IsKeyword("JOIN")) && s.selected:
+			s.inFrom = true
+		case t.Text == "," && s.inFrom:
+		case t.Kind == Word && clauseEnd[t.Upper()]:
+			s.inFrom = false
+			continue
+		default:
+			continue
+		}
+
+		name, next, ok := readTableName(dbType, tokens, i+1)
+		if !ok {
+			continue
+		}
+		i = next - 1
+		if name.Catalog == "" && name.Schema == "" && ctes[strings.ToLower(name.Name)] {
+			continue
+		}
+		if !seen[name] {
+			seen[name] = true
+			refs = append(refs, name)
+		}
+	}
+	return refs
+}
+
+// scope is the parsing state of one parenthesis level of a query.
+type scope struct {
+	selected bool // selected indicates whether a SELECT started at this level.
+	inFrom   bool // inFrom indicates whether the current tokens belong to a FROM clause.
+}
+
+// readTableName reads a dotted name starting at tokens[i].
+// It returns the index of the token after the name, and false when no table name starts at i.
+func readTableName(dbType types.DbType, tokens []Token, i int) (types.TableName, int, bool) {
+	var raw strings.Builder
+	j := i
+	for j < len(tokens) && isIdent(tokens[j]) {
+		if tokens[j].Kind == Word && (clauseEnd[tokens[j].Upper()] || tokens[j].IsKeyword("LATERAL")) {
+			break
+		}
+		text := tokens[j].Text
+		// BigQuery allows the whole path in one pair of backticks
+		if dbType == types.BigQuery && strings.HasPrefix(text, "`") {
+			text = strings.Trim(text, "`")
+		}
+		raw.WriteString(text)
+		j++
+		if j+1 < len(tokens) && tokens[j].Text == "." && isIdent(tokens[j+1]) {
+			raw.WriteString(".")
+			j++
+			continue
+		}
+		break
+	}
+	if j == i {
+		return types.TableName{}, i, false
+	}
+	// a name followed by ( is a table function
+	if j < len(tokens) && tokens[j].Text == "(" {
+		return types.TableName{}, j, false
+	}
+	return types.ParseTableName(raw.String()), j, true
+}
+
+// isIdent reports whether the token can be part of an identifier.
+func isIdent(t Token) bool {
+	return t.Kind == Word || t.Kind == QuotedIdent
+}
+
+// unquote removes the quotes around a quoted identifier.
+func unquote(s string) string {
+	return types.ParseTableName(s).Name
+}
+
+// ApplyViewReferences sets the dependencies of the views and materialized views to the tables their
+// definition reads from, for databases whose catalog does not record view dependencies.
+// Unqualified names are resolved against the schema of the view.
+func ApplyViewReferences(dbType types.DbType, tables []types.TableInfo) {
+	for i, t := range tables {
+		if t.Kind != types.KindView && t.Kind != types.KindMaterializedView {
+			continue
+		}
+		var dependencies []types.TableDependency
+		for _, ref := range TableReferences(dbType, t.Definition) {
+			if ref.Schema == "" {
+				ref.Schema = t.Schema
+			}
+			dependencies = append(dependencies, types.TableDependency{Schema: t.Schema, Name: t.Name, Referenced: ref})
+		}
+		types.ApplyDependencies(tables[i:i+1], dependencies)
+	}
+}
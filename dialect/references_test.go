@@ -0,0 +1,74 @@
+package dialect
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestTableReferences is a unit test function that tests finding the tables a query reads from.
+func TestTableReferences(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType types.DbType
+		query  string
+		want   []types.TableName
+	}{
+		{
+			name:   "from and joins",
+			dbType: types.Postgres,
+			query:  "SELECT o.id FROM sales.orders o JOIN public.users u ON u.id = o.user_id LEFT JOIN items ON true",
+			want:   []types.TableName{{Schema: "sales", Name: "orders"}, {Schema: "public", Name: "users"}, {Name: "items"}},
+		},
+		{
+			name:   "comma separated from list and subquery",
+			dbType: types.Postgres,
+			query:  `SELECT * FROM a, "My Table" t, (SELECT id FROM b WHERE x IS DISTINCT FROM y) s WHERE a.id IN (SELECT id FROM c)`,
+			want:   []types.TableName{{Name: "a"}, {Name: "My Table"}, {Name: "b"}, {Name: "c"}},
+		},
+		{
+			name:   "common table expressions and functions are skipped",
+			dbType: types.Postgres,
+			query:  "WITH recent AS (SELECT * FROM events WHERE EXTRACT(YEAR FROM ts) = 2024) SELECT * FROM recent, generate_series(1, 3)",
+			want:   []types.TableName{{Name: "events"}},
+		},
+		{
+			name:   "bigquery backtick paths",
+			dbType: types.BigQuery,
+			query:  "SELECT * FROM `my-project.sales.orders` JOIN `my-project`.sales.users USING (id)",
+			want:   []types.TableName{{Catalog: "my-project", Schema: "sales", Name: "orders"}, {Catalog: "my-project", Schema: "sales", Name: "users"}},
+		},
+		{
+			name:   "duplicates are reported once",
+			dbType: types.MySQL,
+			query:  "SELECT * FROM t UNION SELECT * FROM t",
+			want:   []types.TableName{{Name: "t"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TableReferences(tt.dbType, tt.query)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TableReferences() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestApplyViewReferences is a unit test function that tests deriving view dependencies from their definition.
+func TestApplyViewReferences(t *testing.T) {
+	tables := []types.TableInfo{
+		{Name: "orders", Schema: "PUBLIC", Kind: types.KindTable},
+		{Name: "recent_orders", Schema: "PUBLIC", Kind: types.KindView, Definition: "create view recent_orders as select * from orders o join sales.users u on u.id = o.user_id"},
+	}
+	ApplyViewReferences(types.Snowflake, tables)
+
+	if tables[0].Dependencies != nil {
+		t.Errorf("table dependencies = %+v, want none", tables[0].Dependencies)
+	}
+	want := []types.TableName{{Schema: "PUBLIC", Name: "orders"}, {Schema: "sales", Name: "users"}}
+	if !reflect.DeepEqual(tables[1].Dependencies, want) {
+		t.Errorf("view dependencies = %+v, want %+v", tables[1].Dependencies, want)
+	}
+}
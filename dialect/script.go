@@ -0,0 +1,102 @@
+package dialect
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// SplitScript splits a script into the statements that are sent to the database one at a time.
+// MSSQL scripts are split into batches at GO lines, as sqlcmd does: GO n repeats the batch n times and the
+// statements of a batch are sent together. MySQL scripts may change the statement delimiter with DELIMITER lines,
+// as the mysql client does, so that the semicolons of stored program bodies do not end the statement.
+// Postgres and Snowflake $$ bodies are single tokens and are never split.
+func SplitScript(dbType types.DbType, script string) ([]string, error) {
+	if dbType == types.MSSQL {
+		return splitBatches(script), nil
+	}
+
+	var statements []string
+	flush := func(text string) {
+		if len(Significant(Tokenize(dbType, text))) > 0 {
+			statements = append(statements, strings.TrimSpace(text))
+		}
+	}
+
+	delimiter, start, skip := ";", 0, 0
+	for _, t := range Tokenize(dbType, script) {
+		if t.Pos < skip {
+			continue
+		}
+		if dbType == types.MySQL && t.IsKeyword("DELIMITER") && strings.TrimSpace(script[start:t.Pos]) == "" && atLineStart(script, t.Pos) {
+			end := lineEnd(script, t.Pos)
+			fields := strings.Fields(script[t.Pos+len(t.Text) : end])
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("DELIMITER at offset %d must be followed by a delimiter", t.Pos)
+			}
+			delimiter, start, skip = fields[0], end, end
+			continue
+		}
+		if t.Kind == String || t.Kind == QuotedIdent || t.Kind == Comment {
+			continue
+		}
+		// a delimiter such as $$ may be read as part of an identifier, as in END$$
+		for pos := max(t.Pos, skip); pos < t.Pos+len(t.Text); pos++ {
+			if strings.HasPrefix(script[pos:], delimiter) {
+				flush(script[start:pos])
+				start = pos + len(delimiter)
+				skip = start
+				pos = start - 1
+			}
+		}
+	}
+	flush(script[start:])
+	return statements, nil
+}
+
+// splitBatches splits an MSSQL script at its GO lines.
+func splitBatches(script string) []string {
+	var batches []string
+	start, skip := 0, 0
+	for _, t := range Tokenize(types.MSSQL, script) {
+		if t.Pos < skip || !t.IsKeyword("GO") || !atLineStart(script, t.Pos) {
+			continue
+		}
+		end := lineEnd(script, t.Pos)
+		count := 1
+		if rest := strings.TrimSpace(script[t.Pos+len(t.Text) : end]); rest != "" {
+			n, err := strconv.Atoi(rest)
+			if err != nil || n < 1 {
+				// GO is only a batch separator when it is alone on its line
+				continue
+			}
+			count = n
+		}
+		batch := script[start:t.Pos]
+		if len(Significant(Tokenize(types.MSSQL, batch))) > 0 {
+			for i := 0; i < count; i++ {
+				batches = append(batches, strings.TrimSpace(batch))
+			}
+		}
+		start, skip = end, end
+	}
+	if batch := script[start:]; len(Significant(Tokenize(types.MSSQL, batch))) > 0 {
+		batches = append(batches, strings.TrimSpace(batch))
+	}
+	return batches
+}
+
+// atLineStart reports whether only spaces precede the offset on its line.
+func atLineStart(script string, pos int) bool {
+	return strings.TrimSpace(script[strings.LastIndexByte(script[:pos], '\n')+1:pos]) == ""
+}
+
+// lineEnd returns the offset of the end of the line holding the offset.
+func lineEnd(script string, pos int) int {
+	if i := strings.IndexByte(script[pos:], '\n'); i >= 0 {
+		return pos + i
+	}
+	return len(script)
+}
@@ -0,0 +1,70 @@
+package dialect
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestSplitScript is a unit test function that tests splitting scripts with GO batches, $$ bodies and DELIMITER lines.
+func TestSplitScript(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType types.DbType
+		script string
+		want   []string
+	}{
+		{
+			"postgres dollar quoted function",
+			types.Postgres,
+			"CREATE FUNCTION one() RETURNS int AS $$ SELECT 1; $$ LANGUAGE sql;\n-- done;\nSELECT one();",
+			[]string{"CREATE FUNCTION one() RETURNS int AS $$ SELECT 1; $$ LANGUAGE sql", "-- done;\nSELECT one()"},
+		},
+		{
+			"mssql go batches",
+			types.MSSQL,
+			"CREATE TABLE t (id INT);\nGO\nCREATE PROCEDURE p AS BEGIN SELECT 'GO'; SELECT 1 END\ngo\nINSERT INTO t VALUES (1)\nGO 2\nSELECT * FROM t -- GO\n",
+			[]string{
+				"CREATE TABLE t (id INT);",
+				"CREATE PROCEDURE p AS BEGIN SELECT 'GO'; SELECT 1 END",
+				"INSERT INTO t VALUES (1)",
+				"INSERT INTO t VALUES (1)",
+				"SELECT * FROM t -- GO",
+			},
+		},
+		{
+			"mysql delimiter",
+			types.MySQL,
+			"DROP PROCEDURE IF EXISTS p;\nDELIMITER //\nCREATE PROCEDURE p() BEGIN SELECT '//'; SELECT 1; END//\nDELIMITER ;\nCALL p();",
+			[]string{"DROP PROCEDURE IF EXISTS p", "CREATE PROCEDURE p() BEGIN SELECT '//'; SELECT 1; END", "CALL p()"},
+		},
+		{
+			"mysql delimiter inside an identifier",
+			types.MySQL,
+			"DELIMITER $$\nCREATE PROCEDURE p() BEGIN SELECT 1; END$$\nDELIMITER ;\nCALL p();",
+			[]string{"CREATE PROCEDURE p() BEGIN SELECT 1; END", "CALL p()"},
+		},
+		{
+			"empty statements",
+			types.Snowflake,
+			";; -- nothing\n",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitScript(tt.dbType, tt.script)
+			if err != nil {
+				t.Fatalf("SplitScript() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitScript() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := SplitScript(types.MySQL, "DELIMITER\nSELECT 1"); err == nil {
+		t.Error("expected an error for a DELIMITER line without a delimiter")
+	}
+}
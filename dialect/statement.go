@@ -0,0 +1,212 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// StatementKind is the kind of a SQL statement as classified by Statements.
+type StatementKind int
+
+// These constants represent the kinds of statements.
+const (
+	ReadStatement  StatementKind = iota // ReadStatement only reads data, such as SELECT, SHOW, DESCRIBE or EXPLAIN.
+	WriteStatement                      // WriteStatement is any other statement: DML, DDL, transaction control, session settings or procedure calls.
+)
+
+// Statement is a single statement of a SQL text.
+type Statement struct {
+	Text    string        // Text is the SQL text of the statement, without the terminating semicolon.
+	Kind    StatementKind // Kind is the kind of the statement.
+	Keyword string        // Keyword is the upper case keyword that made the statement a write, or the first keyword of a read.
+}
+
+// readKeywords are the keywords starting statements that only read data.
+var readKeywords = map[string]bool{
+	"SELECT": true, "WITH": true, "SHOW": true, "DESCRIBE": true, "DESC": true, "EXPLAIN": true,
+	"VALUES": true, "TABLE": true,
+}
+
+// writeKeywords are the keywords that make a statement a write wherever they appear in it, such as the DELETE of
+// a Postgres data-modifying CTE, SELECT ... INTO, FOR UPDATE or a T-SQL statement following a SELECT without semicolon.
+var writeKeywords = map[string]bool{
+	"INSERT": true, "UPDATE": true, "DELETE": true, "MERGE": true, "UPSERT": true, "TRUNCATE": true,
+	"DROP": true, "ALTER": true, "CREATE": true, "RENAME": true, "GRANT": true, "REVOKE": true,
+	"INTO": true, "COPY": true, "UNLOAD": true, "LOCK": true, "CALL": true, "EXEC": true, "EXECUTE": true,
+	"KILL": true, "SHUTDOWN": true, "BACKUP": true, "RESTORE": true, "DBCC": true,
+}
+
+// statementKeywords are the keywords starting statements that are not reads, besides the write keywords. T-SQL and
+// BigQuery scripts need no semicolon between statements, so they are rejected after a read too when the database
+// has no read-only transaction to fall back on.
+var statementKeywords = map[string]bool{
+	"DENY": true, "ENABLE": true, "DISABLE": true, "SET": true, "UNSET": true, "DECLARE": true, "USE": true,
+	"BEGIN": true, "COMMIT": true, "ROLLBACK": true, "SAVE": true, "ABORT": true, "WAITFOR": true, "PRINT": true,
+	"RAISERROR": true, "THROW": true, "IF": true, "WHILE": true, "GOTO": true, "RETURN": true, "CHECKPOINT": true,
+	"RECONFIGURE": true, "REVERT": true, "SETUSER": true, "BULK": true, "WRITETEXT": true, "UPDATETEXT": true,
+	"DEALLOCATE": true, "PUT": true, "GET": true, "REMOVE": true, "UNDROP": true, "REPLACE": true,
+	"ANALYZE": true, "VACUUM": true, "REFRESH": true, "LOAD": true, "EXPORT": true, "ASSERT": true, "RAISE": true,
+}
+
+// procedureKeywords are the write keywords starting procedure calls, whose procedures may return rows.
+var procedureKeywords = map[string]bool{"CALL": true, "EXEC": true, "EXECUTE": true}
+
+// systemFunctions are the Snowflake system functions that change the account when called from a SELECT,
+// such as SELECT SYSTEM$CANCEL_ALL_QUERIES(...). Other SYSTEM$ functions only read, like SYSTEM$CLUSTERING_INFORMATION.
+var systemFunctions = map[string]bool{
+	"SYSTEM$CANCEL_ALL_QUERIES": true, "SYSTEM$CANCEL_QUERY": true, "SYSTEM$ABORT_SESSION": true,
+	"SYSTEM$ABORT_TRANSACTION": true, "SYSTEM$USER_TASK_CANCEL_ONGOING_EXECUTIONS": true, "SYSTEM$TASK_DEPENDENTS_ENABLE": true,
+	"SYSTEM$PIPE_FORCE_RESUME": true, "SYSTEM$SET_RETURN_VALUE": true, "SYSTEM$ENABLE_BEHAVIOR_CHANGE_BUNDLE": true,
+	"SYSTEM$DISABLE_BEHAVIOR_CHANGE_BUNDLE": true, "SYSTEM$AUTHORIZE_PRIVATELINK": true, "SYSTEM$REVOKE_PRIVATELINK": true,
+	"SYSTEM$GLOBAL_ACCOUNT_SET_PARAMETER": true, "SYSTEM$CLEANUP_DATABASE_ROLE_GRANTS": true,
+}
+
+// lockingHints are the T-SQL table hints that take locks while reading, such as WITH (TABLOCKX, HOLDLOCK).
+var lockingHints = map[string]bool{
+	"TABLOCK": true, "TABLOCKX": true, "HOLDLOCK": true, "UPDLOCK": true, "XLOCK": true, "PAGLOCK": true,
+	"ROWLOCK": true, "SERIALIZABLE": true, "REPEATABLEREAD": true,
+}
+
+// readOnlyTransactions reports whether the drivers of a database run read-only mode in read-only transactions,
+// which reject the writes the keyword checks miss.
+func readOnlyTransactions(dbType types.DbType) bool {
+	switch dbType {
+	case types.Postgres, types.MySQL, types.Redshift:
+		return true
+	}
+	return false
+}
+
+// Statements splits a SQL text into its statements at the semicolons outside of strings, quoted identifiers
+// and comments, and classifies every statement. Statements made only of whitespace and comments are left out.
+func Statements(dbType types.DbType, sql string) []Statement {
+	var statements []Statement
+	var current []Token
+	flush := func() {
+		if statement, ok := newStatement(dbType, current); ok {
+			statements = append(statements, statement)
+		}
+		current = nil
+	}
+	for _, t := range Tokenize(dbType, sql) {
+		if t.Kind == Punct && t.Text == ";" {
+			flush()
+			continue
+		}
+		current = append(current, t)
+	}
+	flush()
+	return statements
+}
+
+// CheckReadOnly returns an error naming the first statement of the SQL text that is not a read.
+// Anything that is not known to be a read is rejected, including transaction control and procedure calls.
+func CheckReadOnly(dbType types.DbType, sql string) error {
+	for i, statement := range Statements(dbType, sql) {
+		if statement.Kind != ReadStatement {
+			return fmt.Errorf("read-only mode: statement %d is a %s statement, only reads are allowed", i+1, statement.Keyword)
+		}
+	}
+	return nil
+}
+
+// MayReturnRows reports whether the SQL text may return rows: it holds a read or a procedure call, such as
+// EXEC on SQL Server or CALL on Snowflake, whose procedure may select rows besides changing them.
+func MayReturnRows(dbType types.DbType, sql string) bool {
+	for _, statement := range Statements(dbType, sql) {
+		if statement.Kind == ReadStatement || procedureKeywords[statement.Keyword] {
+			return true
+		}
+	}
+	return false
+}
+
+// newStatement classifies the tokens of a statement, it returns false when the statement has no significant tokens.
+func newStatement(dbType types.DbType, tokens []Token) (Statement, bool) {
+	significant := Significant(tokens)
+	if len(significant) == 0 {
+		return Statement{}, false
+	}
+
+	var text strings.Builder
+	for _, t := range tokens {
+		text.WriteString(t.Text)
+	}
+	statement := Statement{Text: strings.TrimSpace(text.String())}
+	statement.Kind, statement.Keyword = classify(dbType, significant)
+	return statement, true
+}
+
+// classify returns the kind of a statement and the keyword it was classified by.
+// When the database has no read-only transaction, every keyword that starts a statement outside of
+// parentheses must be a read keyword, so that SELECT 1 DENY SELECT ON t TO public is not taken for a read.
+func classify(dbType types.DbType, tokens []Token) (StatementKind, string) {
+	// (SELECT ...) UNION (SELECT ...) starts with parentheses
+	first := 0
+	for first < len(tokens) && tokens[first].Text == "(" {
+		first++
+	}
+	if first == len(tokens) {
+		return WriteStatement, tokens[0].Text
+	}
+	keyword := tokens[first].Upper()
+	if tokens[first].Kind != Word || !readKeywords[keyword] {
+		return WriteStatement, keyword
+	}
+
+	strict := !readOnlyTransactions(dbType)
+	depth := first
+	for i := first + 1; i < len(tokens); i++ {
+		t := tokens[i]
+		switch {
+		case t.Text == "(":
+			depth++
+		case t.Text == ")":
+			depth--
+		}
+		if t.Kind != Word {
+			continue
+		}
+		// t.update or update.t are qualified identifiers, not keywords
+		if tokens[i-1].Text == "." || (i+1 < len(tokens) && tokens[i+1].Text == ".") {
+			continue
+		}
+		upper := t.Upper()
+		if writeKeywords[upper] || lockingHints[upper] {
+			return WriteStatement, upper
+		}
+		// FOR SHARE and FOR KEY SHARE lock the rows they read
+		if upper == "SHARE" && (tokens[i-1].IsKeyword("FOR") || tokens[i-1].IsKeyword("KEY")) {
+			return WriteStatement, "FOR SHARE"
+		}
+		if strict && systemFunctions[upper] {
+			return WriteStatement, upper
+		}
+		if strict && depth <= first && statementKeyword(tokens, i) {
+			return WriteStatement, upper
+		}
+	}
+	return ReadStatement, keyword
+}
+
+// statementKeyword reports whether the word at index i of the tokens starts a statement other than a read.
+// Function calls such as IF(a, b, c) and aliases following AS are not keywords.
+func statementKeyword(tokens []Token, i int) bool {
+	if !statementKeywords[tokens[i].Upper()] || tokens[i-1].IsKeyword("AS") {
+		return false
+	}
+	return i+1 == len(tokens) || tokens[i+1].Text != "("
+}
+
+// SingleStatement returns the only statement of a SQL text.
+// It returns an error when the text is empty or holds several statements, so that a statement appended
+// after a semicolon cannot ride along when the text is prefixed with EXPLAIN.
+func SingleStatement(dbType types.DbType, sql string) (Statement, error) {
+	statements := Statements(dbType, sql)
+	if len(statements) != 1 {
+		return Statement{}, fmt.Errorf("expected a single statement, got %d", len(statements))
+	}
+	return statements[0], nil
+}
@@ -0,0 +1,102 @@
+package dialect
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestStatements is a unit test function that tests splitting a SQL text into classified statements.
+func TestStatements(t *testing.T) {
+	got := Statements(types.Postgres, "SELECT ';' AS a; -- DROP TABLE x;\n\n;DELETE FROM t /* ; */ WHERE id = 1;\nSHOW search_path")
+	want := []Statement{
+		{Text: "SELECT ';' AS a", Kind: ReadStatement, Keyword: "SELECT"},
+		{Text: "DELETE FROM t /* ; */ WHERE id = 1", Kind: WriteStatement, Keyword: "DELETE"},
+		{Text: "SHOW search_path", Kind: ReadStatement, Keyword: "SHOW"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Statements() = %+v, want %+v", got, want)
+	}
+}
+
+// TestCheckReadOnly is a unit test function that tests accepting reads and rejecting writes in read-only mode.
+func TestCheckReadOnly(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType types.DbType
+		query  string
+		ok     bool
+	}{
+		{"select", types.Postgres, "SELECT * FROM users WHERE name = 'DROP TABLE users'", true},
+		{"keywords in comments and identifiers", types.Postgres, `/* DELETE */ SELECT "update", t.delete FROM t -- ; DROP TABLE t`, true},
+		{"parenthesized union", types.MySQL, "(SELECT 1) UNION (SELECT 2)", true},
+		{"cte", types.Snowflake, "WITH r AS (SELECT * FROM events) SELECT COUNT(*) FROM r", true},
+		{"show describe explain", types.MySQL, "SHOW TABLES; DESCRIBE users; EXPLAIN SELECT 1;", true},
+		{"mssql bracketed keyword", types.MSSQL, "SELECT [delete] FROM [update]", true},
+		{"mysql backslash escape", types.MySQL, `SELECT 'it\'s; DROP TABLE t' FROM dual`, true},
+		{"dollar quoted string", types.Postgres, "SELECT $$; DELETE FROM t$$", true},
+		{"empty", types.Postgres, " -- nothing\n", true},
+		{"delete", types.Postgres, "DELETE FROM users", false},
+		{"second statement", types.Postgres, "SELECT 1; DROP TABLE users", false},
+		{"lower case update", types.MySQL, "update users set admin = 1", false},
+		{"data-modifying cte", types.Postgres, "WITH d AS (DELETE FROM t RETURNING *) SELECT * FROM d", false},
+		{"select into", types.MSSQL, "SELECT * INTO backup FROM users", false},
+		{"into outfile", types.MySQL, "SELECT * FROM users INTO OUTFILE '/tmp/users'", false},
+		{"for update", types.Postgres, "SELECT * FROM users FOR UPDATE", false},
+		{"for share", types.Postgres, "SELECT * FROM users FOR SHARE", false},
+		{"t-sql batch without semicolon", types.MSSQL, "SELECT 1 DROP TABLE users", false},
+		{"transaction control", types.Postgres, "BEGIN", false},
+		{"session setting", types.Snowflake, "USE ROLE ACCOUNTADMIN", false},
+		{"procedure call", types.MySQL, "CALL purge()", false},
+		{"bigquery script", types.BigQuery, "DECLARE x INT64", false},
+		{"mysql replace", types.MySQL, "REPLACE INTO users VALUES (1)", false},
+		{"quoted keyword statement", types.Postgres, `"SELECT"`, false},
+		{"t-sql deny after select", types.MSSQL, "SELECT 1 DENY SELECT ON t TO public", false},
+		{"t-sql disable trigger after select", types.MSSQL, "SELECT 1 DISABLE TRIGGER trg ON t", false},
+		{"t-sql enable trigger after select", types.MSSQL, "SELECT 1 ENABLE TRIGGER ALL ON t", false},
+		{"t-sql locking hints", types.MSSQL, "SELECT * FROM t WITH (TABLOCKX, HOLDLOCK)", false},
+		{"t-sql set after select", types.MSSQL, "SELECT 1 SET NOCOUNT ON", false},
+		{"bigquery statement after select", types.BigQuery, "SELECT 1\nBEGIN TRANSACTION", false},
+		{"snowflake statement after select", types.Snowflake, "SELECT 1 UNDROP TABLE t", false},
+		{"snowflake side-effecting system function", types.Snowflake, "SELECT SYSTEM$CANCEL_ALL_QUERIES(1234)", false},
+		{"lower case snowflake system function", types.Snowflake, "select 1 from t where system$abort_session(42) is not null", false},
+		{"snowflake reading system function", types.Snowflake, "SELECT SYSTEM$CLUSTERING_INFORMATION('events')", true},
+		{"t-sql nolock hint", types.MSSQL, "SELECT * FROM t WITH (NOLOCK)", true},
+		{"function named like a keyword", types.BigQuery, "SELECT IF(a > 1, 'x', 'y') AS set_name, REPLACE(b, 'a', 'b') FROM t", true},
+		{"alias named like a keyword", types.MSSQL, "SELECT name AS [print], 1 AS print FROM t", true},
+		{"left to the postgres read-only transaction", types.Postgres, "SELECT 1 SET", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckReadOnly(tt.dbType, tt.query)
+			if (err == nil) != tt.ok {
+				t.Errorf("CheckReadOnly(%q) = %v, want ok %v", tt.query, err, tt.ok)
+			}
+		})
+	}
+}
+
+// TestMayReturnRows is a unit test function that tests recognizing the statements that may return rows.
+func TestMayReturnRows(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType types.DbType
+		query  string
+		want   bool
+	}{
+		{"select", types.Postgres, "SELECT 1", true},
+		{"t-sql procedure", types.MSSQL, "EXEC dbo.list_users @active = 1", true},
+		{"t-sql batch with a read", types.MSSQL, "INSERT INTO t VALUES (1); SELECT * FROM t", true},
+		{"snowflake procedure", types.Snowflake, "CALL list_users()", true},
+		{"insert", types.Postgres, "INSERT INTO t VALUES (1)", false},
+		{"create procedure", types.MySQL, "CREATE PROCEDURE p() BEGIN SELECT 1; END", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MayReturnRows(tt.dbType, tt.query); got != tt.want {
+				t.Errorf("MayReturnRows(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
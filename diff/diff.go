@@ -0,0 +1,274 @@
+// Package diff compares table schemas, for example of the same database before and after a migration,
+// or of a table copied between MySQL, Postgres and Snowflake.
+// Tables and columns are matched by case-insensitive name and column types are normalised across
+// dialects, so only real differences are reported.
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// SchemaDiff is the difference between two database schemas.
+type SchemaDiff struct {
+	AddedTables   []types.Table `json:"added_tables"`   // AddedTables are the tables that only exist in the target.
+	RemovedTables []types.Table `json:"removed_tables"` // RemovedTables are the tables that only exist in the source.
+	ChangedTables []TableDiff   `json:"changed_tables"` // ChangedTables are the tables that exist in both, or were renamed, and differ.
+}
+
+// TableDiff is the difference between two versions of a table.
+type TableDiff struct {
+	Name           string         `json:"name"`            // Name is the name of the table in the target.
+	Schema         string         `json:"schema"`          // Schema is the schema of the table in the target.
+	DbType         types.DbType   `json:"db_type"`         // DbType is the database of the target, whose spelling the column types are read in.
+	RenamedFrom    string         `json:"renamed_from"`    // RenamedFrom is the name of the table in the source when it was renamed.
+	AddedColumns   []types.Column `json:"added_columns"`   // AddedColumns are the columns that only exist in the target.
+	RemovedColumns []types.Column `json:"removed_columns"` // RemovedColumns are the columns that only exist in the source.
+	ChangedColumns []ColumnDiff   `json:"changed_columns"` // ChangedColumns are the columns that exist in both and differ.
+	PrimaryKey     *Change        `json:"primary_key"`     // PrimaryKey is the change of the primary key columns, nil when it did not change.
+	AddedIndexes   []types.Index  `json:"added_indexes"`   // AddedIndexes are the indexes that only exist in the target.
+	RemovedIndexes []types.Index  `json:"removed_indexes"` // RemovedIndexes are the indexes that only exist in the source.
+}
+
+// ColumnDiff is the difference between two versions of a column.
+type ColumnDiff struct {
+	Name    string       `json:"name"`    // Name is the name of the column.
+	From    types.Column `json:"from"`    // From is the column in the source.
+	To      types.Column `json:"to"`      // To is the column in the target.
+	Changes []Change     `json:"changes"` // Changes are the attributes of the column that differ.
+}
+
+// These constants name the attributes reported by a Change.
+const (
+	FieldType       = "type"        // FieldType is the normalised type of a column.
+	FieldNullable   = "nullable"    // FieldNullable is whether a column accepts NULL, YES or NO.
+	FieldDefault    = "default"     // FieldDefault is the default value of a column.
+	FieldPrimaryKey = "primary_key" // FieldPrimaryKey is the comma separated list of primary key columns of a table.
+)
+
+// Change is a single attribute that differs between the source and the target.
+type Change struct {
+	Field string `json:"field"` // Field is the attribute that changed, one of the Field constants.
+	From  string `json:"from"`  // From is the normalised value in the source.
+	To    string `json:"to"`    // To is the normalised value in the target.
+}
+
+// Empty reports whether the schemas are the same.
+func (d SchemaDiff) Empty() bool {
+	return len(d.AddedTables) == 0 && len(d.RemovedTables) == 0 && len(d.ChangedTables) == 0
+}
+
+// Empty reports whether the tables are the same. A renamed table is not empty.
+func (d TableDiff) Empty() bool {
+	return d.RenamedFrom == "" && len(d.AddedColumns) == 0 && len(d.RemovedColumns) == 0 &&
+		len(d.ChangedColumns) == 0 && d.PrimaryKey == nil && len(d.AddedIndexes) == 0 && len(d.RemovedIndexes) == 0
+}
+
+// JSON returns the diff as an indented JSON document.
+func (d SchemaDiff) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling schema diff: %v", err)
+	}
+	return data, nil
+}
+
+// Live takes a snapshot of two databases and compares them.
+func Live(ctx context.Context, from types.ISQLContext, fromDatabase string, to types.ISQLContext, toDatabase string, opts types.SnapshotOptions) (SchemaDiff, error) {
+	source, err := from.Snapshot(ctx, fromDatabase, opts)
+	if err != nil {
+		return SchemaDiff{}, fmt.Errorf("error reading source schema: %v", err)
+	}
+	target, err := to.Snapshot(ctx, toDatabase, opts)
+	if err != nil {
+		return SchemaDiff{}, fmt.Errorf("error reading target schema: %v", err)
+	}
+	return Schemas(source, target), nil
+}
+
+// Schemas compares two database schemas, the column types of each are read as spelled by its DbType.
+// A table that was removed and a table that was added with the same columns are reported as a rename.
+func Schemas(from, to types.DatabaseSchema) SchemaDiff {
+	var d SchemaDiff
+
+	source := map[string]types.Table{}
+	for _, t := range from.Tables {
+		source[key(t.Name)] = t
+	}
+	matched := map[string]bool{}
+	var added []types.Table
+	for _, t := range to.Tables {
+		old, ok := source[key(t.Name)]
+		if !ok {
+			added = append(added, t)
+			continue
+		}
+		matched[key(t.Name)] = true
+		if td := Tables(old, t, from.DbType, to.DbType); !td.Empty() {
+			d.ChangedTables = append(d.ChangedTables, td)
+		}
+	}
+	var removed []types.Table
+	for _, t := range from.Tables {
+		if !matched[key(t.Name)] {
+			removed = append(removed, t)
+		}
+	}
+
+	// pair removed and added tables with the same columns as renames
+	renamed := map[int]bool{}
+	for _, t := range added {
+		match := -1
+		for i, old := range removed {
+			if !renamed[i] && len(t.Columns) > 0 && columnSignature(old, from.DbType, to.DbType) == columnSignature(t, to.DbType, from.DbType) {
+				match = i
+				break
+			}
+		}
+		if match < 0 {
+			d.AddedTables = append(d.AddedTables, t)
+			continue
+		}
+		renamed[match] = true
+		td := Tables(removed[match], t, from.DbType, to.DbType)
+		td.RenamedFrom = removed[match].Name
+		d.ChangedTables = append(d.ChangedTables, td)
+	}
+	for i, t := range removed {
+		if !renamed[i] {
+			d.RemovedTables = append(d.RemovedTables, t)
+		}
+	}
+	return d
+}
+
+// Tables compares two versions of a table, read from the given source and target databases.
+func Tables(from, to types.Table, fromType, toType types.DbType) TableDiff {
+	d := TableDiff{Name: to.Name, Schema: to.Schema, DbType: toType}
+
+	source := map[string]types.Column{}
+	for _, c := range from.Columns {
+		source[key(c.Name)] = c
+	}
+	target := map[string]bool{}
+	for _, c := range to.Columns {
+		target[key(c.Name)] = true
+		old, ok := source[key(c.Name)]
+		if !ok {
+			d.AddedColumns = append(d.AddedColumns, c)
+			continue
+		}
+		if cd := Columns(old, c, fromType, toType); len(cd.Changes) > 0 {
+			d.ChangedColumns = append(d.ChangedColumns, cd)
+		}
+	}
+	for _, c := range from.Columns {
+		if !target[key(c.Name)] {
+			d.RemovedColumns = append(d.RemovedColumns, c)
+		}
+	}
+
+	if fromKey, toKey := primaryKey(from), primaryKey(to); fromKey != toKey {
+		d.PrimaryKey = &Change{Field: FieldPrimaryKey, From: fromKey, To: toKey}
+	}
+
+	sourceIndexes := map[string]bool{}
+	for _, index := range from.Indexes {
+		if !index.Primary {
+			sourceIndexes[indexSignature(index)] = true
+		}
+	}
+	targetIndexes := map[string]bool{}
+	for _, index := range to.Indexes {
+		if index.Primary {
+			continue
+		}
+		targetIndexes[indexSignature(index)] = true
+		if !sourceIndexes[indexSignature(index)] {
+			d.AddedIndexes = append(d.AddedIndexes, index)
+		}
+	}
+	for _, index := range from.Indexes {
+		if !index.Primary && !targetIndexes[indexSignature(index)] {
+			d.RemovedIndexes = append(d.RemovedIndexes, index)
+		}
+	}
+	return d
+}
+
+// Columns compares two versions of a column, read from the given source and target databases.
+// Nullability is only compared when both databases report it.
+func Columns(from, to types.Column, fromType, toType types.DbType) ColumnDiff {
+	d := ColumnDiff{Name: to.Name, From: from, To: to}
+	if f, t := columnType(from, fromType, toType), columnType(to, toType, fromType); f != t {
+		d.Changes = append(d.Changes, Change{Field: FieldType, From: f, To: t})
+	}
+	if f, t := nullable(from), nullable(to); f != "" && t != "" && f != t {
+		d.Changes = append(d.Changes, Change{Field: FieldNullable, From: f, To: t})
+	}
+	if f, t := defaultValue(from), defaultValue(to); f != t {
+		d.Changes = append(d.Changes, Change{Field: FieldDefault, From: f, To: t})
+	}
+	return d
+}
+
+// key is the name tables and columns are matched by.
+func key(name string) string {
+	return strings.ToLower(name)
+}
+
+// primaryKey returns the comma separated primary key columns of a table, in column order.
+func primaryKey(table types.Table) string {
+	columns := PrimaryKeyColumns(table)
+	for i, c := range columns {
+		columns[i] = key(c)
+	}
+	return strings.Join(columns, ",")
+}
+
+// PrimaryKeyColumns returns the names of the primary key columns of a table, in column order.
+func PrimaryKeyColumns(table types.Table) []string {
+	var columns []string
+	for _, c := range table.Columns {
+		if c.IsPrimary || c.Key == "PRI" {
+			columns = append(columns, c.Name)
+		}
+	}
+	return columns
+}
+
+// PrimaryKeyName returns the name of the index backing the primary key of a table, or an empty string when it is not known.
+func PrimaryKeyName(table types.Table) string {
+	for _, index := range table.Indexes {
+		if index.Primary {
+			return index.Name
+		}
+	}
+	return ""
+}
+
+// columnSignature identifies a table of the given database by its column names and types, to detect renamed
+// tables of the other database.
+func columnSignature(table types.Table, dbType, other types.DbType) string {
+	columns := make([]string, len(table.Columns))
+	for i, c := range table.Columns {
+		columns[i] = key(c.Name) + " " + columnType(c, dbType, other)
+	}
+	sort.Strings(columns)
+	return strings.Join(columns, ",")
+}
+
+// indexSignature identifies an index by what it does rather than by its name, which often
+// differs between databases.
+func indexSignature(index types.Index) string {
+	columns := make([]string, len(index.Columns))
+	for i, c := range index.Columns {
+		columns[i] = key(c)
+	}
+	return fmt.Sprintf("%s|%t|%s", strings.Join(columns, ","), index.Unique, strings.ToLower(index.Predicate))
+}
@@ -0,0 +1,192 @@
+package diff
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// mysqlUsers is a users table as read from MySQL.
+func mysqlUsers() types.Table {
+	return types.Table{
+		Name: "users",
+		Columns: []types.Column{
+			{Name: "id", Type: "int(11)", IsNullable: "NO", Key: "PRI", IsPrimary: true},
+			{Name: "email", Type: "varchar(100)", IsNullable: "YES"},
+			{Name: "status", Type: "varchar(10)", IsNullable: "NO", DefaultValue: sql.NullString{String: "'active'", Valid: true}},
+			{Name: "nickname", Type: "varchar(50)", IsNullable: "YES"},
+		},
+		Indexes: []types.Index{
+			{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Primary: true},
+			{Name: "idx_status", Columns: []string{"status"}},
+		},
+	}
+}
+
+// postgresUsers is the users table after it was migrated to Postgres and changed.
+func postgresUsers() types.Table {
+	return types.Table{
+		Name: "users",
+		Columns: []types.Column{
+			{Name: "id", Type: "integer", IsNullable: "NO", IsPrimary: true},
+			{Name: "email", Type: "character varying", CharacterMaximumLength: sql.NullInt64{Int64: 255, Valid: true}, IsNullable: "NO"},
+			{Name: "status", Type: "character varying", CharacterMaximumLength: sql.NullInt64{Int64: 10, Valid: true}, IsNullable: "NO", DefaultValue: sql.NullString{String: "'active'::character varying", Valid: true}},
+			{Name: "created_at", Type: "timestamp without time zone", IsNullable: "YES"},
+		},
+		Indexes: []types.Index{
+			{Name: "users_pkey", Columns: []string{"id"}, Unique: true, Primary: true},
+			{Name: "users_status_idx", Columns: []string{"status"}},
+			{Name: "users_email_key", Columns: []string{"email"}, Unique: true},
+		},
+	}
+}
+
+// TestTables is a unit test function that tests comparing two versions of a table across dialects.
+func TestTables(t *testing.T) {
+	d := Tables(mysqlUsers(), postgresUsers(), types.MySQL, types.Postgres)
+
+	if len(d.AddedColumns) != 1 || d.AddedColumns[0].Name != "created_at" {
+		t.Errorf("added columns = %+v", d.AddedColumns)
+	}
+	if len(d.RemovedColumns) != 1 || d.RemovedColumns[0].Name != "nickname" {
+		t.Errorf("removed columns = %+v", d.RemovedColumns)
+	}
+	// id and status only differ in spelling
+	if len(d.ChangedColumns) != 1 || d.ChangedColumns[0].Name != "email" {
+		t.Fatalf("changed columns = %+v", d.ChangedColumns)
+	}
+	wantChanges := []Change{
+		{Field: FieldType, From: "varchar(100)", To: "varchar(255)"},
+		{Field: FieldNullable, From: "YES", To: "NO"},
+	}
+	if !reflect.DeepEqual(d.ChangedColumns[0].Changes, wantChanges) {
+		t.Errorf("changes = %+v, want %+v", d.ChangedColumns[0].Changes, wantChanges)
+	}
+	if d.PrimaryKey != nil {
+		t.Errorf("primary key = %+v, want nil", d.PrimaryKey)
+	}
+	if len(d.AddedIndexes) != 1 || d.AddedIndexes[0].Name != "users_email_key" || len(d.RemovedIndexes) != 0 {
+		t.Errorf("indexes = %+v, %+v", d.AddedIndexes, d.RemovedIndexes)
+	}
+}
+
+// TestTablesPrimaryKey is a unit test function that tests that primary key changes are reported.
+func TestTablesPrimaryKey(t *testing.T) {
+	to := mysqlUsers()
+	to.Columns[1].IsPrimary = true
+
+	d := Tables(mysqlUsers(), to, types.MySQL, types.MySQL)
+	want := &Change{Field: FieldPrimaryKey, From: "id", To: "id,email"}
+	if !reflect.DeepEqual(d.PrimaryKey, want) {
+		t.Errorf("primary key = %+v, want %+v", d.PrimaryKey, want)
+	}
+}
+
+// TestTablesCatalogTypes is a unit test function that tests comparing tables whose catalogs report the length,
+// precision and scale separately from the type, such as a Postgres table copied to Snowflake.
+func TestTablesCatalogTypes(t *testing.T) {
+	precision := func(p, s int64) (sql.NullInt64, sql.NullInt64) {
+		return sql.NullInt64{Int64: p, Valid: true}, sql.NullInt64{Int64: s, Valid: true}
+	}
+	length := func(n int64) sql.NullInt64 { return sql.NullInt64{Int64: n, Valid: true} }
+
+	postgres := types.Table{Name: "orders", Columns: []types.Column{
+		{Name: "id", Type: "bigint"},
+		{Name: "total", Type: "numeric"},
+		{Name: "code", Type: "character varying", CharacterMaximumLength: length(20)},
+		{Name: "note", Type: "text"},
+		{Name: "quantity", Type: "integer"},
+	}}
+	postgres.Columns[0].NumericPrecision, postgres.Columns[0].NumericScale = precision(64, 0)
+	postgres.Columns[1].NumericPrecision, postgres.Columns[1].NumericScale = precision(10, 2)
+	snowflake := types.Table{Name: "ORDERS", Columns: []types.Column{
+		{Name: "ID", Type: "NUMBER"},
+		{Name: "TOTAL", Type: "NUMBER"},
+		{Name: "CODE", Type: "TEXT", CharacterMaximumLength: length(20)},
+		{Name: "NOTE", Type: "TEXT", CharacterMaximumLength: length(16777216)},
+		{Name: "QUANTITY", Type: "NUMBER"},
+	}}
+	snowflake.Columns[0].NumericPrecision, snowflake.Columns[0].NumericScale = precision(38, 0)
+	snowflake.Columns[1].NumericPrecision, snowflake.Columns[1].NumericScale = precision(10, 2)
+	snowflake.Columns[4].NumericPrecision, snowflake.Columns[4].NumericScale = precision(38, 0)
+
+	if d := Tables(postgres, snowflake, types.Postgres, types.Snowflake); !d.Empty() {
+		t.Errorf("Tables() = %s, want no differences", d)
+	}
+
+	snowflake.Columns[1].NumericPrecision, snowflake.Columns[1].NumericScale = precision(12, 2)
+	d := Tables(postgres, snowflake, types.Postgres, types.Snowflake)
+	want := []Change{{Field: FieldType, From: "decimal(10,2)", To: "decimal(12,2)"}}
+	if len(d.ChangedColumns) != 1 || !reflect.DeepEqual(d.ChangedColumns[0].Changes, want) {
+		t.Errorf("changed columns = %+v, want %+v", d.ChangedColumns, want)
+	}
+
+	// MySQL BIT(8) is a bit string, not a boolean
+	flags := types.Table{Name: "flags", Columns: []types.Column{{Name: "mask", Type: "bit(8)"}, {Name: "active", Type: "bit(1)"}}}
+	booleans := types.Table{Name: "flags", Columns: []types.Column{{Name: "mask", Type: "boolean"}, {Name: "active", Type: "boolean"}}}
+	if d := Tables(flags, booleans, types.MySQL, types.Postgres); len(d.ChangedColumns) != 1 || d.ChangedColumns[0].Name != "mask" {
+		t.Errorf("changed columns = %+v, want mask", d.ChangedColumns)
+	}
+}
+
+// TestSchemas is a unit test function that tests comparing two database schemas, including renamed tables.
+func TestSchemas(t *testing.T) {
+	orders := types.Table{Name: "orders", Columns: []types.Column{{Name: "id", Type: "int"}, {Name: "total", Type: "decimal(10,2)"}}}
+	purchases := types.Table{Name: "PURCHASES", Columns: []types.Column{{Name: "ID", Type: "integer"}, {Name: "TOTAL", Type: "numeric(10,2)"}}}
+	legacy := types.Table{Name: "legacy", Columns: []types.Column{{Name: "id", Type: "int"}}}
+	audit := types.Table{Name: "audit", Columns: []types.Column{{Name: "at", Type: "timestamp"}}}
+
+	from := types.DatabaseSchema{DbType: types.MySQL, Tables: []types.Table{mysqlUsers(), orders, legacy}}
+	to := types.DatabaseSchema{DbType: types.Postgres, Tables: []types.Table{postgresUsers(), purchases, audit}}
+	d := Schemas(from, to)
+
+	if len(d.AddedTables) != 1 || d.AddedTables[0].Name != "audit" {
+		t.Errorf("added tables = %+v", d.AddedTables)
+	}
+	if len(d.RemovedTables) != 1 || d.RemovedTables[0].Name != "legacy" {
+		t.Errorf("removed tables = %+v", d.RemovedTables)
+	}
+	if len(d.ChangedTables) != 2 {
+		t.Fatalf("changed tables = %+v", d.ChangedTables)
+	}
+	if renamed := d.ChangedTables[1]; renamed.Name != "PURCHASES" || renamed.RenamedFrom != "orders" || len(renamed.ChangedColumns) != 0 {
+		t.Errorf("renamed table = %+v", renamed)
+	}
+
+	if diff := Schemas(from, from); !diff.Empty() {
+		t.Errorf("Schemas() of the same schema = %+v, want no differences", diff)
+	}
+}
+
+// TestSchemaDiffOutput is a unit test function that tests the text and JSON forms of a diff.
+func TestSchemaDiffOutput(t *testing.T) {
+	from := types.DatabaseSchema{DbType: types.MySQL, Tables: []types.Table{mysqlUsers()}}
+	to := types.DatabaseSchema{DbType: types.Postgres, Tables: []types.Table{postgresUsers(), {Name: "audit", Columns: []types.Column{{Name: "at", Type: "timestamp"}}}}}
+	d := Schemas(from, to)
+
+	want := `+ table audit
+~ table users
+  + column created_at timestamp
+  - column nickname
+  ~ column email: type varchar(100) -> varchar(255); nullable YES -> NO
+  + index users_email_key (email) unique
+`
+	if got := d.String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+
+	data, err := d.JSON()
+	if err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+	var decoded SchemaDiff
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("error decoding diff: %v", err)
+	}
+	if len(decoded.ChangedTables) != 1 || len(decoded.ChangedTables[0].ChangedColumns[0].Changes) != 2 {
+		t.Errorf("decoded diff = %+v", decoded)
+	}
+}
@@ -0,0 +1,146 @@
+package diff
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/thesaas-company/xray/translate"
+	"github.com/thesaas-company/xray/types"
+)
+
+// NormalizeType returns the canonical spelling of a column type of the given database, as parsed by
+// translate.Parse, so that for example int4, INT(11) and INTEGER all become integer and character varying(20)
+// and NVARCHAR(20) become varchar(20). The fractional second precision of temporal types is dropped, because
+// most catalogs do not report it with the type. Types translate does not know are returned lower cased.
+func NormalizeType(dbType types.DbType, typ string) string {
+	return compared(translate.Parse(dbType, typ)).String()
+}
+
+// compared returns the parts of a type that are compared: the fractional second precision of temporal
+// types is dropped.
+func compared(t translate.Type) translate.Type {
+	switch t.Kind {
+	case translate.Time, translate.TimeTZ, translate.Timestamp, translate.TimestampTZ:
+		t.Precision = 0
+	case translate.Array:
+		if t.Element != nil {
+			element := compared(*t.Element)
+			t.Element = &element
+		}
+	}
+	return t
+}
+
+// singleInteger reports whether a database stores every integer as the same type: Snowflake integers are
+// all NUMBER(38,0) and BigQuery only has INT64.
+func singleInteger(dbType types.DbType) bool {
+	return dbType == types.Snowflake || dbType == types.BigQuery
+}
+
+// columnType returns the normalised type of a column read from the given database, to be compared with a
+// column of the other database. Integer widths are only compared when both databases keep them.
+func columnType(column types.Column, dbType, other types.DbType) string {
+	t := compared(translate.ParseColumn(dbType, column))
+	if singleInteger(dbType) || singleInteger(other) {
+		t = widestInteger(t)
+	}
+	return t.String()
+}
+
+// widestInteger returns bigint for the signed integer types and the type itself for any other type.
+func widestInteger(t translate.Type) translate.Type {
+	switch t.Kind {
+	case translate.TinyInt, translate.SmallInt, translate.Integer:
+		if !t.Unsigned {
+			t.Kind = translate.BigInt
+		}
+	}
+	return t
+}
+
+// Widens reports whether changing a column of the given database from one type to the other only increases
+// the length of a character or byte string or the precision of a decimal, the only type changes most
+// warehouses make in place.
+func Widens(dbType types.DbType, from, to types.Column) bool {
+	f, t := translate.ParseColumn(dbType, from), translate.ParseColumn(dbType, to)
+	switch {
+	case f.Kind == translate.Varchar && t.Kind == translate.Text:
+		return true
+	case f.Kind != t.Kind || f.Unsigned != t.Unsigned:
+		return false
+	}
+	switch f.Kind {
+	case translate.Decimal:
+		// the scale of a decimal cannot change
+		return f.Precision > 0 && t.Precision >= f.Precision && t.Scale == f.Scale
+	case translate.Char, translate.Varchar, translate.Binary, translate.Varbinary:
+		return f.Length > 0 && t.Length >= f.Length
+	}
+	return false
+}
+
+// nullable returns YES or NO for a column, or an empty string when the database did not report it.
+func nullable(column types.Column) string {
+	switch strings.ToUpper(strings.TrimSpace(column.IsNullable)) {
+	case "YES", "Y", "TRUE", "1":
+		return "YES"
+	case "NO", "N", "FALSE", "0":
+		return "NO"
+	}
+	return ""
+}
+
+var (
+	// castSuffix matches the casts Postgres adds to default values, such as 'a'::character varying.
+	castSuffix = regexp.MustCompile(`::[a-z_ ]+(\[\])?$`)
+	// currentTimestamp matches the spellings of the current time used as a default.
+	currentTimestamp = regexp.MustCompile(`^(now\(\)|current_timestamp(\(\))?|getdate\(\)|sysdatetime\(\)|localtimestamp(\(\))?)$`)
+)
+
+// defaultValue returns a normalised default value of a column, or an empty string when it has none.
+func defaultValue(column types.Column) string {
+	value := column.DefaultValue
+	if !value.Valid {
+		value = column.ColumnDefault
+	}
+	if !value.Valid {
+		return ""
+	}
+
+	d := strings.TrimSpace(value.String)
+	// MSSQL wraps defaults in parentheses, ((0)) or ('a')
+	for strings.HasPrefix(d, "(") && strings.HasSuffix(d, ")") && balanced(d[1:len(d)-1]) {
+		d = strings.TrimSpace(d[1 : len(d)-1])
+	}
+	lower := strings.ToLower(d)
+	if strings.EqualFold(d, "null") {
+		return ""
+	}
+	if m := castSuffix.FindStringIndex(lower); m != nil {
+		d, lower = d[:m[0]], lower[:m[0]]
+	}
+	if currentTimestamp.MatchString(lower) {
+		return "CURRENT_TIMESTAMP"
+	}
+	if strings.HasPrefix(d, "N'") {
+		d = d[1:]
+	}
+	return d
+}
+
+// balanced reports whether the parentheses of s are balanced, so that (a) + (b) is not unwrapped.
+func balanced(s string) bool {
+	depth := 0
+	for _, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
@@ -0,0 +1,100 @@
+package diff
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestNormalizeType is a unit test function that tests that the spellings of a type used by different databases normalise to the same name.
+func TestNormalizeType(t *testing.T) {
+	tests := []struct {
+		dbType types.DbType
+		types  []string
+		want   string
+	}{
+		{types.Postgres, []string{"int4", "INTEGER", "serial"}, "integer"},
+		{types.MySQL, []string{"int(11)", "INT", "mediumint"}, "integer"},
+		{types.Snowflake, []string{"NUMBER(38,0)", "NUMBER"}, "bigint"},
+		{types.BigQuery, []string{"INT64", "BIGINT"}, "bigint"},
+		{types.Postgres, []string{"character varying(20)", "VARCHAR(20)"}, "varchar(20)"},
+		{types.MSSQL, []string{"nvarchar(20)", "VARCHAR(20)"}, "varchar(20)"},
+		{types.Postgres, []string{"text", "varchar", "character varying"}, "text"},
+		{types.MSSQL, []string{"nvarchar(max)", "ntext"}, "text"},
+		{types.Snowflake, []string{"TEXT", "STRING", "VARCHAR(16777216)"}, "text"},
+		{types.Postgres, []string{"numeric(10,2)", "DECIMAL(10, 2)"}, "decimal(10,2)"},
+		{types.Snowflake, []string{"NUMBER(10,2)"}, "decimal(10,2)"},
+		{types.Postgres, []string{"timestamp without time zone", "timestamp(6)"}, "timestamp"},
+		{types.MySQL, []string{"DATETIME", "datetime(6)"}, "timestamp"},
+		{types.MSSQL, []string{"datetime2(7)", "smalldatetime"}, "timestamp"},
+		{types.Postgres, []string{"timestamp with time zone", "timestamptz", "timestamp(6) with time zone"}, "timestamptz"},
+		{types.Snowflake, []string{"TIMESTAMP_TZ", "TIMESTAMP_LTZ(9)"}, "timestamptz"},
+		{types.Postgres, []string{"double precision", "float8", "float"}, "double"},
+		{types.MSSQL, []string{"float", "float(53)"}, "double"},
+		{types.MySQL, []string{"float", "float(10)"}, "real"},
+		{types.Postgres, []string{"bool", "BOOLEAN"}, "boolean"},
+		{types.MySQL, []string{"tinyint(1)", "bit(1)", "bool"}, "boolean"},
+		{types.MSSQL, []string{"bit"}, "boolean"},
+		{types.MySQL, []string{"bit(8)"}, "varbinary(1)"},
+		{types.Postgres, []string{"jsonb", "JSON"}, "json"},
+		{types.Snowflake, []string{"VARIANT", "OBJECT"}, "json"},
+		{types.Postgres, []string{"integer[]", "int4[]"}, "array<integer>"},
+		{types.BigQuery, []string{"ARRAY<INT64>"}, "array<bigint>"},
+		{types.MySQL, []string{"int(10) unsigned", "INT UNSIGNED"}, "integer unsigned"},
+		{types.Postgres, []string{"geography"}, "geography"},
+		{types.Postgres, []string{"tsvector", "TSVECTOR"}, "tsvector"},
+	}
+	for _, tt := range tests {
+		for _, typ := range tt.types {
+			if got := NormalizeType(tt.dbType, typ); got != tt.want {
+				t.Errorf("NormalizeType(%s, %q) = %q, want %q", tt.dbType, typ, got, tt.want)
+			}
+		}
+	}
+}
+
+// TestDefaultValue is a unit test function that tests the normalisation of default values.
+func TestDefaultValue(t *testing.T) {
+	tests := []struct {
+		value sql.NullString
+		want  string
+	}{
+		{value: sql.NullString{}, want: ""},
+		{value: sql.NullString{String: "'active'::character varying", Valid: true}, want: "'active'"},
+		{value: sql.NullString{String: "('active')", Valid: true}, want: "'active'"},
+		{value: sql.NullString{String: "((0))", Valid: true}, want: "0"},
+		{value: sql.NullString{String: "(1) + (2)", Valid: true}, want: "(1) + (2)"},
+		{value: sql.NullString{String: "now()", Valid: true}, want: "CURRENT_TIMESTAMP"},
+		{value: sql.NullString{String: "(getdate())", Valid: true}, want: "CURRENT_TIMESTAMP"},
+		{value: sql.NullString{String: "NULL", Valid: true}, want: ""},
+	}
+	for _, tt := range tests {
+		if got := defaultValue(types.Column{DefaultValue: tt.value}); got != tt.want {
+			t.Errorf("defaultValue(%q) = %q, want %q", tt.value.String, got, tt.want)
+		}
+	}
+}
+
+// TestWidens is a unit test function that tests detecting type changes that only widen a column.
+func TestWidens(t *testing.T) {
+	tests := []struct {
+		dbType   types.DbType
+		from, to types.Column
+		want     bool
+	}{
+		{types.MySQL, types.Column{Type: "varchar(100)"}, types.Column{Type: "VARCHAR(255)"}, true},
+		{types.Postgres, types.Column{Type: "character varying(100)"}, types.Column{Type: "text"}, true},
+		{types.Postgres, types.Column{Type: "character varying", CharacterMaximumLength: sql.NullInt64{Int64: 100, Valid: true}}, types.Column{Type: "character varying", CharacterMaximumLength: sql.NullInt64{Int64: 200, Valid: true}}, true},
+		{types.MySQL, types.Column{Type: "varchar(255)"}, types.Column{Type: "varchar(100)"}, false},
+		{types.Snowflake, types.Column{Type: "NUMBER(10,2)"}, types.Column{Type: "NUMBER(12,2)"}, true},
+		{types.Snowflake, types.Column{Type: "NUMBER(10,2)"}, types.Column{Type: "NUMBER(12,4)"}, false},
+		{types.Postgres, types.Column{Type: "integer"}, types.Column{Type: "bigint"}, false},
+		{types.Postgres, types.Column{Type: "text"}, types.Column{Type: "varchar(100)"}, false},
+	}
+	for _, tt := range tests {
+		if got := Widens(tt.dbType, tt.from, tt.to); got != tt.want {
+			t.Errorf("Widens(%s, %q, %q) = %v, want %v", tt.dbType, tt.from.Type, tt.to.Type, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// String returns the diff in a human readable form, one line per difference.
+// Lines start with + for additions, - for removals and ~ for changes.
+func (d SchemaDiff) String() string {
+	if d.Empty() {
+		return "no differences\n"
+	}
+
+	var b strings.Builder
+	for _, t := range d.AddedTables {
+		fmt.Fprintf(&b, "+ table %s\n", t.Name)
+	}
+	for _, t := range d.RemovedTables {
+		fmt.Fprintf(&b, "- table %s\n", t.Name)
+	}
+	for _, t := range d.ChangedTables {
+		b.WriteString(t.String())
+	}
+	return b.String()
+}
+
+// String returns the table diff in a human readable form, one line per difference.
+func (d TableDiff) String() string {
+	var b strings.Builder
+	if d.RenamedFrom != "" {
+		fmt.Fprintf(&b, "~ table %s renamed from %s\n", d.Name, d.RenamedFrom)
+	} else {
+		fmt.Fprintf(&b, "~ table %s\n", d.Name)
+	}
+	for _, c := range d.AddedColumns {
+		fmt.Fprintf(&b, "  + column %s %s\n", c.Name, columnType(c, d.DbType, d.DbType))
+	}
+	for _, c := range d.RemovedColumns {
+		fmt.Fprintf(&b, "  - column %s\n", c.Name)
+	}
+	for _, c := range d.ChangedColumns {
+		changes := make([]string, len(c.Changes))
+		for i, change := range c.Changes {
+			changes[i] = change.String()
+		}
+		fmt.Fprintf(&b, "  ~ column %s: %s\n", c.Name, strings.Join(changes, "; "))
+	}
+	if d.PrimaryKey != nil {
+		fmt.Fprintf(&b, "  ~ %s\n", d.PrimaryKey)
+	}
+	for _, index := range d.AddedIndexes {
+		fmt.Fprintf(&b, "  + index %s\n", indexString(index))
+	}
+	for _, index := range d.RemovedIndexes {
+		fmt.Fprintf(&b, "  - index %s\n", indexString(index))
+	}
+	return b.String()
+}
+
+// String returns the change as "field from -> to", with (none) standing in for empty values.
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s -> %s", strings.ReplaceAll(c.Field, "_", " "), orNone(c.From), orNone(c.To))
+}
+
+// orNone returns s, or (none) when it is empty.
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+// indexString describes an index as name (columns), marking unique indexes.
+func indexString(index types.Index) string {
+	s := fmt.Sprintf("%s (%s)", index.Name, strings.Join(index.Columns, ", "))
+	if index.Unique {
+		s += " unique"
+	}
+	return s
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
 	_ "github.com/lib/pq"
@@ -30,17 +31,14 @@ func main() {
 	}
 	fmt.Println("Tables :", data)
 
-	var response []types.Table
-	for _, v := range data {
-		table, err := client.Schema(v)
-		if err != nil {
-			panic(err)
-		}
-		response = append(response, table)
+	// read the schema of every table at once instead of calling Schema per table
+	snapshot, err := client.Snapshot(context.Background(), config.Database, types.SnapshotOptions{Concurrency: 8})
+	if err != nil {
+		panic(err)
 	}
-	fmt.Println(response)
+	fmt.Println(snapshot.Tables)
 
-	for _, v := range response {
+	for _, v := range snapshot.Tables {
 		query := client.GenerateCreateTableQuery(v)
 		fmt.Println(query)
 	}
@@ -0,0 +1,92 @@
+// Package explain parses the query plans databases return as indented text into a types.PlanNode tree.
+// Plans returned as JSON or XML are parsed by the database packages themselves.
+package explain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+var (
+	// estimate matches the planner estimates of a node, such as (cost=0.00..1.25 rows=125 width=4) or (cost=4.95 rows=9).
+	estimate = regexp.MustCompile(`\(cost=(?:[0-9.e+]+\.\.)?([0-9.e+]+) rows=([0-9.e+]+)`)
+	// actual matches the measured values of a node, such as (actual time=0.153..0.200 rows=9 loops=1).
+	actual = regexp.MustCompile(`\(actual time=[0-9.]+\.\.([0-9.]+) rows=([0-9.e+]+)(?: loops=(\d+))?`)
+	// relation matches the table a node reads, such as Seq Scan on sales or Index lookup on t using idx.
+	relation = regexp.MustCompile(` on ([^\s(]+)`)
+)
+
+// ParseText parses a text plan whose child nodes start with "->" and are indented below their parent,
+// the format of Redshift EXPLAIN and MySQL EXPLAIN ANALYZE. Lines without "->" other than the first
+// hold node details and are skipped.
+func ParseText(plan string) (*types.PlanNode, error) {
+	type level struct {
+		indent int
+		node   *types.PlanNode
+	}
+
+	var root *types.PlanNode
+	var stack []level
+	for i, line := range strings.Split(plan, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := strings.Index(line, "->")
+		if indent < 0 || strings.TrimSpace(line[:indent]) != "" {
+			if root != nil {
+				continue
+			}
+			indent = len(line) - len(strings.TrimLeft(line, " "))
+		}
+
+		node := parseLine(strings.TrimPrefix(strings.TrimSpace(line), "->"))
+		if root == nil {
+			root = node
+			stack = []level{{indent, node}}
+			continue
+		}
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			return nil, fmt.Errorf("error parsing query plan: line %d is not below the first node", i+1)
+		}
+		parent := stack[len(stack)-1].node
+		parent.Children = append(parent.Children, node)
+		stack = append(stack, level{indent, node})
+	}
+	if root == nil {
+		return nil, fmt.Errorf("error parsing query plan: empty plan")
+	}
+	return root, nil
+}
+
+// parseLine reads the operation, relation, estimates and measured values of a node line.
+func parseLine(line string) *types.PlanNode {
+	line = strings.TrimSpace(line)
+	node := &types.PlanNode{Type: line}
+	if i := strings.Index(line, "  ("); i >= 0 {
+		node.Type = line[:i]
+	}
+	if m := relation.FindStringSubmatch(node.Type); m != nil {
+		node.Relation = strings.Trim(m[1], "`\"")
+	}
+	if m := estimate.FindStringSubmatch(line); m != nil {
+		node.EstimatedCost, _ = strconv.ParseFloat(m[1], 64)
+		node.EstimatedRows, _ = strconv.ParseFloat(m[2], 64)
+	}
+	if m := actual.FindStringSubmatch(line); m != nil {
+		node.ActualTime, _ = strconv.ParseFloat(m[1], 64)
+		node.ActualRows, _ = strconv.ParseFloat(m[2], 64)
+		// the measured values are averages over the loops of the node
+		if loops, err := strconv.ParseFloat(m[3], 64); err == nil {
+			node.ActualTime *= loops
+			node.ActualRows *= loops
+		}
+	}
+	return node
+}
@@ -0,0 +1,68 @@
+package explain
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestParseTextRedshift is a unit test function that tests parsing a Redshift plan, whose first node has no arrow.
+func TestParseTextRedshift(t *testing.T) {
+	plan := `XN Merge  (cost=1000000000136.38..1000000000136.39 rows=2 width=17)
+  Merge Key: sum(sales.pricepaid)
+  ->  XN Network  (cost=1000000000136.38..1000000000136.39 rows=2 width=17)
+        Send to leader
+        ->  XN Sort  (cost=1000000000136.38..1000000000136.39 rows=2 width=17)
+              ->  XN HashAggregate  (cost=136.35..136.36 rows=2 width=17)
+                    ->  XN Seq Scan on sales  (cost=0.00..87.98 rows=8798 width=17)
+        ->  XN Seq Scan on event  (cost=0.00..0.10 rows=10 width=4)`
+
+	root, err := ParseText(plan)
+	if err != nil {
+		t.Fatalf("error parsing plan: %s", err)
+	}
+
+	want := &types.PlanNode{Type: "XN Merge", EstimatedCost: 1000000000136.39, EstimatedRows: 2, Children: []*types.PlanNode{
+		{Type: "XN Network", EstimatedCost: 1000000000136.39, EstimatedRows: 2, Children: []*types.PlanNode{
+			{Type: "XN Sort", EstimatedCost: 1000000000136.39, EstimatedRows: 2, Children: []*types.PlanNode{
+				{Type: "XN HashAggregate", EstimatedCost: 136.36, EstimatedRows: 2, Children: []*types.PlanNode{
+					{Type: "XN Seq Scan on sales", Relation: "sales", EstimatedCost: 87.98, EstimatedRows: 8798},
+				}},
+			}},
+			{Type: "XN Seq Scan on event", Relation: "event", EstimatedCost: 0.10, EstimatedRows: 10},
+		}},
+	}}
+	if !reflect.DeepEqual(root, want) {
+		t.Errorf("unexpected plan tree for %q", plan)
+	}
+}
+
+// TestParseTextMySQL is a unit test function that tests parsing the tree of a MySQL EXPLAIN ANALYZE.
+func TestParseTextMySQL(t *testing.T) {
+	plan := "-> Nested loop inner join  (cost=4.95 rows=9) (actual time=0.153..0.200 rows=9 loops=1)\n" +
+		"    -> Table scan on `t1`  (cost=1.15 rows=9) (actual time=0.050..0.060 rows=9 loops=1)\n" +
+		"    -> Index lookup on t2 using idx (a=t1.a)  (cost=0.26 rows=1) (actual time=0.010..0.012 rows=1 loops=9)\n"
+
+	root, err := ParseText(plan)
+	if err != nil {
+		t.Fatalf("error parsing plan: %s", err)
+	}
+
+	// the index lookup runs once per row of t1, its values are multiplied by its loops
+	loops := 9.0
+	want := &types.PlanNode{Type: "Nested loop inner join", EstimatedCost: 4.95, EstimatedRows: 9, ActualTime: 0.2, ActualRows: 9, Children: []*types.PlanNode{
+		{Type: "Table scan on `t1`", Relation: "t1", EstimatedCost: 1.15, EstimatedRows: 9, ActualTime: 0.06, ActualRows: 9},
+		{Type: "Index lookup on t2 using idx (a=t1.a)", Relation: "t2", EstimatedCost: 0.26, EstimatedRows: 1, ActualTime: 0.012 * loops, ActualRows: loops},
+	}}
+	if !reflect.DeepEqual(root, want) {
+		t.Errorf("unexpected plan tree for %q", plan)
+	}
+}
+
+// TestParseTextEmpty is a unit test function that tests that an empty plan is an error.
+func TestParseTextEmpty(t *testing.T) {
+	if _, err := ParseText("\n"); err == nil {
+		t.Errorf("expected an error for an empty plan")
+	}
+}
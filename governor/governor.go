@@ -0,0 +1,147 @@
+// Package governor limits the size of query results.
+// Queries without a row limit are sent with the LIMIT, TOP or OFFSET ... FETCH clause of the database, and
+// results cut by the row or byte limit are continued page by page with opaque page tokens.
+package governor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/types"
+)
+
+// Limits are the size limits of a result page, zero means no limit.
+type Limits struct {
+	MaxRows  int64 // MaxRows is the maximum number of rows of a page.
+	MaxBytes int64 // MaxBytes is the maximum size of the rows of a page, measured as JSON.
+}
+
+// Querier is a database that streams the rows of a query.
+type Querier interface {
+	Query(context.Context, string, ...interface{}) (types.RowIterator, error) // Query executes a query and streams its rows.
+}
+
+// pageToken is the decoded content of a page token.
+type pageToken struct {
+	Query  string `json:"q"` // Query is the hash of the query the token continues.
+	Offset int64  `json:"o"` // Offset is the number of rows read by the previous pages.
+}
+
+// Execute executes a query within the limits and returns the page of its result selected by the page token,
+// the first page when the token is empty. It is meant to be called by drivers from their ExecutePage method.
+//
+// The query asks the database for one row more than MaxRows, starting at the offset of the page, so that a
+// truncated result is detected without reading the rest. Queries that cannot be rewritten, such as SHOW or a
+// query with its own LIMIT, are read from the start and the rows of the previous pages are skipped. Pages of
+// a query without ORDER BY are only consistent when the database returns the rows in a stable order.
+func Execute(ctx context.Context, q Querier, dbType types.DbType, limits Limits, query, token string, args ...interface{}) (types.QueryResult, error) {
+	offset, err := decodePageToken(query, token)
+	if err != nil {
+		return types.QueryResult{}, err
+	}
+
+	statement, skip := query, offset
+	if limits.MaxRows > 0 {
+		if paginated, ok := dialect.Paginate(dbType, query, limits.MaxRows+1, offset); ok {
+			statement, skip = paginated, 0
+		}
+	}
+
+	it, err := q.Query(ctx, statement, args...)
+	if err != nil {
+		return types.QueryResult{}, err
+	}
+	defer func() {
+		if err := it.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	result, err := read(it, limits, skip)
+	if err != nil {
+		return types.QueryResult{}, fmt.Errorf("error iterating rows: %v", err)
+	}
+	if result.Truncated {
+		result.NextPageToken = encodePageToken(query, offset+result.RowCount)
+	}
+	return result, nil
+}
+
+// read drains the iterator into a QueryResult after skipping rows, stopping at the first row over the limits.
+// A page always holds at least one row, so that a row larger than MaxBytes does not stop the pagination.
+func read(it types.RowIterator, limits Limits, skip int64) (types.QueryResult, error) {
+	columns := it.Columns()
+	result := types.QueryResult{
+		Columns:     types.ColumnNames(columns),
+		ColumnTypes: columns,
+		Rows:        [][]interface{}{},
+	}
+
+	var size int64
+	for it.Next() {
+		if skip > 0 {
+			skip--
+			continue
+		}
+		if limits.MaxRows > 0 && int64(len(result.Rows)) >= limits.MaxRows {
+			result.Truncated = true
+			break
+		}
+		row := it.Row()
+		if limits.MaxBytes > 0 {
+			encoded, err := json.Marshal(row)
+			if err != nil {
+				return result, fmt.Errorf("error marshaling row: %v", err)
+			}
+			size += int64(len(encoded))
+			if size > limits.MaxBytes && len(result.Rows) > 0 {
+				result.Truncated = true
+				break
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	if err := it.Err(); err != nil {
+		return result, err
+	}
+
+	result.RowCount = int64(len(result.Rows))
+	return result, nil
+}
+
+// encodePageToken returns the token of the page of the query starting at offset.
+func encodePageToken(query string, offset int64) string {
+	data, _ := json.Marshal(pageToken{Query: queryHash(query), Offset: offset})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken returns the offset of the page token, zero for an empty token.
+// Tokens of another query are rejected.
+func decodePageToken(query, token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("error decoding page token: %v", err)
+	}
+	var page pageToken
+	if err := json.Unmarshal(data, &page); err != nil {
+		return 0, fmt.Errorf("error decoding page token: %v", err)
+	}
+	if page.Query != queryHash(query) || page.Offset < 0 {
+		return 0, fmt.Errorf("invalid page token for this query")
+	}
+	return page.Offset, nil
+}
+
+// queryHash returns a short hash binding a page token to its query.
+func queryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,144 @@
+package governor
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// fakeQuerier is an in-memory Querier returning the numbers from 1 to rows and recording the queries it runs.
+// It honours the LIMIT and OFFSET appended by the governor.
+type fakeQuerier struct {
+	rows    int
+	queries []string
+}
+
+func (f *fakeQuerier) Query(ctx context.Context, query string, args ...interface{}) (types.RowIterator, error) {
+	f.queries = append(f.queries, query)
+	var limit, offset int
+	if i := strings.Index(query, " LIMIT "); i >= 0 {
+		fmt.Sscanf(query[i:], " LIMIT %d OFFSET %d", &limit, &offset)
+	}
+	it := &sliceIterator{}
+	for n := offset + 1; n <= f.rows && (limit == 0 || n <= offset+limit); n++ {
+		it.rows = append(it.rows, []interface{}{n, strings.Repeat("x", 10)})
+	}
+	return it, nil
+}
+
+// sliceIterator is a RowIterator over rows held in memory.
+type sliceIterator struct {
+	rows [][]interface{}
+	next int
+}
+
+func (it *sliceIterator) Columns() []types.ColumnMeta {
+	return []types.ColumnMeta{{Name: "n"}, {Name: "pad"}}
+}
+func (it *sliceIterator) Next() bool         { it.next++; return it.next <= len(it.rows) }
+func (it *sliceIterator) Row() []interface{} { return it.rows[it.next-1] }
+func (it *sliceIterator) Err() error         { return nil }
+func (it *sliceIterator) Close() error       { return nil }
+
+// numbers returns the first column of the rows of a result.
+func numbers(result types.QueryResult) []interface{} {
+	var out []interface{}
+	for _, row := range result.Rows {
+		out = append(out, row[0])
+	}
+	return out
+}
+
+// TestExecutePages is a unit test function that tests reading a result page by page with an injected LIMIT and OFFSET.
+func TestExecutePages(t *testing.T) {
+	q := &fakeQuerier{rows: 5}
+	limits := Limits{MaxRows: 2}
+	query := "SELECT n, pad FROM numbers ORDER BY n"
+
+	var got [][]interface{}
+	token := ""
+	for page := 0; page < 5; page++ {
+		result, err := Execute(context.Background(), q, types.Postgres, limits, query, token)
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		got = append(got, numbers(result))
+		if result.Truncated != (result.NextPageToken != "") {
+			t.Fatalf("Truncated = %v with NextPageToken %q", result.Truncated, result.NextPageToken)
+		}
+		if !result.Truncated {
+			break
+		}
+		token = result.NextPageToken
+	}
+
+	want := [][]interface{}{{1, 2}, {3, 4}, {5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("pages = %v, want %v", got, want)
+	}
+	wantQueries := []string{query + " LIMIT 3", query + " LIMIT 3 OFFSET 2", query + " LIMIT 3 OFFSET 4"}
+	if !reflect.DeepEqual(q.queries, wantQueries) {
+		t.Errorf("queries = %q, want %q", q.queries, wantQueries)
+	}
+}
+
+// TestExecuteSkipsRows is a unit test function that tests paging through a query that cannot be rewritten.
+func TestExecuteSkipsRows(t *testing.T) {
+	q := &fakeQuerier{rows: 5}
+	query := "SHOW numbers"
+
+	first, err := Execute(context.Background(), q, types.MySQL, Limits{MaxRows: 3}, query, "")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	second, err := Execute(context.Background(), q, types.MySQL, Limits{MaxRows: 3}, query, first.NextPageToken)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := numbers(first); !reflect.DeepEqual(got, []interface{}{1, 2, 3}) || !first.Truncated {
+		t.Errorf("first page = %v, truncated %v", got, first.Truncated)
+	}
+	if got := numbers(second); !reflect.DeepEqual(got, []interface{}{4, 5}) || second.Truncated {
+		t.Errorf("second page = %v, truncated %v", got, second.Truncated)
+	}
+	if !reflect.DeepEqual(q.queries, []string{query, query}) {
+		t.Errorf("queries = %q, want the query unchanged", q.queries)
+	}
+}
+
+// TestExecuteMaxBytes is a unit test function that tests cutting a result by its size.
+func TestExecuteMaxBytes(t *testing.T) {
+	q := &fakeQuerier{rows: 5}
+	// every row is [n,"xxxxxxxxxx"], 16 bytes as JSON
+	result, err := Execute(context.Background(), q, types.Postgres, Limits{MaxBytes: 40}, "SELECT n, pad FROM numbers", "")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := numbers(result); !reflect.DeepEqual(got, []interface{}{1, 2}) || !result.Truncated || result.RowCount != 2 {
+		t.Errorf("result = %v, truncated %v, row count %d", got, result.Truncated, result.RowCount)
+	}
+
+	// a single row larger than the limit is still returned
+	result, err = Execute(context.Background(), q, types.Postgres, Limits{MaxBytes: 1}, "SELECT n, pad FROM numbers", "")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := numbers(result); !reflect.DeepEqual(got, []interface{}{1}) {
+		t.Errorf("result = %v, want the first row", got)
+	}
+}
+
+// TestExecuteInvalidPageToken is a unit test function that tests that page tokens only continue their own query.
+func TestExecuteInvalidPageToken(t *testing.T) {
+	token := encodePageToken("SELECT * FROM a", 10)
+	if _, err := Execute(context.Background(), &fakeQuerier{}, types.Postgres, Limits{MaxRows: 10}, "SELECT * FROM b", token); err == nil {
+		t.Error("expected an error for the token of another query")
+	}
+	if _, err := Execute(context.Background(), &fakeQuerier{}, types.Postgres, Limits{MaxRows: 10}, "SELECT * FROM a", "not a token"); err == nil {
+		t.Error("expected an error for a malformed token")
+	}
+}
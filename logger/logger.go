@@ -2,19 +2,21 @@
 package logger
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/thesaas-company/xray/types"
 )
 
-// Logger is a struct that implements the ISQL interface and adds logging functionality.
+// Logger is a struct that implements the ISQLContext interface and adds logging functionality.
 type Logger struct {
-	logs types.ISQL // The underlying ISQL interface for database operations.
+	logs types.ISQLContext // The underlying ISQLContext interface for database operations.
 }
 
-// NewLogger creates a new Logger instance with the provided ISQL implementation.
-func NewLogger(logs types.ISQL) *Logger {
+// NewLogger creates a new Logger instance with the provided ISQLContext implementation.
+func NewLogger(logs types.ISQLContext) *Logger {
 	return &Logger{
 		logs: logs,
 	}
@@ -23,6 +25,12 @@ func NewLogger(logs types.ISQL) *Logger {
 // Schema retrieves the schema for the specified table.
 // It logs the execution time and any errors that occur during the retrieval process.
 func (l *Logger) Schema(table string) (types.Table, error) {
+	return l.SchemaContext(context.Background(), table)
+}
+
+// SchemaContext retrieves the schema for the specified table using the given context.
+// It logs the execution time and any errors that occur during the retrieval process.
+func (l *Logger) SchemaContext(ctx context.Context, table string) (types.Table, error) {
 	defer func(start time.Time) {
 		// Log the execution time
 		logrus.WithFields(logrus.Fields{
@@ -31,7 +39,7 @@ func (l *Logger) Schema(table string) (types.Table, error) {
 		}).Info("Schema retrieval completed")
 	}(time.Now())
 
-	result, err := l.logs.Schema(table)
+	result, err := l.logs.SchemaContext(ctx, table)
 	if err != nil {
 		// Log the error
 		logrus.WithFields(logrus.Fields{
@@ -43,9 +51,44 @@ func (l *Logger) Schema(table string) (types.Table, error) {
 	return result, err
 }
 
+// TableStats retrieves the statistics of the specified table.
+// It logs the execution time and any errors that occur during the retrieval process.
+func (l *Logger) TableStats(table string) (types.TableStats, error) {
+	return l.TableStatsContext(context.Background(), table)
+}
+
+// TableStatsContext retrieves the statistics of the specified table using the given context.
+// It logs the execution time and any errors that occur during the retrieval process.
+func (l *Logger) TableStatsContext(ctx context.Context, table string) (types.TableStats, error) {
+	defer func(start time.Time) {
+		// Log the execution time
+		logrus.WithFields(logrus.Fields{
+			"table_name":           table,
+			"Query_Execution_time": time.Since(start),
+		}).Info("Table statistics retrieval completed")
+	}(time.Now())
+
+	result, err := l.logs.TableStatsContext(ctx, table)
+	if err != nil {
+		// Log the error
+		logrus.WithFields(logrus.Fields{
+			"table_name": table,
+			"error":      err.Error(),
+		}).Error("Table statistics retrieval failed")
+	}
+
+	return result, err
+}
+
 // Execute executes the given SQL query.
 // It logs the execution time and any errors that occur during the execution process.
 func (l *Logger) Execute(query string) ([]byte, error) {
+	return l.ExecuteContext(context.Background(), query)
+}
+
+// ExecuteContext executes the given SQL query with the bind arguments using the given context.
+// It logs the execution time and any errors that occur during the execution process.
+func (l *Logger) ExecuteContext(ctx context.Context, query string, args ...interface{}) ([]byte, error) {
 	defer func(start time.Time) {
 		// Log the execution time
 		logrus.WithFields(logrus.Fields{
@@ -54,7 +97,7 @@ func (l *Logger) Execute(query string) ([]byte, error) {
 		}).Info("Query execution completed")
 	}(time.Now())
 
-	result, err := l.logs.Execute(query)
+	result, err := l.logs.ExecuteContext(ctx, query, args...)
 	if err != nil {
 		// Log the error
 		logrus.WithFields(logrus.Fields{
@@ -66,9 +109,192 @@ func (l *Logger) Execute(query string) ([]byte, error) {
 	return result, err
 }
 
+// ExecutePage executes the given SQL query with the bind arguments and returns the page selected by the page token.
+// It logs the execution time and any errors that occur during the execution process.
+func (l *Logger) ExecutePage(ctx context.Context, query, pageToken string, args ...interface{}) ([]byte, error) {
+	defer func(start time.Time) {
+		// Log the execution time
+		logrus.WithFields(logrus.Fields{
+			"query":                query,
+			"page_token":           pageToken,
+			"Query_Execution_time": time.Since(start),
+		}).Info("Query page execution completed")
+	}(time.Now())
+
+	result, err := l.logs.ExecutePage(ctx, query, pageToken, args...)
+	if err != nil {
+		// Log the error
+		logrus.WithFields(logrus.Fields{
+			"query":      query,
+			"page_token": pageToken,
+			"error":      err.Error(),
+		}).Error("Query page execution failed")
+	}
+
+	return result, err
+}
+
+// Estimate returns the estimated cost of the given SQL query when the underlying client implements types.Estimator.
+// It logs the execution time, the estimate and any errors that occur during the estimation.
+func (l *Logger) Estimate(ctx context.Context, query string) (types.CostEstimate, error) {
+	estimator, ok := l.logs.(types.Estimator)
+	if !ok {
+		return types.CostEstimate{}, fmt.Errorf("cost estimation is not supported by this database")
+	}
+
+	start := time.Now()
+	result, err := estimator.Estimate(ctx, query)
+	if err != nil {
+		// Log the error
+		logrus.WithFields(logrus.Fields{
+			"query": query,
+			"error": err.Error(),
+		}).Error("Query estimation failed")
+		return result, err
+	}
+
+	// Log the execution time
+	logrus.WithFields(logrus.Fields{
+		"query":                query,
+		"Bytes_Processed":      result.BytesProcessed,
+		"Cost":                 result.Cost,
+		"Query_Execution_time": time.Since(start),
+	}).Info("Query estimation completed")
+	return result, nil
+}
+
+// Explain returns the plan of the given SQL query when the underlying client implements types.Explainer.
+// It logs the execution time, the top operation of the plan and any errors that occur while explaining.
+func (l *Logger) Explain(ctx context.Context, query string, analyze bool) (types.Plan, error) {
+	explainer, ok := l.logs.(types.Explainer)
+	if !ok {
+		return types.Plan{}, fmt.Errorf("query plans are not supported by this database")
+	}
+
+	start := time.Now()
+	result, err := explainer.Explain(ctx, query, analyze)
+	if err != nil {
+		// Log the error
+		logrus.WithFields(logrus.Fields{
+			"query":   query,
+			"analyze": analyze,
+			"error":   err.Error(),
+		}).Error("Query explain failed")
+		return result, err
+	}
+
+	// Log the execution time
+	logrus.WithFields(logrus.Fields{
+		"query":                query,
+		"analyze":              analyze,
+		"Plan_Root":            result.Root.Type,
+		"Query_Execution_time": time.Since(start),
+	}).Info("Query explain completed")
+	return result, nil
+}
+
+// Query executes the given SQL query with the bind arguments and returns an iterator over its rows.
+// It logs the time taken to start the query and any errors that occur while starting it.
+func (l *Logger) Query(ctx context.Context, query string, args ...interface{}) (types.RowIterator, error) {
+	defer func(start time.Time) {
+		// Log the execution time
+		logrus.WithFields(logrus.Fields{
+			"query":                query,
+			"Query_Execution_time": time.Since(start),
+		}).Info("Query started")
+	}(time.Now())
+
+	result, err := l.logs.Query(ctx, query, args...)
+	if err != nil {
+		// Log the error
+		logrus.WithFields(logrus.Fields{
+			"query": query,
+			"error": err.Error(),
+		}).Error("Query failed")
+	}
+
+	return result, err
+}
+
+// Begin starts a transaction with the given options.
+// It logs the time taken to start the transaction and any errors that occur while starting it.
+func (l *Logger) Begin(ctx context.Context, opts types.TxOptions) (types.Tx, error) {
+	defer func(start time.Time) {
+		// Log the execution time
+		logrus.WithFields(logrus.Fields{
+			"isolation":            opts.Isolation.String(),
+			"read_only":            opts.ReadOnly,
+			"Query_Execution_time": time.Since(start),
+		}).Info("Transaction started")
+	}(time.Now())
+
+	result, err := l.logs.Begin(ctx, opts)
+	if err != nil {
+		// Log the error
+		logrus.WithFields(logrus.Fields{
+			"isolation": opts.Isolation.String(),
+			"read_only": opts.ReadOnly,
+			"error":     err.Error(),
+		}).Error("Transaction start failed")
+	}
+
+	return result, err
+}
+
+// Session pins a connection for the statements of a session.
+// It logs the time taken to open the session and any errors that occur while opening it.
+func (l *Logger) Session(ctx context.Context) (types.Session, error) {
+	defer func(start time.Time) {
+		// Log the execution time
+		logrus.WithFields(logrus.Fields{
+			"Query_Execution_time": time.Since(start),
+		}).Info("Session opened")
+	}(time.Now())
+
+	result, err := l.logs.Session(ctx)
+	if err != nil {
+		// Log the error
+		logrus.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Session open failed")
+	}
+
+	return result, err
+}
+
+// ExecuteScript runs the statements of the given script one after the other in a session.
+// It logs the execution time, the number of statements and failures and any errors that stop the script.
+func (l *Logger) ExecuteScript(ctx context.Context, script string, opts types.ScriptOptions) (types.ScriptResult, error) {
+	start := time.Now()
+	result, err := l.logs.ExecuteScript(ctx, script, opts)
+	if err != nil {
+		// Log the error
+		logrus.WithFields(logrus.Fields{
+			"script": script,
+			"error":  err.Error(),
+		}).Error("Script execution failed")
+		return result, err
+	}
+
+	// Log the execution time
+	logrus.WithFields(logrus.Fields{
+		"script":               script,
+		"Statements":           len(result.Statements),
+		"Failed":               result.Failed,
+		"Query_Execution_time": time.Since(start),
+	}).Info("Script execution completed")
+	return result, nil
+}
+
 // Tables retrieves the list of tables for the specified database.
 // It logs the execution time and any errors that occur during the retrieval process.
 func (l *Logger) Tables(databaseName string) ([]string, error) {
+	return l.TablesContext(context.Background(), databaseName)
+}
+
+// TablesContext retrieves the list of tables for the specified database using the given context.
+// It logs the execution time and any errors that occur during the retrieval process.
+func (l *Logger) TablesContext(ctx context.Context, databaseName string) ([]string, error) {
 	defer func(start time.Time) {
 		// Log the execution time
 		logrus.WithFields(logrus.Fields{
@@ -77,7 +303,7 @@ func (l *Logger) Tables(databaseName string) ([]string, error) {
 		}).Info("Tables retrieval completed")
 	}(time.Now())
 
-	result, err := l.logs.Tables(databaseName)
+	result, err := l.logs.TablesContext(ctx, databaseName)
 	if err != nil {
 		// Log the error
 		logrus.WithFields(logrus.Fields{
@@ -89,6 +315,75 @@ func (l *Logger) Tables(databaseName string) ([]string, error) {
 	return result, err
 }
 
+// Schemas retrieves the list of schemas in the specified database using the given context.
+// It logs the execution time and any errors that occur during the retrieval process.
+func (l *Logger) Schemas(ctx context.Context, databaseName string) ([]string, error) {
+	defer func(start time.Time) {
+		// Log the execution time
+		logrus.WithFields(logrus.Fields{
+			"Database_Name":        databaseName,
+			"Query_Execution_time": time.Since(start),
+		}).Info("Schemas retrieval completed")
+	}(time.Now())
+
+	result, err := l.logs.Schemas(ctx, databaseName)
+	if err != nil {
+		// Log the error
+		logrus.WithFields(logrus.Fields{
+			"Database_Name": databaseName,
+			"error":         err.Error(),
+		}).Error("Schemas retrieval failed")
+	}
+
+	return result, err
+}
+
+// ListTables retrieves the tables, views and external tables in the specified database using the given context.
+// It logs the execution time and any errors that occur during the retrieval process.
+func (l *Logger) ListTables(ctx context.Context, databaseName string, opts types.ListTablesOptions) ([]types.TableInfo, error) {
+	defer func(start time.Time) {
+		// Log the execution time
+		logrus.WithFields(logrus.Fields{
+			"Database_Name":        databaseName,
+			"Query_Execution_time": time.Since(start),
+		}).Info("Table listing completed")
+	}(time.Now())
+
+	result, err := l.logs.ListTables(ctx, databaseName, opts)
+	if err != nil {
+		// Log the error
+		logrus.WithFields(logrus.Fields{
+			"Database_Name": databaseName,
+			"error":         err.Error(),
+		}).Error("Table listing failed")
+	}
+
+	return result, err
+}
+
+// Snapshot reads the schema of every table of the specified database using the given context.
+// It logs the execution time, the number of tables read and any errors that occur during the snapshot.
+func (l *Logger) Snapshot(ctx context.Context, databaseName string, opts types.SnapshotOptions) (types.DatabaseSchema, error) {
+	start := time.Now()
+	result, err := l.logs.Snapshot(ctx, databaseName, opts)
+	if err != nil {
+		// Log the error
+		logrus.WithFields(logrus.Fields{
+			"Database_Name": databaseName,
+			"error":         err.Error(),
+		}).Error("Snapshot failed")
+		return result, err
+	}
+
+	// Log the execution time
+	logrus.WithFields(logrus.Fields{
+		"Database_Name":        databaseName,
+		"Table_Count":          len(result.Tables),
+		"Query_Execution_time": time.Since(start),
+	}).Info("Snapshot completed")
+	return result, nil
+}
+
 // GenerateCreateTableQuery generates a CREATE TABLE query for the specified table.
 // It logs the execution time.
 func (l *Logger) GenerateCreateTableQuery(table types.Table) string {
@@ -103,3 +398,24 @@ func (l *Logger) GenerateCreateTableQuery(table types.Table) string {
 	result := l.logs.GenerateCreateTableQuery(table)
 	return result
 }
+
+// GenerateAlterTableQueries generates the ALTER TABLE statements turning one version of a table into another.
+// It logs the execution time and the number of warnings.
+func (l *Logger) GenerateAlterTableQueries(from, to types.Table) types.Migration {
+	defer func(start time.Time) {
+		// Log the execution time
+		logrus.WithFields(logrus.Fields{
+			"table_name":            to.Name,
+			"Method_Execution_time": time.Since(start),
+		}).Info("Alter table query generation completed")
+	}(time.Now())
+
+	result := l.logs.GenerateAlterTableQueries(from, to)
+	if len(result.Warnings) > 0 {
+		logrus.WithFields(logrus.Fields{
+			"table_name": to.Name,
+			"warnings":   result.Warnings,
+		}).Warn("Alter table query generation produced warnings")
+	}
+	return result
+}
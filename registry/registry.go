@@ -0,0 +1,74 @@
+// Package registry keeps track of the database drivers known to xray.
+// Every driver registers itself from an init function, so new databases can be plugged in
+// without changing the core package.
+package registry
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/thesaas-company/xray/config"
+	"github.com/thesaas-company/xray/types"
+)
+
+// Factory creates clients for a registered database driver.
+type Factory struct {
+	New           func(*sql.DB) (types.ISQLContext, error)        // New creates a client from an existing database handle.
+	NewWithConfig func(*config.Config) (types.ISQLContext, error) // NewWithConfig creates a client that opens its own connection from the configuration.
+}
+
+var (
+	mu        sync.RWMutex
+	factories = map[types.DbType]Factory{}
+)
+
+// Register makes a database driver available under the given name and returns its DbType.
+// Built-in names map to the existing DbType constants, any other name is allocated a new DbType.
+// Like database/sql.Register, it panics if a driver is registered twice under the same name
+// or if the factory has no constructors.
+func Register(name string, factory Factory) types.DbType {
+	if factory.New == nil && factory.NewWithConfig == nil {
+		panic(fmt.Sprintf("registry: Register factory for %q is nil", name))
+	}
+
+	dbType := types.RegisterDbType(name)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if _, dup := factories[dbType]; dup {
+		panic(fmt.Sprintf("registry: Register called twice for driver %q", name))
+	}
+	factories[dbType] = factory
+	return dbType
+}
+
+// Lookup returns the factory registered under the given name or one of its aliases.
+func Lookup(name string) (Factory, bool) {
+	dbType, err := types.ParseDbType(name)
+	if err != nil {
+		return Factory{}, false
+	}
+	return LookupType(dbType)
+}
+
+// LookupType returns the factory registered for the given DbType.
+func LookupType(dbType types.DbType) (Factory, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	factory, ok := factories[dbType]
+	return factory, ok
+}
+
+// Drivers returns the sorted names of the registered drivers.
+func Drivers() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(factories))
+	for dbType := range factories {
+		names = append(names, dbType.String())
+	}
+	sort.Strings(names)
+	return names
+}
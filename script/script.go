@@ -0,0 +1,96 @@
+// Package script runs multi-statement scripts, such as migrations, one statement at a time.
+// The statements run in order on a single session, so that SET options and temporary tables
+// created by a statement are seen by the next ones.
+package script
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/types"
+)
+
+// Sessioner opens the sessions scripts run in, it is implemented by the database clients.
+type Sessioner interface {
+	Session(context.Context) (types.Session, error) // Session pins a connection for the statements of a script.
+}
+
+// Run splits a script with dialect.SplitScript and runs its statements in a new session.
+// Reads return their rows, within the size limits of the client, and writes their affected row count.
+// With types.StopOnError the error of the failing statement is returned along with the results of the
+// statements that ran; with types.ContinueOnError failures are only counted in the result.
+func Run(ctx context.Context, client Sessioner, dbType types.DbType, script string, opts types.ScriptOptions) (types.ScriptResult, error) {
+	start := time.Now()
+	statements, err := dialect.SplitScript(dbType, script)
+	if err != nil {
+		return types.ScriptResult{}, fmt.Errorf("error splitting script: %v", err)
+	}
+
+	session, err := client.Session(ctx)
+	if err != nil {
+		return types.ScriptResult{}, err
+	}
+	defer func() {
+		if err := session.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	result := types.ScriptResult{Statements: []types.StatementResult{}}
+	for i, statement := range statements {
+		statementResult := runStatement(ctx, session, dbType, statement)
+		result.Statements = append(result.Statements, statementResult)
+		if statementResult.Error == "" {
+			continue
+		}
+		result.Failed++
+		if opts.Mode == types.StopOnError {
+			result.Time = time.Since(start).Milliseconds()
+			return result, fmt.Errorf("error executing statement %d of the script: %s", i+1, statementResult.Error)
+		}
+	}
+	result.Time = time.Since(start).Milliseconds()
+	return result, nil
+}
+
+// runStatement runs a read with Execute, for its rows, and any other statement with Exec, for its affected row count.
+// Procedure calls may return rows, they run with Execute too and report the affected rows as unknown when they
+// return none, since a query does not report them.
+func runStatement(ctx context.Context, session types.Session, dbType types.DbType, statement string) types.StatementResult {
+	start := time.Now()
+	result := types.StatementResult{Statement: statement}
+	var err error
+	switch {
+	case dialect.CheckReadOnly(dbType, statement) == nil:
+		result.Result, err = session.Execute(ctx, statement)
+	case dialect.MayReturnRows(dbType, statement):
+		result.Result, result.RowsAffected, err = runProcedure(ctx, session, statement)
+	default:
+		result.RowsAffected, err = session.Exec(ctx, statement)
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	result.Time = time.Since(start).Milliseconds()
+	return result
+}
+
+// runProcedure runs a statement that may return rows with Execute. Its result is only kept when it has columns,
+// otherwise the affected row count is returned as -1.
+func runProcedure(ctx context.Context, session types.Session, statement string) (json.RawMessage, int64, error) {
+	b, err := session.Execute(ctx, statement)
+	if err != nil {
+		return nil, 0, err
+	}
+	var queryResult types.QueryResult
+	if err := json.Unmarshal(b, &queryResult); err != nil {
+		return nil, 0, fmt.Errorf("error parsing statement result: %v", err)
+	}
+	if len(queryResult.Columns) == 0 {
+		return nil, -1, nil
+	}
+	return b, 0, nil
+}
@@ -0,0 +1,114 @@
+package script
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/thesaas-company/xray/session"
+	"github.com/thesaas-company/xray/types"
+)
+
+// sqlSessioner opens the sessions of a *sql.DB for Postgres.
+type sqlSessioner struct {
+	db *sql.DB
+}
+
+func (s sqlSessioner) Session(ctx context.Context) (types.Session, error) {
+	return session.Open(ctx, s.db, session.Config{
+		DbType:  types.Postgres,
+		Convert: func(_ types.ColumnMeta, v interface{}) (interface{}, error) { return v, nil },
+	})
+}
+
+// TestRun is a unit test function that tests running the statements of a script with their row counts and results.
+func TestRun(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TEMP TABLE t (id int)")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO t VALUES (1), (2)")).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM t")).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+	result, err := Run(context.Background(), sqlSessioner{db}, types.Postgres,
+		"CREATE TEMP TABLE t (id int);\nINSERT INTO t VALUES (1), (2);\nSELECT id FROM t;", types.ScriptOptions{})
+	if err != nil {
+		t.Fatalf("error running script: %s", err)
+	}
+	if len(result.Statements) != 3 || result.Failed != 0 {
+		t.Fatalf("Run() = %+v, want 3 statements without failures", result)
+	}
+	if result.Statements[1].RowsAffected != 2 {
+		t.Errorf("RowsAffected = %d, want 2", result.Statements[1].RowsAffected)
+	}
+	var rows types.QueryResult
+	if err := json.Unmarshal(result.Statements[2].Result, &rows); err != nil || rows.RowCount != 2 {
+		t.Errorf("unexpected result %s, %v", result.Statements[2].Result, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestRunModes is a unit test function that tests stopping at and continuing after a failing statement.
+func TestRunModes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	script := "DELETE FROM missing; UPDATE t SET id = 3"
+	mock.ExpectExec("DELETE FROM missing").WillReturnError(fmt.Errorf(`relation "missing" does not exist`))
+	result, err := Run(context.Background(), sqlSessioner{db}, types.Postgres, script, types.ScriptOptions{Mode: types.StopOnError})
+	if err == nil || len(result.Statements) != 1 || result.Failed != 1 {
+		t.Errorf("Run() = %+v, %v, want to stop at the first statement", result, err)
+	}
+
+	mock.ExpectExec("DELETE FROM missing").WillReturnError(fmt.Errorf(`relation "missing" does not exist`))
+	mock.ExpectExec("UPDATE t SET id = 3").WillReturnResult(sqlmock.NewResult(0, 1))
+	result, err = Run(context.Background(), sqlSessioner{db}, types.Postgres, script, types.ScriptOptions{Mode: types.ContinueOnError})
+	if err != nil || len(result.Statements) != 2 || result.Failed != 1 || result.Statements[1].RowsAffected != 1 {
+		t.Errorf("Run() = %+v, %v, want to run both statements", result, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestRunProcedure is a unit test function that tests keeping the rows returned by a procedure call.
+func TestRunProcedure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("CALL list_users()")).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("CALL archive_users()")).WillReturnRows(sqlmock.NewRows(nil))
+
+	result, err := Run(context.Background(), sqlSessioner{db}, types.Postgres, "CALL list_users(); CALL archive_users()", types.ScriptOptions{})
+	if err != nil || len(result.Statements) != 2 || result.Failed != 0 {
+		t.Fatalf("Run() = %+v, %v, want 2 statements without failures", result, err)
+	}
+	var rows types.QueryResult
+	if err := json.Unmarshal(result.Statements[0].Result, &rows); err != nil || rows.RowCount != 1 {
+		t.Errorf("unexpected result %s, %v", result.Statements[0].Result, err)
+	}
+	if result.Statements[1].Result != nil || result.Statements[1].RowsAffected != -1 {
+		t.Errorf("Statements[1] = %+v, want no result and unknown affected rows", result.Statements[1])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,221 @@
+// Package session runs the statements of the database/sql drivers on a pinned connection,
+// inside a transaction or a session, with the bind syntax, read-only checks and size limits of the clients.
+package session
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/governor"
+	"github.com/thesaas-company/xray/types"
+)
+
+// Config describes how a driver runs statements and starts transactions.
+type Config struct {
+	DbType           types.DbType         // DbType selects the bind syntax and the read-only checks of the statements.
+	Convert          types.ValueConverter // Convert converts the values of the rows.
+	Limits           governor.Limits      // Limits are the size limits of the results of Execute.
+	ReadOnly         bool                 // ReadOnly is the read-only mode of the client, every transaction is then read-only.
+	Isolation        []sql.IsolationLevel // Isolation lists the isolation levels the database supports besides the default one.
+	DefaultIsolation sql.IsolationLevel   // DefaultIsolation is the level of the default transactions, requesting it starts a default transaction.
+	ReadOnlyTx       bool                 // ReadOnlyTx indicates whether the driver starts read-only transactions, they are otherwise only checked statement by statement.
+}
+
+// queryer runs queries, it is implemented by *sql.Conn and *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// execer runs statements without rows, it is implemented by *sql.Conn and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Session is a types.Session on a connection of a *sql.DB.
+type Session struct {
+	conn *sql.Conn
+	cfg  Config
+}
+
+// Tx is a types.Tx started on a *sql.DB or a Session.
+type Tx struct {
+	tx       *sql.Tx
+	cfg      Config
+	readOnly bool // readOnly rejects the statements that are not reads
+}
+
+// Open pins a connection of the pool until the session is closed.
+func Open(ctx context.Context, db *sql.DB, cfg Config) (types.Session, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error opening session: %v", err)
+	}
+	return &Session{conn: conn, cfg: cfg}, nil
+}
+
+// Begin starts a transaction on a connection of the pool.
+func Begin(ctx context.Context, db *sql.DB, cfg Config, opts types.TxOptions) (types.Tx, error) {
+	return begin(ctx, db, cfg, opts)
+}
+
+// begin starts a transaction with the options translated for the driver.
+func begin(ctx context.Context, db types.TxBeginner, cfg Config, opts types.TxOptions) (types.Tx, error) {
+	isolation := opts.Isolation
+	if isolation == cfg.DefaultIsolation {
+		isolation = sql.LevelDefault
+	}
+	if isolation != sql.LevelDefault && !supported(cfg.Isolation, isolation) {
+		return nil, fmt.Errorf("isolation level %s is not supported by %s", opts.Isolation, cfg.DbType)
+	}
+
+	readOnly := opts.ReadOnly || cfg.ReadOnly
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: isolation, ReadOnly: readOnly && cfg.ReadOnlyTx})
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %v", err)
+	}
+	return &Tx{tx: tx, cfg: cfg, readOnly: readOnly}, nil
+}
+
+// supported reports whether the isolation level is one of the levels.
+func supported(levels []sql.IsolationLevel, level sql.IsolationLevel) bool {
+	for _, l := range levels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+// Execute executes a statement on the session and returns its result as JSON.
+func (s *Session) Execute(ctx context.Context, query string, args ...interface{}) ([]byte, error) {
+	return execute(ctx, s, s.cfg, query, args)
+}
+
+// Query executes a statement on the session and streams its rows. In read-only mode the reads
+// run in a read-only transaction where the driver supports them.
+func (s *Session) Query(ctx context.Context, query string, args ...interface{}) (types.RowIterator, error) {
+	query, args, err := prepare(s.cfg, s.cfg.ReadOnly, query, args)
+	if err != nil {
+		return nil, err
+	}
+	if s.cfg.ReadOnly && s.cfg.ReadOnlyTx {
+		return types.QueryReadOnly(ctx, s.conn, s.cfg.Convert, query, args...)
+	}
+	return run(ctx, s.conn, s.cfg, query, args)
+}
+
+// Exec executes a statement on the session and returns the number of affected rows.
+func (s *Session) Exec(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	return exec(ctx, s.conn, s.cfg, s.cfg.ReadOnly, query, args)
+}
+
+// Begin starts a transaction on the session.
+func (s *Session) Begin(ctx context.Context, opts types.TxOptions) (types.Tx, error) {
+	return begin(ctx, s.conn, s.cfg, opts)
+}
+
+// Close returns the connection of the session to the pool. The session state is not reset,
+// SET options should be reverted before closing.
+func (s *Session) Close() error {
+	return s.conn.Close()
+}
+
+// Execute executes a statement in the transaction and returns its result as JSON.
+func (t *Tx) Execute(ctx context.Context, query string, args ...interface{}) ([]byte, error) {
+	return execute(ctx, t, t.cfg, query, args)
+}
+
+// Query executes a statement in the transaction and streams its rows.
+func (t *Tx) Query(ctx context.Context, query string, args ...interface{}) (types.RowIterator, error) {
+	query, args, err := prepare(t.cfg, t.readOnly, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return run(ctx, t.tx, t.cfg, query, args)
+}
+
+// Exec executes a statement in the transaction and returns the number of affected rows.
+func (t *Tx) Exec(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	return exec(ctx, t.tx, t.cfg, t.readOnly, query, args)
+}
+
+// Commit commits the transaction.
+func (t *Tx) Commit() error {
+	if err := t.tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+	return nil
+}
+
+// Rollback aborts the transaction.
+func (t *Tx) Rollback() error {
+	if err := t.tx.Rollback(); err != nil {
+		return fmt.Errorf("error rolling back transaction: %v", err)
+	}
+	return nil
+}
+
+// prepare binds the arguments of a statement and, in read-only mode, rejects the statements that are not reads.
+func prepare(cfg Config, readOnly bool, query string, args []interface{}) (string, []interface{}, error) {
+	query, args, err := dialect.Bind(cfg.DbType, query, args)
+	if err != nil {
+		return "", nil, fmt.Errorf("error binding query arguments: %v", err)
+	}
+	if readOnly {
+		if err := dialect.CheckReadOnly(cfg.DbType, query); err != nil {
+			return "", nil, err
+		}
+	}
+	return query, args, nil
+}
+
+// run executes a prepared statement and streams its rows.
+func run(ctx context.Context, q queryer, cfg Config, query string, args []interface{}) (types.RowIterator, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+	it, err := types.NewRowIterator(rows, cfg.Convert)
+	if err != nil {
+		_ = rows.Close()
+		return nil, err
+	}
+	return it, nil
+}
+
+// exec executes a statement and returns the number of affected rows, -1 when the driver does not report it.
+func exec(ctx context.Context, e execer, cfg Config, readOnly bool, query string, args []interface{}) (int64, error) {
+	query, args, err := prepare(cfg, readOnly, query, args)
+	if err != nil {
+		return 0, err
+	}
+	result, err := e.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("error executing sql statement: %v", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return -1, nil
+	}
+	return affected, nil
+}
+
+// execute reads the result of a statement within the size limits and marshals it to JSON.
+func execute(ctx context.Context, q governor.Querier, cfg Config, query string, args []interface{}) ([]byte, error) {
+	start := time.Now()
+	queryResult, err := governor.Execute(ctx, q, cfg.DbType, cfg.Limits, query, "", args...)
+	if err != nil {
+		return nil, err
+	}
+	queryResult.Time = time.Since(start).Milliseconds()
+
+	jsonData, err := json.Marshal(queryResult)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %v", err)
+	}
+	return jsonData, nil
+}
@@ -0,0 +1,133 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/thesaas-company/xray/types"
+)
+
+// testConfig describes a database with read-only transactions whose only isolation level is SERIALIZABLE.
+var testConfig = Config{
+	DbType:           types.Postgres,
+	Convert:          func(_ types.ColumnMeta, v interface{}) (interface{}, error) { return v, nil },
+	DefaultIsolation: sql.LevelSerializable,
+	ReadOnlyTx:       true,
+}
+
+// TestBegin is a unit test function that tests running statements in a transaction and committing it.
+func TestBegin(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE accounts SET balance = balance - $1 WHERE id = $2")).WithArgs(10, 1).
+		WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT balance FROM accounts WHERE id = $1")).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(90))
+	mock.ExpectCommit()
+
+	tx, err := Begin(context.Background(), db, testConfig, types.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if _, err := tx.Execute(context.Background(), "UPDATE accounts SET balance = balance - $1 WHERE id = $2", 10, 1); err != nil {
+		t.Fatalf("error executing update: %s", err)
+	}
+	b, err := tx.Execute(context.Background(), "SELECT balance FROM accounts WHERE id = $1", 1)
+	if err != nil {
+		t.Fatalf("error executing select: %s", err)
+	}
+	var result types.QueryResult
+	if err := json.Unmarshal(b, &result); err != nil || result.RowCount != 1 {
+		t.Errorf("unexpected result %s, %v", b, err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Errorf("error committing transaction: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestBeginOptions is a unit test function that tests rejecting unsupported isolation levels
+// and the statement checks of read-only transactions.
+func TestBeginOptions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	if _, err := Begin(context.Background(), db, testConfig, types.TxOptions{Isolation: sql.LevelReadCommitted}); err == nil || !strings.Contains(err.Error(), "Read Committed") {
+		t.Errorf("Begin() error = %v, want an unsupported isolation level", err)
+	}
+
+	// the driver has no read-only transactions, the statements are checked instead
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	cfg := testConfig
+	cfg.ReadOnlyTx = false
+	tx, err := Begin(context.Background(), db, cfg, types.TxOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if _, err := tx.Query(context.Background(), "DELETE FROM accounts"); err == nil {
+		t.Error("expected an error for a write in a read-only transaction")
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Errorf("error rolling back transaction: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestSession is a unit test function that tests running statements and a transaction on a pinned connection.
+func TestSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SET search_path TO sales")).WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM orders")).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectRollback()
+
+	s, err := Open(context.Background(), db, testConfig)
+	if err != nil {
+		t.Fatalf("error opening session: %s", err)
+	}
+	if _, err := s.Execute(context.Background(), "SET search_path TO sales"); err != nil {
+		t.Fatalf("error executing statement: %s", err)
+	}
+	tx, err := s.Begin(context.Background(), types.TxOptions{})
+	if err != nil {
+		t.Fatalf("error starting transaction: %s", err)
+	}
+	if _, err := tx.Execute(context.Background(), "SELECT id FROM orders"); err != nil {
+		t.Fatalf("error executing statement: %s", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Errorf("error rolling back transaction: %s", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("error closing session: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,182 @@
+// Package snapshot reads the schema of a whole database.
+// Tables are read concurrently by a bounded pool of workers, or in a few bulk queries when the
+// driver supports it, instead of one round trip per table.
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/types"
+)
+
+// DefaultConcurrency is the number of tables read at the same time when the options do not set it.
+const DefaultConcurrency = 4
+
+// Source is a database a snapshot can be taken from.
+type Source interface {
+	ListTables(context.Context, string, types.ListTablesOptions) ([]types.TableInfo, error) // ListTables lists the tables of the database.
+	SchemaContext(context.Context, string) (types.Table, error)                             // SchemaContext reads the schema of a single table.
+}
+
+// BulkSource is a Source that can read the schemas of many tables with a fixed number of queries,
+// typically against information_schema. Take uses it instead of reading the tables one by one.
+type BulkSource interface {
+	Source
+	// BulkSchemas reads the schemas of the given tables of the database.
+	// The tables are returned in the order given.
+	BulkSchemas(context.Context, string, []types.TableInfo) ([]types.Table, error)
+}
+
+// Take reads the schema of every table of the database that matches the options.
+// It is meant to be called by drivers from their Snapshot method.
+func Take(ctx context.Context, src Source, dbType types.DbType, database string, opts types.SnapshotOptions) (types.DatabaseSchema, error) {
+	takenAt := time.Now().UTC()
+
+	infos, err := src.ListTables(ctx, database, types.ListTablesOptions{Kinds: opts.Kinds})
+	if err != nil {
+		return types.DatabaseSchema{}, err
+	}
+	infos, err = Filter(infos, opts.Include, opts.Exclude)
+	if err != nil {
+		return types.DatabaseSchema{}, err
+	}
+
+	var tables []types.Table
+	if bulk, ok := src.(BulkSource); ok {
+		tables, err = bulk.BulkSchemas(ctx, database, infos)
+	} else {
+		tables, err = parallel(ctx, src, dbType, infos, opts.Concurrency)
+	}
+	if err != nil {
+		return types.DatabaseSchema{}, err
+	}
+	for i := range tables {
+		tables[i].Kind = infos[i].Kind
+	}
+
+	return types.DatabaseSchema{
+		Version:  types.DatabaseSchemaVersion,
+		DbType:   dbType,
+		Database: database,
+		TakenAt:  takenAt,
+		Tables:   tables,
+	}, nil
+}
+
+// parallel reads the schemas of the tables one by one with at most concurrency workers.
+// The first error cancels the remaining reads.
+func parallel(ctx context.Context, src Source, dbType types.DbType, infos []types.TableInfo, concurrency int) ([]types.Table, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	if concurrency > len(infos) {
+		concurrency = len(infos)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tables := make([]types.Table, len(infos))
+	jobs := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// drain the remaining jobs once the snapshot failed or was cancelled
+				if ctx.Err() != nil {
+					continue
+				}
+				name := qualifiedName(dbType, infos[i])
+				table, err := src.SchemaContext(ctx, name)
+				if err != nil {
+					once.Do(func() {
+						firstErr = fmt.Errorf("error reading the schema of %s: %v", name, err)
+						cancel()
+					})
+					continue
+				}
+				tables[i] = table
+			}
+		}()
+	}
+
+feed:
+	for i := range infos {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+// qualifiedName returns the schema qualified name of the table as accepted by SchemaContext.
+// The parts are quoted for the database so that dots and quotes in them are kept.
+func qualifiedName(dbType types.DbType, info types.TableInfo) string {
+	return dialect.QuoteTableName(dbType, types.TableName{Schema: info.Schema, Name: info.Name})
+}
+
+// Filter returns the tables matching one of the include patterns and none of the exclude patterns.
+// It returns an error for malformed patterns.
+func Filter(infos []types.TableInfo, include, exclude []string) ([]types.TableInfo, error) {
+	var filtered []types.TableInfo
+	for _, info := range infos {
+		excluded, err := matchAny(exclude, info)
+		if err != nil {
+			return nil, err
+		}
+		if excluded {
+			continue
+		}
+		included, err := matchAny(include, info)
+		if err != nil {
+			return nil, err
+		}
+		if len(include) > 0 && !included {
+			continue
+		}
+		filtered = append(filtered, info)
+	}
+	return filtered, nil
+}
+
+// matchAny reports whether one of the patterns matches the table name or its schema qualified name.
+func matchAny(patterns []string, info types.TableInfo) (bool, error) {
+	name := strings.ToLower(info.Name)
+	qualified := strings.ToLower(info.Schema + "." + info.Name)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		for _, candidate := range []string{name, qualified} {
+			ok, err := path.Match(pattern, candidate)
+			if err != nil {
+				return false, fmt.Errorf("error matching pattern %q: %v", pattern, err)
+			}
+			if ok {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
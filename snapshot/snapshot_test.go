@@ -0,0 +1,198 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// fakeSource is an in-memory Source that records how many schemas are read at the same time.
+type fakeSource struct {
+	tables []types.TableInfo
+	fail   string // fail is the qualified name of a table whose schema cannot be read.
+
+	mu      sync.Mutex
+	active  int
+	maxSeen int
+	read    []string
+}
+
+func (f *fakeSource) ListTables(ctx context.Context, database string, opts types.ListTablesOptions) ([]types.TableInfo, error) {
+	return types.FilterTables(f.tables, opts), nil
+}
+
+func (f *fakeSource) SchemaContext(ctx context.Context, table string) (types.Table, error) {
+	f.mu.Lock()
+	f.active++
+	if f.active > f.maxSeen {
+		f.maxSeen = f.active
+	}
+	f.read = append(f.read, table)
+	f.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	f.mu.Lock()
+	f.active--
+	f.mu.Unlock()
+
+	if table == f.fail {
+		return types.Table{}, errors.New("permission denied")
+	}
+	name := types.ParseTableName(table)
+	return types.Table{Name: name.Name, Schema: name.Schema}, nil
+}
+
+// fakeBulkSource is a BulkSource that fails when a table is read one by one.
+type fakeBulkSource struct {
+	fakeSource
+	calls int
+}
+
+func (f *fakeBulkSource) SchemaContext(ctx context.Context, table string) (types.Table, error) {
+	return types.Table{}, errors.New("unexpected SchemaContext call")
+}
+
+func (f *fakeBulkSource) BulkSchemas(ctx context.Context, database string, tables []types.TableInfo) ([]types.Table, error) {
+	f.calls++
+	result := make([]types.Table, len(tables))
+	for i, t := range tables {
+		result[i] = types.Table{Name: t.Name, Schema: t.Schema}
+	}
+	return result, nil
+}
+
+func testTables() []types.TableInfo {
+	return []types.TableInfo{
+		{Name: "users", Schema: "public", Kind: types.KindTable},
+		{Name: "orders", Schema: "public", Kind: types.KindTable},
+		{Name: "audit_login", Schema: "public", Kind: types.KindTable},
+		{Name: "audit_logout", Schema: "public", Kind: types.KindTable},
+		{Name: "active_users", Schema: "public", Kind: types.KindView},
+		{Name: "events", Schema: "staging", Kind: types.KindTable},
+	}
+}
+
+// TestTake is a unit test function that tests that Take reads every table, keeps the listing order and sets the kinds.
+func TestTake(t *testing.T) {
+	src := &fakeSource{tables: testTables()}
+	schema, err := Take(context.Background(), src, types.Postgres, "shop", types.SnapshotOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("Take() error = %v", err)
+	}
+
+	if schema.Version != types.DatabaseSchemaVersion || schema.DbType != types.Postgres || schema.Database != "shop" {
+		t.Errorf("header = %d, %v, %q", schema.Version, schema.DbType, schema.Database)
+	}
+	if schema.TakenAt.IsZero() {
+		t.Error("TakenAt is not set")
+	}
+	var names []string
+	for _, table := range schema.Tables {
+		names = append(names, table.Schema+"."+table.Name)
+	}
+	want := []string{"public.users", "public.orders", "public.audit_login", "public.audit_logout", "public.active_users", "staging.events"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("tables = %v, want %v", names, want)
+	}
+	if schema.Tables[4].Kind != types.KindView {
+		t.Errorf("kind = %q, want %q", schema.Tables[4].Kind, types.KindView)
+	}
+	if src.maxSeen > 2 {
+		t.Errorf("%d schemas read at the same time, want at most 2", src.maxSeen)
+	}
+}
+
+// TestTakeError is a unit test function that tests that Take returns the first error and stops reading.
+func TestTakeError(t *testing.T) {
+	src := &fakeSource{tables: testTables(), fail: `"public"."users"`}
+	_, err := Take(context.Background(), src, types.Postgres, "shop", types.SnapshotOptions{Concurrency: 1})
+	if err == nil {
+		t.Fatal("Take() error = nil, want an error")
+	}
+	if want := `error reading the schema of "public"."users": permission denied`; err.Error() != want {
+		t.Errorf("Take() error = %q, want %q", err, want)
+	}
+	if len(src.read) == len(src.tables) {
+		t.Errorf("all %d tables were read after the first error", len(src.read))
+	}
+}
+
+// TestQualifiedName is a unit test function that tests that the names passed to SchemaContext are quoted
+// for the database and parse back to the listed schema and name.
+func TestQualifiedName(t *testing.T) {
+	tests := []struct {
+		dbType types.DbType
+		info   types.TableInfo
+		want   string
+	}{
+		{types.Postgres, types.TableInfo{Name: "users", Schema: "public"}, `"public"."users"`},
+		{types.Postgres, types.TableInfo{Name: `my"table`, Schema: "v1.2"}, `"v1.2"."my""table"`},
+		{types.MySQL, types.TableInfo{Name: "order`s"}, "`order``s`"},
+		{types.MSSQL, types.TableInfo{Name: "a.b]", Schema: "dbo"}, "[dbo].[a.b]]]"},
+	}
+	for _, tt := range tests {
+		got := qualifiedName(tt.dbType, tt.info)
+		if got != tt.want {
+			t.Errorf("qualifiedName(%v, %+v) = %q, want %q", tt.dbType, tt.info, got, tt.want)
+		}
+		if name := types.ParseTableName(got); name.Schema != tt.info.Schema || name.Name != tt.info.Name {
+			t.Errorf("ParseTableName(%q) = %+v, want %q.%q", got, name, tt.info.Schema, tt.info.Name)
+		}
+	}
+}
+
+// TestTakeBulk is a unit test function that tests that Take prefers BulkSchemas when the source implements it.
+func TestTakeBulk(t *testing.T) {
+	src := &fakeBulkSource{fakeSource: fakeSource{tables: testTables()}}
+	schema, err := Take(context.Background(), src, types.BigQuery, "sales", types.SnapshotOptions{Kinds: []types.TableKind{types.KindView}})
+	if err != nil {
+		t.Fatalf("Take() error = %v", err)
+	}
+	if src.calls != 1 {
+		t.Errorf("BulkSchemas called %d times, want 1", src.calls)
+	}
+	if len(schema.Tables) != 1 || schema.Tables[0].Name != "active_users" || schema.Tables[0].Kind != types.KindView {
+		t.Errorf("tables = %+v", schema.Tables)
+	}
+}
+
+// TestFilter is a unit test function that tests the include and exclude patterns.
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name             string
+		include, exclude []string
+		want             []string
+	}{
+		{name: "all", want: []string{"users", "orders", "audit_login", "audit_logout", "active_users", "events"}},
+		{name: "include", include: []string{"audit_*"}, want: []string{"audit_login", "audit_logout"}},
+		{name: "exclude", exclude: []string{"audit_*", "*users"}, want: []string{"orders", "events"}},
+		{name: "exclude wins", include: []string{"audit_*"}, exclude: []string{"*_logout"}, want: []string{"audit_login"}},
+		{name: "schema qualified", include: []string{"staging.*"}, want: []string{"events"}},
+		{name: "case insensitive", include: []string{"PUBLIC.ORDERS"}, want: []string{"orders"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Filter(testTables(), tt.include, tt.exclude)
+			if err != nil {
+				t.Fatalf("Filter() error = %v", err)
+			}
+			var names []string
+			for _, info := range got {
+				names = append(names, info.Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("Filter() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+
+	if _, err := Filter(testTables(), []string{"[users"}, nil); err == nil {
+		t.Error("Filter() with a malformed pattern: error = nil, want an error")
+	}
+}
@@ -0,0 +1,491 @@
+package translate
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// formatters spell canonical types for each database.
+var formatters = map[types.DbType]func(t Type, w *warnings) string{
+	types.Postgres:  postgresType,
+	types.MySQL:     mysqlType,
+	types.MSSQL:     mssqlType,
+	types.Snowflake: snowflakeType,
+	types.BigQuery:  bigQueryType,
+	types.Redshift:  redshiftType,
+}
+
+// Format returns the type as spelled by the given database, with warnings for any information
+// the database cannot represent. Unknown types keep their original spelling.
+func Format(dbType types.DbType, t Type) (string, []string) {
+	format, ok := formatters[dbType]
+	if !ok {
+		return "", []string{fmt.Sprintf("unsupported database type: %s", dbType)}
+	}
+	var w warnings
+	return format(t, &w), w
+}
+
+// warnings collects the warnings of a conversion.
+type warnings []string
+
+func (w *warnings) add(format string, args ...any) {
+	*w = append(*w, fmt.Sprintf(format, args...))
+}
+
+// unknown returns the original spelling of an Unknown type.
+func unknown(dbType types.DbType, t Type, w *warnings) string {
+	w.add("type %s has no known %s equivalent and is kept as is", t.Name, dbType)
+	return t.Name
+}
+
+// length formats a length parameter such as (255), it returns an empty string for 0.
+func length(n int64) string {
+	if n <= 0 {
+		return ""
+	}
+	return "(" + strconv.FormatInt(n, 10) + ")"
+}
+
+// fraction formats the fractional second precision of a temporal type, capped at max digits.
+func fraction(dbType types.DbType, t Type, max int, w *warnings) string {
+	if t.Precision <= 0 {
+		return ""
+	}
+	if t.Precision > max {
+		w.add("%s supports %d fractional second digits, %d are truncated", dbType, max, t.Precision-max)
+		return "(" + strconv.Itoa(max) + ")"
+	}
+	return "(" + strconv.Itoa(t.Precision) + ")"
+}
+
+// decimal formats a precision and scale capped at maxPrecision digits. Unconstrained decimals,
+// which only Postgres supports, become (38,9) like the BigQuery NUMERIC.
+func decimal(dbType types.DbType, name string, t Type, maxPrecision int, w *warnings) string {
+	precision, scale := t.Precision, t.Scale
+	if precision == 0 {
+		w.add("%s has no unconstrained decimal, %s(38,9) is used", dbType, name)
+		precision, scale = 38, 9
+	}
+	if precision > maxPrecision {
+		w.add("%s supports %d decimal digits, %s(%d,%d) is reduced", dbType, maxPrecision, name, precision, scale)
+		scale -= precision - maxPrecision
+		if scale < 0 {
+			scale = 0
+		}
+		precision = maxPrecision
+	}
+	return fmt.Sprintf("%s(%d,%d)", name, precision, scale)
+}
+
+// integer reports whether the type is an integer.
+func (t Type) integer() bool {
+	switch t.Kind {
+	case TinyInt, SmallInt, Integer, BigInt:
+		return true
+	}
+	return false
+}
+
+// unsignedInteger returns the integer type holding every value of an unsigned integer,
+// for the databases without unsigned integers.
+func unsignedInteger(t Type, smallint, integer, bigint, decimal string) string {
+	switch t.Kind {
+	case TinyInt:
+		return smallint
+	case SmallInt:
+		return integer
+	case Integer:
+		return bigint
+	}
+	return decimal
+}
+
+func postgresType(t Type, w *warnings) string {
+	if t.Unsigned && t.integer() {
+		return unsignedInteger(t, "SMALLINT", "INTEGER", "BIGINT", "NUMERIC(20,0)")
+	}
+	switch t.Kind {
+	case Boolean:
+		return "BOOLEAN"
+	case TinyInt, SmallInt:
+		return "SMALLINT"
+	case Integer:
+		return "INTEGER"
+	case BigInt:
+		return "BIGINT"
+	case Decimal:
+		if t.Precision == 0 {
+			return "NUMERIC"
+		}
+		return decimal(types.Postgres, "NUMERIC", t, 1000, w)
+	case Real:
+		return "REAL"
+	case Double:
+		return "DOUBLE PRECISION"
+	case Char:
+		return "CHAR" + length(t.Length)
+	case Varchar:
+		return "VARCHAR" + length(t.Length)
+	case Text:
+		return "TEXT"
+	case Binary, Varbinary, Blob:
+		return "BYTEA"
+	case Date:
+		return "DATE"
+	case Time:
+		return "TIME" + fraction(types.Postgres, t, 6, w)
+	case TimeTZ:
+		return "TIME" + fraction(types.Postgres, t, 6, w) + " WITH TIME ZONE"
+	case Timestamp:
+		return "TIMESTAMP" + fraction(types.Postgres, t, 6, w)
+	case TimestampTZ:
+		return "TIMESTAMP" + fraction(types.Postgres, t, 6, w) + " WITH TIME ZONE"
+	case Interval:
+		return "INTERVAL"
+	case JSON:
+		return "JSONB"
+	case UUID:
+		return "UUID"
+	case Geometry:
+		w.add("Postgres GEOMETRY requires the PostGIS extension")
+		return "GEOMETRY"
+	case Geography:
+		w.add("Postgres GEOGRAPHY requires the PostGIS extension")
+		return "GEOGRAPHY"
+	case Array:
+		if t.Element == nil || t.Element.Kind == Array {
+			w.add("the element type of the array is not known, JSONB is used")
+			return "JSONB"
+		}
+		return postgresType(*t.Element, w) + "[]"
+	}
+	return unknown(types.Postgres, t, w)
+}
+
+func mysqlType(t Type, w *warnings) string {
+	switch t.Kind {
+	case Boolean:
+		return "TINYINT(1)"
+	case TinyInt, SmallInt, Integer, BigInt:
+		name := map[Kind]string{TinyInt: "TINYINT", SmallInt: "SMALLINT", Integer: "INT", BigInt: "BIGINT"}[t.Kind]
+		if t.Unsigned {
+			name += " UNSIGNED"
+		}
+		return name
+	case Decimal:
+		return decimal(types.MySQL, "DECIMAL", t, 65, w)
+	case Real:
+		return "FLOAT"
+	case Double:
+		return "DOUBLE"
+	case Char:
+		if t.Length > 255 {
+			return "VARCHAR" + length(t.Length)
+		}
+		return "CHAR" + length(t.Length)
+	case Varchar:
+		if t.Length > 16383 {
+			return "LONGTEXT"
+		}
+		return "VARCHAR" + length(t.Length)
+	case Text:
+		return "LONGTEXT"
+	case Binary:
+		if t.Length > 255 {
+			return "VARBINARY" + length(t.Length)
+		}
+		return "BINARY" + length(t.Length)
+	case Varbinary:
+		if t.Length > 65535 {
+			return "LONGBLOB"
+		}
+		return "VARBINARY" + length(t.Length)
+	case Blob:
+		return "LONGBLOB"
+	case Date:
+		return "DATE"
+	case Time:
+		return "TIME" + fraction(types.MySQL, t, 6, w)
+	case TimeTZ:
+		w.add("MySQL has no time with time zone, the offset is dropped")
+		return "TIME" + fraction(types.MySQL, t, 6, w)
+	case Timestamp:
+		return "DATETIME" + fraction(types.MySQL, t, 6, w)
+	case TimestampTZ:
+		return "TIMESTAMP" + fraction(types.MySQL, t, 6, w)
+	case Interval:
+		w.add("MySQL has no interval type, VARCHAR(64) is used")
+		return "VARCHAR(64)"
+	case JSON:
+		return "JSON"
+	case UUID:
+		return "CHAR(36)"
+	case Geometry:
+		return "GEOMETRY"
+	case Geography:
+		w.add("MySQL has no geography type, GEOMETRY is used and the spatial reference has to be set with SRID")
+		return "GEOMETRY"
+	case Array:
+		w.add("MySQL has no arrays, JSON is used")
+		return "JSON"
+	}
+	return unknown(types.MySQL, t, w)
+}
+
+func mssqlType(t Type, w *warnings) string {
+	if t.Unsigned && t.integer() {
+		return unsignedInteger(t, "SMALLINT", "INT", "BIGINT", "DECIMAL(20,0)")
+	}
+	switch t.Kind {
+	case Boolean:
+		return "BIT"
+	case TinyInt, SmallInt:
+		// SQL Server TINYINT is unsigned
+		return "SMALLINT"
+	case Integer:
+		return "INT"
+	case BigInt:
+		return "BIGINT"
+	case Decimal:
+		return decimal(types.MSSQL, "DECIMAL", t, 38, w)
+	case Real:
+		return "REAL"
+	case Double:
+		return "FLOAT"
+	case Char:
+		if t.Length > 4000 {
+			return "NVARCHAR(MAX)"
+		}
+		return "NCHAR" + length(t.Length)
+	case Varchar:
+		if t.Length > 4000 {
+			return "NVARCHAR(MAX)"
+		}
+		return "NVARCHAR" + length(t.Length)
+	case Text:
+		return "NVARCHAR(MAX)"
+	case Binary:
+		if t.Length > 8000 {
+			return "VARBINARY(MAX)"
+		}
+		return "BINARY" + length(t.Length)
+	case Varbinary:
+		if t.Length > 8000 {
+			return "VARBINARY(MAX)"
+		}
+		return "VARBINARY" + length(t.Length)
+	case Blob:
+		return "VARBINARY(MAX)"
+	case Date:
+		return "DATE"
+	case Time:
+		return "TIME" + fraction(types.MSSQL, t, 7, w)
+	case TimeTZ:
+		w.add("SQL Server has no time with time zone, the offset is dropped")
+		return "TIME" + fraction(types.MSSQL, t, 7, w)
+	case Timestamp:
+		return "DATETIME2" + fraction(types.MSSQL, t, 7, w)
+	case TimestampTZ:
+		return "DATETIMEOFFSET" + fraction(types.MSSQL, t, 7, w)
+	case Interval:
+		w.add("SQL Server has no interval type, NVARCHAR(64) is used")
+		return "NVARCHAR(64)"
+	case JSON:
+		w.add("SQL Server stores JSON as text, NVARCHAR(MAX) is used")
+		return "NVARCHAR(MAX)"
+	case UUID:
+		return "UNIQUEIDENTIFIER"
+	case Geometry:
+		return "GEOMETRY"
+	case Geography:
+		return "GEOGRAPHY"
+	case Array:
+		w.add("SQL Server has no arrays, the values are stored as JSON in NVARCHAR(MAX)")
+		return "NVARCHAR(MAX)"
+	}
+	return unknown(types.MSSQL, t, w)
+}
+
+func snowflakeType(t Type, w *warnings) string {
+	if t.Unsigned && t.Kind == BigInt {
+		return "NUMBER(20,0)"
+	}
+	switch t.Kind {
+	case Boolean:
+		return "BOOLEAN"
+	case TinyInt:
+		return "TINYINT"
+	case SmallInt:
+		return "SMALLINT"
+	case Integer:
+		return "INTEGER"
+	case BigInt:
+		return "BIGINT"
+	case Decimal:
+		return decimal(types.Snowflake, "NUMBER", t, 38, w)
+	case Real, Double:
+		return "FLOAT"
+	case Char:
+		return "CHAR" + length(t.Length)
+	case Varchar:
+		return "VARCHAR" + length(t.Length)
+	case Text:
+		return "TEXT"
+	case Binary, Varbinary:
+		return "BINARY" + length(t.Length)
+	case Blob:
+		return "BINARY"
+	case Date:
+		return "DATE"
+	case Time:
+		return "TIME" + fraction(types.Snowflake, t, 9, w)
+	case TimeTZ:
+		w.add("Snowflake has no time with time zone, the offset is dropped")
+		return "TIME" + fraction(types.Snowflake, t, 9, w)
+	case Timestamp:
+		return "TIMESTAMP_NTZ" + fraction(types.Snowflake, t, 9, w)
+	case TimestampTZ:
+		return "TIMESTAMP_TZ" + fraction(types.Snowflake, t, 9, w)
+	case Interval:
+		w.add("Snowflake has no interval type, VARCHAR(64) is used")
+		return "VARCHAR(64)"
+	case JSON:
+		return "VARIANT"
+	case UUID:
+		return "VARCHAR(36)"
+	case Geometry:
+		return "GEOMETRY"
+	case Geography:
+		return "GEOGRAPHY"
+	case Array:
+		// Snowflake arrays are untyped
+		return "ARRAY"
+	}
+	return unknown(types.Snowflake, t, w)
+}
+
+func bigQueryType(t Type, w *warnings) string {
+	if t.Unsigned && t.Kind == BigInt {
+		return "NUMERIC(20,0)"
+	}
+	switch t.Kind {
+	case Boolean:
+		return "BOOL"
+	case TinyInt, SmallInt, Integer, BigInt:
+		return "INT64"
+	case Decimal:
+		// NUMERIC holds up to 29 integer and 9 fractional digits, BIGNUMERIC up to 38 of each
+		switch {
+		case t.Precision == 0:
+			return "NUMERIC"
+		case t.Scale <= 9 && t.Precision-t.Scale <= 29:
+			return fmt.Sprintf("NUMERIC(%d,%d)", t.Precision, t.Scale)
+		case t.Scale <= 38 && t.Precision-t.Scale <= 38:
+			return fmt.Sprintf("BIGNUMERIC(%d,%d)", t.Precision, t.Scale)
+		}
+		w.add("BigQuery supports 38 integer and 38 fractional digits, DECIMAL(%d,%d) is stored as BIGNUMERIC", t.Precision, t.Scale)
+		return "BIGNUMERIC"
+	case Real, Double:
+		return "FLOAT64"
+	case Char, Varchar:
+		return "STRING" + length(t.Length)
+	case Text, UUID:
+		return "STRING"
+	case Binary, Varbinary:
+		return "BYTES" + length(t.Length)
+	case Blob:
+		return "BYTES"
+	case Date:
+		return "DATE"
+	case Time:
+		return "TIME"
+	case TimeTZ:
+		w.add("BigQuery has no time with time zone, the offset is dropped")
+		return "TIME"
+	case Timestamp:
+		return "DATETIME"
+	case TimestampTZ:
+		return "TIMESTAMP"
+	case Interval:
+		return "INTERVAL"
+	case JSON:
+		return "JSON"
+	case Geometry:
+		w.add("BigQuery only has GEOGRAPHY, planar coordinates are interpreted on the spheroid")
+		return "GEOGRAPHY"
+	case Geography:
+		return "GEOGRAPHY"
+	case Array:
+		if t.Element == nil || t.Element.Kind == Array {
+			w.add("BigQuery arrays need a scalar element type, JSON is used")
+			return "JSON"
+		}
+		return "ARRAY<" + bigQueryType(*t.Element, w) + ">"
+	}
+	return unknown(types.BigQuery, t, w)
+}
+
+func redshiftType(t Type, w *warnings) string {
+	if t.Unsigned && t.integer() {
+		return unsignedInteger(t, "SMALLINT", "INTEGER", "BIGINT", "DECIMAL(20,0)")
+	}
+	switch t.Kind {
+	case Boolean:
+		return "BOOLEAN"
+	case TinyInt, SmallInt:
+		return "SMALLINT"
+	case Integer:
+		return "INTEGER"
+	case BigInt:
+		return "BIGINT"
+	case Decimal:
+		return decimal(types.Redshift, "DECIMAL", t, 38, w)
+	case Real:
+		return "REAL"
+	case Double:
+		return "DOUBLE PRECISION"
+	case Char:
+		if t.Length > 4096 {
+			return "VARCHAR" + length(t.Length)
+		}
+		return "CHAR" + length(t.Length)
+	case Varchar:
+		if t.Length > 65535 {
+			w.add("Redshift VARCHAR holds up to 65535 bytes, longer values are truncated")
+			return "VARCHAR(65535)"
+		}
+		return "VARCHAR" + length(t.Length)
+	case Text:
+		w.add("Redshift has no unbounded text, VARCHAR(65535) is used")
+		return "VARCHAR(65535)"
+	case Binary, Varbinary:
+		return "VARBYTE" + length(t.Length)
+	case Blob:
+		return "VARBYTE(1024000)"
+	case Date:
+		return "DATE"
+	case Time:
+		return "TIME"
+	case TimeTZ:
+		return "TIMETZ"
+	case Timestamp:
+		return "TIMESTAMP"
+	case TimestampTZ:
+		return "TIMESTAMPTZ"
+	case Interval:
+		w.add("Redshift intervals are either year to month or day to second, INTERVAL DAY TO SECOND is used")
+		return "INTERVAL DAY TO SECOND"
+	case JSON, Array:
+		return "SUPER"
+	case UUID:
+		return "CHAR(36)"
+	case Geometry:
+		return "GEOMETRY"
+	case Geography:
+		return "GEOGRAPHY"
+	}
+	return unknown(types.Redshift, t, w)
+}
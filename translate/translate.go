@@ -0,0 +1,197 @@
+package translate
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/types"
+)
+
+var (
+	numberLiteral = regexp.MustCompile(`^[-+]?\d+(\.\d+)?([eE][-+]?\d+)?$`)
+	stringLiteral = regexp.MustCompile(`^[nN]?'((?:[^']|'')*)'$`)
+	// postgresCast matches a literal followed by a cast such as 'active'::character varying
+	postgresCast = regexp.MustCompile(`^(.*?)::[a-zA-Z_ ]+(\(\d+(,\d+)?\))?(\[\])?$`)
+)
+
+// Table translates the column types, defaults and auto-increment columns of a table read from one
+// database into those of another, so that the target driver's GenerateCreateTableQuery produces valid DDL.
+// Attributes the target driver's DDL does not emit itself, such as Postgres identity columns or BigQuery
+// NOT NULL, are appended to the column type. It returns warnings for every conversion that loses information.
+func Table(table types.Table, from, to types.DbType) (types.Table, []string) {
+	if from == to {
+		return table, nil
+	}
+	if _, ok := formatters[to]; !ok {
+		return table, []string{fmt.Sprintf("unsupported database type: %s", to)}
+	}
+
+	var warnings []string
+	result := table
+	result.Columns = make([]types.Column, len(table.Columns))
+	if to == types.BigQuery && result.Dataset == "" {
+		result.Dataset = table.Schema
+	}
+	for i, column := range table.Columns {
+		translated, columnWarnings := Column(column, from, to)
+		result.Columns[i] = translated
+		for _, warning := range columnWarnings {
+			warnings = append(warnings, fmt.Sprintf("column %s: %s", column.Name, warning))
+		}
+	}
+	return result, warnings
+}
+
+// Column translates the type, default and auto-increment of a column read from one database into another.
+func Column(column types.Column, from, to types.DbType) (types.Column, []string) {
+	value := column.DefaultValue
+	if !value.Valid {
+		value = column.ColumnDefault
+	}
+	autoIncrement := autoIncrement(column, value)
+
+	t := ParseColumn(from, column)
+	if autoIncrement {
+		// identity columns must be integers, sequences never reach the unsigned range
+		t.Unsigned = false
+	}
+	typ, warnings := Format(to, t)
+
+	result := column
+	result.CharacterMaximumLength = sql.NullInt64{}
+	result.NumericPrecision, result.NumericScale = sql.NullInt64{}, sql.NullInt64{}
+	result.Type = typ
+	result.DefaultValue, result.ColumnDefault = sql.NullString{}, sql.NullString{}
+	if value.Valid && !autoIncrement {
+		if translated, ok := Default(value.String, t, to); ok {
+			result.DefaultValue = sql.NullString{String: translated, Valid: true}
+			result.ColumnDefault = result.DefaultValue
+		} else {
+			warnings = append(warnings, fmt.Sprintf("default %s has no %s equivalent and is dropped", value.String, to))
+		}
+	}
+
+	result.AutoIncrement = autoIncrement
+	switch to {
+	case types.Postgres:
+		// the Postgres DDL has no AUTO_INCREMENT, identity columns replace serial
+		if autoIncrement {
+			result.Type += " GENERATED BY DEFAULT AS IDENTITY"
+		}
+	case types.Redshift:
+		if autoIncrement {
+			result.Type += " IDENTITY(1,1)"
+			result.AutoIncrement = false
+		}
+		if result.DefaultValue.Valid {
+			result.Type += " DEFAULT " + result.DefaultValue.String
+		}
+	case types.BigQuery:
+		if autoIncrement {
+			warnings = append(warnings, "BigQuery has no auto-increment columns")
+			result.AutoIncrement = false
+		}
+		if result.DefaultValue.Valid {
+			result.Type += " DEFAULT " + result.DefaultValue.String
+		}
+		if column.IsNullable == "NO" {
+			result.Type += " NOT NULL"
+		}
+	}
+	return result, warnings
+}
+
+// autoIncrement reports whether a column is generated from a sequence or identity in the source database.
+func autoIncrement(column types.Column, value sql.NullString) bool {
+	if column.AutoIncrement || column.IsIdentity.String == "YES" || strings.Contains(strings.ToLower(column.Extra), "auto_increment") {
+		return true
+	}
+	if value.Valid && strings.HasPrefix(strings.ToLower(value.String), "nextval(") {
+		return true
+	}
+	switch strings.ToUpper(column.Type) {
+	case "SERIAL", "SERIAL2", "SERIAL4", "SERIAL8", "SMALLSERIAL", "BIGSERIAL":
+		return true
+	}
+	return false
+}
+
+// currentTimestamp, currentDate and newUUID spell the common default functions for each database.
+var (
+	currentTimestamp = map[types.DbType]string{
+		types.Postgres: "CURRENT_TIMESTAMP", types.MySQL: "CURRENT_TIMESTAMP", types.MSSQL: "GETDATE()",
+		types.Snowflake: "CURRENT_TIMESTAMP()", types.BigQuery: "CURRENT_TIMESTAMP()", types.Redshift: "GETDATE()",
+	}
+	currentDate = map[types.DbType]string{
+		types.Postgres: "CURRENT_DATE", types.MySQL: "(CURRENT_DATE)", types.MSSQL: "CAST(GETDATE() AS DATE)",
+		types.Snowflake: "CURRENT_DATE()", types.BigQuery: "CURRENT_DATE()", types.Redshift: "CURRENT_DATE",
+	}
+	newUUID = map[types.DbType]string{
+		types.Postgres: "gen_random_uuid()", types.MySQL: "(UUID())", types.MSSQL: "NEWID()",
+		types.Snowflake: "UUID_STRING()", types.BigQuery: "GENERATE_UUID()",
+	}
+)
+
+// Default translates a column default of the given canonical type into the target database.
+// Literals and the common current time and UUID functions are translated, it returns false for
+// any other expression.
+func Default(value string, t Type, to types.DbType) (string, bool) {
+	v := strings.TrimSpace(value)
+	// SQL Server wraps defaults in parentheses, ((0)) or ('text')
+	for strings.HasPrefix(v, "(") && strings.HasSuffix(v, ")") {
+		v = strings.TrimSpace(v[1 : len(v)-1])
+	}
+	if m := postgresCast.FindStringSubmatch(v); m != nil {
+		v = strings.TrimSpace(m[1])
+	}
+	upper := strings.ToUpper(v)
+
+	switch {
+	case upper == "NULL":
+		return "NULL", true
+	case t.Kind == Boolean:
+		var b bool
+		switch upper {
+		case "TRUE", "1", "B'1'", "'1'", "'T'", "'TRUE'":
+			b = true
+		case "FALSE", "0", "B'0'", "'0'", "'F'", "'FALSE'":
+		default:
+			return "", false
+		}
+		switch {
+		case to == types.MSSQL && b:
+			return "1", true
+		case to == types.MSSQL:
+			return "0", true
+		case b:
+			return "TRUE", true
+		}
+		return "FALSE", true
+	case numberLiteral.MatchString(v):
+		return v, true
+	case upper == "TRUE" || upper == "FALSE":
+		return upper, true
+	}
+	if m := stringLiteral.FindStringSubmatch(v); m != nil {
+		return dialect.QuoteString(to, strings.ReplaceAll(m[1], "''", "'")), true
+	}
+
+	function := strings.TrimSuffix(upper, "()")
+	var spellings map[types.DbType]string
+	switch function {
+	case "CURRENT_TIMESTAMP", "NOW", "GETDATE", "SYSDATETIME", "SYSDATE", "LOCALTIMESTAMP", "GETUTCDATE", "SYSUTCDATETIME", "SYSDATETIMEOFFSET":
+		spellings = currentTimestamp
+	case "CURRENT_DATE", "CURDATE":
+		spellings = currentDate
+	case "GEN_RANDOM_UUID", "UUID_GENERATE_V4", "NEWID", "UUID", "UUID_STRING", "GENERATE_UUID":
+		spellings = newUUID
+	}
+	if strings.HasPrefix(function, "CURRENT_TIMESTAMP(") {
+		spellings = currentTimestamp
+	}
+	translated, ok := spellings[to]
+	return translated, ok
+}
@@ -0,0 +1,109 @@
+package translate
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestTable is a unit test function that tests translating a Postgres table into BigQuery and Redshift.
+func TestTable(t *testing.T) {
+	table := types.Table{
+		Name:   "users",
+		Schema: "public",
+		Columns: []types.Column{
+			{Name: "id", Type: "integer", IsNullable: "NO", IsPrimary: true, DefaultValue: sql.NullString{String: "nextval('users_id_seq'::regclass)", Valid: true}},
+			{Name: "email", Type: "character varying", CharacterMaximumLength: sql.NullInt64{Int64: 320, Valid: true}, IsNullable: "NO"},
+			{Name: "status", Type: "text", DefaultValue: sql.NullString{String: "'active'::text", Valid: true}},
+			{Name: "tags", Type: "text[]"},
+			{Name: "created_at", Type: "timestamp with time zone", DefaultValue: sql.NullString{String: "now()", Valid: true}},
+			{Name: "rank", Type: "integer", DefaultValue: sql.NullString{String: "next_rank()", Valid: true}},
+		},
+	}
+
+	got, warnings := Table(table, types.Postgres, types.BigQuery)
+	if got.Dataset != "public" {
+		t.Errorf("Dataset = %q, want public", got.Dataset)
+	}
+	wantTypes := []string{"INT64 NOT NULL", "STRING(320) NOT NULL", `STRING DEFAULT "active"`, "ARRAY<STRING>", "TIMESTAMP DEFAULT CURRENT_TIMESTAMP()", "INT64"}
+	for i, column := range got.Columns {
+		if column.Type != wantTypes[i] {
+			t.Errorf("BigQuery column %s type = %q, want %q", column.Name, column.Type, wantTypes[i])
+		}
+	}
+	wantWarnings := []string{
+		"column id: BigQuery has no auto-increment columns",
+		"column rank: default next_rank() has no bigquery equivalent and is dropped",
+	}
+	if !reflect.DeepEqual(warnings, wantWarnings) {
+		t.Errorf("warnings = %q, want %q", warnings, wantWarnings)
+	}
+
+	got, _ = Table(table, types.Postgres, types.Redshift)
+	wantTypes = []string{"INTEGER IDENTITY(1,1)", "VARCHAR(320)", "VARCHAR(65535) DEFAULT 'active'", "SUPER", "TIMESTAMPTZ DEFAULT GETDATE()", "INTEGER"}
+	for i, column := range got.Columns {
+		if column.Type != wantTypes[i] {
+			t.Errorf("Redshift column %s type = %q, want %q", column.Name, column.Type, wantTypes[i])
+		}
+		if column.AutoIncrement || column.CharacterMaximumLength.Valid {
+			t.Errorf("Redshift column %s = %+v, want no AutoIncrement and length", column.Name, column)
+		}
+	}
+
+	// the source table is not modified
+	if table.Columns[1].Type != "character varying" || !table.Columns[1].CharacterMaximumLength.Valid {
+		t.Errorf("source column modified: %+v", table.Columns[1])
+	}
+}
+
+// TestColumnAutoIncrement is a unit test function that tests translating auto-increment columns.
+func TestColumnAutoIncrement(t *testing.T) {
+	column := types.Column{Name: "id", Type: "int(11) unsigned", Extra: "auto_increment"}
+	tests := []struct {
+		to            types.DbType
+		typ           string
+		autoIncrement bool
+	}{
+		{types.Postgres, "INTEGER GENERATED BY DEFAULT AS IDENTITY", true},
+		{types.MSSQL, "INT", true},
+		{types.Snowflake, "INTEGER", true},
+		{types.Redshift, "INTEGER IDENTITY(1,1)", false},
+	}
+	for _, tt := range tests {
+		got, _ := Column(column, types.MySQL, tt.to)
+		if got.Type != tt.typ || got.AutoIncrement != tt.autoIncrement {
+			t.Errorf("Column(%s) = %q, %v, want %q, %v", tt.to, got.Type, got.AutoIncrement, tt.typ, tt.autoIncrement)
+		}
+	}
+}
+
+// TestDefault is a unit test function that tests translating column defaults.
+func TestDefault(t *testing.T) {
+	tests := []struct {
+		value string
+		typ   Type
+		to    types.DbType
+		want  string
+		ok    bool
+	}{
+		{"((0))", Type{Kind: Integer}, types.Postgres, "0", true},
+		{"((1))", Type{Kind: Boolean}, types.Postgres, "TRUE", true},
+		{"true", Type{Kind: Boolean}, types.MSSQL, "1", true},
+		{"(N'it''s')", Type{Kind: Varchar}, types.MySQL, "'it''s'", true},
+		{"'draft'::character varying", Type{Kind: Varchar}, types.MSSQL, "N'draft'", true},
+		{"(getdate())", Type{Kind: Timestamp}, types.Snowflake, "CURRENT_TIMESTAMP()", true},
+		{"CURRENT_TIMESTAMP(6)", Type{Kind: Timestamp}, types.MSSQL, "GETDATE()", true},
+		{"gen_random_uuid()", Type{Kind: UUID}, types.MSSQL, "NEWID()", true},
+		{"gen_random_uuid()", Type{Kind: UUID}, types.Redshift, "", false},
+		{"NULL", Type{Kind: Text}, types.BigQuery, "NULL", true},
+		{"lower(name)", Type{Kind: Text}, types.Postgres, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Default(tt.value, tt.typ, tt.to)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Default(%q, %s) = %q, %v, want %q, %v", tt.value, tt.to, got, ok, tt.want, tt.ok)
+		}
+	}
+}
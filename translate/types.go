@@ -0,0 +1,277 @@
+// Package translate converts column types and table definitions between the supported databases.
+// Types are parsed into a canonical, dialect independent Type and formatted again for the target
+// database; conversions that lose information are reported as warnings.
+package translate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/thesaas-company/xray/dialect"
+	"github.com/thesaas-company/xray/types"
+)
+
+// Kind is a dialect independent family of column types.
+type Kind string
+
+// These constants represent the canonical kinds of column types.
+const (
+	Unknown     Kind = "unknown"     // Unknown is a type that is not recognised, its original spelling is kept in Type.Name.
+	Boolean     Kind = "boolean"     // Boolean is a true or false value.
+	TinyInt     Kind = "tinyint"     // TinyInt is a one byte integer.
+	SmallInt    Kind = "smallint"    // SmallInt is a two byte integer.
+	Integer     Kind = "integer"     // Integer is a four byte integer.
+	BigInt      Kind = "bigint"      // BigInt is an eight byte integer.
+	Decimal     Kind = "decimal"     // Decimal is an exact number with a precision and scale.
+	Real        Kind = "real"        // Real is a single precision floating point number.
+	Double      Kind = "double"      // Double is a double precision floating point number.
+	Char        Kind = "char"        // Char is a fixed length character string.
+	Varchar     Kind = "varchar"     // Varchar is a character string with a maximum length.
+	Text        Kind = "text"        // Text is a character string without a maximum length.
+	Binary      Kind = "binary"      // Binary is a fixed length byte string.
+	Varbinary   Kind = "varbinary"   // Varbinary is a byte string with a maximum length.
+	Blob        Kind = "blob"        // Blob is a byte string without a maximum length.
+	Date        Kind = "date"        // Date is a calendar date.
+	Time        Kind = "time"        // Time is a time of day.
+	TimeTZ      Kind = "timetz"      // TimeTZ is a time of day with a time zone offset.
+	Timestamp   Kind = "timestamp"   // Timestamp is a date and time without a time zone.
+	TimestampTZ Kind = "timestamptz" // TimestampTZ is an instant in time.
+	Interval    Kind = "interval"    // Interval is a duration.
+	JSON        Kind = "json"        // JSON is a semi-structured document, including objects, variants and structs.
+	UUID        Kind = "uuid"        // UUID is a universally unique identifier.
+	Geometry    Kind = "geometry"    // Geometry is a planar spatial value.
+	Geography   Kind = "geography"   // Geography is a spatial value on the earth's spheroid.
+	Array       Kind = "array"       // Array is a list of values of the element type.
+)
+
+// Type is a canonical column type.
+type Type struct {
+	Kind      Kind   // Kind is the family of the type.
+	Length    int64  // Length is the maximum length of character and byte strings, 0 when unbounded or not given.
+	Precision int    // Precision is the number of digits of a decimal, or of fractional seconds of a temporal type, 0 when not given.
+	Scale     int    // Scale is the number of digits after the decimal point of a decimal.
+	Unsigned  bool   // Unsigned indicates a MySQL unsigned integer.
+	Element   *Type  // Element is the element type of an array, nil when the database does not record it.
+	Name      string // Name is the original spelling of an Unknown type.
+}
+
+// names maps the type names of every supported database to their kind. Names whose meaning
+// depends on the database are resolved by special.
+var names = map[string]Kind{
+	"BOOL": Boolean, "BOOLEAN": Boolean,
+	"TINYINT": TinyInt, "INT1": TinyInt, "BYTEINT": TinyInt,
+	"SMALLINT": SmallInt, "INT2": SmallInt, "SMALLSERIAL": SmallInt, "SERIAL2": SmallInt,
+	"INT": Integer, "INTEGER": Integer, "INT4": Integer, "MEDIUMINT": Integer, "INT3": Integer, "MIDDLEINT": Integer, "SERIAL": Integer, "SERIAL4": Integer,
+	"BIGINT": BigInt, "INT8": BigInt, "INT64": BigInt, "BIGSERIAL": BigInt, "SERIAL8": BigInt,
+	"DECIMAL": Decimal, "NUMERIC": Decimal, "DEC": Decimal, "FIXED": Decimal, "BIGNUMERIC": Decimal, "BIGDECIMAL": Decimal,
+	"REAL": Real, "FLOAT4": Real,
+	"DOUBLE": Double, "DOUBLE PRECISION": Double, "FLOAT8": Double, "FLOAT64": Double,
+	"CHAR": Char, "CHARACTER": Char, "NCHAR": Char, "BPCHAR": Char, "NATIONAL CHARACTER": Char,
+	"VARCHAR": Varchar, "CHARACTER VARYING": Varchar, "CHAR VARYING": Varchar, "NVARCHAR": Varchar, "VARCHAR2": Varchar, "NVARCHAR2": Varchar, "NATIONAL CHARACTER VARYING": Varchar,
+	"TEXT": Text, "TINYTEXT": Text, "MEDIUMTEXT": Text, "LONGTEXT": Text, "NTEXT": Text, "CLOB": Text, "CITEXT": Text, "STRING": Text, "LONG VARCHAR": Text, "LONG": Text,
+	"BINARY":    Binary,
+	"VARBINARY": Varbinary, "VARBYTE": Varbinary, "BINARY VARYING": Varbinary,
+	"BYTEA": Blob, "BLOB": Blob, "TINYBLOB": Blob, "MEDIUMBLOB": Blob, "LONGBLOB": Blob, "BYTES": Blob, "IMAGE": Blob, "LONG VARBINARY": Blob,
+	"DATE": Date,
+	"TIME": Time, "TIME WITHOUT TIME ZONE": Time,
+	"TIMETZ": TimeTZ, "TIME WITH TIME ZONE": TimeTZ,
+	"TIMESTAMP": Timestamp, "TIMESTAMP WITHOUT TIME ZONE": Timestamp, "TIMESTAMP_NTZ": Timestamp, "DATETIME": Timestamp, "DATETIME2": Timestamp, "SMALLDATETIME": Timestamp,
+	"TIMESTAMPTZ": TimestampTZ, "TIMESTAMP WITH TIME ZONE": TimestampTZ, "TIMESTAMP_TZ": TimestampTZ, "TIMESTAMP_LTZ": TimestampTZ, "DATETIMEOFFSET": TimestampTZ,
+	"INTERVAL": Interval,
+	"JSON":     JSON, "JSONB": JSON, "VARIANT": JSON, "OBJECT": JSON, "SUPER": JSON, "STRUCT": JSON, "RECORD": JSON,
+	"UUID": UUID, "UNIQUEIDENTIFIER": UUID,
+	"GEOMETRY": Geometry, "POINT": Geometry, "LINESTRING": Geometry, "POLYGON": Geometry, "MULTIPOINT": Geometry,
+	"MULTILINESTRING": Geometry, "MULTIPOLYGON": Geometry, "GEOMETRYCOLLECTION": Geometry, "GEOMCOLLECTION": Geometry,
+	"GEOGRAPHY": Geography,
+	"ARRAY":     Array,
+}
+
+// These constants are the maximum lengths Snowflake reports for strings and binaries declared without a length.
+const (
+	snowflakeMaxText   = 16777216
+	snowflakeMaxBinary = 8388608
+)
+
+// ParseColumn parses the type of a column read from the given database, including the length, precision
+// and scale that some databases report separately from the type.
+func ParseColumn(dbType types.DbType, column types.Column) Type {
+	return Parse(dbType, column.Type+dialect.ColumnLength(column))
+}
+
+// Parse parses a column type as spelled by the given database.
+// Types it does not recognise are returned as Unknown with their original spelling.
+func Parse(dbType types.DbType, typ string) Type {
+	t := strings.ToUpper(strings.Join(strings.Fields(typ), " "))
+
+	// arrays: INTEGER[], ARRAY<INT64> and the untyped ARRAY of Snowflake and Postgres
+	if strings.HasSuffix(t, "[]") {
+		element := Parse(dbType, strings.TrimSuffix(t, "[]"))
+		return Type{Kind: Array, Element: &element}
+	}
+	if strings.HasPrefix(t, "ARRAY<") && strings.HasSuffix(t, ">") {
+		element := Parse(dbType, t[len("ARRAY<"):len(t)-1])
+		return Type{Kind: Array, Element: &element}
+	}
+	// BigQuery structs are semi-structured documents in the other databases
+	if strings.HasPrefix(t, "STRUCT<") {
+		return Type{Kind: JSON}
+	}
+
+	name, params, modifiers := split(t)
+	// the time zone of timestamp(6) with time zone follows the precision
+	if strings.HasSuffix(modifiers, "TIME ZONE") {
+		name, modifiers = name+" "+modifiers, ""
+	}
+	if strings.HasPrefix(name, "INTERVAL") {
+		name = "INTERVAL"
+	}
+	first, second, max := parseParams(params)
+	unsigned := strings.Contains(modifiers, "UNSIGNED")
+	if result, ok := special(dbType, name, first, second); ok {
+		result.Unsigned = unsigned
+		return result
+	}
+	result := Type{Kind: names[name], Unsigned: unsigned}
+	if result.Kind == "" {
+		return Type{Kind: Unknown, Name: strings.TrimSpace(typ)}
+	}
+
+	switch name {
+	case "TIMESTAMP":
+		// BigQuery and MySQL timestamps are instants, their DATETIME is a local date and time
+		if dbType == types.BigQuery || dbType == types.MySQL {
+			result.Kind = TimestampTZ
+		}
+	case "TEXT", "STRING":
+		// Snowflake TEXT is a synonym of VARCHAR and BigQuery STRING(L) limits the length
+		if first > 0 {
+			result.Kind = Varchar
+		}
+	case "BYTES":
+		if first > 0 {
+			result.Kind = Varbinary
+		}
+	}
+
+	switch result.Kind {
+	case Decimal:
+		result.Precision, result.Scale = first, second
+	case Char, Varchar, Binary, Varbinary:
+		result.Length = int64(first)
+		if max || (result.Kind == Varchar || result.Kind == Varbinary) && first == 0 {
+			result.Kind, result.Length = unbounded(result.Kind), 0
+		}
+		// Snowflake reports the maximum length for strings and binaries declared without one
+		if dbType == types.Snowflake && (result.Kind == Varchar && first == snowflakeMaxText || result.Kind == Binary && first == snowflakeMaxBinary) {
+			result.Kind, result.Length = unbounded(result.Kind), 0
+		}
+	case Time, TimeTZ, Timestamp, TimestampTZ:
+		result.Precision = first
+	}
+	return result
+}
+
+// String returns the canonical spelling of the type, such as bigint, decimal(10,2), varchar(20) or
+// array<integer>, the same for every database. Unknown types are spelled lower cased.
+func (t Type) String() string {
+	switch t.Kind {
+	case Unknown:
+		return strings.ToLower(strings.Join(strings.Fields(t.Name), " "))
+	case Array:
+		if t.Element == nil {
+			return string(Array)
+		}
+		return "array<" + t.Element.String() + ">"
+	}
+
+	s := string(t.Kind)
+	switch t.Kind {
+	case Decimal:
+		if t.Precision > 0 {
+			s += fmt.Sprintf("(%d,%d)", t.Precision, t.Scale)
+		}
+	case Char, Varchar, Binary, Varbinary:
+		s += length(t.Length)
+	case Time, TimeTZ, Timestamp, TimestampTZ:
+		if t.Precision > 0 {
+			s += "(" + strconv.Itoa(t.Precision) + ")"
+		}
+	}
+	if t.Unsigned {
+		s += " unsigned"
+	}
+	return s
+}
+
+// special resolves the type names whose meaning depends on the database, it returns false for other names.
+func special(dbType types.DbType, name string, first, second int) (Type, bool) {
+	switch name {
+	case "BIT":
+		// SQL Server BIT is a boolean, elsewhere BIT(n) is a bit string
+		if dbType == types.MSSQL || first <= 1 {
+			return Type{Kind: Boolean}, true
+		}
+		return Type{Kind: Varbinary, Length: int64((first + 7) / 8)}, true
+	case "TINYINT":
+		// MySQL spells booleans TINYINT(1)
+		if dbType == types.MySQL && first == 1 {
+			return Type{Kind: Boolean}, true
+		}
+	case "NUMBER":
+		// Snowflake integers are NUMBER(38,0)
+		if second == 0 && (first == 0 || first == 38) {
+			return Type{Kind: BigInt}, true
+		}
+		return Type{Kind: Decimal, Precision: first, Scale: second}, true
+	case "FLOAT":
+		// FLOAT(p) is single precision up to 24 bits, a bare FLOAT is single precision in MySQL only
+		if first > 0 && first <= 24 || first == 0 && dbType == types.MySQL {
+			return Type{Kind: Real}, true
+		}
+		return Type{Kind: Double}, true
+	case "MONEY":
+		return Type{Kind: Decimal, Precision: 19, Scale: 4}, true
+	case "SMALLMONEY":
+		return Type{Kind: Decimal, Precision: 10, Scale: 4}, true
+	}
+	return Type{}, false
+}
+
+// unbounded returns the kind of a string type without a maximum length.
+func unbounded(kind Kind) Kind {
+	switch kind {
+	case Binary, Varbinary, Blob:
+		return Blob
+	}
+	return Text
+}
+
+// split splits an upper cased type into its name, its parameters and trailing modifiers such as UNSIGNED.
+func split(t string) (name, params, modifiers string) {
+	if open := strings.Index(t, "("); open >= 0 {
+		if end := strings.Index(t[open:], ")"); end >= 0 {
+			end += open
+			return strings.TrimSpace(t[:open]), t[open+1 : end], strings.TrimSpace(t[end+1:])
+		}
+	}
+	for _, modifier := range []string{" UNSIGNED ZEROFILL", " UNSIGNED", " ZEROFILL"} {
+		if strings.HasSuffix(t, modifier) {
+			return strings.TrimSuffix(t, modifier), "", strings.TrimSpace(modifier)
+		}
+	}
+	return t, "", ""
+}
+
+// parseParams parses the parameters of a type such as 10,2 or MAX.
+func parseParams(params string) (first, second int, max bool) {
+	parts := strings.Split(params, ",")
+	if strings.EqualFold(strings.TrimSpace(parts[0]), "MAX") {
+		return 0, 0, true
+	}
+	first, _ = strconv.Atoi(strings.TrimSpace(parts[0]))
+	if len(parts) > 1 {
+		second, _ = strconv.Atoi(strings.TrimSpace(parts[1]))
+	}
+	return first, second, false
+}
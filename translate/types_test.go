@@ -0,0 +1,146 @@
+package translate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thesaas-company/xray/types"
+)
+
+// TestParse is a unit test function that tests parsing the type spellings of every database.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		dbType types.DbType
+		typ    string
+		want   Type
+	}{
+		{types.Postgres, "integer", Type{Kind: Integer}},
+		{types.Postgres, "character varying(255)", Type{Kind: Varchar, Length: 255}},
+		{types.Postgres, "character varying", Type{Kind: Text}},
+		{types.Postgres, "numeric(10,2)", Type{Kind: Decimal, Precision: 10, Scale: 2}},
+		{types.Postgres, "numeric", Type{Kind: Decimal}},
+		{types.Postgres, "timestamp(3) with time zone", Type{Kind: TimestampTZ, Precision: 3}},
+		{types.Postgres, "timestamp without time zone", Type{Kind: Timestamp}},
+		{types.Postgres, "integer[]", Type{Kind: Array, Element: &Type{Kind: Integer}}},
+		{types.Postgres, "jsonb", Type{Kind: JSON}},
+		{types.Postgres, "bigserial", Type{Kind: BigInt}},
+		{types.Postgres, "interval day to second", Type{Kind: Interval}},
+		{types.Postgres, "tsvector", Type{Kind: Unknown, Name: "tsvector"}},
+		{types.MySQL, "tinyint(1)", Type{Kind: Boolean}},
+		{types.MySQL, "tinyint(4)", Type{Kind: TinyInt}},
+		{types.MySQL, "int(10) unsigned", Type{Kind: Integer, Unsigned: true}},
+		{types.MySQL, "float", Type{Kind: Real}},
+		{types.MySQL, "timestamp", Type{Kind: TimestampTZ}},
+		{types.MySQL, "datetime(6)", Type{Kind: Timestamp, Precision: 6}},
+		{types.MySQL, "bit(1)", Type{Kind: Boolean}},
+		{types.MySQL, "point", Type{Kind: Geometry}},
+		{types.MySQL, "longblob", Type{Kind: Blob}},
+		{types.MSSQL, "bit", Type{Kind: Boolean}},
+		{types.MSSQL, "nvarchar(MAX)", Type{Kind: Text}},
+		{types.MSSQL, "varbinary(max)", Type{Kind: Blob}},
+		{types.MSSQL, "float", Type{Kind: Double}},
+		{types.MSSQL, "datetimeoffset(7)", Type{Kind: TimestampTZ, Precision: 7}},
+		{types.MSSQL, "uniqueidentifier", Type{Kind: UUID}},
+		{types.MSSQL, "money", Type{Kind: Decimal, Precision: 19, Scale: 4}},
+		{types.Snowflake, "NUMBER(38,0)", Type{Kind: BigInt}},
+		{types.Snowflake, "NUMBER(12,2)", Type{Kind: Decimal, Precision: 12, Scale: 2}},
+		{types.Snowflake, "TEXT(100)", Type{Kind: Varchar, Length: 100}},
+		{types.Snowflake, "TIMESTAMP_LTZ(9)", Type{Kind: TimestampTZ, Precision: 9}},
+		{types.Snowflake, "VARIANT", Type{Kind: JSON}},
+		{types.Snowflake, "ARRAY", Type{Kind: Array}},
+		{types.BigQuery, "TIMESTAMP", Type{Kind: TimestampTZ}},
+		{types.BigQuery, "DATETIME", Type{Kind: Timestamp}},
+		{types.BigQuery, "STRING(20)", Type{Kind: Varchar, Length: 20}},
+		{types.BigQuery, "ARRAY<STRING>", Type{Kind: Array, Element: &Type{Kind: Text}}},
+		{types.BigQuery, "STRUCT<a INT64, b STRING>", Type{Kind: JSON}},
+		{types.BigQuery, "FLOAT64", Type{Kind: Double}},
+		{types.Redshift, "character varying(65535)", Type{Kind: Varchar, Length: 65535}},
+		{types.Redshift, "super", Type{Kind: JSON}},
+		{types.Redshift, "varbyte(64)", Type{Kind: Varbinary, Length: 64}},
+		{types.Redshift, "geography", Type{Kind: Geography}},
+	}
+	for _, tt := range tests {
+		if got := Parse(tt.dbType, tt.typ); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%s, %q) = %+v, want %+v", tt.dbType, tt.typ, got, tt.want)
+		}
+	}
+}
+
+// TestFormat is a unit test function that tests spelling every canonical type for every database.
+func TestFormat(t *testing.T) {
+	dbTypes := []types.DbType{types.Postgres, types.MySQL, types.MSSQL, types.Snowflake, types.BigQuery, types.Redshift}
+	tests := []struct {
+		typ  Type
+		want []string // in the order of dbTypes
+	}{
+		{Type{Kind: Boolean}, []string{"BOOLEAN", "TINYINT(1)", "BIT", "BOOLEAN", "BOOL", "BOOLEAN"}},
+		{Type{Kind: Integer, Unsigned: true}, []string{"BIGINT", "INT UNSIGNED", "BIGINT", "INTEGER", "INT64", "BIGINT"}},
+		{Type{Kind: Decimal, Precision: 12, Scale: 2}, []string{"NUMERIC(12,2)", "DECIMAL(12,2)", "DECIMAL(12,2)", "NUMBER(12,2)", "NUMERIC(12,2)", "DECIMAL(12,2)"}},
+		{Type{Kind: Decimal, Precision: 50, Scale: 20}, []string{"NUMERIC(50,20)", "DECIMAL(50,20)", "DECIMAL(38,8)", "NUMBER(38,8)", "BIGNUMERIC(50,20)", "DECIMAL(38,8)"}},
+		{Type{Kind: Double}, []string{"DOUBLE PRECISION", "DOUBLE", "FLOAT", "FLOAT", "FLOAT64", "DOUBLE PRECISION"}},
+		{Type{Kind: Varchar, Length: 255}, []string{"VARCHAR(255)", "VARCHAR(255)", "NVARCHAR(255)", "VARCHAR(255)", "STRING(255)", "VARCHAR(255)"}},
+		{Type{Kind: Text}, []string{"TEXT", "LONGTEXT", "NVARCHAR(MAX)", "TEXT", "STRING", "VARCHAR(65535)"}},
+		{Type{Kind: Blob}, []string{"BYTEA", "LONGBLOB", "VARBINARY(MAX)", "BINARY", "BYTES", "VARBYTE(1024000)"}},
+		{Type{Kind: Timestamp, Precision: 6}, []string{"TIMESTAMP(6)", "DATETIME(6)", "DATETIME2(6)", "TIMESTAMP_NTZ(6)", "DATETIME", "TIMESTAMP"}},
+		{Type{Kind: TimestampTZ}, []string{"TIMESTAMP WITH TIME ZONE", "TIMESTAMP", "DATETIMEOFFSET", "TIMESTAMP_TZ", "TIMESTAMP", "TIMESTAMPTZ"}},
+		{Type{Kind: JSON}, []string{"JSONB", "JSON", "NVARCHAR(MAX)", "VARIANT", "JSON", "SUPER"}},
+		{Type{Kind: UUID}, []string{"UUID", "CHAR(36)", "UNIQUEIDENTIFIER", "VARCHAR(36)", "STRING", "CHAR(36)"}},
+		{Type{Kind: Geography}, []string{"GEOGRAPHY", "GEOMETRY", "GEOGRAPHY", "GEOGRAPHY", "GEOGRAPHY", "GEOGRAPHY"}},
+		{Type{Kind: Array, Element: &Type{Kind: BigInt}}, []string{"BIGINT[]", "JSON", "NVARCHAR(MAX)", "ARRAY", "ARRAY<INT64>", "SUPER"}},
+	}
+	for _, tt := range tests {
+		for i, dbType := range dbTypes {
+			if got, _ := Format(dbType, tt.typ); got != tt.want[i] {
+				t.Errorf("Format(%s, %+v) = %q, want %q", dbType, tt.typ, got, tt.want[i])
+			}
+		}
+	}
+}
+
+// TestFormatWarnings is a unit test function that tests that lossy conversions are reported.
+func TestFormatWarnings(t *testing.T) {
+	tests := []struct {
+		dbType types.DbType
+		typ    Type
+		warn   bool
+	}{
+		{types.MySQL, Type{Kind: Array, Element: &Type{Kind: Integer}}, true},
+		{types.Postgres, Type{Kind: Array, Element: &Type{Kind: Integer}}, false},
+		{types.MSSQL, Type{Kind: Timestamp, Precision: 9}, true},
+		{types.Snowflake, Type{Kind: Timestamp, Precision: 9}, false},
+		{types.Redshift, Type{Kind: Decimal}, true},
+		{types.Postgres, Type{Kind: Decimal}, false},
+		{types.BigQuery, Type{Kind: Unknown, Name: "tsvector"}, true},
+	}
+	for _, tt := range tests {
+		_, warnings := Format(tt.dbType, tt.typ)
+		if got := len(warnings) > 0; got != tt.warn {
+			t.Errorf("Format(%s, %+v) warnings = %v, want warning %v", tt.dbType, tt.typ, warnings, tt.warn)
+		}
+	}
+}
+
+// TestRoundTrip is a unit test function that tests that types formatted for a database parse back to the same type.
+func TestRoundTrip(t *testing.T) {
+	typs := []Type{
+		{Kind: Boolean},
+		{Kind: SmallInt},
+		{Kind: Integer},
+		{Kind: BigInt},
+		{Kind: Decimal, Precision: 18, Scale: 4},
+		{Kind: Varchar, Length: 100},
+		{Kind: Char, Length: 2},
+		{Kind: Date},
+		{Kind: Timestamp, Precision: 6},
+		{Kind: TimestampTZ, Precision: 6},
+		{Kind: Geography},
+	}
+	for _, dbType := range []types.DbType{types.Postgres, types.MySQL, types.MSSQL, types.Snowflake} {
+		for _, typ := range typs {
+			formatted, _ := Format(dbType, typ)
+			if got := Parse(dbType, formatted); !reflect.DeepEqual(got, typ) && typ.Kind != Geography {
+				t.Errorf("Parse(%s, Format(%+v)) = %+v", dbType, typ, got)
+			}
+		}
+	}
+}
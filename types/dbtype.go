@@ -0,0 +1,139 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DbType represents a type of SQL database.
+type DbType int
+
+// These constants represent the built-in types of SQL databases.
+// Additional types are allocated with RegisterDbType.
+const (
+	MySQL DbType = iota + 1
+	Postgres
+	Snowflake
+	BigQuery
+	Redshift
+	MSSQL
+)
+
+var (
+	dbTypesMu sync.RWMutex
+	// dbTypeNames holds the name of every known DbType, indexed by DbType-1.
+	dbTypeNames = []string{"mysql", "postgres", "snowflake", "bigquery", "redshift", "mssql"}
+	// dbTypeAliases maps alternative names accepted by ParseDbType to their DbType.
+	dbTypeAliases = map[string]DbType{
+		"mariadb":    MySQL,
+		"postgresql": Postgres,
+		"pg":         Postgres,
+		"sqlserver":  MSSQL,
+		"bq":         BigQuery,
+	}
+)
+
+// String returns the string representation of the DbType.
+// Unknown types are formatted as DbType(n) instead of panicking.
+func (w DbType) String() string {
+	if !w.valid() {
+		return fmt.Sprintf("DbType(%d)", int(w))
+	}
+	dbTypesMu.RLock()
+	defer dbTypesMu.RUnlock()
+	return dbTypeNames[w-1]
+}
+
+// Index returns the integer representation of the DbType.
+func (w DbType) Index() int {
+	return int(w)
+}
+
+// RegisterDbType returns the DbType with the given name, allocating a new one if the name is not known yet.
+// Names are case-insensitive.
+func RegisterDbType(name string) DbType {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	dbTypesMu.Lock()
+	defer dbTypesMu.Unlock()
+	for i, n := range dbTypeNames {
+		if n == name {
+			return DbType(i + 1)
+		}
+	}
+	dbTypeNames = append(dbTypeNames, name)
+	return DbType(len(dbTypeNames))
+}
+
+// RegisterDbTypeAlias makes ParseDbType accept alias as another name for dbType.
+// Aliases are case-insensitive.
+func RegisterDbTypeAlias(alias string, dbType DbType) {
+	dbTypesMu.Lock()
+	defer dbTypesMu.Unlock()
+	dbTypeAliases[strings.ToLower(strings.TrimSpace(alias))] = dbType
+}
+
+// ParseDbType returns the DbType with the given name or alias.
+// It returns an error if the name is not known.
+func ParseDbType(name string) (DbType, error) {
+	if dbType, ok := LookupDbType(name); ok {
+		return dbType, nil
+	}
+
+	dbTypesMu.RLock()
+	defer dbTypesMu.RUnlock()
+	if dbType, ok := dbTypeAliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return dbType, nil
+	}
+	return 0, fmt.Errorf("unknown database type %q", name)
+}
+
+// MarshalText implements encoding.TextMarshaler, which also covers JSON and YAML.
+// The zero DbType marshals to an empty string.
+func (w DbType) MarshalText() ([]byte, error) {
+	if w == 0 {
+		return []byte{}, nil
+	}
+	if !w.valid() {
+		return nil, fmt.Errorf("invalid database type %d", int(w))
+	}
+	return []byte(w.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, which also covers JSON and YAML.
+// An empty string unmarshals to the zero DbType.
+func (w *DbType) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*w = 0
+		return nil
+	}
+	dbType, err := ParseDbType(string(text))
+	if err != nil {
+		return err
+	}
+	*w = dbType
+	return nil
+}
+
+// valid reports whether the DbType has a registered name.
+func (w DbType) valid() bool {
+	dbTypesMu.RLock()
+	defer dbTypesMu.RUnlock()
+	return w >= 1 && int(w) <= len(dbTypeNames)
+}
+
+// LookupDbType returns the DbType with the given name.
+// Names are case-insensitive.
+func LookupDbType(name string) (DbType, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	dbTypesMu.RLock()
+	defer dbTypesMu.RUnlock()
+	for i, n := range dbTypeNames {
+		if n == name {
+			return DbType(i + 1), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestParseDbType is a unit test function that tests parsing database type names and aliases.
+func TestParseDbType(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    DbType
+		wantErr bool
+	}{
+		{name: "mysql", want: MySQL},
+		{name: "Postgres", want: Postgres},
+		{name: "postgresql", want: Postgres},
+		{name: "pg", want: Postgres},
+		{name: "sqlserver", want: MSSQL},
+		{name: " bq ", want: BigQuery},
+		{name: "snowflake", want: Snowflake},
+		{name: "redshift", want: Redshift},
+		{name: "oracle", wantErr: true},
+		{name: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDbType(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDbType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDbType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDbTypeString is a unit test function that tests that String never panics.
+func TestDbTypeString(t *testing.T) {
+	if got := DbType(0).String(); got != "DbType(0)" {
+		t.Errorf("String() = %q, want %q", got, "DbType(0)")
+	}
+	if got := DbType(-3).String(); got != "DbType(-3)" {
+		t.Errorf("String() = %q, want %q", got, "DbType(-3)")
+	}
+	if got := MSSQL.String(); got != "mssql" {
+		t.Errorf("String() = %q, want %q", got, "mssql")
+	}
+}
+
+// TestDbTypeMarshalling is a unit test function that tests the JSON and YAML encoding of DbType.
+func TestDbTypeMarshalling(t *testing.T) {
+	type payload struct {
+		Type DbType `json:"type" yaml:"type"`
+	}
+
+	data, err := json.Marshal(payload{Type: Postgres})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(data) != `{"type":"postgres"}` {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+
+	var p payload
+	if err := json.Unmarshal([]byte(`{"type":"sqlserver"}`), &p); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if p.Type != MSSQL {
+		t.Errorf("json.Unmarshal() type = %v, want %v", p.Type, MSSQL)
+	}
+	if err := json.Unmarshal([]byte(`{"type":"oracle"}`), &p); err == nil {
+		t.Error("expected an error for an unknown database type")
+	}
+
+	if err := yaml.Unmarshal([]byte("type: bq\n"), &p); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	if p.Type != BigQuery {
+		t.Errorf("yaml.Unmarshal() type = %v, want %v", p.Type, BigQuery)
+	}
+	data, err = yaml.Marshal(payload{Type: Snowflake})
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	if string(data) != "type: snowflake\n" {
+		t.Errorf("yaml.Marshal() = %q", data)
+	}
+
+	if _, err := json.Marshal(payload{Type: DbType(99)}); err == nil {
+		t.Error("expected an error when marshalling an invalid database type")
+	}
+	data, err = json.Marshal(payload{})
+	if err != nil || string(data) != `{"type":""}` {
+		t.Errorf("json.Marshal() of the zero type = %s, %v", data, err)
+	}
+}
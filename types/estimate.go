@@ -0,0 +1,18 @@
+package types
+
+import "context"
+
+// Estimator is implemented by the clients that can estimate the cost of a query without running it.
+// BigQuery estimates with a dry run, Postgres, Redshift and Snowflake with the EXPLAIN of the query.
+type Estimator interface {
+	Estimate(ctx context.Context, query string) (CostEstimate, error) // Estimate returns the estimated cost of a single statement.
+}
+
+// CostEstimate is the estimated cost of a query, read from a dry run or from the query plan.
+// Values a database does not report are zero.
+type CostEstimate struct {
+	BytesProcessed int64   `json:"bytes_processed"` // BytesProcessed is the number of bytes the query reads, reported by BigQuery and Snowflake.
+	Rows           int64   `json:"rows"`            // Rows is the number of rows the planner expects the query to return.
+	Cost           float64 `json:"cost"`            // Cost is the total cost of the plan in the units of the planner.
+	Plan           string  `json:"plan"`            // Plan is the raw plan or dry run statistics the estimate was read from.
+}
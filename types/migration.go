@@ -0,0 +1,24 @@
+package types
+
+import "strings"
+
+// Migration holds the statements turning one version of a table into another.
+type Migration struct {
+	Statements []string `json:"statements"` // Statements are the DDL statements to run, in order.
+	Warnings   []string `json:"warnings"`   // Warnings describe destructive operations and changes the database cannot make in place.
+}
+
+// Add appends a statement to the migration.
+func (m *Migration) Add(statement string) {
+	m.Statements = append(m.Statements, statement)
+}
+
+// Warn appends a warning to the migration.
+func (m *Migration) Warn(warning string) {
+	m.Warnings = append(m.Warnings, warning)
+}
+
+// String returns the statements of the migration, one per line.
+func (m Migration) String() string {
+	return strings.Join(m.Statements, "\n")
+}
@@ -0,0 +1,29 @@
+package types
+
+import "context"
+
+// Explainer is implemented by the clients that can return the execution plan of a query.
+type Explainer interface {
+	// Explain returns the plan of a single statement. With analyze the statement is run and the plan
+	// holds the actual row counts and times; writes are rolled back where the database allows it.
+	Explain(ctx context.Context, query string, analyze bool) (Plan, error)
+}
+
+// Plan is the execution plan of a query, normalised from the plan format of each database.
+type Plan struct {
+	Root     *PlanNode `json:"root"`     // Root is the operation producing the result of the query.
+	Analyzed bool      `json:"analyzed"` // Analyzed indicates whether the query was run and the actual values measured.
+	Format   string    `json:"format"`   // Format is the format of Raw: json, text or xml.
+	Raw      string    `json:"raw"`      // Raw is the plan as returned by the database.
+}
+
+// PlanNode is an operation of a query plan. Values a database does not report are zero.
+type PlanNode struct {
+	Type          string      `json:"type"`           // Type is the operation, such as Seq Scan, Hash Join or TableScan.
+	Relation      string      `json:"relation"`       // Relation is the table the operation reads, if any.
+	EstimatedRows float64     `json:"estimated_rows"` // EstimatedRows is the number of rows the planner expects the operation to return.
+	EstimatedCost float64     `json:"estimated_cost"` // EstimatedCost is the cost of the operation and its children in the units of the planner.
+	ActualRows    float64     `json:"actual_rows"`    // ActualRows is the number of rows the operation returned, only in analyzed plans.
+	ActualTime    float64     `json:"actual_time"`    // ActualTime is the time in milliseconds the operation took, only in analyzed plans.
+	Children      []*PlanNode `json:"children"`       // Children are the operations feeding this one.
+}
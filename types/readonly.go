@@ -0,0 +1,57 @@
+package types
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ReadOnlySetter is implemented by the clients whose read-only mode can be switched after they are created.
+// In read-only mode Execute and Query reject every statement that is not a read.
+type ReadOnlySetter interface {
+	SetReadOnly(readOnly bool) // SetReadOnly enables or disables the read-only mode of the client.
+}
+
+// TxBeginner starts transactions, it is implemented by *sql.DB and *sql.Conn.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) // BeginTx starts a transaction.
+}
+
+// txRowIterator is a RowIterator whose transaction is rolled back when it is closed.
+type txRowIterator struct {
+	RowIterator
+	tx *sql.Tx
+}
+
+// QueryReadOnly executes a query inside a read-only transaction and streams its rows.
+// The transaction is rolled back when the returned iterator is closed, so a statement that
+// slips past the caller's checks cannot persist any change.
+func QueryReadOnly(ctx context.Context, db TxBeginner, convert ValueConverter, query string, args ...interface{}) (RowIterator, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("error starting read-only transaction: %v", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("error executing sql statement: %v", err)
+	}
+
+	it, err := NewRowIterator(rows, convert)
+	if err != nil {
+		_ = rows.Close()
+		_ = tx.Rollback()
+		return nil, err
+	}
+	return &txRowIterator{RowIterator: it, tx: tx}, nil
+}
+
+// Close closes the rows and rolls back the read-only transaction.
+func (it *txRowIterator) Close() error {
+	err := it.RowIterator.Close()
+	if rollbackErr := it.tx.Rollback(); rollbackErr != nil && rollbackErr != sql.ErrTxDone && err == nil {
+		err = fmt.Errorf("error rolling back read-only transaction: %v", rollbackErr)
+	}
+	return err
+}
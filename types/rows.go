@@ -0,0 +1,167 @@
+package types
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// ColumnMeta describes a single column of a query result.
+type ColumnMeta struct {
+	Name         string `json:"name"`          // Name is the name of the column.
+	DatabaseType string `json:"database_type"` // DatabaseType is the database specific type name of the column.
+	ScanType     string `json:"scan_type"`     // ScanType is the Go type the driver scans the column into.
+	Nullable     bool   `json:"nullable"`      // Nullable indicates whether the column may contain null values.
+	Length       int64  `json:"length"`        // Length is the length of variable length columns.
+	Precision    int64  `json:"precision"`     // Precision is the precision of decimal columns.
+	Scale        int64  `json:"scale"`         // Scale is the scale of decimal columns.
+}
+
+// RowIterator streams the rows of a query result one at a time.
+// Callers must call Close once they are done with the iterator.
+type RowIterator interface {
+	Columns() []ColumnMeta // Columns returns the metadata of the result columns.
+	Next() bool            // Next advances the iterator to the next row and reports whether there is one.
+	Row() []interface{}    // Row returns the converted values of the current row.
+	Err() error            // Err returns the error, if any, that was encountered during iteration.
+	Close() error          // Close releases the underlying result set.
+}
+
+// ValueConverter converts a value scanned by the driver into the value returned by a RowIterator.
+type ValueConverter func(column ColumnMeta, value interface{}) (interface{}, error)
+
+// sqlRowIterator is a RowIterator backed by *sql.Rows.
+type sqlRowIterator struct {
+	rows    *sql.Rows
+	columns []ColumnMeta
+	convert ValueConverter
+	row     []interface{}
+	err     error
+}
+
+// NewRowIterator creates a RowIterator over the given rows.
+// Every scanned value is passed through convert before it is returned; a nil convert returns values as scanned.
+func NewRowIterator(rows *sql.Rows, convert ValueConverter) (RowIterator, error) {
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("error getting column types: %v", err)
+	}
+
+	columns := make([]ColumnMeta, len(columnTypes))
+	for i, ct := range columnTypes {
+		columns[i] = columnMetaFromType(ct)
+	}
+
+	return &sqlRowIterator{
+		rows:    rows,
+		columns: columns,
+		convert: convert,
+	}, nil
+}
+
+// Columns returns the metadata of the result columns.
+func (it *sqlRowIterator) Columns() []ColumnMeta {
+	return it.columns
+}
+
+// Next scans the next row and converts its values.
+func (it *sqlRowIterator) Next() bool {
+	if it.err != nil || !it.rows.Next() {
+		return false
+	}
+
+	values := make([]interface{}, len(it.columns))
+	pointers := make([]interface{}, len(it.columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	if err := it.rows.Scan(pointers...); err != nil {
+		it.err = fmt.Errorf("error scanning row: %v", err)
+		return false
+	}
+
+	if it.convert != nil {
+		for i, v := range values {
+			converted, err := it.convert(it.columns[i], v)
+			if err != nil {
+				it.err = fmt.Errorf("error converting column %s: %v", it.columns[i].Name, err)
+				return false
+			}
+			values[i] = converted
+		}
+	}
+
+	it.row = values
+	return true
+}
+
+// Row returns the values of the current row.
+// The returned slice is not reused by subsequent calls to Next.
+func (it *sqlRowIterator) Row() []interface{} {
+	return it.row
+}
+
+// Err returns the first error encountered while scanning or iterating.
+func (it *sqlRowIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.rows.Err()
+}
+
+// Close closes the underlying rows.
+func (it *sqlRowIterator) Close() error {
+	return it.rows.Close()
+}
+
+// ReadAll drains the iterator into a QueryResult.
+// The caller remains responsible for closing the iterator and for setting the elapsed time.
+func ReadAll(it RowIterator) (QueryResult, error) {
+	columns := it.Columns()
+	result := QueryResult{
+		Columns:     ColumnNames(columns),
+		ColumnTypes: columns,
+		Rows:        [][]interface{}{},
+	}
+
+	for it.Next() {
+		result.Rows = append(result.Rows, it.Row())
+	}
+	if err := it.Err(); err != nil {
+		return result, err
+	}
+
+	result.RowCount = int64(len(result.Rows))
+	return result, nil
+}
+
+// ColumnNames returns the names of the given columns.
+func ColumnNames(columns []ColumnMeta) []string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// columnMetaFromType builds a ColumnMeta from the column type reported by the driver.
+func columnMetaFromType(ct *sql.ColumnType) ColumnMeta {
+	meta := ColumnMeta{
+		Name:         ct.Name(),
+		DatabaseType: ct.DatabaseTypeName(),
+	}
+	if scanType := ct.ScanType(); scanType != nil {
+		meta.ScanType = scanType.String()
+	}
+	if nullable, ok := ct.Nullable(); ok {
+		meta.Nullable = nullable
+	}
+	if length, ok := ct.Length(); ok {
+		meta.Length = length
+	}
+	if precision, scale, ok := ct.DecimalSize(); ok {
+		meta.Precision = precision
+		meta.Scale = scale
+	}
+	return meta
+}
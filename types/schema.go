@@ -0,0 +1,279 @@
+package types
+
+import (
+	"database/sql"
+	"strings"
+)
+
+// ForeignKeyColumn is a single column pair of a foreign key constraint as read from the database catalog.
+type ForeignKeyColumn struct {
+	Constraint       string // Constraint is the name of the constraint.
+	Column           string // Column is the local column.
+	ReferencedSchema string // ReferencedSchema is the schema of the referenced table.
+	ReferencedTable  string // ReferencedTable is the name of the referenced table.
+	ReferencedColumn string // ReferencedColumn is the referenced column.
+	OnDelete         string // OnDelete is the referential action on delete.
+	OnUpdate         string // OnUpdate is the referential action on update.
+}
+
+// GroupForeignKeys groups column pairs into foreign keys by constraint name.
+// Constraints and their columns keep the order in which they are given, so catalog queries
+// should sort by constraint and column position.
+func GroupForeignKeys(columns []ForeignKeyColumn) []ForeignKey {
+	foreignKeys := []ForeignKey{}
+	index := map[string]int{}
+	for _, c := range columns {
+		i, ok := index[c.Constraint]
+		if !ok {
+			foreignKeys = append(foreignKeys, ForeignKey{
+				Name:              c.Constraint,
+				Columns:           []string{},
+				ReferencedSchema:  c.ReferencedSchema,
+				ReferencedTable:   c.ReferencedTable,
+				ReferencedColumns: []string{},
+				OnDelete:          ReferentialAction(c.OnDelete),
+				OnUpdate:          ReferentialAction(c.OnUpdate),
+			})
+			i = len(foreignKeys) - 1
+			index[c.Constraint] = i
+		}
+		foreignKeys[i].Columns = append(foreignKeys[i].Columns, c.Column)
+		foreignKeys[i].ReferencedColumns = append(foreignKeys[i].ReferencedColumns, c.ReferencedColumn)
+	}
+	return foreignKeys
+}
+
+// ReferentialAction normalises a referential action such as "NO_ACTION" or "set null"
+// to the upper case SQL spelling, e.g. "NO ACTION" or "SET NULL".
+func ReferentialAction(action string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(action), "_", " "))
+}
+
+// IndexColumn is a single column of an index as read from the database catalog.
+type IndexColumn struct {
+	Index     string // Index is the name of the index.
+	Column    string // Column is the indexed column or expression.
+	Unique    bool   // Unique indicates whether the index enforces uniqueness.
+	Primary   bool   // Primary indicates whether the index backs the primary key.
+	Method    string // Method is the index method.
+	Predicate string // Predicate is the WHERE clause of a partial index.
+}
+
+// GroupIndexes groups index columns into indexes by index name.
+// Indexes and their columns keep the order in which they are given, so catalog queries
+// should sort by index and column position.
+func GroupIndexes(columns []IndexColumn) []Index {
+	indexes := []Index{}
+	position := map[string]int{}
+	for _, c := range columns {
+		i, ok := position[c.Index]
+		if !ok {
+			indexes = append(indexes, Index{
+				Name:      c.Index,
+				Columns:   []string{},
+				Unique:    c.Unique,
+				Primary:   c.Primary,
+				Method:    strings.ToLower(c.Method),
+				Predicate: c.Predicate,
+			})
+			i = len(indexes) - 1
+			position[c.Index] = i
+		}
+		indexes[i].Columns = append(indexes[i].Columns, c.Column)
+	}
+	return indexes
+}
+
+// ApplyIndexes derives the IsIndex, IsUnique and IsPrimary flags of the columns from the indexes.
+// A column is unique when a unique index covers exactly that column, partial indexes excluded.
+func ApplyIndexes(columns []Column, indexes []Index) {
+	indexed := map[string]bool{}
+	unique := map[string]bool{}
+	primary := map[string]bool{}
+	for _, index := range indexes {
+		for _, column := range index.Columns {
+			indexed[column] = true
+			if index.Primary {
+				primary[column] = true
+			}
+		}
+		if index.Unique && len(index.Columns) == 1 && index.Predicate == "" {
+			unique[index.Columns[0]] = true
+		}
+	}
+
+	for i := range columns {
+		name := columns[i].Name
+		columns[i].IsIndex = indexed[name]
+		if primary[name] {
+			columns[i].IsPrimary = true
+		}
+		if unique[name] {
+			columns[i].IsUnique = sql.NullString{String: "YES", Valid: true}
+		} else {
+			columns[i].IsUnique = sql.NullString{String: "NO", Valid: true}
+		}
+	}
+}
+
+// TableName is a table identifier qualified with its catalog (database or project) and schema (or dataset).
+type TableName struct {
+	Catalog string `json:"catalog"` // Catalog is the database, or the project for BigQuery.
+	Schema  string `json:"schema"`  // Schema is the schema, or the dataset for BigQuery and the database for MySQL.
+	Name    string `json:"name"`    // Name is the name of the table.
+}
+
+// ParseTableName parses a table reference of the form name, schema.name or catalog.schema.name.
+// Parts may be quoted with double quotes, backticks or square brackets, in which case dots inside them
+// are part of the name and the quotes are removed.
+func ParseTableName(s string) TableName {
+	parts := splitQualifiedName(strings.TrimSpace(s))
+	switch len(parts) {
+	case 0:
+		return TableName{}
+	case 1:
+		return TableName{Name: parts[0]}
+	case 2:
+		return TableName{Schema: parts[0], Name: parts[1]}
+	default:
+		n := len(parts)
+		return TableName{Catalog: strings.Join(parts[:n-2], "."), Schema: parts[n-2], Name: parts[n-1]}
+	}
+}
+
+// String returns the dot separated form of the name, leaving out empty parts.
+func (t TableName) String() string {
+	var parts []string
+	for _, part := range []string{t.Catalog, t.Schema, t.Name} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// splitQualifiedName splits s at the dots that are not inside quotes and unquotes every part.
+func splitQualifiedName(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	var parts []string
+	var part strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		var closing byte
+		switch c {
+		case '"', '`':
+			closing = c
+		case '[':
+			closing = ']'
+		case '.':
+			parts = append(parts, part.String())
+			part.Reset()
+			continue
+		default:
+			part.WriteByte(c)
+			continue
+		}
+
+		// quoted part, a doubled closing quote stands for the quote itself
+		for i++; i < len(s); i++ {
+			if s[i] == closing {
+				if i+1 < len(s) && s[i+1] == closing {
+					part.WriteByte(closing)
+					i++
+					continue
+				}
+				break
+			}
+			part.WriteByte(s[i])
+		}
+	}
+	return append(parts, part.String())
+}
+
+// Includes reports whether objects of the given kind are selected by the options.
+func (o ListTablesOptions) Includes(kind TableKind) bool {
+	if len(o.Kinds) == 0 {
+		return true
+	}
+	for _, k := range o.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterTables returns the tables whose kind is selected by the options.
+func FilterTables(tables []TableInfo, opts ListTablesOptions) []TableInfo {
+	filtered := []TableInfo{}
+	for _, t := range tables {
+		if opts.Includes(t.Kind) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// HasViews reports whether any of the tables is a view or a materialized view.
+func HasViews(tables []TableInfo) bool {
+	for _, t := range tables {
+		if t.Kind == KindView || t.Kind == KindMaterializedView {
+			return true
+		}
+	}
+	return false
+}
+
+// TableDependency is a row of a dependency query: the object Schema.Name reads from Referenced.
+type TableDependency struct {
+	Schema     string    // Schema is the schema of the dependent view.
+	Name       string    // Name is the name of the dependent view.
+	Referenced TableName // Referenced is the object the view reads from.
+}
+
+// ApplyDependencies adds the dependencies to the tables they belong to.
+// Duplicate references and references of a view to itself are dropped.
+func ApplyDependencies(tables []TableInfo, dependencies []TableDependency) {
+	for i := range tables {
+		seen := map[TableName]bool{}
+		for _, d := range dependencies {
+			if d.Schema != tables[i].Schema || d.Name != tables[i].Name {
+				continue
+			}
+			if d.Referenced.Schema == d.Schema && d.Referenced.Name == d.Name {
+				continue
+			}
+			if seen[d.Referenced] {
+				continue
+			}
+			seen[d.Referenced] = true
+			tables[i].Dependencies = append(tables[i].Dependencies, d.Referenced)
+		}
+	}
+}
+
+// Comment is a row of a comment query: the comment of a column, or of the table itself when Column is empty.
+type Comment struct {
+	Column string // Column is the commented column, empty for the table comment.
+	Text   string // Text is the comment.
+}
+
+// ApplyComments sets the description of the commented columns and returns the table comment.
+func ApplyComments(columns []Column, comments []Comment) string {
+	var description string
+	for _, c := range comments {
+		if c.Column == "" {
+			description = c.Text
+			continue
+		}
+		for i := range columns {
+			if columns[i].Name == c.Column {
+				columns[i].Description = c.Text
+			}
+		}
+	}
+	return description
+}
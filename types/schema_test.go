@@ -0,0 +1,68 @@
+package types
+
+import "testing"
+
+// TestParseTableName is a unit test function that tests parsing plain, qualified and quoted table names.
+func TestParseTableName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want TableName
+	}{
+		{"", TableName{}},
+		{"users", TableName{Name: "users"}},
+		{"sales.users", TableName{Schema: "sales", Name: "users"}},
+		{"db.sales.users", TableName{Catalog: "db", Schema: "sales", Name: "users"}},
+		{`"My Schema"."my.table"`, TableName{Schema: "My Schema", Name: "my.table"}},
+		{"`my-project`.dataset.events", TableName{Catalog: "my-project", Schema: "dataset", Name: "events"}},
+		{"[dbo].[order details]", TableName{Schema: "dbo", Name: "order details"}},
+		{`"say ""hi"""`, TableName{Name: `say "hi"`}},
+	}
+	for _, tt := range tests {
+		if got := ParseTableName(tt.in); got != tt.want {
+			t.Errorf("ParseTableName(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestTableNameString is a unit test function that tests formatting table names.
+func TestTableNameString(t *testing.T) {
+	tests := []struct {
+		in   TableName
+		want string
+	}{
+		{TableName{Name: "users"}, "users"},
+		{TableName{Schema: "sales", Name: "users"}, "sales.users"},
+		{TableName{Catalog: "db", Schema: "sales", Name: "users"}, "db.sales.users"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestApplyDependencies is a unit test function that tests attaching dependency rows to the listed views.
+func TestApplyDependencies(t *testing.T) {
+	tables := []TableInfo{
+		{Name: "orders", Schema: "public", Kind: KindTable},
+		{Name: "recent", Schema: "public", Kind: KindView},
+	}
+	ApplyDependencies(tables, []TableDependency{
+		{Schema: "public", Name: "recent", Referenced: TableName{Schema: "public", Name: "orders"}},
+		{Schema: "public", Name: "recent", Referenced: TableName{Schema: "public", Name: "orders"}},
+		{Schema: "public", Name: "recent", Referenced: TableName{Schema: "public", Name: "recent"}},
+		{Schema: "other", Name: "recent", Referenced: TableName{Schema: "other", Name: "users"}},
+	})
+
+	if len(tables[0].Dependencies) != 0 {
+		t.Errorf("table dependencies = %+v, want none", tables[0].Dependencies)
+	}
+	if want := (TableName{Schema: "public", Name: "orders"}); len(tables[1].Dependencies) != 1 || tables[1].Dependencies[0] != want {
+		t.Errorf("view dependencies = %+v, want [%+v]", tables[1].Dependencies, want)
+	}
+
+	filtered := FilterTables(tables, ListTablesOptions{Kinds: []TableKind{KindView}})
+	if len(filtered) != 1 || filtered[0].Name != "recent" {
+		t.Errorf("FilterTables() = %+v, want only the view", filtered)
+	}
+}
@@ -0,0 +1,32 @@
+package types
+
+import "encoding/json"
+
+// ScriptMode selects what ExecuteScript does after a statement fails.
+type ScriptMode int
+
+const (
+	StopOnError     ScriptMode = iota // StopOnError stops the script at the first failing statement.
+	ContinueOnError                   // ContinueOnError runs the remaining statements after a failing statement.
+)
+
+// ScriptOptions are the options of ExecuteScript.
+type ScriptOptions struct {
+	Mode ScriptMode // Mode selects whether the script stops or continues after a failing statement.
+}
+
+// StatementResult is the outcome of a statement, or an MSSQL batch, of a script.
+type StatementResult struct {
+	Statement    string          `json:"statement"`        // Statement is the text sent to the database.
+	Result       json.RawMessage `json:"result,omitempty"` // Result is the QueryResult of a read or of a procedure returning rows, as returned by ExecuteContext.
+	RowsAffected int64           `json:"rows_affected"`    // RowsAffected is the number of rows changed by a write, -1 when the database does not report it.
+	Time         int64           `json:"time"`             // Time is the execution time of the statement in milliseconds.
+	Error        string          `json:"error,omitempty"`  // Error is the error of a failed statement.
+}
+
+// ScriptResult is the outcome of ExecuteScript, with a result for every statement that ran.
+type ScriptResult struct {
+	Statements []StatementResult `json:"statements"` // Statements are the results of the statements that ran, in order.
+	Failed     int               `json:"failed"`     // Failed is the number of failed statements.
+	Time       int64             `json:"time"`       // Time is the execution time of the script in milliseconds.
+}
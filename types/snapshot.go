@@ -0,0 +1,53 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DatabaseSchemaVersion is the version of the DatabaseSchema document format.
+// It is increased whenever a change would stop older readers from understanding a document.
+const DatabaseSchemaVersion = 1
+
+// DatabaseSchema is a serialisable snapshot of the schema of every table in a database.
+type DatabaseSchema struct {
+	Version  int       `json:"version"`  // Version is the format version of the document, see DatabaseSchemaVersion.
+	DbType   DbType    `json:"db_type"`  // DbType is the type of the database the snapshot was taken from.
+	Database string    `json:"database"` // Database is the name of the database the snapshot was taken from.
+	TakenAt  time.Time `json:"taken_at"` // TakenAt is the time the snapshot was taken.
+	Tables   []Table   `json:"tables"`   // Tables are the schemas of the tables, ordered as listed by the database.
+}
+
+// SnapshotOptions controls which tables a snapshot covers and how they are read.
+// Patterns use path.Match syntax and are matched case-insensitively against both the table name
+// and the schema qualified name, so "audit_*" and "staging.*" both work.
+type SnapshotOptions struct {
+	Include     []string    // Include restricts the snapshot to the tables matching one of the patterns, all tables are included when empty.
+	Exclude     []string    // Exclude leaves out the tables matching one of the patterns, it takes precedence over Include.
+	Kinds       []TableKind // Kinds restricts the snapshot to the given kinds, all kinds are included when empty.
+	Concurrency int         // Concurrency is the maximum number of tables read at the same time, 4 when zero or negative.
+}
+
+// Table returns the table with the given name and schema, an empty schema matches any schema.
+func (d DatabaseSchema) Table(schema, name string) (Table, bool) {
+	for _, t := range d.Tables {
+		if t.Name == name && (schema == "" || t.Schema == schema) {
+			return t, true
+		}
+	}
+	return Table{}, false
+}
+
+// ParseDatabaseSchema decodes a DatabaseSchema document.
+// It returns an error for documents written by a newer, incompatible version.
+func ParseDatabaseSchema(data []byte) (DatabaseSchema, error) {
+	var schema DatabaseSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return DatabaseSchema{}, fmt.Errorf("error decoding database schema: %v", err)
+	}
+	if schema.Version < 1 || schema.Version > DatabaseSchemaVersion {
+		return DatabaseSchema{}, fmt.Errorf("unsupported database schema version %d", schema.Version)
+	}
+	return schema, nil
+}
@@ -0,0 +1,43 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestParseDatabaseSchema is a unit test function that tests that a DatabaseSchema survives a JSON round trip.
+func TestParseDatabaseSchema(t *testing.T) {
+	schema := DatabaseSchema{
+		Version:  DatabaseSchemaVersion,
+		DbType:   Postgres,
+		Database: "shop",
+		TakenAt:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Tables: []Table{{
+			Name:    "users",
+			Schema:  "public",
+			Kind:    KindTable,
+			Columns: []Column{{Name: "id", Type: "integer", IsPrimary: true}},
+		}},
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	got, err := ParseDatabaseSchema(data)
+	if err != nil {
+		t.Fatalf("ParseDatabaseSchema() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, schema) {
+		t.Errorf("ParseDatabaseSchema() = %+v, want %+v", got, schema)
+	}
+	if table, ok := got.Table("", "users"); !ok || table.Schema != "public" {
+		t.Errorf("Table() = %+v, %v", table, ok)
+	}
+
+	if _, err := ParseDatabaseSchema([]byte(`{"version": 99}`)); err == nil {
+		t.Error("ParseDatabaseSchema() of a newer version: error = nil, want an error")
+	}
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"context"
+	"database/sql"
+)
+
+// TxOptions are the options of a transaction. The zero value starts a read-write transaction
+// with the default isolation level of the database.
+type TxOptions struct {
+	Isolation sql.IsolationLevel // Isolation is the isolation level, a database rejects the levels it does not support.
+	ReadOnly  bool               // ReadOnly rejects the statements that are not reads, databases with read-only transactions also enforce it.
+}
+
+// Executor runs statements on a connection pinned by a transaction or a session.
+// The iterator returned by Query must be closed before the next statement is run.
+type Executor interface {
+	Execute(context.Context, string, ...interface{}) ([]byte, error)    // Execute executes the given SQL query and returns its result as JSON, within the size limits of the client.
+	Query(context.Context, string, ...interface{}) (RowIterator, error) // Query executes the given SQL query and streams its rows.
+	Exec(context.Context, string, ...interface{}) (int64, error)        // Exec executes the given SQL statement and returns the number of affected rows, -1 when the database does not report it.
+}
+
+// Tx is a transaction started by ISQLContext.Begin. Its statements run on a single connection until Commit or Rollback.
+type Tx interface {
+	Executor
+	Commit() error   // Commit commits the transaction.
+	Rollback() error // Rollback aborts the transaction.
+}
+
+// Session is a connection pinned by ISQLContext.Session. Its statements run in order on the same connection
+// and share its state, such as SET options and temporary tables. Close returns the connection.
+type Session interface {
+	Executor
+	Begin(context.Context, TxOptions) (Tx, error) // Begin starts a transaction on the session.
+	Close() error                                 // Close ends the session.
+}
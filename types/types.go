@@ -1,25 +1,110 @@
 package types
 
 import (
+	"context"
 	"database/sql"
 )
 
 // ISQL is an interface that defines the methods that a SQL database must implement.
 type ISQL interface {
-	Schema(string) (Table, error)          // Schema retrieves the schema for the specified table.
+	Schema(string) (Table, error)          // Schema retrieves the schema for the specified, optionally schema qualified, table.
 	Execute(string) ([]byte, error)        // Execute executes the given SQL query.
 	Tables(string) ([]string, error)       // Tables retrieves the list of tables for the specified database.
 	GenerateCreateTableQuery(Table) string // GenerateCreateTableQuery generates the CREATE TABLE query for the specified table.
+	TableStats(string) (TableStats, error) // TableStats retrieves the approximate size and modification times of the specified table.
+}
+
+// ISQLContext is the context-aware variant of ISQL.
+// The context controls cancellation and deadlines of the underlying database calls.
+type ISQLContext interface {
+	ISQL
+	SchemaContext(context.Context, string) (Table, error)                        // SchemaContext retrieves the schema for the specified table.
+	TableStatsContext(context.Context, string) (TableStats, error)               // TableStatsContext retrieves the statistics of the specified table.
+	ExecuteContext(context.Context, string, ...interface{}) ([]byte, error)      // ExecuteContext executes the given SQL query with optional bind arguments.
+	ExecutePage(context.Context, string, string, ...interface{}) ([]byte, error) // ExecutePage executes the given SQL query and returns the page of its result selected by the page token.
+	TablesContext(context.Context, string) ([]string, error)                     // TablesContext retrieves the list of tables for the specified database.
+	Schemas(context.Context, string) ([]string, error)                           // Schemas retrieves the list of schemas (datasets for BigQuery) in the specified database.
+	ListTables(context.Context, string, ListTablesOptions) ([]TableInfo, error)  // ListTables retrieves the tables, views and external tables of the specified database with their kind.
+	Query(context.Context, string, ...interface{}) (RowIterator, error)          // Query executes the given SQL query with optional bind arguments and streams its rows.
+	Snapshot(context.Context, string, SnapshotOptions) (DatabaseSchema, error)   // Snapshot reads the schema of every table of the specified database.
+	GenerateAlterTableQueries(Table, Table) Migration                            // GenerateAlterTableQueries generates the ALTER TABLE statements turning the first table into the second.
+	Begin(context.Context, TxOptions) (Tx, error)                                // Begin starts a transaction whose statements run on a single connection.
+	Session(context.Context) (Session, error)                                    // Session pins a connection, so that session state holds between statements.
+	ExecuteScript(context.Context, string, ScriptOptions) (ScriptResult, error)  // ExecuteScript splits a script into statements and runs them one after the other in a session.
 }
 
 // Table represents a database table.
 type Table struct {
-	Name        string   `json:"name"`         // Name is the name of the table.
-	Dataset     string   `json:"dataset"`      // Dataset is the dataset of the bigquery table.
-	Columns     []Column `json:"columns"`      // Columns are the columns in the table.
-	ColumnCount int64    `json:"column_count"` // ColumnCount is the number of columns in the table.
-	Description string   `json:"description"`  // Description is a description of the table.
-	Metatags    []string `json:"metatags"`     // Metatags contains all column names.
+	Name        string       `json:"name"`         // Name is the name of the table.
+	Catalog     string       `json:"catalog"`      // Catalog is the database, or the project for BigQuery, the table belongs to.
+	Schema      string       `json:"schema"`       // Schema is the schema the table belongs to.
+	Kind        TableKind    `json:"kind"`         // Kind is the kind of the table, it is only set by Snapshot.
+	Dataset     string       `json:"dataset"`      // Dataset is the dataset of the bigquery table.
+	Columns     []Column     `json:"columns"`      // Columns are the columns in the table.
+	ColumnCount int64        `json:"column_count"` // ColumnCount is the number of columns in the table.
+	Description string       `json:"description"`  // Description is a description of the table.
+	Metatags    []string     `json:"metatags"`     // Metatags contains all column names.
+	ForeignKeys []ForeignKey `json:"foreign_keys"` // ForeignKeys are the declared foreign key constraints of the table.
+	Indexes     []Index      `json:"indexes"`      // Indexes are the indexes, sort keys or clustering keys of the table.
+}
+
+// TableStats holds the approximate size and modification times of a table as recorded by the database catalog.
+// The counts come from catalog statistics and may lag behind the actual table contents.
+type TableStats struct {
+	Name         string       `json:"name"`          // Name is the name of the table.
+	Schema       string       `json:"schema"`        // Schema is the schema, or the dataset for BigQuery, of the table.
+	RowCount     int64        `json:"row_count"`     // RowCount is the approximate number of rows.
+	Bytes        int64        `json:"bytes"`         // Bytes is the storage used by the table, including its indexes where the database reports them.
+	LastDDL      sql.NullTime `json:"last_ddl"`      // LastDDL is the last change of the table definition, or its creation time where no DDL time is kept.
+	LastModified sql.NullTime `json:"last_modified"` // LastModified is the last change of the table data, when the database records it.
+}
+
+// TableKind is the kind of a table like object.
+type TableKind string
+
+// These constants represent the kinds of objects returned by ListTables.
+const (
+	KindTable            TableKind = "table"             // KindTable is a regular table.
+	KindView             TableKind = "view"              // KindView is a view.
+	KindMaterializedView TableKind = "materialized_view" // KindMaterializedView is a materialized view.
+	KindExternalTable    TableKind = "external_table"    // KindExternalTable is a table whose data lives outside the database, such as a Redshift Spectrum or BigQuery external table.
+)
+
+// TableInfo describes a table like object returned by ListTables.
+type TableInfo struct {
+	Name         string      `json:"name"`         // Name is the name of the object.
+	Schema       string      `json:"schema"`       // Schema is the schema, or the dataset for BigQuery, the object belongs to.
+	Kind         TableKind   `json:"kind"`         // Kind is the kind of the object.
+	Definition   string      `json:"definition"`   // Definition is the SQL of a view or materialized view, empty for tables.
+	Dependencies []TableName `json:"dependencies"` // Dependencies are the objects a view or materialized view reads from.
+}
+
+// ListTablesOptions controls which objects ListTables returns.
+type ListTablesOptions struct {
+	Kinds []TableKind // Kinds restricts the listing to the given kinds, all kinds are listed when empty.
+}
+
+// Index represents an index of a table.
+// Warehouses without real indexes report their sort or clustering keys as an index.
+type Index struct {
+	Name      string   `json:"name"`      // Name is the name of the index.
+	Columns   []string `json:"columns"`   // Columns are the indexed columns or expressions, in index order.
+	Unique    bool     `json:"unique"`    // Unique indicates whether the index enforces uniqueness.
+	Primary   bool     `json:"primary"`   // Primary indicates whether the index backs the primary key.
+	Method    string   `json:"method"`    // Method is the index method, such as btree, gin, clustered or sortkey.
+	Predicate string   `json:"predicate"` // Predicate is the WHERE clause of a partial index.
+}
+
+// ForeignKey represents a foreign key constraint of a table.
+// Columns and ReferencedColumns are ordered so that Columns[i] references ReferencedColumns[i].
+type ForeignKey struct {
+	Name              string   `json:"name"`               // Name is the name of the constraint.
+	Columns           []string `json:"columns"`            // Columns are the local columns of the constraint.
+	ReferencedSchema  string   `json:"referenced_schema"`  // ReferencedSchema is the schema of the referenced table.
+	ReferencedTable   string   `json:"referenced_table"`   // ReferencedTable is the name of the referenced table.
+	ReferencedColumns []string `json:"referenced_columns"` // ReferencedColumns are the referenced columns.
+	OnDelete          string   `json:"on_delete"`          // OnDelete is the referential action on delete, such as CASCADE or NO ACTION.
+	OnUpdate          string   `json:"on_update"`          // OnUpdate is the referential action on update, such as CASCADE or NO ACTION.
 }
 
 // Column represents a column in a database table.
@@ -38,6 +123,8 @@ type Column struct {
 	IsPrimary              bool           `json:"is_primary"`               // IsPrimary indicates whether the column is a primary key.
 	ColumnDefault          sql.NullString `json:"column_default"`           // ColumnDefault is the default value of the column.
 	CharacterMaximumLength sql.NullInt64  `json:"character_maximum_length"` // CharacterMaximumLength is the maximum length of the column.
+	NumericPrecision       sql.NullInt64  `json:"numeric_precision"`        // NumericPrecision is the precision of a numeric column that is reported separately from its type.
+	NumericScale           sql.NullInt64  `json:"numeric_scale"`            // NumericScale is the scale of a numeric column that is reported separately from its type.
 	IsUpdatable            sql.NullString `json:"is_updatable"`             // IsUpdatable indicates whether the column is updatable.
 	IsIdentity             sql.NullString `json:"is_identity"`              // IsIdentity indicates whether the column is an identity column.
 	IsGenerated            sql.NullString `json:"is_generated"`             // IsGenerated indicates whether the column is a generated column.
@@ -67,39 +154,14 @@ type TableResponse struct {
 }
 
 // QueryResult represents the result of a database query.
+// Every driver returns this type; rows keep the column order of the result set.
 type QueryResult struct {
-	Columns []string        `json:"columns"` // Columns are the names of the columns in the result.
-	Rows    [][]interface{} `json:"rows"`    // Rows are the rows in the result.
-	Time    int64           `json:"time"`    // Time is the time it took to execute the query.
-	Error   string          `json:"error"`   // Error is any error that occurred while executing the query.
-}
-
-type BigQueryResult struct {
-    Columns []string                 `json:"columns"` // Columns are the names of the columns in the result.
-    Rows    []map[string]interface{} `json:"rows"`    // Rows are the rows in the result.
-    Time    int64                    `json:"time"`    // Time is the time it took to execute the query.
-    Error   string                   `json:"error"`   // Error is any error that occurred while executing the query.
-}
-
-// DbType represents a type of SQL database.
-type DbType int
-
-// These constants represent the supported types of SQL databases.
-const (
-	MySQL DbType = iota + 1
-	Postgres
-	Snowflake
-	BigQuery
-	Redshift
-	MSSQL
-)
-
-// String returns the string representation of the DbType.
-func (w DbType) String() string {
-	return [...]string{"mysql", "postgres", "snowflake", "bigquery", "redshift", "mssql"}[w-1]
-}
-
-// Index returns the integer representation of the DbType.
-func (w DbType) Index() int {
-	return int(w)
+	Columns       []string        `json:"columns"`         // Columns are the names of the columns in the result.
+	ColumnTypes   []ColumnMeta    `json:"column_types"`    // ColumnTypes describe the type of every column in the result.
+	Rows          [][]interface{} `json:"rows"`            // Rows are the rows in the result, ordered like Columns.
+	RowCount      int64           `json:"row_count"`       // RowCount is the number of rows in the result.
+	Time          int64           `json:"time"`            // Time is the time in milliseconds it took to execute the query.
+	Error         string          `json:"error"`           // Error is any error that occurred while executing the query.
+	Truncated     bool            `json:"truncated"`       // Truncated indicates whether the result was cut by the row or byte limit.
+	NextPageToken string          `json:"next_page_token"` // NextPageToken continues a truncated result with ExecutePage, empty otherwise.
 }
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Layouts used when temporal values are returned in query results.
+const (
+	DateLayout = "2006-01-02"         // DateLayout is the layout of DATE values.
+	TimeLayout = "15:04:05.999999999" // TimeLayout is the layout of TIME values.
+)
+
+// FloatValue returns f as a JSON encodable value.
+// NaN and infinities cannot be represented in JSON and are returned as strings.
+func FloatValue(f float64) interface{} {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	default:
+		return f
+	}
+}
+
+// JSONValue returns b as embedded JSON when it holds a valid JSON document and as a string otherwise.
+func JSONValue(b []byte) interface{} {
+	if json.Valid(b) {
+		return json.RawMessage(append([]byte(nil), b...))
+	}
+	return string(b)
+}
+
+// TimestampValue formats t as an RFC3339 timestamp.
+func TimestampValue(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// IntValue parses s as a signed integer, falling back to the string when it does not fit.
+func IntValue(s string) interface{} {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n
+	}
+	return s
+}
+
+// UintValue parses s as an unsigned integer, falling back to the string when it does not fit.
+func UintValue(s string) interface{} {
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return n
+	}
+	return s
+}
+
+// ParseFloatValue parses s as a float, falling back to the string when it is not a number.
+func ParseFloatValue(s string) interface{} {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return FloatValue(f)
+	}
+	return s
+}
+
+// BoolValue parses s as a boolean, falling back to the string when it is not a boolean.
+func BoolValue(s string) interface{} {
+	if b, err := strconv.ParseBool(s); err == nil {
+		return b
+	}
+	return s
+}
+
+// BinaryEncoding is the encoding used for values of binary columns in query results.
+type BinaryEncoding string
+
+// These constants represent the supported binary encodings.
+const (
+	BinaryBase64 BinaryEncoding = "base64" // BinaryBase64 encodes binary values as standard base64 strings.
+	BinaryHex    BinaryEncoding = "hex"    // BinaryHex encodes binary values as lower case hex strings.
+	BinaryOmit   BinaryEncoding = "omit"   // BinaryOmit replaces binary values with null.
+)
+
+// Encode encodes the binary value b. An empty encoding defaults to base64.
+func (e BinaryEncoding) Encode(b []byte) (interface{}, error) {
+	switch e {
+	case "", BinaryBase64:
+		return base64.StdEncoding.EncodeToString(b), nil
+	case BinaryHex:
+		return hex.EncodeToString(b), nil
+	case BinaryOmit:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported binary encoding %q", string(e))
+	}
+}